@@ -60,7 +60,7 @@ func UseTestStore(store store.Store) {
 	testStore = store
 }
 
-func setupTestHelper(enterprise bool, updateConfig func(*model.Config)) *TestHelper {
+func setupTestHelper(enterprise bool, updateConfig func(*model.Config), serverOptions ...app.Option) *TestHelper {
 	testStore.DropAllTables()
 
 	memoryStore, err := config.NewMemoryStoreWithOptions(&config.MemoryStoreOptions{IgnoreEnvironmentOverrides: true})
@@ -71,6 +71,7 @@ func setupTestHelper(enterprise bool, updateConfig func(*model.Config)) *TestHel
 	var options []app.Option
 	options = append(options, app.ConfigStore(memoryStore))
 	options = append(options, app.StoreOverride(testStore))
+	options = append(options, serverOptions...)
 
 	s, err := app.NewServer(options...)
 	if err != nil {
@@ -152,6 +153,10 @@ func SetupConfig(updateConfig func(cfg *model.Config)) *TestHelper {
 	return setupTestHelper(false, updateConfig)
 }
 
+func SetupWithServerOptions(options ...app.Option) *TestHelper {
+	return setupTestHelper(false, nil, options...)
+}
+
 func (me *TestHelper) ShutdownApp() {
 	done := make(chan bool)
 	go func() {
@@ -6,6 +6,7 @@ package api4
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -13,9 +14,14 @@ import (
 	"github.com/mattermost/mattermost-server/store"
 )
 
+const (
+	MAX_CHANNEL_IDS_BATCH = 200
+)
+
 func (api *API) InitChannel() {
 	api.BaseRoutes.Channels.Handle("", api.ApiSessionRequired(getAllChannels)).Methods("GET")
 	api.BaseRoutes.Channels.Handle("", api.ApiSessionRequired(createChannel)).Methods("POST")
+	api.BaseRoutes.Channels.Handle("/ids", api.ApiSessionRequired(getChannelsByIds)).Methods("POST")
 	api.BaseRoutes.Channels.Handle("/direct", api.ApiSessionRequired(createDirectChannel)).Methods("POST")
 	api.BaseRoutes.Channels.Handle("/search", api.ApiSessionRequired(searchAllChannels)).Methods("POST")
 	api.BaseRoutes.Channels.Handle("/group/search", api.ApiSessionRequired(searchGroupChannels)).Methods("POST")
@@ -30,6 +36,9 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.ChannelsForTeam.Handle("/autocomplete", api.ApiSessionRequired(autocompleteChannelsForTeam)).Methods("GET")
 	api.BaseRoutes.ChannelsForTeam.Handle("/search_autocomplete", api.ApiSessionRequired(autocompleteChannelsForTeamForSearch)).Methods("GET")
 	api.BaseRoutes.User.Handle("/teams/{team_id:[A-Za-z0-9]+}/channels", api.ApiSessionRequired(getChannelsForTeamForUser)).Methods("GET")
+	api.BaseRoutes.User.Handle("/teams/{team_id:[A-Za-z0-9]+}/channels/categories", api.ApiSessionRequired(getSidebarChannelOrder)).Methods("GET")
+	api.BaseRoutes.User.Handle("/teams/{team_id:[A-Za-z0-9]+}/channels/categories/order", api.ApiSessionRequired(updateSidebarChannelOrder)).Methods("PUT")
+	api.BaseRoutes.User.Handle("/channels/recent", api.ApiSessionRequired(getRecentChannelsForUser)).Methods("GET")
 
 	api.BaseRoutes.Channel.Handle("", api.ApiSessionRequired(getChannel)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("", api.ApiSessionRequired(updateChannel)).Methods("PUT")
@@ -39,9 +48,13 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("/restore", api.ApiSessionRequired(restoreChannel)).Methods("POST")
 	api.BaseRoutes.Channel.Handle("", api.ApiSessionRequired(deleteChannel)).Methods("DELETE")
 	api.BaseRoutes.Channel.Handle("/stats", api.ApiSessionRequired(getChannelStats)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/messages/count", api.ApiSessionRequired(getChannelMessageCount)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/topics/history", api.ApiSessionRequired(getChannelTopicHistory)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/pinned", api.ApiSessionRequired(getPinnedPosts)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/timezones", api.ApiSessionRequired(getChannelMembersTimezones)).Methods("GET")
 	api.BaseRoutes.Channel.Handle("/members_minus_group_members", api.ApiSessionRequired(channelMembersMinusGroupMembers)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/moderation", api.ApiSessionRequired(getChannelModerations)).Methods("GET")
+	api.BaseRoutes.Channel.Handle("/moderation", api.ApiSessionRequired(patchChannelModerations)).Methods("PUT")
 	api.BaseRoutes.ChannelForUser.Handle("/unread", api.ApiSessionRequired(getChannelUnread)).Methods("GET")
 
 	api.BaseRoutes.ChannelByName.Handle("", api.ApiSessionRequired(getChannelByName)).Methods("GET")
@@ -50,6 +63,7 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(getChannelMembers)).Methods("GET")
 	api.BaseRoutes.ChannelMembers.Handle("/ids", api.ApiSessionRequired(getChannelMembersByIds)).Methods("POST")
 	api.BaseRoutes.ChannelMembers.Handle("", api.ApiSessionRequired(addChannelMember)).Methods("POST")
+	api.BaseRoutes.ChannelMembers.Handle("/bulk", api.ApiSessionRequired(addChannelMembers)).Methods("POST")
 	api.BaseRoutes.ChannelMembersForUser.Handle("", api.ApiSessionRequired(getChannelMembersForUser)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.ApiSessionRequired(getChannelMember)).Methods("GET")
 	api.BaseRoutes.ChannelMember.Handle("", api.ApiSessionRequired(removeChannelMember)).Methods("DELETE")
@@ -438,6 +452,13 @@ func searchGroupChannels(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(groupChannels.ToJson()))
 }
 
+// createGroupChannel creates a group message channel between the given users and the current user.
+//
+// The channel for a set of users is unique, so if it already exists, it is returned instead. With
+// get_if_exists, an existing channel is looked up before anything is created and returned with a
+// 200 status, while a newly created channel is returned with a 201 status.
+//
+// @query get_if_exists boolean Look up an existing channel before creating one.
 func createGroupChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	userIds := model.ArrayFromJson(r.Body)
 
@@ -485,6 +506,19 @@ func createGroupChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("get_if_exists") == "true" {
+		groupChannel, err := c.App.GetGroupChannel(userIds)
+		if err == nil {
+			w.Write([]byte(groupChannel.ToJson()))
+			return
+		}
+
+		if err.StatusCode != http.StatusNotFound {
+			c.Err = err
+			return
+		}
+	}
+
 	groupChannel, err := c.App.CreateGroupChannel(userIds, c.App.Session.UserId)
 	if err != nil {
 		c.Err = err
@@ -586,6 +620,81 @@ func getChannelStats(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(stats.ToJson()))
 }
 
+// getChannelMessageCount counts the messages posted in a channel, excluding deleted ones.
+//
+// @query since integer Only count messages created at or after this time, in milliseconds.
+// @query until integer Only count messages created before this time, in milliseconds.
+func getChannelMessageCount(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var since, until int64
+	var parseError error
+	if sinceString := r.URL.Query().Get("since"); len(sinceString) > 0 {
+		if since, parseError = strconv.ParseInt(sinceString, 10, 64); parseError != nil || since < 0 {
+			c.SetInvalidParam("since")
+			return
+		}
+	}
+
+	if untilString := r.URL.Query().Get("until"); len(untilString) > 0 {
+		if until, parseError = strconv.ParseInt(untilString, 10, 64); parseError != nil || until < 0 {
+			c.SetInvalidParam("until")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	count, err := c.App.GetChannelMessageCount(c.Params.ChannelId, since, until)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	messageCount := model.ChannelMessageCount{Count: count}
+	w.Write([]byte(messageCount.ToJson()))
+}
+
+// getChannelTopicHistory lists the changes made to the header and purpose of a channel, newest first.
+//
+// Changes older than DataRetentionSettings.TopicHistoryRetentionDays are pruned.
+//
+// @query limit integer The maximum number of changes to return, up to 200. Defaults to 10.
+func getChannelTopicHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	limit := model.CHANNEL_TOPIC_HISTORY_DEFAULT_LIMIT
+	if limitString := r.URL.Query().Get("limit"); len(limitString) > 0 {
+		var parseError error
+		if limit, parseError = strconv.Atoi(limitString); parseError != nil || limit <= 0 || limit > model.CHANNEL_TOPIC_HISTORY_MAX_LIMIT {
+			c.SetInvalidParam("limit")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	history, err := c.App.GetChannelTopicHistory(c.Params.ChannelId, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelTopicHistoryListToJson(history)))
+}
+
 func getPinnedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -740,6 +849,43 @@ func getPublicChannelsByIdsForTeam(c *Context, w http.ResponseWriter, r *http.Re
 	w.Write([]byte(channels.ToJson()))
 }
 
+func getChannelsByIds(c *Context, w http.ResponseWriter, r *http.Request) {
+	channelIds := model.RemoveDuplicateStrings(model.ArrayFromJson(r.Body))
+	if len(channelIds) == 0 || len(channelIds) > MAX_CHANNEL_IDS_BATCH {
+		c.SetInvalidParam("channel_ids")
+		return
+	}
+
+	for _, cid := range channelIds {
+		if !model.IsValidId(cid) {
+			c.SetInvalidParam("channel_id")
+			return
+		}
+	}
+
+	includeAll := c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM)
+
+	channelsById, err := c.App.GetChannelsByIdsForUser(channelIds, c.App.Session.UserId, includeAll)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	channels := model.ChannelList{}
+	for _, channel := range channelsById {
+		if channel != nil {
+			channels = append(channels, channel)
+		}
+	}
+
+	if err = c.App.FillInChannelsProps(&channels); err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ChannelMapToJson(channelsById)))
+}
+
 func getChannelsForTeamForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId().RequireTeamId()
 	if c.Err != nil {
@@ -776,6 +922,127 @@ func getChannelsForTeamForUser(c *Context, w http.ResponseWriter, r *http.Reques
 	w.Write([]byte(channels.ToJson()))
 }
 
+// getSidebarChannelOrder gets the order of the channels in a user's channel sidebar on a team.
+//
+// The order lists the ids of the channels that the user has ordered and is still a member of.
+// Channels that aren't listed haven't been ordered by the user.
+func getSidebarChannelOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	order, err := c.App.GetSidebarChannelOrder(c.Params.UserId, c.Params.TeamId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(order.ToJson()))
+}
+
+// updateSidebarChannelOrder saves the order of the channels in a user's channel sidebar on a team.
+//
+// The request body is the array of the ids of the channels in order, replacing any previously saved
+// order. Each must be a channel on the team or a direct or group message of which the user is a
+// member. The user's other sessions are notified with a sidebar_order_updated event.
+func updateSidebarChannelOrder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	channelIds := model.ArrayFromJson(r.Body)
+	if channelIds == nil {
+		c.SetInvalidParam("order")
+		return
+	}
+
+	for _, channelId := range channelIds {
+		if !model.IsValidId(channelId) {
+			c.SetInvalidParam("order")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	order, err := c.App.UpdateSidebarChannelOrder(c.Params.UserId, c.Params.TeamId, channelIds)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(order.ToJson()))
+}
+
+func getRecentChannelsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	query := r.URL.Query()
+
+	teamId := query.Get("team_id")
+	if teamId != "" {
+		if !model.IsValidId(teamId) {
+			c.SetInvalidUrlParam("team_id")
+			return
+		}
+
+		if !c.App.SessionHasPermissionToTeam(c.App.Session, teamId, model.PERMISSION_VIEW_TEAM) {
+			c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+			return
+		}
+	}
+
+	limit := model.RECENT_CHANNELS_DEFAULT_LIMIT
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.SetInvalidUrlParam("limit")
+			return
+		}
+
+		if limit > model.RECENT_CHANNELS_MAX_LIMIT {
+			limit = model.RECENT_CHANNELS_MAX_LIMIT
+		}
+	}
+
+	recent, err := c.App.GetRecentChannelsForUser(c.Params.UserId, teamId, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.RecentChannelsToJson(recent)))
+}
+
 func autocompleteChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId()
 	if c.Err != nil {
@@ -1342,6 +1609,81 @@ func addChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(cm.ToJson()))
 }
 
+// addChannelMembers adds up to 500 users to a channel at once.
+//
+// Users that are already members of the channel are skipped rather than returned as errors, and
+// users that can't be added are listed in the response without preventing the others from being
+// added. Roles may only be given with the manage_channel_roles permission. No system messages are
+// posted for the added users.
+func addChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	membersToAdd := model.ChannelMembersToAddFromJson(r.Body)
+	if membersToAdd == nil {
+		c.SetInvalidParam("members")
+		return
+	}
+
+	if len(membersToAdd) > model.CHANNEL_MEMBERS_BULK_MAX {
+		c.Err = model.NewAppError("addChannelMembers", "api.channel.add_members_bulk.too_many.app_error", map[string]interface{}{"Max": model.CHANNEL_MEMBERS_BULK_MAX}, "", http.StatusBadRequest)
+		return
+	}
+
+	hasRoles := false
+	for _, member := range membersToAdd {
+		if !model.IsValidId(member.UserId) {
+			c.SetInvalidParam("user_id")
+			return
+		}
+		if member.Roles != "" {
+			if !model.IsValidUserRoles(member.Roles) {
+				c.SetInvalidParam("roles")
+				return
+			}
+			hasRoles = true
+		}
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	switch channel.Type {
+	case model.CHANNEL_OPEN:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS)
+			return
+		}
+	case model.CHANNEL_PRIVATE:
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS)
+			return
+		}
+	default:
+		c.Err = model.NewAppError("addChannelMembers", "api.channel.add_user_to_channel.type.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if hasRoles && !c.App.SessionHasPermissionToChannel(c.App.Session, channel.Id, model.PERMISSION_MANAGE_CHANNEL_ROLES) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_CHANNEL_ROLES)
+		return
+	}
+
+	result, err := c.App.AddChannelMembers(channel, membersToAdd, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("name=" + channel.Name + " added=" + strconv.Itoa(result.Added) + " skipped=" + strconv.Itoa(result.Skipped))
+	w.Write([]byte(result.ToJson()))
+}
+
 func removeChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId().RequireUserId()
 	if c.Err != nil {
@@ -1436,6 +1778,76 @@ func updateChannelScheme(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func getChannelModerations(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	moderations, err := c.App.GetChannelModerations(channel)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(moderations.ToJson()))
+}
+
+func patchChannelModerations(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := model.ChannelModerationsFromJson(r.Body)
+	if patch == nil {
+		c.SetInvalidParam("moderation")
+		return
+	}
+
+	if c.App.License() == nil {
+		c.Err = model.NewAppError("Api4.PatchChannelModerations", "api.channel.update_channel_scheme.license.error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	channel, err := c.App.GetChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if channel.Type == model.CHANNEL_DIRECT || channel.Type == model.CHANNEL_GROUP {
+		c.Err = model.NewAppError("Api4.PatchChannelModerations", "api.channel.patch_channel_moderations.direct_or_group.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+		return
+	}
+
+	moderations, err := c.App.PatchChannelModerations(channel, patch)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("channel_id=" + channel.Id)
+
+	w.Write([]byte(moderations.ToJson()))
+}
+
 func channelMembersMinusGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -541,6 +541,41 @@ func TestCreateGroupChannel(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetOrCreateGroupChannel(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	user3 := th.CreateUser()
+
+	userIds := []string{th.BasicUser.Id, th.BasicUser2.Id, user3.Id}
+
+	rgc, resp := Client.GetOrCreateGroupChannel(userIds)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	require.Equal(t, model.CHANNEL_GROUP, rgc.Type)
+
+	// The user ids can be in any order and the current user can be left out.
+	rgc2, resp := Client.GetOrCreateGroupChannel([]string{user3.Id, th.BasicUser2.Id})
+	CheckNoError(t, resp)
+	CheckOKStatus(t, resp)
+	require.Equal(t, rgc.Id, rgc2.Id)
+
+	_, resp = Client.GetOrCreateGroupChannel([]string{th.BasicUser2.Id})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetOrCreateGroupChannel([]string{th.BasicUser2.Id, user3.Id, GenerateTestId()})
+	CheckBadRequestStatus(t, resp)
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer func() {
+		th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	}()
+	th.RemovePermissionFromRole(model.PERMISSION_CREATE_GROUP_CHANNEL.Id, model.SYSTEM_USER_ROLE_ID)
+
+	_, resp = Client.GetOrCreateGroupChannel(userIds)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestCreateGroupChannelAsGuest(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -860,6 +895,53 @@ func TestGetPublicChannelsByIdsForTeam(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelsByIds(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	// A private channel the basic user does not belong to.
+	otherChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+	missingId := model.NewId()
+
+	channelIds := []string{th.BasicChannel.Id, th.BasicPrivateChannel.Id, otherChannel.Id, missingId}
+
+	channels, resp := Client.GetChannelsByIds(channelIds)
+	CheckNoError(t, resp)
+	require.Len(t, channels, 4)
+	require.NotNil(t, channels[th.BasicChannel.Id])
+	assert.Equal(t, th.BasicChannel.DisplayName, channels[th.BasicChannel.Id].DisplayName)
+	require.NotNil(t, channels[th.BasicPrivateChannel.Id])
+	assert.Equal(t, th.BasicPrivateChannel.DisplayName, channels[th.BasicPrivateChannel.Id].DisplayName)
+	assert.Nil(t, channels[otherChannel.Id])
+	assert.Contains(t, channels, otherChannel.Id)
+	assert.Nil(t, channels[missingId])
+	assert.Contains(t, channels, missingId)
+
+	channels, resp = th.SystemAdminClient.GetChannelsByIds(channelIds)
+	CheckNoError(t, resp)
+	require.NotNil(t, channels[otherChannel.Id])
+	assert.Equal(t, otherChannel.DisplayName, channels[otherChannel.Id].DisplayName)
+	assert.Nil(t, channels[missingId])
+
+	_, resp = Client.GetChannelsByIds([]string{})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelsByIds([]string{"junk"})
+	CheckBadRequestStatus(t, resp)
+
+	tooMany := make([]string, MAX_CHANNEL_IDS_BATCH+1)
+	for i := range tooMany {
+		tooMany[i] = model.NewId()
+	}
+	_, resp = Client.GetChannelsByIds(tooMany)
+	CheckBadRequestStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetChannelsByIds(channelIds)
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestGetChannelsForTeamForUser(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -908,6 +990,121 @@ func TestGetChannelsForTeamForUser(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestSidebarChannelOrder(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	order, resp := Client.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, th.BasicUser.Id, order.UserId)
+	assert.Equal(t, th.BasicTeam.Id, order.TeamId)
+	assert.Empty(t, order.Order)
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	channelIds := []string{th.BasicChannel2.Id, dm.Id, th.BasicPrivateChannel.Id, th.BasicChannel.Id}
+
+	order, resp = Client.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, channelIds)
+	CheckNoError(t, resp)
+	assert.Equal(t, channelIds, order.Order)
+
+	order, resp = Client.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, channelIds, order.Order)
+
+	t.Run("channels the user has left are omitted", func(t *testing.T) {
+		_, resp = Client.RemoveUserFromChannel(th.BasicChannel2.Id, th.BasicUser.Id)
+		CheckNoError(t, resp)
+
+		order, resp = Client.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, channelIds[1:], order.Order)
+	})
+
+	t.Run("invalid orders", func(t *testing.T) {
+		_, resp = Client.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, []string{th.BasicChannel.Id, th.BasicChannel.Id})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, []string{th.BasicChannel2.Id})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, []string{"junk"})
+		CheckBadRequestStatus(t, resp)
+
+		order, resp = Client.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, channelIds[1:], order.Order)
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		_, resp = Client.GetSidebarChannelOrder(th.BasicUser2.Id, th.BasicTeam.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.UpdateSidebarChannelOrder(th.BasicUser2.Id, th.BasicTeam.Id, []string{})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("clearing the order", func(t *testing.T) {
+		order, resp = Client.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, []string{})
+		CheckNoError(t, resp)
+		assert.Empty(t, order.Order)
+
+		order, resp = Client.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+		CheckNoError(t, resp)
+		assert.Empty(t, order.Order)
+	})
+}
+
+func TestGetRecentChannelsForUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	recent, resp := Client.GetRecentChannelsForUser(th.BasicUser.Id, "", 0)
+	CheckBadRequestStatus(t, resp)
+
+	recent, resp = Client.GetRecentChannelsForUser(th.BasicUser.Id, "", 20)
+	CheckNoError(t, resp)
+	require.Empty(t, recent)
+
+	_, resp = Client.ViewChannel(th.BasicUser.Id, &model.ChannelView{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+	time.Sleep(time.Millisecond)
+	_, resp = Client.ViewChannel(th.BasicUser.Id, &model.ChannelView{ChannelId: th.BasicChannel2.Id, PrevChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+
+	recent, resp = Client.GetRecentChannelsForUser(model.ME, "", 20)
+	CheckNoError(t, resp)
+	require.Len(t, recent, 2)
+	assert.Equal(t, th.BasicChannel2.Id, recent[0].ChannelId)
+	assert.Equal(t, th.BasicChannel.Id, recent[1].ChannelId)
+
+	recent, resp = Client.GetRecentChannelsForUser(model.ME, th.BasicTeam.Id, 1)
+	CheckNoError(t, resp)
+	require.Len(t, recent, 1)
+	assert.Equal(t, th.BasicChannel2.Id, recent[0].ChannelId)
+
+	recent, resp = Client.GetRecentChannelsForUser(model.ME, model.NewId(), 20)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = Client.GetRecentChannelsForUser(model.ME, "junk", 20)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetRecentChannelsForUser(th.BasicUser2.Id, "", 20)
+	CheckForbiddenStatus(t, resp)
+
+	recent, resp = th.SystemAdminClient.GetRecentChannelsForUser(th.BasicUser.Id, "", 20)
+	CheckNoError(t, resp)
+	require.Len(t, recent, 2)
+
+	Client.Logout()
+	_, resp = Client.GetRecentChannelsForUser(th.BasicUser.Id, "", 20)
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestGetAllChannels(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1942,6 +2139,114 @@ func TestGetChannelStats(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetChannelMessageCount(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	channel := th.CreatePrivateChannel()
+
+	count, resp := Client.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckNoError(t, resp)
+	require.Equal(t, int64(0), count)
+
+	post1 := th.CreatePostWithClient(Client, channel)
+	time.Sleep(2 * time.Millisecond)
+	post2 := th.CreatePostWithClient(Client, channel)
+
+	count, resp = Client.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckNoError(t, resp)
+	require.Equal(t, int64(2), count)
+
+	count, resp = Client.GetChannelMessageCount(channel.Id, post2.CreateAt, 0)
+	CheckNoError(t, resp)
+	require.Equal(t, int64(1), count)
+
+	count, resp = Client.GetChannelMessageCount(channel.Id, 0, post2.CreateAt)
+	CheckNoError(t, resp)
+	require.Equal(t, int64(1), count)
+
+	_, resp = Client.DeletePost(post1.Id)
+	CheckNoError(t, resp)
+
+	count, resp = Client.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckNoError(t, resp)
+	require.Equal(t, int64(1), count)
+
+	_, resp = Client.GetChannelMessageCount(channel.Id, -1, 0)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelMessageCount("junk", 0, 0)
+	CheckBadRequestStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckUnauthorizedStatus(t, resp)
+
+	th.LoginBasic2()
+
+	_, resp = Client.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetChannelMessageCount(channel.Id, 0, 0)
+	CheckNoError(t, resp)
+}
+
+func TestGetChannelTopicHistory(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	channel := th.CreatePrivateChannel()
+
+	history, resp := Client.GetChannelTopicHistory(channel.Id, 10)
+	CheckNoError(t, resp)
+	require.Empty(t, history)
+
+	_, resp = Client.PatchChannel(channel.Id, &model.ChannelPatch{Header: model.NewString("first header")})
+	CheckNoError(t, resp)
+	time.Sleep(2 * time.Millisecond)
+	_, resp = Client.PatchChannel(channel.Id, &model.ChannelPatch{Purpose: model.NewString("purpose")})
+	CheckNoError(t, resp)
+	time.Sleep(2 * time.Millisecond)
+	_, resp = Client.PatchChannel(channel.Id, &model.ChannelPatch{Header: model.NewString("second header")})
+	CheckNoError(t, resp)
+
+	// Patches that don't change the topics aren't recorded.
+	_, resp = Client.PatchChannel(channel.Id, &model.ChannelPatch{DisplayName: model.NewString("New Name")})
+	CheckNoError(t, resp)
+
+	history, resp = Client.GetChannelTopicHistory(channel.Id, 10)
+	CheckNoError(t, resp)
+	require.Len(t, history, 3)
+	assert.Equal(t, model.CHANNEL_TOPIC_TYPE_HEADER, history[0].Type)
+	assert.Equal(t, "second header", history[0].Topic)
+	assert.Equal(t, th.BasicUser.Id, history[0].SetByUserId)
+	assert.Equal(t, model.CHANNEL_TOPIC_TYPE_PURPOSE, history[1].Type)
+	assert.Equal(t, "purpose", history[1].Topic)
+	assert.Equal(t, "first header", history[2].Topic)
+
+	history, resp = Client.GetChannelTopicHistory(channel.Id, 1)
+	CheckNoError(t, resp)
+	require.Len(t, history, 1)
+	assert.Equal(t, "second header", history[0].Topic)
+
+	_, resp = Client.GetChannelTopicHistory(channel.Id, 0)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetChannelTopicHistory(channel.Id, model.CHANNEL_TOPIC_HISTORY_MAX_LIMIT+1)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetChannelTopicHistory(channel.Id, 10)
+	CheckNoError(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetChannelTopicHistory(channel.Id, 10)
+	CheckUnauthorizedStatus(t, resp)
+
+	th.LoginBasic2()
+	_, resp = Client.GetChannelTopicHistory(channel.Id, 10)
+	CheckForbiddenStatus(t, resp)
+}
+
 func TestGetPinnedPosts(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2215,6 +2520,28 @@ func TestUpdateChannelNotifyProps(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestUpdateChannelNotifyPropsIgnoreChannelMentions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	member, resp := Client.GetChannelMember(th.BasicChannel.Id, th.BasicUser.Id, "")
+	CheckNoError(t, resp)
+	assert.Equal(t, model.IGNORE_CHANNEL_MENTIONS_DEFAULT, member.NotifyProps[model.IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP])
+
+	for _, value := range []string{model.IGNORE_CHANNEL_MENTIONS_ON, model.IGNORE_CHANNEL_MENTIONS_OFF, model.IGNORE_CHANNEL_MENTIONS_DEFAULT} {
+		_, resp = Client.UpdateChannelNotifyProps(th.BasicChannel.Id, th.BasicUser.Id, map[string]string{model.IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP: value})
+		CheckNoError(t, resp)
+
+		member, resp = Client.GetChannelMember(th.BasicChannel.Id, th.BasicUser.Id, "")
+		CheckNoError(t, resp)
+		assert.Equal(t, value, member.NotifyProps[model.IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP])
+	}
+
+	_, resp = Client.UpdateChannelNotifyProps(th.BasicChannel.Id, th.BasicUser.Id, map[string]string{model.IGNORE_CHANNEL_MENTIONS_NOTIFY_PROP: "junk"})
+	CheckBadRequestStatus(t, resp)
+}
+
 func TestAddChannelMember(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2398,6 +2725,97 @@ func TestAddChannelMember(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestAddChannelMembers(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	team := th.BasicTeam
+	publicChannel := th.CreatePublicChannel()
+
+	_, resp := Client.AddChannelMember(publicChannel.Id, th.BasicUser2.Id)
+	CheckNoError(t, resp)
+
+	user3 := th.CreateUserWithClient(th.SystemAdminClient)
+	_, resp = th.SystemAdminClient.AddTeamMember(team.Id, user3.Id)
+	CheckNoError(t, resp)
+
+	otherTeamUser := th.CreateUserWithClient(th.SystemAdminClient)
+
+	result, resp := Client.AddChannelMembers(publicChannel.Id, []*model.ChannelMemberToAdd{
+		{UserId: th.BasicUser2.Id},
+		{UserId: user3.Id},
+		{UserId: user3.Id},
+		{UserId: otherTeamUser.Id},
+		{UserId: model.NewId()},
+	})
+	CheckNoError(t, resp)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, otherTeamUser.Id, result.Errors[0].UserId)
+
+	_, resp = Client.GetChannelMember(publicChannel.Id, user3.Id, "")
+	CheckNoError(t, resp)
+
+	t.Run("roles", func(t *testing.T) {
+		user4 := th.CreateUserWithClient(th.SystemAdminClient)
+		_, resp := th.SystemAdminClient.AddTeamMember(team.Id, user4.Id)
+		CheckNoError(t, resp)
+		user5 := th.CreateUserWithClient(th.SystemAdminClient)
+		_, resp = th.SystemAdminClient.AddTeamMember(team.Id, user5.Id)
+		CheckNoError(t, resp)
+
+		result, resp := th.SystemAdminClient.AddChannelMembers(publicChannel.Id, []*model.ChannelMemberToAdd{
+			{UserId: user4.Id, Roles: "channel_user channel_admin"},
+			{UserId: user5.Id, Roles: "junk_role"},
+		})
+		CheckNoError(t, resp)
+		assert.Equal(t, 1, result.Added)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, user5.Id, result.Errors[0].UserId)
+
+		member, resp := th.SystemAdminClient.GetChannelMember(publicChannel.Id, user4.Id, "")
+		CheckNoError(t, resp)
+		assert.True(t, member.SchemeAdmin)
+		assert.True(t, member.SchemeUser)
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		members := make([]*model.ChannelMemberToAdd, model.CHANNEL_MEMBERS_BULK_MAX+1)
+		for i := range members {
+			members[i] = &model.ChannelMemberToAdd{UserId: model.NewId()}
+		}
+
+		_, resp := Client.AddChannelMembers(publicChannel.Id, members)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		_, resp := Client.AddChannelMembers(publicChannel.Id, []*model.ChannelMemberToAdd{{UserId: "junk"}})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("direct channel", func(t *testing.T) {
+		dm, resp := Client.CreateDirectChannel(th.BasicUser.Id, th.BasicUser2.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.AddChannelMembers(dm.Id, []*model.ChannelMemberToAdd{{UserId: user3.Id}})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("not a channel member", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+
+		_, resp := th.Client.Logout()
+		CheckNoError(t, resp)
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp = th.Client.AddChannelMembers(privateChannel.Id, []*model.ChannelMemberToAdd{{UserId: user3.Id}})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestAddChannelMemberAddMyself(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -3033,3 +3451,97 @@ func TestChannelMembersMinusGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelModerations(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.SetPhase2PermissionsMigrationStatus(true)
+
+	channel := th.BasicChannel
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetChannelModerations(channel.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.PatchChannelModerations(channel.Id, model.ChannelModerations{})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("get defaults", func(t *testing.T) {
+		moderations, resp := th.SystemAdminClient.GetChannelModerations(channel.Id)
+		CheckNoError(t, resp)
+		require.Len(t, moderations, len(model.ChannelModeratedPermissions))
+
+		createPost := moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_POST]
+		require.NotNil(t, createPost)
+		assert.True(t, *createPost.Roles.Members)
+		assert.True(t, *createPost.Roles.Guests)
+
+		manageMembers := moderations[model.CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS]
+		require.NotNil(t, manageMembers)
+		assert.Nil(t, manageMembers.Roles.Guests)
+	})
+
+	t.Run("patch creates a channel scheme", func(t *testing.T) {
+		moderations, resp := th.SystemAdminClient.PatchChannelModerations(channel.Id, model.ChannelModerations{
+			model.CHANNEL_MODERATED_PERMISSION_CREATE_POST:      {Roles: &model.ChannelModeratedRoles{Guests: model.NewBool(false)}},
+			model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS: {Roles: &model.ChannelModeratedRoles{Members: model.NewBool(false), Guests: model.NewBool(false)}},
+		})
+		CheckNoError(t, resp)
+		assert.True(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_POST].Roles.Members)
+		assert.False(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_POST].Roles.Guests)
+		assert.False(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS].Roles.Members)
+		assert.False(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS].Roles.Guests)
+
+		updatedChannel, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		require.NotNil(t, updatedChannel.SchemeId)
+		require.NotEmpty(t, *updatedChannel.SchemeId)
+
+		moderations, resp = th.SystemAdminClient.GetChannelModerations(channel.Id)
+		CheckNoError(t, resp)
+		assert.False(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS].Roles.Members)
+
+		// The built-in channel roles are left untouched.
+		role, err := th.App.GetRoleByName(model.CHANNEL_USER_ROLE_ID)
+		require.Nil(t, err)
+		assert.Contains(t, role.Permissions, model.PERMISSION_ADD_REACTION.Id)
+	})
+
+	t.Run("patch updates the existing channel scheme", func(t *testing.T) {
+		before, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+
+		moderations, resp := th.SystemAdminClient.PatchChannelModerations(channel.Id, model.ChannelModerations{
+			model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS: {Roles: &model.ChannelModeratedRoles{Members: model.NewBool(true)}},
+		})
+		CheckNoError(t, resp)
+		assert.True(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS].Roles.Members)
+		assert.False(t, *moderations[model.CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS].Roles.Guests)
+
+		after, err := th.App.GetChannel(channel.Id)
+		require.Nil(t, err)
+		assert.Equal(t, *before.SchemeId, *after.SchemeId)
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.PatchChannelModerations(channel.Id, model.ChannelModerations{
+			model.CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS: {Roles: &model.ChannelModeratedRoles{Guests: model.NewBool(true)}},
+		})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.PatchChannelModerations(channel.Id, model.ChannelModerations{
+			"delete_channel": {Roles: &model.ChannelModeratedRoles{Members: model.NewBool(true)}},
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("without a license", func(t *testing.T) {
+		th.App.SetLicense(nil)
+		defer th.App.SetLicense(model.NewTestLicense())
+
+		_, resp := th.SystemAdminClient.PatchChannelModerations(channel.Id, model.ChannelModerations{})
+		CheckNotImplementedStatus(t, resp)
+	})
+}
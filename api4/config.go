@@ -18,6 +18,10 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.ApiRoot.Handle("/config/reload", api.ApiSessionRequired(configReload)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/config/client", api.ApiHandler(getClientConfig)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/config/environment", api.ApiSessionRequired(getEnvironmentConfig)).Methods("GET")
+	api.BaseRoutes.System.Handle("/config/requests", api.ApiSessionRequired(getConfigChangeRequests)).Methods("GET")
+	api.BaseRoutes.System.Handle("/config/requests", api.ApiSessionRequired(proposeConfigChange)).Methods("POST")
+	api.BaseRoutes.System.Handle("/config/requests/{config_change_request_id:[A-Za-z0-9]+}/approve", api.ApiSessionRequired(approveConfigChangeRequest)).Methods("PUT")
+	api.BaseRoutes.System.Handle("/config/requests/{config_change_request_id:[A-Za-z0-9]+}/reject", api.ApiSessionRequired(rejectConfigChangeRequest)).Methods("PUT")
 }
 
 func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -32,6 +36,10 @@ func getConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(cfg.ToJson()))
 }
 
+// configReload reloads the configuration from its backing store, picking up any changes made
+// outside of the server, and returns the resulting sanitized configuration.
+//
+// Requires the manage_system permission.
 func configReload(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
@@ -43,10 +51,17 @@ func configReload(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.App.ReloadConfig()
+	if err := c.App.ReloadConfig(); err != nil {
+		c.Err = model.NewAppError("configReload", "api.config.reload_config.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.LogAudit("config_reloaded")
+
+	cfg := c.App.GetSanitizedConfig()
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	ReturnStatusOK(w)
+	w.Write([]byte(cfg.ToJson()))
 }
 
 func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -61,6 +76,33 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if *c.App.Config().ServiceSettings.RequireConfigChangeApproval {
+		c.Err = model.NewAppError("updateConfig", "api.config.update_config.approval_required.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
+	cfg = prepareConfigUpdate(c, cfg)
+	if c.Err != nil {
+		return
+	}
+
+	err := c.App.SaveConfig(cfg, true)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("updateConfig")
+
+	cfg = c.App.GetSanitizedConfig()
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(cfg.ToJson()))
+}
+
+// prepareConfigUpdate applies the restrictions on changing the configuration through the API to a
+// configuration submitted by a system admin and validates the result.
+func prepareConfigUpdate(c *Context, cfg *model.Config) *model.Config {
 	appCfg := c.App.Config()
 	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
 		// Start with the current configuration, and only merge values not marked as being
@@ -75,6 +117,7 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		})
 		if err != nil {
 			c.Err = model.NewAppError("updateConfig", "api.config.update_config.restricted_merge.app_error", nil, err.Error(), http.StatusInternalServerError)
+			return nil
 		}
 	}
 
@@ -95,24 +138,137 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	err := cfg.IsValid()
+	if err := cfg.IsValid(); err != nil {
+		c.Err = err
+		return nil
+	}
+
+	return cfg
+}
+
+// getConfigChangeRequests returns the proposed configuration changes awaiting review.
+//
+// The requests are returned oldest first, with the secrets in their proposed configurations
+// sanitized. Requires the manage_system permission.
+func getConfigChangeRequests(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	requests, err := c.App.GetPendingConfigChangeRequests()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	for _, request := range requests {
+		request.Sanitize()
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(model.ConfigChangeRequestListToJson(requests)))
+}
+
+// proposeConfigChange proposes a new configuration for another system admin to approve.
+//
+// The body is the complete configuration, as for PUT /config, and is subject to the same
+// restrictions. It only takes effect once approved. Requires the manage_system permission.
+func proposeConfigChange(c *Context, w http.ResponseWriter, r *http.Request) {
+	cfg := model.ConfigFromJson(r.Body)
+	if cfg == nil {
+		c.SetInvalidParam("config")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	cfg = prepareConfigUpdate(c, cfg)
+	if c.Err != nil {
+		return
+	}
+
+	request, err := c.App.ProposeConfigChange(cfg, c.App.Session.UserId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	err = c.App.SaveConfig(cfg, true)
+	c.LogAudit("config_change_request_id=" + request.Id)
+
+	request.Sanitize()
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(request.ToJson()))
+}
+
+// approveConfigChangeRequest approves a pending configuration change and applies it.
+//
+// A change can't be approved by the system admin who proposed it, nor once the configuration has
+// changed since it was proposed. The proposed configuration is subject to the restrictions of PUT
+// /config again before it is applied. Requires the manage_system permission.
+func approveConfigChangeRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigChangeRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	request, err := c.App.GetConfigChangeRequest(c.Params.ConfigChangeRequestId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	c.LogAudit("updateConfig")
+	cfg := prepareConfigUpdate(c, request.ProposedConfig)
+	if c.Err != nil {
+		return
+	}
 
-	cfg = c.App.GetSanitizedConfig()
+	request, err = c.App.ApproveConfigChangeRequest(request, cfg, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
 
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Write([]byte(cfg.ToJson()))
+	c.LogAudit("config_change_request_id=" + request.Id)
+
+	request.Sanitize()
+	w.Write([]byte(request.ToJson()))
+}
+
+// rejectConfigChangeRequest rejects a pending configuration change.
+//
+// The system admin who proposed the change may reject it to withdraw it. Requires the
+// manage_system permission.
+func rejectConfigChangeRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireConfigChangeRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	request, err := c.App.RejectConfigChangeRequest(c.Params.ConfigChangeRequestId, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("config_change_request_id=" + request.Id)
+
+	request.Sanitize()
+	w.Write([]byte(request.ToJson()))
 }
 
 func getClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -6,6 +6,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/app"
+	"github.com/mattermost/mattermost-server/config"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,6 +89,55 @@ func TestReloadConfig(t *testing.T) {
 	})
 }
 
+func TestReloadConfigFromStore(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		cfg, resp := th.Client.ReloadConfigFromStore()
+		CheckForbiddenStatus(t, resp)
+		require.Nil(t, cfg)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		cfg, resp := th.SystemAdminClient.ReloadConfigFromStore()
+		CheckNoError(t, resp)
+		require.NotNil(t, cfg)
+		assert.Equal(t, *th.App.Config().TeamSettings.SiteName, *cfg.TeamSettings.SiteName)
+		assert.Equal(t, model.FAKE_SETTING, *cfg.SqlSettings.DataSource)
+	})
+
+	t.Run("as restricted system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		_, resp := th.SystemAdminClient.ReloadConfigFromStore()
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+// failingLoadConfigStore is a config store that fails to load from its backing store.
+type failingLoadConfigStore struct {
+	config.Store
+}
+
+func (*failingLoadConfigStore) Load() error {
+	return errors.New("failed to load")
+}
+
+func TestReloadConfigFromStoreFailure(t *testing.T) {
+	memoryStore, err := config.NewMemoryStoreWithOptions(&config.MemoryStoreOptions{IgnoreEnvironmentOverrides: true})
+	require.NoError(t, err)
+
+	th := SetupWithServerOptions(app.ConfigStore(&failingLoadConfigStore{memoryStore})).InitBasic()
+	defer th.TearDown()
+
+	cfg, resp := th.SystemAdminClient.ReloadConfigFromStore()
+	CheckInternalErrorStatus(t, resp)
+	CheckErrorMessage(t, resp, "api.config.reload_config.app_error")
+	require.Nil(t, cfg)
+}
+
 func TestUpdateConfig(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -230,6 +283,134 @@ func TestUpdateConfigRestrictSystemAdmin(t *testing.T) {
 	require.Equal(t, returnedCfg, actualCfg)
 }
 
+func TestConfigChangeRequests(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.RequireConfigChangeApproval = true })
+
+	otherAdmin := th.CreateUser()
+	th.App.UpdateUserRoles(otherAdmin.Id, model.SYSTEM_USER_ROLE_ID+" "+model.SYSTEM_ADMIN_ROLE_ID, false)
+	otherAdminClient := th.CreateClient()
+	otherAdminClient.Login(otherAdmin.Email, otherAdmin.Password)
+
+	proposeSiteName := func(t *testing.T, siteName string) *model.ConfigChangeRequest {
+		t.Helper()
+
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+		*cfg.TeamSettings.SiteName = siteName
+
+		request, resp := th.SystemAdminClient.ProposeConfigChange(cfg)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		require.NotNil(t, request)
+		assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_PENDING, request.Status)
+		assert.Equal(t, th.SystemAdminUser.Id, request.ProposedBy)
+		assert.Equal(t, model.FAKE_SETTING, *request.ProposedConfig.SqlSettings.DataSource)
+
+		return request
+	}
+
+	t.Run("update config requires approval", func(t *testing.T) {
+		cfg, resp := th.SystemAdminClient.GetConfig()
+		CheckNoError(t, resp)
+
+		_, resp = th.SystemAdminClient.UpdateConfig(cfg)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.config.update_config.approval_required.app_error")
+	})
+
+	t.Run("enabling plugins requires approval", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.Enable = true })
+
+		_, resp := th.SystemAdminClient.EnablePlugin("testplugin")
+		CheckForbiddenStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.plugin.enable_plugin.approval_required.app_error")
+
+		_, resp = th.SystemAdminClient.DisablePlugin("testplugin")
+		CheckForbiddenStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.plugin.disable_plugin.approval_required.app_error")
+	})
+
+	t.Run("as system user", func(t *testing.T) {
+		cfg := th.App.Config().Clone()
+
+		_, resp := th.Client.ProposeConfigChange(cfg)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.GetConfigChangeRequests()
+		CheckForbiddenStatus(t, resp)
+
+		request := proposeSiteName(t, "NotApprovedByUser")
+		_, resp = th.Client.ApproveConfigChangeRequest(request.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		siteName := *th.App.Config().TeamSettings.SiteName
+		request := proposeSiteName(t, "ApprovedSiteName")
+		assert.Equal(t, siteName, *th.App.Config().TeamSettings.SiteName, "proposing shouldn't change the config")
+
+		requests, resp := otherAdminClient.GetConfigChangeRequests()
+		CheckNoError(t, resp)
+		var ids []string
+		for _, pending := range requests {
+			ids = append(ids, pending.Id)
+		}
+		assert.Contains(t, ids, request.Id)
+
+		_, resp = th.SystemAdminClient.ApproveConfigChangeRequest(request.Id)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorMessage(t, resp, "app.config_change_request.approve.self.app_error")
+
+		approved, resp := otherAdminClient.ApproveConfigChangeRequest(request.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, approved.Status)
+		assert.Equal(t, otherAdmin.Id, approved.ReviewedBy)
+		assert.Equal(t, "ApprovedSiteName", *th.App.Config().TeamSettings.SiteName)
+		assert.NotEqual(t, model.FAKE_SETTING, *th.App.Config().SqlSettings.DataSource)
+
+		_, resp = otherAdminClient.ApproveConfigChangeRequest(request.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("approve after the config changed", func(t *testing.T) {
+		first := proposeSiteName(t, "FirstSiteName")
+		second := proposeSiteName(t, "SecondSiteName")
+
+		_, resp := otherAdminClient.ApproveConfigChangeRequest(first.Id)
+		CheckNoError(t, resp)
+
+		_, resp = otherAdminClient.ApproveConfigChangeRequest(second.Id)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		CheckErrorMessage(t, resp, "app.config_change_request.approve.stale.app_error")
+		assert.Equal(t, "FirstSiteName", *th.App.Config().TeamSettings.SiteName)
+
+		_, resp = otherAdminClient.RejectConfigChangeRequest(second.Id)
+		CheckNoError(t, resp)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		siteName := *th.App.Config().TeamSettings.SiteName
+		request := proposeSiteName(t, "RejectedSiteName")
+
+		rejected, resp := otherAdminClient.RejectConfigChangeRequest(request.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, rejected.Status)
+		assert.Equal(t, siteName, *th.App.Config().TeamSettings.SiteName)
+
+		_, resp = otherAdminClient.ApproveConfigChangeRequest(request.Id)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		_, resp := otherAdminClient.ApproveConfigChangeRequest(model.NewId())
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
 func TestGetEnvironmentConfig(t *testing.T) {
 	os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://example.mattermost.com")
 	os.Setenv("MM_SERVICESETTINGS_ENABLECUSTOMEMOJI", "true")
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/app"
@@ -104,7 +105,29 @@ func getEmojiList(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	listEmoji, err := c.App.GetEmojiList(c.Params.Page, c.Params.PerPage, sort)
+	opts := model.EmojiListOpts{
+		CreatorId: r.URL.Query().Get("creator_id"),
+		Prefix:    r.URL.Query().Get("prefix"),
+	}
+
+	if opts.CreatorId != "" && !model.IsValidId(opts.CreatorId) {
+		c.SetInvalidUrlParam("creator_id")
+		return
+	}
+
+	// The list is returned as a bare array unless the client asks to know whether another page follows
+	if includeHasNext, _ := strconv.ParseBool(r.URL.Query().Get("include_has_next")); includeHasNext {
+		emojiPage, err := c.App.GetEmojiListPage(c.Params.Page, c.Params.PerPage, sort, opts)
+		if err != nil {
+			c.Err = err
+			return
+		}
+
+		w.Write([]byte(emojiPage.ToJson()))
+		return
+	}
+
+	listEmoji, err := c.App.GetEmojiList(c.Params.Page, c.Params.PerPage, sort, opts)
 	if err != nil {
 		c.Err = err
 		return
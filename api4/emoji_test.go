@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateEmoji(t *testing.T) {
@@ -258,6 +259,78 @@ func TestGetEmojiList(t *testing.T) {
 	}
 }
 
+func TestGetEmojiListPage(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	EnableCustomEmoji := *th.App.Config().ServiceSettings.EnableCustomEmoji
+	defer func() {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = EnableCustomEmoji })
+	}()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	prefix := "page" + model.NewId()[:10]
+	emojis := []*model.Emoji{
+		{
+			CreatorId: th.BasicUser.Id,
+			Name:      prefix + "a",
+		},
+		{
+			CreatorId: th.BasicUser.Id,
+			Name:      prefix + "b",
+		},
+		{
+			CreatorId: th.BasicUser.Id,
+			Name:      model.NewId(),
+		},
+	}
+
+	for idx, emoji := range emojis {
+		newEmoji, resp := Client.CreateEmoji(emoji, utils.CreateTestGif(t, 10, 10), "image.gif")
+		CheckNoError(t, resp)
+		emojis[idx] = newEmoji
+	}
+
+	t.Run("filter by prefix", func(t *testing.T) {
+		emojiPage, resp := Client.GetEmojiListPage(0, 100, "", prefix)
+		CheckNoError(t, resp)
+		require.Len(t, emojiPage.Emojis, 2)
+		assert.Equal(t, emojis[0].Id, emojiPage.Emojis[0].Id)
+		assert.Equal(t, emojis[1].Id, emojiPage.Emojis[1].Id)
+		assert.False(t, emojiPage.HasNext)
+	})
+
+	t.Run("paginate with has_next", func(t *testing.T) {
+		emojiPage, resp := Client.GetEmojiListPage(0, 1, "", prefix)
+		CheckNoError(t, resp)
+		require.Len(t, emojiPage.Emojis, 1)
+		assert.Equal(t, emojis[0].Id, emojiPage.Emojis[0].Id)
+		assert.True(t, emojiPage.HasNext)
+
+		emojiPage, resp = Client.GetEmojiListPage(1, 1, "", prefix)
+		CheckNoError(t, resp)
+		require.Len(t, emojiPage.Emojis, 1)
+		assert.Equal(t, emojis[1].Id, emojiPage.Emojis[0].Id)
+		assert.False(t, emojiPage.HasNext)
+	})
+
+	t.Run("filter by creator", func(t *testing.T) {
+		emojiPage, resp := Client.GetEmojiListPage(0, 100, th.BasicUser.Id, "")
+		CheckNoError(t, resp)
+		require.Len(t, emojiPage.Emojis, 3)
+
+		emojiPage, resp = Client.GetEmojiListPage(0, 100, th.BasicUser2.Id, "")
+		CheckNoError(t, resp)
+		assert.Len(t, emojiPage.Emojis, 0)
+	})
+
+	t.Run("invalid creator", func(t *testing.T) {
+		_, resp := Client.GetEmojiListPage(0, 100, "junk", "")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestDeleteEmoji(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func (api *API) InitExport() {
+	api.BaseRoutes.Export.Handle("/schedule", api.ApiSessionRequired(scheduleExport)).Methods("POST")
+	api.BaseRoutes.Export.Handle("/jobs/{job_id:[A-Za-z0-9]+}", api.ApiSessionRequired(getExportJob)).Methods("GET")
+	api.BaseRoutes.Export.Handle("/jobs/{job_id:[A-Za-z0-9]+}", api.ApiSessionRequired(cancelExportJob)).Methods("DELETE")
+	api.BaseRoutes.Export.Handle("/jobs/{job_id:[A-Za-z0-9]+}/download", api.ApiSessionRequired(downloadExportJob)).Methods("GET")
+}
+
+func scheduleExport(c *Context, w http.ResponseWriter, r *http.Request) {
+	request := model.MessageExportRequestFromJson(r.Body)
+	if request == nil {
+		c.SetInvalidParam("export")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.ScheduleMessageExport(request)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("job_id=" + job.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(messageExportJobForClient(c, job).ToJson()))
+}
+
+func getExportJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.GetMessageExportJob(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(messageExportJobForClient(c, job).ToJson()))
+}
+
+func cancelExportJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := c.App.CancelMessageExportJob(c.Params.JobId); err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("job_id=" + c.Params.JobId)
+
+	ReturnStatusOK(w)
+}
+
+func downloadExportJob(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireJobId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	job, err := c.App.GetMessageExportJob(c.Params.JobId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	path := job.Data[model.JOB_DATA_EXPORT_FILE_PATH]
+	if job.Status != model.JOB_STATUS_SUCCESS || path == "" {
+		c.Err = model.NewAppError("downloadExportJob", "api.export.download.not_ready.app_error", nil, "job_id="+job.Id, http.StatusBadRequest)
+		return
+	}
+
+	fileReader, err := c.App.FileReader(path)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	defer fileReader.Close()
+
+	c.LogAudit("job_id=" + job.Id)
+
+	err = writeFileResponse(filepath.Base(path), "", 0, time.Unix(0, job.LastActivityAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, true, w, r)
+	if err != nil {
+		c.Err = err
+		return
+	}
+}
+
+// messageExportJobForClient summarizes the given message export job, including a link to download
+// the export once it has completed.
+func messageExportJobForClient(c *Context, job *model.Job) *model.MessageExportJob {
+	exportJob := &model.MessageExportJob{
+		JobId:    job.Id,
+		Status:   job.Status,
+		Progress: job.Progress,
+	}
+
+	if job.Status == model.JOB_STATUS_SUCCESS && job.Data[model.JOB_DATA_EXPORT_FILE_PATH] != "" {
+		exportJob.DownloadUrl = c.GetSiteURLHeader() + model.API_URL_SUFFIX + "/export/jobs/" + job.Id + "/download"
+	}
+
+	return exportJob
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package api4
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattermost/mattermost-server/messageexport"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleMessageExport(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	request := &model.MessageExportRequest{
+		Type:     model.COMPLIANCE_EXPORT_TYPE_CSV,
+		Channels: []string{th.BasicChannel.Id},
+		From:     1,
+		To:       model.GetMillis(),
+	}
+
+	t.Run("without license", func(t *testing.T) {
+		th.App.SetLicense(nil)
+
+		_, resp := th.SystemAdminClient.ScheduleMessageExport(request)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.SetLicense(model.NewTestLicense("message_export"))
+
+	t.Run("without permission", func(t *testing.T) {
+		_, resp := th.Client.ScheduleMessageExport(request)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.ScheduleMessageExport(&model.MessageExportRequest{Type: "pdf"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("schedule, get and cancel", func(t *testing.T) {
+		exportJob, resp := th.SystemAdminClient.ScheduleMessageExport(request)
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		require.NotEmpty(t, exportJob.JobId)
+		defer th.App.Srv.Store.Job().Delete(exportJob.JobId)
+
+		job, err := th.App.GetJob(exportJob.JobId)
+		require.Nil(t, err)
+		assert.Equal(t, model.JOB_TYPE_SCHEDULED_MESSAGE_EXPORT, job.Type)
+		assert.Equal(t, model.MESSAGE_EXPORT_FORMAT_ZIP, job.Data[model.JOB_DATA_EXPORT_FORMAT])
+		assert.Equal(t, th.BasicChannel.Id, job.Data[model.JOB_DATA_EXPORT_CHANNEL_IDS])
+
+		received, resp := th.SystemAdminClient.GetMessageExportJob(exportJob.JobId)
+		CheckNoError(t, resp)
+		assert.Equal(t, exportJob.JobId, received.JobId)
+		assert.Empty(t, received.DownloadUrl)
+
+		_, resp = th.Client.GetMessageExportJob(exportJob.JobId)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.Client.CancelMessageExportJob(exportJob.JobId)
+		CheckForbiddenStatus(t, resp)
+
+		ok, resp := th.SystemAdminClient.CancelMessageExportJob(exportJob.JobId)
+		CheckNoError(t, resp)
+		require.True(t, ok)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.ScheduleMessageExport(&model.MessageExportRequest{Type: model.COMPLIANCE_EXPORT_TYPE_ACTIANCE})
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("worker exports the requested channels", func(t *testing.T) {
+		otherPost := th.CreatePostWithClient(th.Client, th.BasicChannel2)
+
+		th.App.Srv.Jobs.StartWorkers()
+		defer th.App.Srv.Jobs.StopWorkers()
+
+		for _, format := range []string{model.MESSAGE_EXPORT_FORMAT_STREAM, model.MESSAGE_EXPORT_FORMAT_ZIP} {
+			exportJob, resp := th.SystemAdminClient.ScheduleMessageExport(&model.MessageExportRequest{
+				Type:     model.COMPLIANCE_EXPORT_TYPE_CSV,
+				Channels: []string{th.BasicChannel.Id},
+				From:     1,
+				Format:   format,
+			})
+			CheckNoError(t, resp)
+			defer th.App.Srv.Store.Job().Delete(exportJob.JobId)
+
+			for i := 0; i < 100 && exportJob.Status != model.JOB_STATUS_SUCCESS; i++ {
+				require.NotEqual(t, model.JOB_STATUS_ERROR, exportJob.Status)
+
+				th.App.Srv.Jobs.Workers.Watcher.PollAndNotify()
+				time.Sleep(100 * time.Millisecond)
+
+				exportJob, resp = th.SystemAdminClient.GetMessageExportJob(exportJob.JobId)
+				CheckNoError(t, resp)
+			}
+			require.Equal(t, model.JOB_STATUS_SUCCESS, exportJob.Status)
+			require.True(t, strings.HasSuffix(exportJob.DownloadUrl, "/api/v4/export/jobs/"+exportJob.JobId+"/download"))
+
+			job, err := th.App.GetJob(exportJob.JobId)
+			require.Nil(t, err)
+			defer th.App.RemoveFile(job.Data[model.JOB_DATA_EXPORT_FILE_PATH])
+
+			r, appErr := th.SystemAdminClient.DoApiGet("/export/jobs/"+exportJob.JobId+"/download", "")
+			require.Nil(t, appErr)
+			data, readErr := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			require.Nil(t, readErr)
+
+			if format == model.MESSAGE_EXPORT_FORMAT_ZIP {
+				zipReader, zipErr := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				require.Nil(t, zipErr)
+				require.Len(t, zipReader.File, 1)
+
+				file, zipErr := zipReader.File[0].Open()
+				require.Nil(t, zipErr)
+				data, readErr = ioutil.ReadAll(file)
+				file.Close()
+				require.Nil(t, readErr)
+			}
+
+			records, csvErr := csv.NewReader(bytes.NewReader(data)).ReadAll()
+			require.Nil(t, csvErr)
+
+			var postIds []string
+			for _, record := range records[1:] {
+				assert.Equal(t, th.BasicChannel.Id, record[4])
+				postIds = append(postIds, record[11])
+			}
+			assert.Contains(t, postIds, th.BasicPost.Id)
+			assert.NotContains(t, postIds, otherPost.Id)
+		}
+	})
+
+	t.Run("other job types are not found", func(t *testing.T) {
+		job := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JOB_TYPE_DATA_RETENTION,
+			Status: model.JOB_STATUS_PENDING,
+		}
+		_, err := th.App.Srv.Store.Job().Save(job)
+		require.Nil(t, err)
+		defer th.App.Srv.Store.Job().Delete(job.Id)
+
+		_, resp := th.SystemAdminClient.GetMessageExportJob(job.Id)
+		CheckNotFoundStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CancelMessageExportJob(job.Id)
+		CheckNotFoundStatus(t, resp)
+	})
+}
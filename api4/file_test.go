@@ -703,6 +703,9 @@ func TestGetFileHeaders(t *testing.T) {
 		t.Skip("skipping because no file driver is enabled")
 	}
 
+	// HTML and JavaScript are blocked by default, but they must still be served safely if an administrator allows them
+	th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.BlockedMIMETypes = []string{} })
+
 	testHeaders := func(data []byte, filename string, expectedContentType string, getInline bool) func(*testing.T) {
 		return func(t *testing.T) {
 			fileResp, resp := Client.UploadFile(data, channel.Id, filename)
@@ -758,6 +761,81 @@ func TestGetFileHeaders(t *testing.T) {
 	t.Run("no extension 2", testHeaders([]byte("<html></html>"), "test", "application/octet-stream", false))
 }
 
+func TestUploadFileTypeRestrictions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	channel := th.BasicChannel
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	t.Run("blocked by default", func(t *testing.T) {
+		_, resp := Client.UploadFile([]byte("<html></html>"), channel.Id, "test.html")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.file.upload_file.blocked_type.app_error")
+	})
+
+	t.Run("blocked based on contents", func(t *testing.T) {
+		_, resp := Client.UploadFile([]byte("<html><script>alert(1)</script></html>"), channel.Id, "test.txt")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.file.upload_file.blocked_type.app_error")
+	})
+
+	t.Run("allowed types", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.AllowedMIMETypes = []string{"image/*", "text/plain"} })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.AllowedMIMETypes = []string{} })
+
+		fileResp, resp := Client.UploadFile([]byte("ABC"), channel.Id, "test.txt")
+		CheckNoError(t, resp)
+		require.Len(t, fileResp.FileInfos, 1)
+
+		_, resp = Client.UploadFile([]byte("ABC"), channel.Id, "test.pdf")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.file.upload_file.type_not_allowed.app_error")
+	})
+
+	t.Run("executables blocked based on contents", func(t *testing.T) {
+		pe := make([]byte, 0x44)
+		copy(pe, "MZ")
+		pe[0x3c] = 0x40
+		copy(pe[0x40:], "PE\x00\x00")
+
+		for name, data := range map[string][]byte{
+			"elf":    append([]byte("\x7fELF"), 2, 1, 1, 0),
+			"pe":     pe,
+			"mach-o": {0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, resp := Client.UploadFile(data, channel.Id, "test.png")
+				CheckBadRequestStatus(t, resp)
+				CheckErrorMessage(t, resp, "api.file.upload_file.blocked_type.app_error")
+			})
+		}
+	})
+
+	t.Run("allowed types based on contents", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.AllowedMIMETypes = []string{"image/*", "text/plain"} })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { cfg.FileSettings.AllowedMIMETypes = []string{} })
+
+		_, resp := Client.UploadFile([]byte("%PDF-1.4"), channel.Id, "test.txt")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.file.upload_file.type_not_allowed.app_error")
+	})
+
+	t.Run("blocklist takes precedence", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.FileSettings.AllowedMIMETypes = []string{"text/*"}
+			cfg.FileSettings.BlockedMIMETypes = []string{"text/csv"}
+		})
+
+		_, resp := Client.UploadFile([]byte("a,b,c"), channel.Id, "test.csv")
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.file.upload_file.blocked_type.app_error")
+	})
+}
+
 func TestGetFileThumbnail(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -15,6 +15,7 @@ func (api *API) InitLicense() {
 	api.BaseRoutes.ApiRoot.Handle("/license", api.ApiSessionRequired(addLicense)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/license", api.ApiSessionRequired(removeLicense)).Methods("DELETE")
 	api.BaseRoutes.ApiRoot.Handle("/license/client", api.ApiHandler(getClientLicense)).Methods("GET")
+	api.BaseRoutes.System.Handle("/license/preview", api.ApiSessionRequired(previewLicense)).Methods("POST")
 }
 
 func getClientLicense(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -108,6 +109,48 @@ func addLicense(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(license.ToJson()))
 }
 
+func previewLicense(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	err := r.ParseMultipartForm(*c.App.Config().FileSettings.MaxFileSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fileArray, ok := r.MultipartForm.File["license"]
+	if !ok {
+		c.Err = model.NewAppError("previewLicense", "api.license.add_license.no_file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if len(fileArray) <= 0 {
+		c.Err = model.NewAppError("previewLicense", "api.license.add_license.array.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	file, err := fileArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("previewLicense", "api.license.add_license.open.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	buf := bytes.NewBuffer(nil)
+	io.Copy(buf, file)
+
+	preview, appErr := c.App.PreviewLicense(buf.Bytes())
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Write([]byte(preview.ToJson()))
+}
+
 func removeLicense(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.LogAudit("attempt")
 
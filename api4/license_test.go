@@ -4,9 +4,19 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testLicenseValidator accepts any license given as its unsigned JSON.
+type testLicenseValidator struct{}
+
+func (*testLicenseValidator) ValidateLicense(signed []byte) (bool, string) {
+	return true, string(signed)
+}
+
 func TestGetOldClientLicense(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -72,6 +82,60 @@ func TestUploadLicenseFile(t *testing.T) {
 	})
 }
 
+func TestPreviewLicenseFile(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("as system user", func(t *testing.T) {
+		preview, resp := Client.PreviewLicenseFile([]byte{})
+		CheckForbiddenStatus(t, resp)
+		require.Nil(t, preview)
+	})
+
+	t.Run("as system admin user with an invalid license", func(t *testing.T) {
+		license := model.NewTestLicense()
+		th.App.SetLicense(license)
+		defer th.App.SetLicense(nil)
+
+		preview, resp := th.SystemAdminClient.PreviewLicenseFile([]byte("not a license"))
+		CheckBadRequestStatus(t, resp)
+		CheckErrorMessage(t, resp, model.INVALID_LICENSE_ERROR)
+		require.Nil(t, preview)
+
+		// The current license is left untouched.
+		require.Equal(t, license, th.App.License())
+	})
+}
+
+func TestPreviewLicenseFileWithValidLicense(t *testing.T) {
+	th := SetupWithServerOptions(app.LicenseValidator(&testLicenseValidator{})).InitBasic()
+	defer th.TearDown()
+
+	license := model.NewTestLicense()
+	*license.Features.Cluster = false
+	th.App.SetLicense(license)
+	defer th.App.SetLicense(nil)
+
+	newLicense := model.NewTestLicense()
+	*newLicense.Features.LDAP = false
+	newLicense.ExpiresAt = model.GetMillis() + 60*60*1000
+
+	preview, resp := th.SystemAdminClient.PreviewLicenseFile([]byte(newLicense.ToJson()))
+	CheckNoError(t, resp)
+	require.NotNil(t, preview)
+
+	assert.Equal(t, false, preview.CurrentFeatures["cluster"])
+	assert.Equal(t, true, preview.NewFeatures["cluster"])
+	assert.Equal(t, []*model.LicenseFeatureDiff{
+		{Feature: "cluster", EnabledBefore: false, EnabledAfter: true},
+		{Feature: "ldap", EnabledBefore: true, EnabledAfter: false},
+	}, preview.Diff)
+
+	// The current license is left untouched.
+	require.Equal(t, license, th.App.License())
+}
+
 func TestRemoveLicenseFile(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,385 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// The openapi_generator command generates an OpenAPI 3.0 spec for the API by walking the routes
+// registered by api4.Init and reading the doc comments of their handlers.
+//
+// A handler's doc comment starts with the handler name followed by a summary sentence. Any further
+// paragraphs become the description of the operation, except for lines in one of these formats:
+//
+//	@query <name> <type> <description>    documents a query string parameter
+//	@response <status> <description>      documents a response status
+//
+// Handlers without a doc comment are summarized from their name.
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost-server/api4"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/web"
+)
+
+const SPEC_FILE = "openapi.json"
+
+func main() {
+	check := flag.Bool("check", false, "verify that "+SPEC_FILE+" matches the generated spec instead of writing it")
+	flag.Parse()
+
+	spec, err := GenerateSpec()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *check {
+		committed, err := ioutil.ReadFile(SPEC_FILE)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if !bytes.Equal(committed, spec) {
+			fmt.Fprintln(os.Stderr, "api4/"+SPEC_FILE+" is out of date. Run `make openapi-spec` and commit the result.")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(SPEC_FILE, spec, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type Spec struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Type    string   `json:"type"`
+	Pattern string   `json:"pattern,omitempty"`
+	Enum    []string `json:"enum,omitempty"`
+}
+
+type Response struct {
+	Description string `json:"description"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// HandlerDoc is the documentation parsed from a handler's doc comment.
+type HandlerDoc struct {
+	Summary     string
+	Description string
+	Query       []*Parameter
+	Responses   map[string]*Response
+}
+
+// GenerateSpec builds the spec from the routes registered by api4.Init and the handler doc
+// comments in the current directory, which is expected to be the api4 package.
+func GenerateSpec() ([]byte, error) {
+	docs, err := ParseHandlerDocs(".")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	// Gzip would hide the handler behind another http.Handler.
+	*cfg.ServiceSettings.WebserverMode = "disabled"
+
+	root := mux.NewRouter()
+	api4.Init(&configService{cfg}, nil, root)
+
+	spec := &Spec{
+		OpenAPI: "3.0.0",
+		Info: Info{
+			Title:   "Mattermost API",
+			Version: "4.0.0",
+		},
+		Paths: map[string]map[string]*Operation{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	operationIds := map[string]bool{}
+	err = root.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		handler, ok := route.GetHandler().(*web.Handler)
+		if !ok {
+			return nil
+		}
+
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fmt.Errorf("route %v must be restricted to specific methods", template)
+		}
+
+		path, parameters := ConvertPathTemplate(template)
+		for _, method := range methods {
+			operation := NewOperation(handler, path, parameters, docs[handler.HandlerName])
+
+			// Handlers registered on several routes need distinct operation ids.
+			operationId := operation.OperationId
+			for i := 2; operationIds[operation.OperationId]; i++ {
+				operation.OperationId = fmt.Sprintf("%v%v", operationId, i)
+			}
+			operationIds[operation.OperationId] = true
+
+			if spec.Paths[path] == nil {
+				spec.Paths[path] = map[string]*Operation{}
+			}
+			spec.Paths[path][strings.ToLower(method)] = operation
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+func NewOperation(handler *web.Handler, path string, parameters []*Parameter, doc *HandlerDoc) *Operation {
+	operation := &Operation{
+		OperationId: handler.HandlerName,
+		Summary:     HumanizeHandlerName(handler.HandlerName),
+		Tags:        []string{PathTag(path)},
+		Parameters:  append([]*Parameter{}, parameters...),
+		Responses: map[string]*Response{
+			"default": {Description: "See the Mattermost API reference for the possible responses."},
+		},
+		Security: []map[string][]string{},
+	}
+
+	if handler.RequireSession {
+		operation.Security = append(operation.Security, map[string][]string{"bearerAuth": {}})
+	}
+
+	if doc != nil {
+		if doc.Summary != "" {
+			operation.Summary = doc.Summary
+		}
+		operation.Description = doc.Description
+		operation.Parameters = append(operation.Parameters, doc.Query...)
+		if len(doc.Responses) > 0 {
+			operation.Responses = doc.Responses
+		}
+	}
+
+	return operation
+}
+
+var pathVariableRegexp = regexp.MustCompile(`\{([A-Za-z0-9_]+)(?::([^}]*))?\}`)
+var enumPatternRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+(\|[A-Za-z0-9_]+)+$`)
+
+// ConvertPathTemplate strips the regular expressions from the variables in the given mux path
+// template and returns them as path parameters.
+func ConvertPathTemplate(template string) (string, []*Parameter) {
+	var parameters []*Parameter
+
+	path := pathVariableRegexp.ReplaceAllStringFunc(template, func(variable string) string {
+		match := pathVariableRegexp.FindStringSubmatch(variable)
+		name, pattern := match[1], match[2]
+
+		schema := &Schema{Type: "string"}
+		if enumPatternRegexp.MatchString(pattern) {
+			schema.Enum = strings.Split(pattern, "|")
+		} else if pattern != "" {
+			schema.Pattern = "^" + pattern + "$"
+		}
+
+		parameters = append(parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+
+		return "{" + name + "}"
+	})
+
+	return path, parameters
+}
+
+// PathTag groups operations by the first segment of their path below the API root.
+func PathTag(path string) string {
+	path = strings.TrimPrefix(path, model.API_URL_SUFFIX)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	return segments[0]
+}
+
+// HumanizeHandlerName turns a handler name such as getChannelStats into "Get channel stats".
+func HumanizeHandlerName(name string) string {
+	var words []string
+	var word []rune
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	words = append(words, string(word))
+
+	summary := strings.Join(words, " ")
+	return strings.ToUpper(summary[:1]) + summary[1:]
+}
+
+// ParseHandlerDocs parses the doc comments of the functions in the given package directory.
+func ParseHandlerDocs(dir string) (map[string]*HandlerDoc, error) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := map[string]*HandlerDoc{}
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Recv != nil || funcDecl.Doc == nil {
+					continue
+				}
+
+				doc, err := ParseHandlerDoc(funcDecl.Name.Name, funcDecl.Doc.Text())
+				if err != nil {
+					return nil, fmt.Errorf("%v: %v", fset.Position(funcDecl.Pos()), err)
+				}
+				docs[funcDecl.Name.Name] = doc
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// ParseHandlerDoc parses the given doc comment of the named handler.
+func ParseHandlerDoc(name string, text string) (*HandlerDoc, error) {
+	doc := &HandlerDoc{Responses: map[string]*Response{}}
+
+	var paragraphs []string
+	var paragraph []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "@query "):
+			fields := strings.SplitN(strings.TrimPrefix(line, "@query "), " ", 3)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("expected \"@query <name> <type> <description>\", got %q", line)
+			}
+			parameter := &Parameter{Name: fields[0], In: "query", Schema: &Schema{Type: fields[1]}}
+			if len(fields) == 3 {
+				parameter.Description = fields[2]
+			}
+			doc.Query = append(doc.Query, parameter)
+		case strings.HasPrefix(line, "@response "):
+			fields := strings.SplitN(strings.TrimPrefix(line, "@response "), " ", 2)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("expected \"@response <status> <description>\", got %q", line)
+			}
+			if status, err := strconv.Atoi(fields[0]); err != nil || http.StatusText(status) == "" {
+				return nil, fmt.Errorf("invalid response status in %q", line)
+			}
+			doc.Responses[fields[0]] = &Response{Description: fields[1]}
+		case line == "":
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+				paragraph = nil
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, strings.Join(paragraph, " "))
+	}
+
+	if len(paragraphs) > 0 {
+		summary := strings.TrimSpace(strings.TrimPrefix(paragraphs[0], name))
+		if summary != "" {
+			doc.Summary = strings.ToUpper(summary[:1]) + summary[1:]
+		}
+		doc.Description = strings.Join(paragraphs[1:], "\n\n")
+	}
+
+	return doc, nil
+}
+
+// configService provides the default configuration to api4.Init without starting a server.
+type configService struct {
+	config *model.Config
+}
+
+func (s *configService) Config() *model.Config                                     { return s.config }
+func (s *configService) AddConfigListener(func(old, current *model.Config)) string { return "" }
+func (s *configService) RemoveConfigListener(string)                               {}
+func (s *configService) AsymmetricSigningKey() *ecdsa.PrivateKey                   { return nil }
@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
@@ -39,6 +40,7 @@ func (api *API) InitPlugin() {
 	api.BaseRoutes.Plugins.Handle("/webapp", api.ApiHandler(getWebappPlugins)).Methods("GET")
 
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.ApiSessionRequired(getMarketplacePlugins)).Methods("GET")
+	api.BaseRoutes.Plugins.Handle("/marketplace/bundle", api.ApiSessionRequired(uploadMarketplaceBundle)).Methods("POST")
 }
 
 func uploadPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -279,6 +281,53 @@ func getMarketplacePlugins(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(json)
 }
 
+func uploadMarketplaceBundle(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("uploadMarketplaceBundle", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if err := r.ParseMultipartForm(MAXIMUM_PLUGIN_FILE_SIZE); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bundleArray, ok := r.MultipartForm.File["bundle"]
+	if !ok || len(bundleArray) == 0 {
+		c.Err = model.NewAppError("uploadMarketplaceBundle", "api.plugin.upload_marketplace_bundle.no_file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	file, err := bundleArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("uploadMarketplaceBundle", "api.plugin.upload.file.app_error", nil, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	plugins, appErr := c.App.SaveLocalMarketplaceBundle(file)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	c.LogAudit("plugins=" + strconv.Itoa(len(plugins)))
+
+	json, err := json.Marshal(plugins)
+	if err != nil {
+		c.Err = model.NewAppError("uploadMarketplaceBundle", "app.plugin.marshal.app_error", nil, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(json)
+}
+
 func enablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePluginId()
 	if c.Err != nil {
@@ -295,6 +344,12 @@ func enablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Enabling or disabling a plugin changes the configuration.
+	if *c.App.Config().ServiceSettings.RequireConfigChangeApproval {
+		c.Err = model.NewAppError("enablePlugin", "api.plugin.enable_plugin.approval_required.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
 	if err := c.App.EnablePlugin(c.Params.PluginId); err != nil {
 		c.Err = err
 		return
@@ -319,6 +374,12 @@ func disablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Enabling or disabling a plugin changes the configuration.
+	if *c.App.Config().ServiceSettings.RequireConfigChangeApproval {
+		c.Err = model.NewAppError("disablePlugin", "api.plugin.disable_plugin.approval_required.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
 	if err := c.App.DisablePlugin(c.Params.PluginId); err != nil {
 		c.Err = err
 		return
@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/testlib"
 	"github.com/mattermost/mattermost-server/utils/fileutils"
@@ -746,6 +747,97 @@ func TestSearchGetMarketplacePlugins(t *testing.T) {
 	})
 }
 
+func TestLocalMarketplace(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	bundle := []*model.BaseMarketplacePlugin{
+		{
+			HomepageURL: "https://github.com/mattermost/mattermost-plugin-nps",
+			DownloadURL: "https://example.com/com.mattermost.nps-1.0.3.tar.gz",
+			Manifest: &model.Manifest{
+				Id:               "com.mattermost.nps",
+				Name:             "User Satisfaction Surveys",
+				Version:          "1.0.3",
+				MinServerVersion: "5.14.0",
+			},
+		},
+		{
+			HomepageURL: "https://github.com/mattermost/mattermost-plugin-future",
+			DownloadURL: "https://example.com/com.mattermost.future-1.0.0.tar.gz",
+			Manifest: &model.Manifest{
+				Id:               "com.mattermost.future",
+				Name:             "Future Plugin",
+				Version:          "1.0.0",
+				MinServerVersion: "99.0.0",
+			},
+		},
+	}
+	bundleJson, err := json.Marshal(bundle)
+	require.NoError(t, err)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableMarketplace = true
+		*cfg.PluginSettings.MarketplaceUrl = th.Client.Url + app.LOCAL_MARKETPLACE_ROUTE
+	})
+
+	t.Run("no bundle uploaded", func(t *testing.T) {
+		plugins, resp := th.SystemAdminClient.GetMarketplacePlugins(&model.MarketplacePluginFilter{})
+		CheckInternalErrorStatus(t, resp)
+		require.Nil(t, plugins)
+	})
+
+	t.Run("upload requires permission", func(t *testing.T) {
+		plugins, resp := th.Client.UploadMarketplaceBundle(bytes.NewReader(bundleJson))
+		CheckForbiddenStatus(t, resp)
+		require.Nil(t, plugins)
+	})
+
+	t.Run("upload invalid bundle", func(t *testing.T) {
+		plugins, resp := th.SystemAdminClient.UploadMarketplaceBundle(strings.NewReader("not json"))
+		CheckBadRequestStatus(t, resp)
+		require.Nil(t, plugins)
+
+		plugins, resp = th.SystemAdminClient.UploadMarketplaceBundle(strings.NewReader(`[{"download_url": "https://example.com"}]`))
+		CheckErrorMessage(t, resp, "app.plugin.marketplace_bundle.invalid_plugin.app_error")
+		require.Nil(t, plugins)
+	})
+
+	t.Run("serve uploaded bundle", func(t *testing.T) {
+		plugins, resp := th.SystemAdminClient.UploadMarketplaceBundle(bytes.NewReader(bundleJson))
+		CheckNoError(t, resp)
+		CheckCreatedStatus(t, resp)
+		require.Equal(t, bundle, plugins)
+
+		// The marketplace client filters out plugins requiring a newer server.
+		marketplacePlugins, resp := th.SystemAdminClient.GetMarketplacePlugins(&model.MarketplacePluginFilter{})
+		CheckNoError(t, resp)
+		require.Len(t, marketplacePlugins, 1)
+		require.Equal(t, bundle[0], marketplacePlugins[0].BaseMarketplacePlugin)
+
+		marketplacePlugins, resp = th.SystemAdminClient.GetMarketplacePlugins(&model.MarketplacePluginFilter{Filter: "NOFILTER"})
+		CheckNoError(t, resp)
+		require.Empty(t, marketplacePlugins)
+	})
+
+	t.Run("serve marketplace api", func(t *testing.T) {
+		res, err := http.Get(th.Client.Url + app.LOCAL_MARKETPLACE_ROUTE + "/api/v1/plugins?page=1&per_page=1")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		plugins, err := model.BaseMarketplacePluginsFromReader(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []*model.BaseMarketplacePlugin{bundle[1]}, plugins)
+
+		res, err = http.Get(th.Client.Url + app.LOCAL_MARKETPLACE_ROUTE + "/api/v1/plugins?per_page=-1")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
 func findClusterMessages(event string, msgs []*model.ClusterMessage) []*model.ClusterMessage {
 	var result []*model.ClusterMessage
 	for _, msg := range msgs {
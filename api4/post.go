@@ -13,6 +13,14 @@ import (
 	"github.com/mattermost/mattermost-server/model"
 )
 
+const (
+	DEFAULT_RELATED_POSTS_LIMIT = 5
+	MAX_RELATED_POSTS_LIMIT     = 20
+
+	DEFAULT_POST_STREAM_HEARTBEAT_INTERVAL_SECONDS = 30
+	MAX_POST_STREAM_HEARTBEAT_INTERVAL_SECONDS     = 300
+)
+
 func (api *API) InitPost() {
 	api.BaseRoutes.Posts.Handle("", api.ApiSessionRequired(createPost)).Methods("POST")
 	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(getPost)).Methods("GET")
@@ -20,12 +28,19 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Posts.Handle("/ephemeral", api.ApiSessionRequired(createEphemeralPost)).Methods("POST")
 	api.BaseRoutes.Post.Handle("/thread", api.ApiSessionRequired(getPostThread)).Methods("GET")
 	api.BaseRoutes.Post.Handle("/files/info", api.ApiSessionRequired(getFileInfosForPost)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/related", api.ApiSessionRequired(getRelatedPosts)).Methods("GET")
 	api.BaseRoutes.PostsForChannel.Handle("", api.ApiSessionRequired(getPostsForChannel)).Methods("GET")
+	api.BaseRoutes.PostsForChannel.Handle("/stream", api.ApiSessionRequired(streamPostsForChannel)).Methods("GET")
 	api.BaseRoutes.PostsForUser.Handle("/flagged", api.ApiSessionRequired(getFlaggedPostsForUser)).Methods("GET")
+	api.BaseRoutes.PostsForUser.Handle("/saved", api.ApiSessionRequired(getSavedPosts)).Methods("GET")
+	api.BaseRoutes.PostsForUser.Handle("/saved", api.ApiSessionRequired(addSavedPost)).Methods("POST")
+	api.BaseRoutes.PostsForUser.Handle("/saved", api.ApiSessionRequired(removeSavedPost)).Methods("DELETE")
+	api.BaseRoutes.User.Handle("/mentions", api.ApiSessionRequired(getMentionsForUser)).Methods("GET")
 
 	api.BaseRoutes.ChannelForUser.Handle("/posts/unread", api.ApiSessionRequired(getPostsForChannelAroundLastUnread)).Methods("GET")
 
 	api.BaseRoutes.Team.Handle("/posts/search", api.ApiSessionRequired(searchPosts)).Methods("POST")
+	api.BaseRoutes.Team.Handle("/posts/search/count", api.ApiSessionRequired(countSearchPosts)).Methods("POST")
 	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(updatePost)).Methods("PUT")
 	api.BaseRoutes.Post.Handle("/patch", api.ApiSessionRequired(patchPost)).Methods("PUT")
 	api.BaseRoutes.Post.Handle("/pin", api.ApiSessionRequired(pinPost)).Methods("POST")
@@ -105,6 +120,76 @@ func createEphemeralPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(rp.ToJson()))
 }
 
+// streamPostsForChannel streams the posts created in a channel as newline-delimited JSON.
+//
+// This is intended for clients that can't open a websocket. A {"type":"heartbeat"} line is sent
+// whenever no posts have been sent for the heartbeat interval, and each user may only have one
+// stream open at a time. Streams are still closed after ServiceSettings.WriteTimeout, after which
+// clients should reconnect.
+//
+// @query heartbeat_interval_seconds integer The number of seconds between heartbeats, up to 300. Defaults to 30.
+func streamPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	heartbeatInterval := DEFAULT_POST_STREAM_HEARTBEAT_INTERVAL_SECONDS
+	if intervalStr := r.URL.Query().Get("heartbeat_interval_seconds"); intervalStr != "" {
+		var err error
+		if heartbeatInterval, err = strconv.Atoi(intervalStr); err != nil || heartbeatInterval <= 0 || heartbeatInterval > MAX_POST_STREAM_HEARTBEAT_INTERVAL_SECONDS {
+			c.SetInvalidUrlParam("heartbeat_interval_seconds")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		c.Err = model.NewAppError("streamPostsForChannel", "api.post.stream_posts.not_supported.app_error", nil, "", http.StatusInternalServerError)
+		return
+	}
+
+	stream, err := c.App.OpenPostStream(c.App.Session.UserId, c.Params.ChannelId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	defer c.App.ClosePostStream(stream)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(time.Duration(heartbeatInterval) * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		var line []byte
+		select {
+		case post, ok := <-stream.Posts:
+			if !ok {
+				return
+			}
+			line = []byte(c.App.PreparePostForClient(post, false, false).ToJson())
+		case <-heartbeat.C:
+			line = []byte(`{"type":"heartbeat"}`)
+		case <-r.Context().Done():
+			return
+		}
+
+		if _, writeErr := w.Write(append(line, '\n')); writeErr != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
 func getPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
 	if c.Err != nil {
@@ -307,6 +392,127 @@ func getFlaggedPostsForUser(c *Context, w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(c.App.PreparePostListForClient(pl).ToJson()))
 }
 
+// getSavedPosts gets a page of the posts a user has saved for later, most recently saved first.
+//
+// Saved posts are private, so users can only get their own. Posts flagged before saved posts were
+// introduced are included.
+func getSavedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	savedPosts, err := c.App.GetSavedPosts(c.Params.UserId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SavedPostListToJson(savedPosts)))
+}
+
+// addSavedPost saves a post for later.
+//
+// The post must be in a channel the user can read. Saving a post that is already saved succeeds
+// without changing it.
+func addSavedPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	savedPost := model.SavedPostFromJson(r.Body)
+	if savedPost == nil || !model.IsValidId(savedPost.PostId) {
+		c.SetInvalidParam("post_id")
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, savedPost.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	savedPost, err := c.App.AddSavedPost(c.Params.UserId, savedPost.PostId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(savedPost.ToJson()))
+}
+
+// removeSavedPost removes a post from the posts a user has saved for later.
+func removeSavedPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	savedPost := model.SavedPostFromJson(r.Body)
+	if savedPost == nil || !model.IsValidId(savedPost.PostId) {
+		c.SetInvalidParam("post_id")
+		return
+	}
+
+	if c.Params.UserId != c.App.Session.UserId {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	if err := c.App.RemoveSavedPost(c.Params.UserId, savedPost.PostId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+// getMentionsForUser gets a page of the posts that mentioned a user, newest first.
+//
+// Posts that have been deleted or that are in channels the user is no longer a member of are
+// excluded. Mentions are recorded shortly after a post is created, so posts created before
+// mentions were recorded aren't included.
+//
+// @query team_id string Only return mentions on this team and in direct and group messages.
+// @query page integer The page to select.
+// @query per_page integer The number of posts per page.
+func getMentionsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	teamId := r.URL.Query().Get("team_id")
+	if teamId != "" && !model.IsValidId(teamId) {
+		c.SetInvalidUrlParam("team_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	posts, err := c.App.GetMentionsForUser(c.Params.UserId, teamId, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(c.App.PreparePostListForClient(posts).ToJson()))
+}
+
 func getPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -347,6 +553,40 @@ func getPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(post.ToJson()))
 }
 
+// getRelatedPosts gets posts similar to the given post that the user can read.
+//
+// Requires Elasticsearch and SearchSettings.EnableRelatedPosts.
+//
+// @query limit integer The maximum number of posts to return, up to 20. Defaults to 5.
+func getRelatedPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	limit := DEFAULT_RELATED_POSTS_LIMIT
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitStr); err != nil || limit <= 0 || limit > MAX_RELATED_POSTS_LIMIT {
+			c.SetInvalidUrlParam("limit")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_READ_CHANNEL) {
+		c.SetPermissionError(model.PERMISSION_READ_CHANNEL)
+		return
+	}
+
+	posts, err := c.App.GetRelatedPostsForUser(c.Params.PostId, c.App.Session.UserId, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.PostSliceToJson(posts)))
+}
+
 func deletePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -494,6 +734,54 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(results.ToJson()))
 }
 
+// countSearchPosts returns the number of posts matching a search, without returning the posts.
+//
+// Accepts the same parameters as a post search, apart from page and per_page, and counts the posts
+// in the channels the user is a member of. Unlike a search, the count isn't limited by
+// SearchSettings.MaxSearchResults, though posts matching hashtags are counted up to 1000.
+func countSearchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	params := model.SearchParameterFromJson(r.Body)
+
+	if params.Terms == nil || len(*params.Terms) == 0 {
+		c.SetInvalidParam("terms")
+		return
+	}
+
+	timeZoneOffset := 0
+	if params.TimeZoneOffset != nil {
+		timeZoneOffset = *params.TimeZoneOffset
+	}
+
+	isOrSearch := false
+	if params.IsOrSearch != nil {
+		isOrSearch = *params.IsOrSearch
+	}
+
+	includeDeletedChannels := false
+	if params.IncludeDeletedChannels != nil {
+		includeDeletedChannels = *params.IncludeDeletedChannels
+	}
+
+	count, err := c.App.CountPostsInTeamForUser(*params.Terms, c.App.Session.UserId, c.Params.TeamId, isOrSearch, includeDeletedChannels, timeZoneOffset)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte((&model.PostSearchCount{Count: count}).ToJson()))
+}
+
 func updatePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
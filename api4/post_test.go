@@ -4,8 +4,10 @@
 package api4
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -1167,6 +1169,199 @@ func TestGetPostsForChannel(t *testing.T) {
 	}
 }
 
+func TestStreamPostsForChannel(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("streams new posts", func(t *testing.T) {
+		stream, resp := Client.StreamPostsForChannel(th.BasicChannel.Id, 60)
+		CheckNoError(t, resp)
+		defer stream.Close()
+
+		// A second stream for the same user is rejected while the first is open.
+		_, resp = Client.StreamPostsForChannel(th.BasicChannel.Id, 60)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		post, resp := th.SystemAdminClient.CreatePost(&model.Post{ChannelId: th.BasicChannel.Id, Message: "streamed"})
+		CheckNoError(t, resp)
+
+		line, err := bufio.NewReader(stream).ReadString('\n')
+		require.Nil(t, err)
+
+		received := model.PostFromJson(strings.NewReader(line))
+		require.NotNil(t, received)
+		assert.Equal(t, post.Id, received.Id)
+		assert.Equal(t, "streamed", received.Message)
+	})
+
+	t.Run("sends heartbeats", func(t *testing.T) {
+		var stream io.ReadCloser
+		var resp *model.Response
+		// The previous stream is only released once the server notices that it was closed.
+		for i := 0; i < 50; i++ {
+			stream, resp = Client.StreamPostsForChannel(th.BasicChannel.Id, 1)
+			if resp.Error == nil {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		CheckNoError(t, resp)
+		defer stream.Close()
+
+		line, err := bufio.NewReader(stream).ReadString('\n')
+		require.Nil(t, err)
+		assert.Equal(t, `{"type":"heartbeat"}`+"\n", line)
+	})
+
+	t.Run("invalid heartbeat interval", func(t *testing.T) {
+		_, resp := Client.StreamPostsForChannel(th.BasicChannel.Id, 0)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.StreamPostsForChannel(th.BasicChannel.Id, MAX_POST_STREAM_HEARTBEAT_INTERVAL_SECONDS+1)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE)
+
+		_, resp := Client.StreamPostsForChannel(privateChannel.Id, 60)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp := client.StreamPostsForChannel(th.BasicChannel.Id, 60)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestGetMentionsForUser(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	otherClient := th.CreateClient()
+	_, resp := otherClient.Login(th.BasicUser2.Email, th.BasicUser2.Password)
+	CheckNoError(t, resp)
+
+	post1 := th.CreateMessagePostWithClient(otherClient, th.BasicChannel, "hello @"+th.BasicUser.Username)
+	th.CreateMessagePostWithClient(otherClient, th.BasicChannel, "no mention")
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post2 := th.CreateMessagePostWithClient(otherClient, dm, "direct message")
+
+	// Mentions are saved asynchronously after the posts are created.
+	var posts *model.PostList
+	for i := 0; i < 50; i++ {
+		posts, resp = Client.GetMentionsForUser(th.BasicUser.Id, "", 0, 60)
+		CheckNoError(t, resp)
+		if len(posts.Order) == 2 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.Equal(t, []string{post2.Id, post1.Id}, posts.Order)
+	assert.Equal(t, post1.Message, posts.Posts[post1.Id].Message)
+
+	posts, resp = Client.GetMentionsForUser(th.BasicUser.Id, "", 1, 1)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{post1.Id}, posts.Order)
+
+	posts, resp = Client.GetMentionsForUser(th.BasicUser.Id, th.BasicTeam.Id, 0, 60)
+	CheckNoError(t, resp)
+	assert.Len(t, posts.Order, 2)
+
+	posts, resp = Client.GetMentionsForUser(th.BasicUser.Id, model.NewId(), 0, 60)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{post2.Id}, posts.Order)
+
+	_, resp = otherClient.DeletePost(post2.Id)
+	CheckNoError(t, resp)
+
+	posts, resp = Client.GetMentionsForUser(th.BasicUser.Id, "", 0, 60)
+	CheckNoError(t, resp)
+	assert.Equal(t, []string{post1.Id}, posts.Order)
+
+	_, resp = Client.GetMentionsForUser(th.BasicUser.Id, "junk", 0, 60)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.GetMentionsForUser(th.BasicUser2.Id, "", 0, 60)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetMentionsForUser(th.BasicUser.Id, "", 0, 60)
+	CheckNoError(t, resp)
+
+	Client.Logout()
+	_, resp = Client.GetMentionsForUser(th.BasicUser.Id, "", 0, 60)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestSavedPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	user := th.BasicUser
+	post := th.CreatePost()
+
+	savedPost, resp := Client.AddSavedPost(user.Id, post.Id)
+	CheckNoError(t, resp)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, post.Id, savedPost.PostId)
+	assert.NotZero(t, savedPost.SavedAt)
+
+	again, resp := Client.AddSavedPost(user.Id, post.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, savedPost.SavedAt, again.SavedAt)
+
+	savedPosts, resp := Client.GetSavedPosts(user.Id, 0, 10)
+	CheckNoError(t, resp)
+	require.Len(t, savedPosts, 1)
+	assert.Equal(t, post.Id, savedPosts[0].PostId)
+
+	t.Run("shown as flagged", func(t *testing.T) {
+		flagged, resp := Client.GetFlaggedPostsForUser(user.Id, 0, 10)
+		CheckNoError(t, resp)
+		assert.Equal(t, []string{post.Id}, flagged.Order)
+
+		preference, resp := Client.GetPreferenceByCategoryAndName(user.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, "true", preference.Value)
+	})
+
+	t.Run("invalid post", func(t *testing.T) {
+		_, resp = Client.AddSavedPost(user.Id, "junk")
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.AddSavedPost(user.Id, model.NewId())
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, resp = Client.GetSavedPosts(th.BasicUser2.Id, 0, 10)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.AddSavedPost(th.BasicUser2.Id, post.Id)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.GetSavedPosts(user.Id, 0, 10)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		ok, resp := Client.RemoveSavedPost(user.Id, post.Id)
+		CheckNoError(t, resp)
+		assert.True(t, ok)
+
+		_, resp = Client.RemoveSavedPost(user.Id, post.Id)
+		CheckNotFoundStatus(t, resp)
+
+		savedPosts, resp = Client.GetSavedPosts(user.Id, 0, 10)
+		CheckNoError(t, resp)
+		assert.Empty(t, savedPosts)
+	})
+}
+
 func TestGetFlaggedPostsForUser(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2159,6 +2354,42 @@ func TestDeletePost(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetRelatedPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("disabled", func(t *testing.T) {
+		_, resp := Client.GetRelatedPosts(th.BasicPost.Id, 5)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.EnableRelatedPosts = true })
+
+	t.Run("elasticsearch not enabled", func(t *testing.T) {
+		_, resp := Client.GetRelatedPosts(th.BasicPost.Id, 5)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, resp := Client.GetRelatedPosts(th.BasicPost.Id, 0)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.GetRelatedPosts(th.BasicPost.Id, MAX_RELATED_POSTS_LIMIT+1)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		privatePost := th.CreatePostWithClient(th.Client, th.CreatePrivateChannel())
+
+		Client.Logout()
+		th.LoginBasic2()
+
+		_, resp := Client.GetRelatedPosts(privatePost.Id, 5)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGetPostThread(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2217,6 +2448,35 @@ func TestGetPostThread(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestCountSearchPosts(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.MaxSearchResults = 1 })
+
+	for i := 0; i < 2; i++ {
+		th.CreateMessagePostWithClient(Client, th.BasicChannel, "countable search")
+	}
+	th.CreateMessagePostWithClient(Client, th.BasicChannel, "unrelated")
+
+	terms := "countable"
+	count, resp := Client.CountSearchPosts(th.BasicTeam.Id, &model.SearchParameter{Terms: &terms})
+	CheckNoError(t, resp)
+	assert.EqualValues(t, 2, count)
+
+	empty := ""
+	_, resp = Client.CountSearchPosts(th.BasicTeam.Id, &model.SearchParameter{Terms: &empty})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.CountSearchPosts(model.NewId(), &model.SearchParameter{Terms: &terms})
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePostSearch = false })
+	_, resp = Client.CountSearchPosts(th.BasicTeam.Id, &model.SearchParameter{Terms: &terms})
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestSearchPosts(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
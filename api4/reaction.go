@@ -12,6 +12,7 @@ import (
 func (api *API) InitReaction() {
 	api.BaseRoutes.Reactions.Handle("", api.ApiSessionRequired(saveReaction)).Methods("POST")
 	api.BaseRoutes.Post.Handle("/reactions", api.ApiSessionRequired(getReactions)).Methods("GET")
+	api.BaseRoutes.Post.Handle("/reactions/bulk", api.ApiSessionRequired(saveBulkReactions)).Methods("POST")
 	api.BaseRoutes.ReactionByNameForPostForUser.Handle("", api.ApiSessionRequired(deleteReaction)).Methods("DELETE")
 	api.BaseRoutes.Posts.Handle("/ids/reactions", api.ApiSessionRequired(getBulkReactions)).Methods("POST")
 }
@@ -47,6 +48,55 @@ func saveReaction(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(reaction.ToJson()))
 }
 
+// saveBulkReactions adds several reactions to a post at once.
+//
+// The body is either a list of emoji names, which are added as reactions by the current user, or a
+// list of reactions. Reactions by other users or with a create_at, as used when importing
+// messages, require the manage_system permission. Every reaction must be by a member of the post's
+// channel. All reactions are saved in a single transaction, and one reaction_added event is sent per
+// distinct emoji. Reactions that already exist are returned as duplicates rather than as saved.
+func saveBulkReactions(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	reactions := model.BulkReactionsFromJson(r.Body)
+	if len(reactions) == 0 || len(reactions) > model.REACTIONS_BULK_MAX_COUNT {
+		c.SetInvalidParam("reactions")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(c.App.Session, c.Params.PostId, model.PERMISSION_ADD_REACTION) {
+		c.SetPermissionError(model.PERMISSION_ADD_REACTION)
+		return
+	}
+
+	for _, reaction := range reactions {
+		if reaction.UserId == "" {
+			reaction.UserId = c.App.Session.UserId
+		}
+
+		if len(reaction.UserId) != 26 || len(reaction.EmojiName) == 0 || len(reaction.EmojiName) > model.EMOJI_NAME_MAX_LENGTH || (reaction.PostId != "" && reaction.PostId != c.Params.PostId) {
+			c.Err = model.NewAppError("saveBulkReactions", "api.reaction.save_reaction.invalid.app_error", nil, "", http.StatusBadRequest)
+			return
+		}
+
+		if (reaction.UserId != c.App.Session.UserId || reaction.CreateAt != 0) && !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+			c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+			return
+		}
+	}
+
+	result, err := c.App.SaveReactionsForPost(c.Params.PostId, reactions)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(result.ToJson()))
+}
+
 func getReactions(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/model"
 )
@@ -305,6 +306,125 @@ func TestGetReactions(t *testing.T) {
 	})
 }
 
+func TestSaveBulkReactions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	t.Run("emoji names", func(t *testing.T) {
+		post := th.CreatePost()
+
+		r, err := Client.DoApiPost(Client.GetPostRoute(post.Id)+"/reactions/bulk", `["smile", "+1", "smile"]`)
+		require.Nil(t, err)
+		resp := model.BuildResponse(r)
+		CheckOKStatus(t, resp)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 2)
+		for _, reaction := range reactions {
+			assert.Equal(t, th.BasicUser.Id, reaction.UserId)
+		}
+	})
+
+	t.Run("reactions", func(t *testing.T) {
+		post := th.CreatePost()
+
+		result, resp := Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile"}, {UserId: th.BasicUser.Id, EmojiName: "heart"}})
+		CheckNoError(t, resp)
+		require.Len(t, result.Saved, 2)
+		assert.Empty(t, result.Duplicates)
+		assert.Equal(t, post.Id, result.Saved[0].PostId)
+		assert.Equal(t, th.BasicUser.Id, result.Saved[0].UserId)
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		post := th.CreatePost()
+
+		_, resp := Client.SaveReaction(&model.Reaction{UserId: th.BasicUser.Id, PostId: post.Id, EmojiName: "smile"})
+		CheckNoError(t, resp)
+
+		result, resp := Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile"}, {EmojiName: "heart"}, {EmojiName: "heart"}})
+		CheckNoError(t, resp)
+		require.Len(t, result.Saved, 1)
+		assert.Equal(t, "heart", result.Saved[0].EmojiName)
+		require.Len(t, result.Duplicates, 2)
+		assert.Equal(t, "smile", result.Duplicates[0].EmojiName)
+		assert.Equal(t, "heart", result.Duplicates[1].EmojiName)
+	})
+
+	t.Run("unknown or non-member user", func(t *testing.T) {
+		post := th.CreatePost()
+
+		_, resp := th.SystemAdminClient.SaveBulkReactions(post.Id, []*model.Reaction{{UserId: model.NewId(), EmojiName: "smile"}})
+		CheckBadRequestStatus(t, resp)
+
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+
+		_, resp = th.SystemAdminClient.SaveBulkReactions(post.Id, []*model.Reaction{
+			{UserId: th.BasicUser.Id, EmojiName: "smile"},
+			{UserId: user.Id, EmojiName: "smile"},
+		})
+		CheckBadRequestStatus(t, resp)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, reactions)
+	})
+
+	t.Run("import requires manage_system", func(t *testing.T) {
+		post := th.CreatePost()
+
+		_, resp := Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile", CreateAt: 1000}})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.SaveBulkReactions(post.Id, []*model.Reaction{{UserId: th.BasicUser2.Id, EmojiName: "smile"}})
+		CheckForbiddenStatus(t, resp)
+
+		result, resp := th.SystemAdminClient.SaveBulkReactions(post.Id, []*model.Reaction{
+			{UserId: th.BasicUser.Id, EmojiName: "smile", CreateAt: 1000},
+			{UserId: th.BasicUser2.Id, EmojiName: "smile", CreateAt: 2000},
+		})
+		CheckNoError(t, resp)
+		require.Len(t, result.Saved, 2)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reactions, 2)
+		assert.Equal(t, int64(1000), reactions[0].CreateAt)
+		assert.Equal(t, th.BasicUser2.Id, reactions[1].UserId)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		post := th.CreatePost()
+
+		_, resp := Client.SaveBulkReactions(post.Id, []*model.Reaction{})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: ""}})
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile", PostId: th.BasicPost.Id}})
+		CheckBadRequestStatus(t, resp)
+
+		// Nothing is saved if any of the reactions is invalid.
+		_, resp = Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile"}, {EmojiName: "not valid"}})
+		CheckBadRequestStatus(t, resp)
+
+		reactions, appErr := th.App.GetReactionsForPost(post.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, reactions)
+	})
+
+	t.Run("no access to channel", func(t *testing.T) {
+		post := th.CreatePostWithClient(th.SystemAdminClient, th.CreateChannelWithClient(th.SystemAdminClient, model.CHANNEL_PRIVATE))
+
+		_, resp := Client.SaveBulkReactions(post.Id, []*model.Reaction{{EmojiName: "smile"}})
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestDeleteReaction(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -56,12 +56,25 @@ func parseSamlCertificateRequest(r *http.Request, maxFileSize int64) (*multipart
 	return fileArray[0], nil
 }
 
+// requireSamlCertificateChangeAllowed fails the request when configuration changes require approval,
+// since adding or removing a certificate updates the configuration directly.
+func requireSamlCertificateChangeAllowed(c *Context, where string) {
+	if *c.App.Config().ServiceSettings.RequireConfigChangeApproval {
+		c.Err = model.NewAppError(where, "api.saml.certificate.approval_required.app_error", nil, "", http.StatusForbidden)
+	}
+}
+
 func addSamlPublicCertificate(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "addSamlPublicCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	fileData, err := parseSamlCertificateRequest(r, *c.App.Config().FileSettings.MaxFileSize)
 	if err != nil {
 		c.Err = err
@@ -81,6 +94,11 @@ func addSamlPrivateCertificate(c *Context, w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "addSamlPrivateCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	fileData, err := parseSamlCertificateRequest(r, *c.App.Config().FileSettings.MaxFileSize)
 	if err != nil {
 		c.Err = err
@@ -100,6 +118,11 @@ func addSamlIdpCertificate(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "addSamlIdpCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	fileData, err := parseSamlCertificateRequest(r, *c.App.Config().FileSettings.MaxFileSize)
 	if err != nil {
 		c.Err = err
@@ -119,6 +142,11 @@ func removeSamlPublicCertificate(c *Context, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "removeSamlPublicCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	if err := c.App.RemoveSamlPublicCertificate(); err != nil {
 		c.Err = err
 		return
@@ -133,6 +161,11 @@ func removeSamlPrivateCertificate(c *Context, w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "removeSamlPrivateCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	if err := c.App.RemoveSamlPrivateCertificate(); err != nil {
 		c.Err = err
 		return
@@ -147,6 +180,11 @@ func removeSamlIdpCertificate(c *Context, w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	requireSamlCertificateChangeAllowed(c, "removeSamlIdpCertificate")
+	if c.Err != nil {
+		return
+	}
+
 	if err := c.App.RemoveSamlIdpCertificate(); err != nil {
 		c.Err = err
 		return
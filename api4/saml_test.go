@@ -5,6 +5,10 @@ package api4
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
 )
 
 func TestGetSamlMetadata(t *testing.T) {
@@ -17,3 +21,38 @@ func TestGetSamlMetadata(t *testing.T) {
 
 	// Rest is tested by enterprise tests
 }
+
+func TestSamlCertificatesRequireConfigChangeApproval(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.RequireConfigChangeApproval = true })
+
+	for name, change := range map[string]func() (bool, *model.Response){
+		"upload idp": func() (bool, *model.Response) {
+			return th.SystemAdminClient.UploadSamlIdpCertificate([]byte("cert"), "idp.crt")
+		},
+		"upload public": func() (bool, *model.Response) {
+			return th.SystemAdminClient.UploadSamlPublicCertificate([]byte("cert"), "public.crt")
+		},
+		"upload private": func() (bool, *model.Response) {
+			return th.SystemAdminClient.UploadSamlPrivateCertificate([]byte("key"), "private.key")
+		},
+		"delete idp":     th.SystemAdminClient.DeleteSamlIdpCertificate,
+		"delete public":  th.SystemAdminClient.DeleteSamlPublicCertificate,
+		"delete private": th.SystemAdminClient.DeleteSamlPrivateCertificate,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, resp := change()
+			CheckForbiddenStatus(t, resp)
+			CheckErrorMessage(t, resp, "api.saml.certificate.approval_required.app_error")
+		})
+	}
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.RequireConfigChangeApproval = false })
+
+	_, resp := th.SystemAdminClient.DeleteSamlPublicCertificate()
+	if resp.Error != nil {
+		assert.NotEqual(t, "api.saml.certificate.approval_required.app_error", resp.Error.Id)
+	}
+}
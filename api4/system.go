@@ -7,9 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"runtime"
+	"strconv"
 	"time"
 
+	"github.com/mattermost/mattermost-server/app"
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/services/filesstore"
@@ -24,11 +27,20 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/ping", api.ApiHandler(getSystemPing)).Methods("GET")
 
 	api.BaseRoutes.System.Handle("/timezones", api.ApiSessionRequired(getSupportedTimezones)).Methods("GET")
+	api.BaseRoutes.System.Handle("/db/query", api.ApiSessionRequired(runReportingQuery)).Methods("POST")
+	api.BaseRoutes.System.Handle("/db/migration/status", api.ApiSessionRequired(getSchemaMigrationStatus)).Methods("GET")
+	api.BaseRoutes.System.Handle("/db/transactions", api.ApiSessionRequired(getDatabaseTransactions)).Methods("GET")
+	api.BaseRoutes.System.Handle("/diagnose", api.ApiSessionRequired(diagnoseSystem)).Methods("GET")
+	api.BaseRoutes.System.Handle("/email/queue/stats", api.ApiSessionRequired(getEmailQueueStats)).Methods("GET")
+	api.BaseRoutes.System.Handle("/performance/profile", api.ApiSessionRequired(capturePerformanceProfile)).Methods("POST")
+	api.BaseRoutes.System.Handle("/performance/profile/download", api.ApiHandler(downloadPerformanceProfile)).Methods("GET")
+	api.BaseRoutes.System.Handle("/notifications/test", api.ApiSessionRequired(testPushNotification)).Methods("POST")
 
 	api.BaseRoutes.ApiRoot.Handle("/audits", api.ApiSessionRequired(getAudits)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/email/test", api.ApiSessionRequired(testEmail)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/site_url/test", api.ApiSessionRequired(testSiteURL)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/file/s3_test", api.ApiSessionRequired(testS3)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/integrations/health", api.ApiSessionRequired(getIntegrationsHealth)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/database/recycle", api.ApiSessionRequired(databaseRecycle)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/caches/invalidate", api.ApiSessionRequired(invalidateCaches)).Methods("POST")
 
@@ -146,6 +158,222 @@ func testEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// testPushNotification sends a test notification through the configured push proxy.
+//
+// The body selects the device, either as {"token": "<platform>:<device token>"} or as
+// {"user_id": "..."} for the user's most recently active mobile session. The response reports the
+// proxy's status code, the latency of the request and any error, so that unreachable proxies are
+// reported with a 200 response.
+func testPushNotification(c *Context, w http.ResponseWriter, r *http.Request) {
+	testRequest := model.PushNotificationTestRequestFromJson(r.Body)
+	if testRequest == nil {
+		c.SetInvalidParam("notification_test")
+		return
+	}
+
+	if testRequest.Token == "" && !model.IsValidId(testRequest.UserId) {
+		c.SetInvalidParam("user_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	result, err := c.App.SendTestPushNotification(testRequest)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("user_id=" + testRequest.UserId)
+
+	w.Write([]byte(result.ToJson()))
+}
+
+func runReportingQuery(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := model.ReportingQueryFromJson(r.Body)
+	if query == nil || len(query.Query) == 0 {
+		c.SetInvalidParam("query")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("runReportingQuery", "api.restricted_system_admin", nil, "", http.StatusForbidden)
+		return
+	}
+
+	c.LogAudit("query=" + model.NormalizeReportingQuery(query.Query))
+
+	results, err := c.App.RunReportingQuery(query)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.ReportingQueryResultsToJson(results)))
+}
+
+// getSchemaMigrationStatus lists the upgrades to the database schema and whether they have been
+// applied.
+//
+// Upgrades are applied when a server starts, so a pending upgrade usually means that no server
+// running this version has started against the database yet.
+func getSchemaMigrationStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	migrations, err := c.App.GetSchemaMigrations()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SchemaMigrationListToJson(migrations)))
+}
+
+// getDatabaseTransactions lists the transactions open on the master database, oldest first.
+//
+// Long running transactions hold back vacuuming on Postgres and cause lock waits on MySQL. On MySQL,
+// the database user needs the PROCESS privilege to see the transactions of other connections.
+func getDatabaseTransactions(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	transactions, err := c.App.GetOpenDatabaseTransactions()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.DatabaseTransactionListToJson(transactions)))
+}
+
+// diagnoseSystem checks the server for common misconfigurations and reports the result of each check.
+//
+// The checks are whether the SiteURL is reachable from the server, whether the SMTP server accepts
+// the configured credentials, whether the file storage is writable, whether the database connection
+// pool is close to being exhausted and how many days are left before the TLS certificate expires.
+// Each result has a status of ok, warn or error.
+func diagnoseSystem(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	diagnostics := c.App.RunSystemDiagnostics()
+	w.Write([]byte(model.SystemDiagnosticListToJson(diagnostics)))
+}
+
+// getEmailQueueStats returns the number of emails waiting to be sent by the server handling the
+// request, along with how many were sent, how many failed and their average latency over the last
+// minute.
+//
+// Emails are only counted while an SMTP server is configured. A growing queue depth usually means
+// that the SMTP server is slow or overloaded.
+func getEmailQueueStats(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	w.Write([]byte(c.App.GetEmailQueueStats().ToJson()))
+}
+
+// capturePerformanceProfile captures a pprof profile and returns a signed link to download it.
+//
+// CPU profiles are sampled for the given duration, while heap and goroutine profiles are snapshots.
+// Only one profile may be captured at a time. The download link expires after an hour.
+//
+// @query type string The type of profile to capture, one of cpu, heap or goroutine. Defaults to cpu.
+// @query duration_seconds integer The number of seconds to sample a CPU profile for, up to 120. Defaults to 30.
+func capturePerformanceProfile(c *Context, w http.ResponseWriter, r *http.Request) {
+	profileType := r.URL.Query().Get("type")
+	if profileType == "" {
+		profileType = model.PERFORMANCE_PROFILE_TYPE_CPU
+	}
+	if !model.IsValidPerformanceProfileType(profileType) {
+		c.SetInvalidUrlParam("type")
+		return
+	}
+
+	durationSeconds := model.PERFORMANCE_PROFILE_DEFAULT_DURATION_SECONDS
+	if durationStr := r.URL.Query().Get("duration_seconds"); durationStr != "" {
+		var err error
+		if durationSeconds, err = strconv.Atoi(durationStr); err != nil || durationSeconds <= 0 || durationSeconds > model.PERFORMANCE_PROFILE_MAX_DURATION_SECONDS {
+			c.SetInvalidUrlParam("duration_seconds")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	if *c.App.Config().ExperimentalSettings.RestrictSystemAdmin {
+		c.Err = model.NewAppError("capturePerformanceProfile", "api.restricted_system_admin", nil, "", http.StatusForbidden)
+		return
+	}
+
+	profile, err := c.App.CapturePerformanceProfile(profileType, time.Duration(durationSeconds)*time.Second)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("file_name=" + profile.FileName)
+
+	query := url.Values{}
+	query.Set("file_name", profile.FileName)
+	query.Set("expire_at", strconv.FormatInt(profile.ExpireAt, 10))
+	query.Set("h", app.GeneratePerformanceProfileLinkHash(profile.FileName, profile.ExpireAt, *c.App.Config().FileSettings.PublicLinkSalt))
+	profile.DownloadUrl = c.GetSiteURLHeader() + model.API_URL_SUFFIX + "/system/performance/profile/download?" + query.Encode()
+
+	w.Write([]byte(profile.ToJson()))
+}
+
+// downloadPerformanceProfile downloads a profile captured by capturePerformanceProfile.
+//
+// The link returned when the profile was captured is signed, so no session is required.
+//
+// @query file_name string The file name of the profile.
+// @query expire_at integer The time at which the link expires, in milliseconds.
+// @query h string The signature of the link.
+func downloadPerformanceProfile(c *Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	expireAt, parseErr := strconv.ParseInt(query.Get("expire_at"), 10, 64)
+	if parseErr != nil {
+		c.SetInvalidUrlParam("expire_at")
+		return
+	}
+
+	fileName := query.Get("file_name")
+	fileReader, err := c.App.PerformanceProfileReader(fileName, expireAt, query.Get("h"))
+	if err != nil {
+		c.Err = err
+		return
+	}
+	defer fileReader.Close()
+
+	err = writeFileResponse(fileName, "application/octet-stream", 0, time.Now(), *c.App.Config().ServiceSettings.WebserverMode, fileReader, true, w, r)
+	if err != nil {
+		c.Err = err
+		return
+	}
+}
+
 func testSiteURL(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
@@ -318,6 +546,16 @@ func getSupportedTimezones(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write(b)
 }
 
+func getIntegrationsHealth(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	health := c.App.GetIntegrationsHealth()
+	w.Write([]byte(health.ToJson()))
+}
+
 func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	cfg := model.ConfigFromJson(r.Body)
 	if cfg == nil {
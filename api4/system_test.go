@@ -2,15 +2,19 @@ package api4
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPing(t *testing.T) {
@@ -397,6 +401,298 @@ func TestGetAnalyticsOld(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetIntegrationsHealth(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetIntegrationsHealth()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.EmailSettings.SendPushNotifications = false
+			*cfg.FileSettings.DriverName = model.IMAGE_DRIVER_LOCAL
+		})
+
+		health, resp := th.SystemAdminClient.GetIntegrationsHealth()
+		CheckNoError(t, resp)
+		require.NotNil(t, health)
+		assert.Equal(t, model.INTEGRATION_HEALTH_DISABLED, health.Ldap.Status)
+		assert.Equal(t, model.INTEGRATION_HEALTH_DISABLED, health.S3.Status)
+		assert.Equal(t, model.INTEGRATION_HEALTH_DISABLED, health.Push.Status)
+		assert.NotEmpty(t, health.Status)
+	})
+}
+
+func TestTestPushNotification(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	var pushed *model.PushNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushed = model.PushNotificationFromJson(r.Body)
+		ok := model.NewOkPushResponse()
+		w.Write([]byte(ok.ToJson()))
+	}))
+	defer server.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.SendPushNotifications = true
+		*cfg.EmailSettings.PushNotificationServer = server.URL
+	})
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.TestPushNotification(&model.PushNotificationTestRequest{UserId: th.BasicUser.Id})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("without a user or token", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.TestPushNotification(&model.PushNotificationTestRequest{})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("to a device token", func(t *testing.T) {
+		result, resp := th.SystemAdminClient.TestPushNotification(&model.PushNotificationTestRequest{Token: model.PUSH_NOTIFY_ANDROID_REACT_NATIVE + ":abc"})
+		CheckNoError(t, resp)
+		require.NotNil(t, result)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Empty(t, result.Error)
+
+		require.NotNil(t, pushed)
+		assert.Equal(t, "abc", pushed.DeviceId)
+		assert.Equal(t, model.PUSH_NOTIFY_ANDROID_REACT_NATIVE, pushed.Platform)
+		assert.Equal(t, "This is a test notification", pushed.Message)
+	})
+
+	t.Run("to a user without devices", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.TestPushNotification(&model.PushNotificationTestRequest{UserId: th.BasicUser2.Id})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("to a user's device", func(t *testing.T) {
+		_, err := th.App.CreateSession(&model.Session{
+			UserId:   th.BasicUser.Id,
+			DeviceId: model.PUSH_NOTIFY_APPLE_REACT_NATIVE + ":def",
+		})
+		require.Nil(t, err)
+
+		result, resp := th.SystemAdminClient.TestPushNotification(&model.PushNotificationTestRequest{UserId: th.BasicUser.Id})
+		CheckNoError(t, resp)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "def", pushed.DeviceId)
+	})
+
+	t.Run("with push notifications disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SendPushNotifications = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SendPushNotifications = true })
+
+		_, resp := th.SystemAdminClient.TestPushNotification(&model.PushNotificationTestRequest{UserId: th.BasicUser.Id})
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestRunReportingQuery(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.SqlSettings.AllowedReportingQueries = []string{
+			"SELECT Id, Username FROM Users WHERE Id = :UserId",
+		}
+	})
+
+	query := &model.ReportingQuery{
+		Query:  "SELECT Id, Username\n\tFROM Users\n\tWHERE Id = :UserId",
+		Params: map[string]interface{}{"UserId": th.BasicUser.Id},
+	}
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.RunReportingQuery(query)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("allowed query", func(t *testing.T) {
+		results, resp := th.SystemAdminClient.RunReportingQuery(query)
+		CheckNoError(t, resp)
+		require.Len(t, results, 1)
+		assert.Equal(t, th.BasicUser.Id, results[0]["id"])
+		assert.Equal(t, th.BasicUser.Username, results[0]["username"])
+	})
+
+	t.Run("query not allowed", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.RunReportingQuery(&model.ReportingQuery{Query: "SELECT Id, Password FROM Users"})
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("not a select", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.RunReportingQuery(&model.ReportingQuery{Query: "DELETE FROM Users"})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.RunReportingQuery(&model.ReportingQuery{})
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetSchemaMigrationStatus(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetSchemaMigrationStatus()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		migrations, resp := th.SystemAdminClient.GetSchemaMigrationStatus()
+		CheckNoError(t, resp)
+		require.NotEmpty(t, migrations)
+
+		for _, migration := range migrations {
+			assert.NotEmpty(t, migration.Id)
+			assert.NotEmpty(t, migration.Name)
+			assert.Equal(t, model.SCHEMA_MIGRATION_STATUS_APPLIED, migration.Status)
+		}
+	})
+}
+
+func TestGetDatabaseTransactions(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetDatabaseTransactions()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		transactions, resp := th.SystemAdminClient.GetDatabaseTransactions()
+		CheckNoError(t, resp)
+		require.NotNil(t, transactions)
+	})
+}
+
+func TestDiagnoseSystem(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.DiagnoseSystem()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		diagnostics, resp := th.SystemAdminClient.DiagnoseSystem()
+		CheckNoError(t, resp)
+		require.Len(t, diagnostics, 5)
+
+		statuses := map[string]string{}
+		for _, diagnostic := range diagnostics {
+			assert.NotEmpty(t, diagnostic.Detail, diagnostic.Name)
+			statuses[diagnostic.Name] = diagnostic.Status
+		}
+		assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_OK, statuses[model.SYSTEM_DIAGNOSTIC_FILE_STORAGE])
+		assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_OK, statuses[model.SYSTEM_DIAGNOSTIC_DATABASE_CONNECTIONS])
+	})
+}
+
+func TestGetEmailQueueStats(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetEmailQueueStats()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		stats, resp := th.SystemAdminClient.GetEmailQueueStats()
+		CheckNoError(t, resp)
+		require.NotNil(t, stats)
+		assert.True(t, stats.QueueDepth >= 0)
+	})
+}
+
+func TestCapturePerformanceProfile(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	download := func(t *testing.T, downloadUrl string) *http.Response {
+		t.Helper()
+
+		parsed, err := url.Parse(downloadUrl)
+		require.Nil(t, err)
+
+		resp, err := http.Get(th.Client.Url + parsed.RequestURI())
+		require.Nil(t, err)
+		return resp
+	}
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_HEAP, 1)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.CapturePerformanceProfile("block", 1)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_CPU, 0)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_CPU, model.PERFORMANCE_PROFILE_MAX_DURATION_SECONDS+1)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("heap profile", func(t *testing.T) {
+		profile, resp := th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_HEAP, 1)
+		CheckNoError(t, resp)
+		assert.Equal(t, model.PERFORMANCE_PROFILE_TYPE_HEAP, profile.Type)
+		assert.Equal(t, 0, profile.DurationSeconds)
+		require.NotEmpty(t, profile.DownloadUrl)
+
+		httpResp := download(t, profile.DownloadUrl)
+		defer httpResp.Body.Close()
+		assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+
+		data, err := ioutil.ReadAll(httpResp.Body)
+		require.Nil(t, err)
+		assert.NotEmpty(t, data)
+	})
+
+	t.Run("cpu profile", func(t *testing.T) {
+		done := make(chan *model.Response)
+		go func() {
+			_, resp := th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_CPU, 2)
+			done <- resp
+		}()
+
+		// Only one profile may be captured at a time.
+		time.Sleep(500 * time.Millisecond)
+		_, resp := th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_GOROUTINE, 1)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		CheckNoError(t, <-done)
+	})
+
+	t.Run("tampered link", func(t *testing.T) {
+		profile, resp := th.SystemAdminClient.CapturePerformanceProfile(model.PERFORMANCE_PROFILE_TYPE_GOROUTINE, 1)
+		CheckNoError(t, resp)
+
+		httpResp := download(t, strings.Replace(profile.DownloadUrl, "expire_at=", "expire_at=1", 1))
+		httpResp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, httpResp.StatusCode)
+
+		httpResp = download(t, strings.Replace(profile.DownloadUrl, "file_name=", "file_name=..%2F", 1))
+		httpResp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, httpResp.StatusCode)
+	})
+}
+
 func TestS3TestConnection(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
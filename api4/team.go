@@ -20,6 +20,7 @@ import (
 
 const (
 	MAX_ADD_MEMBERS_BATCH    = 20
+	MAX_REMOVE_MEMBERS_BATCH = 500
 	MAXIMUM_BULK_IMPORT_SIZE = 10 * 1024 * 1024
 	groupIDsParamPattern     = "[^a-zA-Z0-9,]*"
 )
@@ -43,6 +44,9 @@ func (api *API) InitTeam() {
 	api.BaseRoutes.Team.Handle("", api.ApiSessionRequired(deleteTeam)).Methods("DELETE")
 	api.BaseRoutes.Team.Handle("/patch", api.ApiSessionRequired(patchTeam)).Methods("PUT")
 	api.BaseRoutes.Team.Handle("/stats", api.ApiSessionRequired(getTeamStats)).Methods("GET")
+	api.BaseRoutes.Team.Handle("/stats/growth", api.ApiSessionRequired(getTeamGrowth)).Methods("GET")
+	api.BaseRoutes.Team.Handle("/onboarding/checklist", api.ApiSessionRequired(getTeamOnboardingChecklist)).Methods("GET")
+	api.BaseRoutes.Team.Handle("/onboarding/checklist", api.ApiSessionRequired(updateTeamOnboardingChecklist)).Methods("PUT")
 	api.BaseRoutes.Team.Handle("/regenerate_invite_id", api.ApiSessionRequired(regenerateTeamInviteId)).Methods("POST")
 
 	api.BaseRoutes.Team.Handle("/image", api.ApiSessionRequiredTrustRequester(getTeamIcon)).Methods("GET")
@@ -56,6 +60,7 @@ func (api *API) InitTeam() {
 	api.BaseRoutes.Teams.Handle("/members/invite", api.ApiSessionRequired(addUserToTeamFromInvite)).Methods("POST")
 	api.BaseRoutes.TeamMembers.Handle("/batch", api.ApiSessionRequired(addTeamMembers)).Methods("POST")
 	api.BaseRoutes.TeamMember.Handle("", api.ApiSessionRequired(removeTeamMember)).Methods("DELETE")
+	api.BaseRoutes.TeamMembers.Handle("/bulk_remove", api.ApiSessionRequired(bulkRemoveTeamMembers)).Methods("POST")
 
 	api.BaseRoutes.TeamForUser.Handle("/unread", api.ApiSessionRequired(getTeamUnread)).Methods("GET")
 
@@ -625,6 +630,57 @@ func removeTeamMember(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func bulkRemoveTeamMembers(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	bulkRemove := model.TeamMembersBulkRemoveFromJson(r.Body)
+	if bulkRemove == nil || len(bulkRemove.UserIds) == 0 {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	if len(bulkRemove.UserIds) > MAX_REMOVE_MEMBERS_BATCH {
+		c.SetInvalidParam("too many members in batch")
+		return
+	}
+
+	for _, userId := range bulkRemove.UserIds {
+		if !model.IsValidId(userId) {
+			c.SetInvalidParam("user_ids")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_REMOVE_USER_FROM_TEAM) {
+		c.SetPermissionError(model.PERMISSION_REMOVE_USER_FROM_TEAM)
+		return
+	}
+
+	team, err := c.App.GetTeam(c.Params.TeamId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if team.IsGroupConstrained() {
+		c.Err = model.NewAppError("bulkRemoveTeamMembers", "api.team.remove_member.group_constrained.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.App.RemoveUsersFromTeam(c.Params.TeamId, bulkRemove.UserIds, c.App.Session.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit("team_id=" + c.Params.TeamId + " removed=" + strconv.Itoa(result.Removed))
+
+	w.Write([]byte(result.ToJson()))
+}
+
 func getTeamUnread(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId().RequireUserId()
 	if c.Err != nil {
@@ -650,6 +706,65 @@ func getTeamUnread(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(unreadTeam.ToJson()))
 }
 
+// getTeamOnboardingChecklist returns the steps for setting up a team and whether they have been
+// completed.
+//
+// Steps are completed automatically when the corresponding action is taken, such as another member
+// joining the team, and the team admins are sent an onboarding_step_completed event.
+func getTeamOnboardingChecklist(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	checklist, err := c.App.GetTeamOnboardingChecklist(c.Params.TeamId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(checklist.ToJson()))
+}
+
+// updateTeamOnboardingChecklist marks steps of a team's onboarding checklist as completed or not.
+//
+// Steps that are left out of the request are not changed.
+func updateTeamOnboardingChecklist(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	checklist := model.TeamOnboardingChecklistFromJson(r.Body)
+	if checklist == nil {
+		c.SetInvalidParam("checklist")
+		return
+	}
+
+	if err := checklist.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_MANAGE_TEAM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_TEAM)
+		return
+	}
+
+	checklist, err := c.App.UpdateTeamOnboardingChecklist(c.Params.TeamId, checklist)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(checklist.ToJson()))
+}
+
 func getTeamStats(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId()
 	if c.Err != nil {
@@ -676,6 +791,63 @@ func getTeamStats(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(stats.ToJson()))
 }
 
+// getTeamGrowth counts the users who joined and left a team in each week or month.
+//
+// Weeks start on Monday and both weeks and months start at midnight UTC. Periods are always counted
+// in full, from the period containing from through the period containing to. Membership changes made
+// before team member history was recorded aren't counted.
+//
+// @query granularity string Either week or month. Defaults to week.
+// @query from integer The time in milliseconds within the first period. Defaults to 11 periods before to.
+// @query to integer The time in milliseconds within the last period. Defaults to now.
+func getTeamGrowth(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	query := r.URL.Query()
+
+	granularity := query.Get("granularity")
+	if granularity == "" {
+		granularity = model.TEAM_GROWTH_GRANULARITY_WEEK
+	} else if !model.IsValidTeamGrowthGranularity(granularity) {
+		c.SetInvalidParam("granularity")
+		return
+	}
+
+	var from int64
+	if fromString := query.Get("from"); fromString != "" {
+		var parseError error
+		if from, parseError = strconv.ParseInt(fromString, 10, 64); parseError != nil || from <= 0 {
+			c.SetInvalidParam("from")
+			return
+		}
+	}
+
+	to := model.GetMillis()
+	if toString := query.Get("to"); toString != "" {
+		var parseError error
+		if to, parseError = strconv.ParseInt(toString, 10, 64); parseError != nil || to <= 0 {
+			c.SetInvalidParam("to")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	growth, err := c.App.GetTeamGrowth(c.Params.TeamId, granularity, from, to)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.TeamGrowthPeriodListToJson(growth)))
+}
+
 func updateTeamMemberRoles(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireTeamId().RequireUserId()
 	if c.Err != nil {
@@ -882,7 +1054,7 @@ func importTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	switch importFrom {
 	case "slack":
 		var err *model.AppError
-		if err, log = c.App.SlackImport(fileData, fileSize, c.Params.TeamId); err != nil {
+		if err, log = c.App.SlackImport(fileData, fileSize, c.Params.TeamId, false); err != nil {
 			c.Err = err
 			c.Err.StatusCode = http.StatusBadRequest
 		}
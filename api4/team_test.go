@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"encoding/base64"
 
@@ -1829,6 +1830,59 @@ func TestRemoveTeamMember(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestBulkRemoveTeamMembers(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	_, resp := Client.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{th.BasicUser2.Id})
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{})
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{"junk"})
+	CheckBadRequestStatus(t, resp)
+
+	tooMany := make([]string, MAX_REMOVE_MEMBERS_BATCH+1)
+	for i := range tooMany {
+		tooMany[i] = model.NewId()
+	}
+	_, resp = th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, tooMany)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.BulkRemoveTeamMembers(model.NewId(), []string{th.BasicUser.Id})
+	CheckNotFoundStatus(t, resp)
+
+	unknownUserId := model.NewId()
+	result, resp := th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{th.BasicUser.Id, th.BasicUser2.Id, unknownUserId})
+	CheckNoError(t, resp)
+	assert.Equal(t, 2, result.Removed)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, unknownUserId, result.Errors[0].UserId)
+	assert.NotEmpty(t, result.Errors[0].Error)
+
+	for _, userId := range []string{th.BasicUser.Id, th.BasicUser2.Id} {
+		member, err := th.App.GetTeamMember(th.BasicTeam.Id, userId)
+		require.Nil(t, err)
+		assert.NotZero(t, member.DeleteAt)
+	}
+
+	// Users who are no longer members are reported as errors
+	result, resp = th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{th.BasicUser.Id})
+	CheckNoError(t, resp)
+	assert.Equal(t, 0, result.Removed)
+	require.Len(t, result.Errors, 1)
+
+	// If the team is group-constrained users cannot be removed
+	th.BasicTeam.GroupConstrained = model.NewBool(true)
+	_, err := th.App.UpdateTeam(th.BasicTeam)
+	require.Nil(t, err)
+	_, resp = th.SystemAdminClient.BulkRemoveTeamMembers(th.BasicTeam.Id, []string{th.SystemAdminUser.Id})
+	CheckBadRequestStatus(t, resp)
+	require.Equal(t, "api.team.remove_member.group_constrained.app_error", resp.Error.Id)
+}
+
 func TestGetTeamStats(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1850,6 +1904,11 @@ func TestGetTeamStats(t *testing.T) {
 		t.Fatal("wrong count")
 	}
 
+	channelCount, err := th.App.Srv.Store.Channel().GetTeamChannelCount(team.Id)
+	require.Nil(t, err)
+	assert.Equal(t, channelCount, rstats.ChannelCount)
+	assert.Equal(t, *th.App.Config().TeamSettings.MaxChannelsPerTeam, rstats.MaxChannels)
+
 	_, resp = Client.GetTeamStats("junk", "")
 	CheckBadRequestStatus(t, resp)
 
@@ -1884,6 +1943,123 @@ func TestGetTeamStats(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetTeamGrowth(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	team := th.BasicTeam
+
+	growth, resp := Client.GetTeamGrowth(team.Id, model.TEAM_GROWTH_GRANULARITY_WEEK, 0, model.GetMillis())
+	CheckNoError(t, resp)
+	require.Len(t, growth, model.TEAM_GROWTH_DEFAULT_PERIODS)
+	current := growth[len(growth)-1]
+	assert.Equal(t, model.TeamGrowthPeriodStart(time.Now(), model.TEAM_GROWTH_GRANULARITY_WEEK).Format(model.TEAM_GROWTH_PERIOD_FORMAT), current.Period)
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, team)
+	_, resp = Client.RemoveTeamMember(team.Id, user.Id)
+	CheckNoError(t, resp)
+
+	growth, resp = Client.GetTeamGrowth(team.Id, model.TEAM_GROWTH_GRANULARITY_WEEK, 0, model.GetMillis())
+	CheckNoError(t, resp)
+	require.Len(t, growth, model.TEAM_GROWTH_DEFAULT_PERIODS)
+	assert.Equal(t, current.NewMembers+1, growth[len(growth)-1].NewMembers)
+	assert.Equal(t, current.DepartedMembers+1, growth[len(growth)-1].DepartedMembers)
+	assert.Equal(t, current.Net, growth[len(growth)-1].Net)
+
+	t.Run("monthly", func(t *testing.T) {
+		from := time.Now().AddDate(0, -2, 0)
+		growth, resp = Client.GetTeamGrowth(team.Id, model.TEAM_GROWTH_GRANULARITY_MONTH, model.GetMillisForTime(from), model.GetMillis())
+		CheckNoError(t, resp)
+		require.Len(t, growth, 3)
+		assert.Equal(t, model.TeamGrowthPeriodStart(from, model.TEAM_GROWTH_GRANULARITY_MONTH).Format(model.TEAM_GROWTH_PERIOD_FORMAT), growth[0].Period)
+		assert.NotZero(t, growth[2].NewMembers)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, resp = Client.GetTeamGrowth(team.Id, "day", 0, model.GetMillis())
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.GetTeamGrowth(team.Id, model.TEAM_GROWTH_GRANULARITY_WEEK, model.GetMillis(), model.GetMillis()-1000)
+		CheckBadRequestStatus(t, resp)
+
+		from := time.Now().AddDate(-5, 0, 0)
+		_, resp = Client.GetTeamGrowth(team.Id, model.TEAM_GROWTH_GRANULARITY_WEEK, model.GetMillisForTime(from), model.GetMillis())
+		CheckBadRequestStatus(t, resp)
+
+		_, resp = Client.GetTeamGrowth("junk", model.TEAM_GROWTH_GRANULARITY_WEEK, 0, model.GetMillis())
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("non-member", func(t *testing.T) {
+		_, resp = Client.GetTeamGrowth(model.NewId(), model.TEAM_GROWTH_GRANULARITY_WEEK, 0, model.GetMillis())
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestTeamOnboardingChecklist(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+	team := th.CreateTeam()
+
+	checklist, resp := Client.GetTeamOnboardingChecklist(team.Id)
+	CheckNoError(t, resp)
+	assert.Equal(t, team.Id, checklist.TeamId)
+	require.Len(t, checklist.Steps, len(model.TeamOnboardingSteps))
+	for _, step := range checklist.Steps {
+		assert.False(t, step.Completed, step.Id)
+	}
+
+	t.Run("update", func(t *testing.T) {
+		checklist, resp = Client.UpdateTeamOnboardingChecklist(team.Id, &model.TeamOnboardingChecklist{
+			Steps: []*model.TeamOnboardingStep{{Id: model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION, Completed: true}},
+		})
+		CheckNoError(t, resp)
+		assert.True(t, checklist.Steps[2].Completed)
+		assert.NotZero(t, checklist.Steps[2].CompletedAt)
+		assert.False(t, checklist.Steps[0].Completed)
+
+		checklist, resp = Client.UpdateTeamOnboardingChecklist(team.Id, &model.TeamOnboardingChecklist{
+			Steps: []*model.TeamOnboardingStep{{Id: model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION, Completed: false}},
+		})
+		CheckNoError(t, resp)
+		assert.False(t, checklist.Steps[2].Completed)
+	})
+
+	t.Run("completed by creating a channel", func(t *testing.T) {
+		_, resp = Client.CreateChannel(&model.Channel{TeamId: team.Id, Name: GenerateTestChannelName(), DisplayName: "Onboarding", Type: model.CHANNEL_OPEN})
+		CheckNoError(t, resp)
+
+		checklist, resp = Client.GetTeamOnboardingChecklist(team.Id)
+		CheckNoError(t, resp)
+		assert.Equal(t, model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL, checklist.Steps[1].Id)
+		assert.True(t, checklist.Steps[1].Completed)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		_, resp = Client.UpdateTeamOnboardingChecklist(team.Id, &model.TeamOnboardingChecklist{
+			Steps: []*model.TeamOnboardingStep{{Id: "junk", Completed: true}},
+		})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		th.LinkUserToTeam(th.BasicUser2, team)
+		Client.Logout()
+		th.LoginBasic2()
+
+		_, resp = Client.GetTeamOnboardingChecklist(team.Id)
+		CheckNoError(t, resp)
+
+		_, resp = Client.UpdateTeamOnboardingChecklist(team.Id, &model.TeamOnboardingChecklist{})
+		CheckForbiddenStatus(t, resp)
+
+		_, resp = Client.GetTeamOnboardingChecklist(model.NewId())
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestUpdateTeamMemberRoles(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
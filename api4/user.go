@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost-server/app"
@@ -19,10 +20,15 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 )
 
+const (
+	MAX_USER_IDS_BATCH = 1000
+)
+
 func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("", api.ApiHandler(createUser)).Methods("POST")
 	api.BaseRoutes.Users.Handle("", api.ApiSessionRequired(getUsers)).Methods("GET")
 	api.BaseRoutes.Users.Handle("/ids", api.ApiSessionRequired(getUsersByIds)).Methods("POST")
+	api.BaseRoutes.Users.Handle("/batch", api.ApiSessionRequired(getUsersBatch)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/usernames", api.ApiSessionRequired(getUsersByNames)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/search", api.ApiSessionRequired(searchUsers)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/autocomplete", api.ApiSessionRequired(autocompleteUsers)).Methods("GET")
@@ -54,6 +60,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.Users.Handle("/mfa", api.ApiHandler(checkUserMfa)).Methods("POST")
 	api.BaseRoutes.User.Handle("/mfa", api.ApiSessionRequiredMfa(updateUserMfa)).Methods("PUT")
 	api.BaseRoutes.User.Handle("/mfa/generate", api.ApiSessionRequiredMfa(generateMfaSecret)).Methods("POST")
+	api.BaseRoutes.User.Handle("/mfa/totp/verify", api.ApiSessionRequired(verifyUserMfaToken)).Methods("POST")
 
 	api.BaseRoutes.Users.Handle("/login", api.ApiHandler(login)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/login/switch", api.ApiHandler(switchAccountType)).Methods("POST")
@@ -63,6 +70,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.UserByEmail.Handle("", api.ApiSessionRequired(getUserByEmail)).Methods("GET")
 
 	api.BaseRoutes.User.Handle("/sessions", api.ApiSessionRequired(getSessions)).Methods("GET")
+	api.BaseRoutes.User.Handle("/sessions/activity", api.ApiSessionRequired(getSessionActivity)).Methods("GET")
 	api.BaseRoutes.User.Handle("/sessions/revoke", api.ApiSessionRequired(revokeSession)).Methods("POST")
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.ApiSessionRequired(revokeAllSessionsForUser)).Methods("POST")
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.ApiSessionRequired(revokeAllSessionsAllUsers)).Methods("POST")
@@ -491,6 +499,12 @@ func getUsersByGroupChannelIds(c *Context, w http.ResponseWriter, r *http.Reques
 }
 
 func getUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	// GET /users?ids=id1,id2 is an alias of POST /users/batch.
+	if ids := r.URL.Query().Get("ids"); len(ids) > 0 {
+		getUsersBatchByIds(c, w, r, strings.Split(ids, ","))
+		return
+	}
+
 	inTeamId := r.URL.Query().Get("in_team")
 	notInTeamId := r.URL.Query().Get("not_in_team")
 	inChannelId := r.URL.Query().Get("in_channel")
@@ -638,6 +652,33 @@ func getUsersByIds(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	writeUsersByIds(c, w, r, userIds)
+}
+
+func getUsersBatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	batch := model.UserIdsBatchFromJson(r.Body)
+	if batch == nil {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	getUsersBatchByIds(c, w, r, batch.UserIds)
+}
+
+// getUsersBatchByIds writes the profiles of up to MAX_USER_IDS_BATCH distinct users.
+func getUsersBatchByIds(c *Context, w http.ResponseWriter, r *http.Request, userIds []string) {
+	userIds = model.RemoveDuplicateStrings(userIds)
+	if len(userIds) == 0 || len(userIds) > MAX_USER_IDS_BATCH {
+		c.SetInvalidParam("user_ids")
+		return
+	}
+
+	writeUsersByIds(c, w, r, userIds)
+}
+
+// writeUsersByIds writes the profiles of the given users that are visible to the session. Users that
+// do not exist are left out rather than causing an error.
+func writeUsersByIds(c *Context, w http.ResponseWriter, r *http.Request, userIds []string) {
 	sinceString := r.URL.Query().Get("since")
 
 	options := &store.UserGetByIdsOpts{
@@ -742,6 +783,10 @@ func searchUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		Role:             props.Role,
 	}
 
+	if props.IncludeCustomAttrs && *c.App.Config().ServiceSettings.SearchCustomProfileAttributes {
+		options.IncludeCustomAttrs = true
+	}
+
 	if c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		options.AllowEmails = true
 		options.AllowFullNames = true
@@ -762,6 +807,11 @@ func searchUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The response is a bare list of users, so truncation is reported in a header instead.
+	if maxResults := *c.App.Config().SearchSettings.MaxSearchResults; props.Limit > maxResults && len(profiles) >= maxResults {
+		w.Header().Set(model.HEADER_SEARCH_TRUNCATED, "true")
+	}
+
 	w.Write([]byte(model.UserListToJson(profiles)))
 }
 
@@ -784,6 +834,10 @@ func autocompleteUsers(c *Context, w http.ResponseWriter, r *http.Request) {
 		Limit:       limit,
 	}
 
+	if fuzzy, _ := strconv.ParseBool(r.URL.Query().Get("fuzzy")); fuzzy && *c.App.Config().ServiceSettings.EnableFuzzyMentionAutocomplete {
+		options.Fuzzy = true
+	}
+
 	if c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
 		options.AllowFullNames = true
 	} else {
@@ -1205,6 +1259,38 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(secret.ToJson()))
 }
 
+func verifyUserMfaToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(c.App.Session, model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	props := model.MapFromJson(r.Body)
+	token := props["token"]
+	if len(token) == 0 {
+		c.SetInvalidParam("token")
+		return
+	}
+
+	c.LogAudit("attempt - user_id=" + c.Params.UserId)
+
+	isValid, err := c.App.VerifyMfaToken(c.Params.UserId, token)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	c.LogAudit(fmt.Sprintf("success - user_id=%v is_valid=%v", c.Params.UserId, isValid))
+
+	resp := map[string]interface{}{"is_valid": isValid}
+	w.Write([]byte(model.StringInterfaceToJson(resp)))
+}
+
 func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -1470,6 +1556,26 @@ func getSessions(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.SessionsToJson(sessions)))
 }
 
+func getSessionActivity(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(c.App.Session, c.Params.UserId) {
+		c.SetPermissionError(model.PERMISSION_EDIT_OTHER_USERS)
+		return
+	}
+
+	activity, err := c.App.GetSessionActivity(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SessionActivityListToJson(activity)))
+}
+
 func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
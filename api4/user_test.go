@@ -988,6 +988,47 @@ func TestSearchUsers(t *testing.T) {
 	}
 }
 
+func TestSearchUsersCustomAttributes(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+	user.Props = model.StringMap{"location": "Lisbon", "note": "Lighthouse"}
+	_, err := th.App.UpdateUser(user, false)
+	require.Nil(t, err)
+
+	search := &model.UserSearch{Term: "lisb", TeamId: th.BasicTeam.Id, IncludeCustomAttrs: true}
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SearchCustomProfileAttributes = false })
+
+		users, resp := th.Client.SearchUsers(search)
+		CheckNoError(t, resp)
+		assert.False(t, findUserInList(user.Id, users))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SearchCustomProfileAttributes = true })
+
+	t.Run("searchable attribute", func(t *testing.T) {
+		users, resp := th.Client.SearchUsers(search)
+		CheckNoError(t, resp)
+		assert.True(t, findUserInList(user.Id, users))
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		users, resp := th.Client.SearchUsers(&model.UserSearch{Term: "lisb", TeamId: th.BasicTeam.Id})
+		CheckNoError(t, resp)
+		assert.False(t, findUserInList(user.Id, users))
+	})
+
+	t.Run("other props", func(t *testing.T) {
+		users, resp := th.Client.SearchUsers(&model.UserSearch{Term: "lighth", TeamId: th.BasicTeam.Id, IncludeCustomAttrs: true})
+		CheckNoError(t, resp)
+		assert.False(t, findUserInList(user.Id, users))
+	})
+}
+
 func findUserInList(id string, users []*model.User) bool {
 	for _, user := range users {
 		if user.Id == id {
@@ -1119,6 +1160,42 @@ func TestAutocompleteUsersInChannel(t *testing.T) {
 	})
 }
 
+func TestFuzzyAutocompleteUsersInChannel(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	user, err := th.App.CreateUser(&model.User{
+		Email:    th.GenerateTestEmail(),
+		Username: "fuzzyjohn" + model.NewId()[:4],
+		Password: "Password1",
+	})
+	require.Nil(t, err)
+	th.LinkUserToTeam(user, th.BasicTeam)
+	th.AddUserToChannel(user, th.BasicChannel)
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFuzzyMentionAutocomplete = false })
+
+		rusers, resp := th.Client.FuzzyAutocompleteUsersInChannel(th.BasicTeam.Id, th.BasicChannel.Id, "fuzzyjhn", model.USER_SEARCH_DEFAULT_LIMIT, "")
+		CheckNoError(t, resp)
+		assert.Empty(t, rusers.Users)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableFuzzyMentionAutocomplete = true })
+
+		rusers, resp := th.Client.FuzzyAutocompleteUsersInChannel(th.BasicTeam.Id, th.BasicChannel.Id, "fuzzyjhn", model.USER_SEARCH_DEFAULT_LIMIT, "")
+		CheckNoError(t, resp)
+		require.Len(t, rusers.Users, 1)
+		assert.Equal(t, user.Id, rusers.Users[0].Id)
+
+		// Without the fuzzy parameter, only prefix matches are returned
+		rusers, resp = th.Client.AutocompleteUsersInChannel(th.BasicTeam.Id, th.BasicChannel.Id, "fuzzyjhn", model.USER_SEARCH_DEFAULT_LIMIT, "")
+		CheckNoError(t, resp)
+		assert.Empty(t, rusers.Users)
+	})
+}
+
 func TestAutocompleteUsersInTeam(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -1333,6 +1410,68 @@ func TestGetUsersByIds(t *testing.T) {
 	})
 }
 
+func TestGetUsersBatch(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	t.Run("should return the users", func(t *testing.T) {
+		users, resp := th.Client.GetUsersBatch([]string{th.BasicUser.Id, th.BasicUser2.Id, th.BasicUser.Id})
+
+		CheckNoError(t, resp)
+		require.Len(t, users, 2)
+		for _, user := range users {
+			CheckUserSanitization(t, user)
+		}
+	})
+
+	t.Run("should return an empty list when no IDs exist", func(t *testing.T) {
+		users, resp := th.Client.GetUsersBatch([]string{model.NewId(), model.NewId()})
+
+		CheckNoError(t, resp)
+		require.NotNil(t, users)
+		require.Empty(t, users)
+	})
+
+	t.Run("should return error when no IDs are specified", func(t *testing.T) {
+		_, resp := th.Client.GetUsersBatch([]string{})
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should return error when too many IDs are specified", func(t *testing.T) {
+		userIds := make([]string, MAX_USER_IDS_BATCH+1)
+		for i := range userIds {
+			userIds[i] = model.NewId()
+		}
+
+		_, resp := th.Client.GetUsersBatch(userIds)
+		CheckBadRequestStatus(t, resp)
+
+		// The limit only applies to the batch endpoint.
+		users, resp := th.Client.GetUsersByIds(append(userIds, th.BasicUser.Id))
+		CheckNoError(t, resp)
+		require.Len(t, users, 1)
+	})
+
+	t.Run("should support the GET alias", func(t *testing.T) {
+		r, err := th.Client.DoApiGet(th.Client.GetUsersRoute()+"?ids="+th.BasicUser.Id+","+th.BasicUser2.Id+","+model.NewId(), "")
+		require.Nil(t, err)
+		defer r.Body.Close()
+
+		users := model.UserListFromJson(r.Body)
+		require.Len(t, users, 2)
+		for _, user := range users {
+			CheckUserSanitization(t, user)
+		}
+	})
+
+	t.Run("should return error when not logged in", func(t *testing.T) {
+		th.Client.Logout()
+
+		_, resp := th.Client.GetUsersBatch([]string{th.BasicUser.Id})
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestGetUsersByIdsWithOptions(t *testing.T) {
 	t.Run("should only return specified users that have been updated since the given time", func(t *testing.T) {
 		th := Setup().InitBasic()
@@ -2389,6 +2528,55 @@ func TestGenerateMfaSecret(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestVerifyUserMfaToken(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, resp := th.SystemAdminClient.VerifyUserMfaToken(th.BasicUser.Id, "123456")
+	CheckErrorMessage(t, resp, "api.user.verify_mfa_token.not_active.app_error")
+	CheckBadRequestStatus(t, resp)
+
+	secret, err := th.App.GenerateMfaSecret(th.BasicUser.Id)
+	require.Nil(t, err)
+	require.Nil(t, th.Server.Store.User().UpdateMfaActive(th.BasicUser.Id, true))
+	require.Nil(t, th.Server.Store.User().UpdateMfaSecret(th.BasicUser.Id, secret.Secret))
+
+	code := fmt.Sprintf("%06d", dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30))
+
+	t.Run("not a system admin", func(t *testing.T) {
+		_, resp := th.Client.VerifyUserMfaToken(th.BasicUser.Id, code)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.VerifyUserMfaToken(th.BasicUser.Id, "")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		isValid, resp := th.SystemAdminClient.VerifyUserMfaToken(th.BasicUser.Id, code)
+		CheckNoError(t, resp)
+		assert.True(t, isValid)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		wrongCode := fmt.Sprintf("%06d", dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30-100))
+		isValid, resp := th.SystemAdminClient.VerifyUserMfaToken(th.BasicUser.Id, wrongCode)
+		CheckNoError(t, resp)
+		assert.False(t, isValid)
+	})
+
+	t.Run("mfa disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+		_, resp := th.SystemAdminClient.VerifyUserMfaToken(th.BasicUser.Id, code)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
 func TestUpdateUserPassword(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -2579,6 +2767,40 @@ func TestGetSessions(t *testing.T) {
 	CheckNoError(t, resp)
 }
 
+func TestGetSessionActivity(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+
+	user := th.BasicUser
+
+	session, err := th.App.GetSession(th.Client.AuthToken)
+	require.Nil(t, err)
+
+	activity, resp := th.Client.GetSessionActivity(user.Id)
+	CheckNoError(t, resp)
+
+	found := false
+	for _, a := range activity {
+		if a.SessionId == session.Id {
+			found = true
+			assert.NotEmpty(t, a.Ip)
+			assert.NotEmpty(t, a.Platform)
+			assert.Equal(t, session.CreateAt, a.CreateAt)
+		}
+	}
+	assert.True(t, found, "current session should be listed")
+
+	_, resp = th.Client.GetSessionActivity(th.BasicUser2.Id)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.GetSessionActivity(user.Id)
+	CheckNoError(t, resp)
+
+	th.Client.Logout()
+	_, resp = th.Client.GetSessionActivity(user.Id)
+	CheckUnauthorizedStatus(t, resp)
+}
+
 func TestRevokeSessions(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
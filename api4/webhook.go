@@ -18,10 +18,13 @@ func (api *API) InitWebhook() {
 
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(createOutgoingHook)).Methods("POST")
 	api.BaseRoutes.OutgoingHooks.Handle("", api.ApiSessionRequired(getOutgoingHooks)).Methods("GET")
+	api.BaseRoutes.OutgoingHooks.Handle("/test_payload_template", api.ApiSessionRequired(testOutgoingHookPayloadTemplate)).Methods("POST")
 	api.BaseRoutes.OutgoingHook.Handle("", api.ApiSessionRequired(getOutgoingHook)).Methods("GET")
 	api.BaseRoutes.OutgoingHook.Handle("", api.ApiSessionRequired(updateOutgoingHook)).Methods("PUT")
 	api.BaseRoutes.OutgoingHook.Handle("", api.ApiSessionRequired(deleteOutgoingHook)).Methods("DELETE")
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.ApiSessionRequired(regenOutgoingHookToken)).Methods("POST")
+
+	api.BaseRoutes.Channel.Handle("/webhooks", api.ApiSessionRequired(getChannelHooks)).Methods("GET")
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -347,6 +350,41 @@ func createOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(rhook.ToJson()))
 }
 
+// testOutgoingHookPayloadTemplate executes an outgoing webhook payload template against a sample payload.
+//
+// The request body is a JSON object with the team_id of a team in which the user can manage outgoing
+// webhooks and the payload_template to test. The response contains the sample payload, whose fields
+// are the variables available to the template, and the output of the template. Templates may also
+// use the json function to encode a value for inclusion in a JSON document.
+func testOutgoingHookPayloadTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJson(r.Body)
+
+	teamId := props["team_id"]
+	if len(teamId) != 26 {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	payloadTemplate := props["payload_template"]
+	if payloadTemplate == "" {
+		c.SetInvalidParam("payload_template")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(c.App.Session, teamId, model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS)
+		return
+	}
+
+	result, err := c.App.TestOutgoingWebhookPayloadTemplate(payloadTemplate)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(result.ToJson()))
+}
+
 func getOutgoingHooks(c *Context, w http.ResponseWriter, r *http.Request) {
 	channelId := r.URL.Query().Get("channel_id")
 	teamId := r.URL.Query().Get("team_id")
@@ -401,6 +439,43 @@ func getOutgoingHooks(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(model.OutgoingWebhookListToJson(hooks)))
 }
 
+// getChannelHooks lists the incoming and outgoing webhooks of a channel.
+//
+// Managing both kinds of webhooks in the channel is required. Users who can't manage the webhooks
+// of others only see their own.
+func getChannelHooks(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	for _, permission := range []*model.Permission{model.PERMISSION_MANAGE_INCOMING_WEBHOOKS, model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS} {
+		if !c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, permission) {
+			c.SetPermissionError(permission)
+			return
+		}
+	}
+
+	// Remove the user as a filter if they have permission to manage others.
+	incomingUserId := c.App.Session.UserId
+	if c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_MANAGE_OTHERS_INCOMING_WEBHOOKS) {
+		incomingUserId = ""
+	}
+
+	outgoingUserId := c.App.Session.UserId
+	if c.App.SessionHasPermissionToChannel(c.App.Session, c.Params.ChannelId, model.PERMISSION_MANAGE_OTHERS_OUTGOING_WEBHOOKS) {
+		outgoingUserId = ""
+	}
+
+	webhooks, err := c.App.GetWebhooksForChannel(c.Params.ChannelId, incomingUserId, outgoingUserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(webhooks.ToJson()))
+}
+
 func getOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
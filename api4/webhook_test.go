@@ -409,6 +409,40 @@ func TestCreateOutgoingWebhook(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestTestOutgoingWebhookPayloadTemplate(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	Client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	result, resp := th.SystemAdminClient.TestOutgoingWebhookPayloadTemplate(th.BasicTeam.Id, `{"channel": {{json .ChannelName}}}`)
+	CheckNoError(t, resp)
+	assert.Equal(t, model.SampleOutgoingWebhookPayload(), result.Payload)
+	assert.Equal(t, `{"channel": "`+result.Payload.ChannelName+`"}`, result.Output)
+
+	_, resp = th.SystemAdminClient.TestOutgoingWebhookPayloadTemplate(th.BasicTeam.Id, `{{.Unknown}}`)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.TestOutgoingWebhookPayloadTemplate(th.BasicTeam.Id, "")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = th.SystemAdminClient.TestOutgoingWebhookPayloadTemplate("junk", "{{.Text}}")
+	CheckBadRequestStatus(t, resp)
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer func() {
+		th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	}()
+	th.RemovePermissionFromRole(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id, model.TEAM_USER_ROLE_ID)
+	_, resp = Client.TestOutgoingWebhookPayloadTemplate(th.BasicTeam.Id, "{{.Text}}")
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp = th.SystemAdminClient.TestOutgoingWebhookPayloadTemplate(th.BasicTeam.Id, "{{.Text}}")
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestGetOutgoingWebhooks(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -592,6 +626,77 @@ func TestGetOutgoingWebhooksByChannel(t *testing.T) {
 
 }
 
+func TestGetChannelWebhooks(t *testing.T) {
+	th := Setup().InitBasic()
+	defer th.TearDown()
+	BasicClient := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+	})
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer func() {
+		th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	}()
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_INCOMING_WEBHOOKS.Id, model.TEAM_USER_ROLE_ID)
+	th.AddPermissionToRole(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id, model.TEAM_USER_ROLE_ID)
+
+	basicIncoming, resp := BasicClient.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+	basicOutgoing, resp := BasicClient.CreateOutgoingWebhook(&model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}})
+	CheckNoError(t, resp)
+
+	_, resp = th.SystemAdminClient.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+	CheckNoError(t, resp)
+	_, resp = th.SystemAdminClient.CreateOutgoingWebhook(&model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}})
+	CheckNoError(t, resp)
+
+	// A webhook in another channel shouldn't be listed
+	_, resp = th.SystemAdminClient.CreateIncomingWebhook(&model.IncomingWebhook{ChannelId: th.BasicChannel2.Id})
+	CheckNoError(t, resp)
+
+	t.Run("as system admin", func(t *testing.T) {
+		webhooks, resp := th.SystemAdminClient.GetChannelWebhooks(th.BasicChannel.Id)
+		CheckNoError(t, resp)
+		assert.Len(t, webhooks.Incoming, 2)
+		assert.Len(t, webhooks.Outgoing, 2)
+	})
+
+	t.Run("without permission to manage others", func(t *testing.T) {
+		webhooks, resp := BasicClient.GetChannelWebhooks(th.BasicChannel.Id)
+		CheckNoError(t, resp)
+		require.Len(t, webhooks.Incoming, 1)
+		assert.Equal(t, basicIncoming.Id, webhooks.Incoming[0].Id)
+		require.Len(t, webhooks.Outgoing, 1)
+		assert.Equal(t, basicOutgoing.Id, webhooks.Outgoing[0].Id)
+	})
+
+	t.Run("without permission to manage outgoing webhooks", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id, model.TEAM_USER_ROLE_ID)
+		defer th.AddPermissionToRole(model.PERMISSION_MANAGE_OUTGOING_WEBHOOKS.Id, model.TEAM_USER_ROLE_ID)
+
+		_, resp := BasicClient.GetChannelWebhooks(th.BasicChannel.Id)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("with outgoing webhooks disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+		webhooks, resp := th.SystemAdminClient.GetChannelWebhooks(th.BasicChannel.Id)
+		CheckNoError(t, resp)
+		assert.Len(t, webhooks.Incoming, 2)
+		assert.Len(t, webhooks.Outgoing, 0)
+	})
+
+	t.Run("invalid channel id", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.GetChannelWebhooks("junk")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestGetOutgoingWebhooksListByUser(t *testing.T) {
 	th := Setup().InitBasic()
 	defer th.TearDown()
@@ -175,6 +175,63 @@ func (a *App) RecycleDatabaseConnection() {
 	mlog.Warn("Finished recycling the database connection.")
 }
 
+func (a *App) GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError) {
+	return a.Srv.Store.System().GetSchemaMigrations()
+}
+
+func (a *App) GetOpenDatabaseTransactions() ([]*model.DatabaseTransaction, *model.AppError) {
+	return a.Srv.Store.System().GetOpenTransactions()
+}
+
+// CheckLongRunningDatabaseTransactions logs the database transactions that have been open for longer
+// than SqlSettings.TransactionMaxAgeWarningSeconds and reports how many there are to the metrics
+// server, where they can be alerted on.
+func (a *App) CheckLongRunningDatabaseTransactions() *model.AppError {
+	maxAgeSeconds := *a.Config().SqlSettings.TransactionMaxAgeWarningSeconds
+	if maxAgeSeconds <= 0 {
+		return nil
+	}
+
+	transactions, err := a.GetOpenDatabaseTransactions()
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	for _, transaction := range transactions {
+		if transaction.DurationMs <= int64(maxAgeSeconds)*1000 {
+			continue
+		}
+
+		count++
+		mlog.Warn("Long running database transaction", mlog.Int64("pid", transaction.Pid), mlog.Int64("duration_ms", transaction.DurationMs), mlog.String("state", transaction.State), mlog.String("query", transaction.QuerySnippet))
+	}
+
+	if a.Metrics != nil {
+		a.Metrics.SetDatabaseLongRunningTransactionCount(count)
+	}
+
+	return nil
+}
+
+// RunReportingQuery runs the given query if it matches one of the SELECT statements whitelisted in
+// SqlSettings.AllowedReportingQueries, ignoring differences in whitespace. Each row of the results
+// is keyed by lower cased column name, and text is returned as strings on every database.
+func (a *App) RunReportingQuery(query *model.ReportingQuery) ([]map[string]interface{}, *model.AppError) {
+	if !model.IsReportingQuerySelect(query.Query) {
+		return nil, model.NewAppError("RunReportingQuery", "app.admin.run_reporting_query.not_select.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	normalized := model.NormalizeReportingQuery(query.Query)
+	for _, allowed := range a.Config().SqlSettings.AllowedReportingQueries {
+		if model.NormalizeReportingQuery(allowed) == normalized {
+			return a.Srv.Store.System().RunReportingQuery(allowed, query.Params)
+		}
+	}
+
+	return nil, model.NewAppError("RunReportingQuery", "app.admin.run_reporting_query.not_allowed.app_error", nil, "", http.StatusForbidden)
+}
+
 func (a *App) TestSiteURL(siteURL string) *model.AppError {
 	url := fmt.Sprintf("%s/api/v4/system/ping", siteURL)
 	res, err := http.Get(url)
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestCheckLongRunningDatabaseTransactions(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		metrics := &mocks.MetricsInterface{}
+		th.App.Metrics = metrics
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.TransactionMaxAgeWarningSeconds = 0 })
+
+		require.Nil(t, th.App.CheckLongRunningDatabaseTransactions())
+		metrics.AssertNotCalled(t, "SetDatabaseLongRunningTransactionCount")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("SetDatabaseLongRunningTransactionCount", int64(0)).Return()
+		th.App.Metrics = metrics
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SqlSettings.TransactionMaxAgeWarningSeconds = 3600 })
+
+		require.Nil(t, th.App.CheckLongRunningDatabaseTransactions())
+		metrics.AssertExpectations(t)
+	})
+}
@@ -93,6 +93,9 @@ func (s *Server) initJobs() {
 	if jobsPluginsInterface != nil {
 		s.Jobs.Plugins = jobsPluginsInterface(s.FakeApp())
 	}
+	if jobsScheduledMessageExportInterface != nil {
+		s.Jobs.ScheduledMessageExport = jobsScheduledMessageExportInterface(s.FakeApp())
+	}
 	s.Jobs.Workers = s.Jobs.InitWorkers()
 	s.Jobs.Schedulers = s.Jobs.InitSchedulers()
 }
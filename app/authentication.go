@@ -72,6 +72,10 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 		return passErr
 	}
 
+	if err := a.checkUserPasswordNotExpired(user); err != nil {
+		return err
+	}
+
 	if err := a.CheckUserPostflightAuthenticationCriteria(user); err != nil {
 		return err
 	}
@@ -107,6 +111,21 @@ func (a *App) checkUserPassword(user *model.User, password string) *model.AppErr
 	return nil
 }
 
+// checkUserPasswordNotExpired rejects users who have not changed their password within
+// PasswordSettings.ExpiryDays. They have to reset it before they can log in again.
+func (a *App) checkUserPasswordNotExpired(user *model.User) *model.AppError {
+	expiryDays := *a.Config().PasswordSettings.ExpiryDays
+	if expiryDays <= 0 || user.AuthService != "" {
+		return nil
+	}
+
+	if user.LastPasswordUpdate < model.GetMillis()-int64(expiryDays)*DAY_MILLISECONDS {
+		return model.NewAppError("checkUserPasswordNotExpired", "api.user.check_user_password.expired.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
 func (a *App) checkLdapUserPasswordAndAllCriteria(ldapId *string, password string, mfaToken string) (*model.User, *model.AppError) {
 	if a.Ldap == nil || ldapId == nil {
 		err := model.NewAppError("doLdapAuthentication", "api.user.login_ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
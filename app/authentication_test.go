@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,3 +51,31 @@ func TestParseAuthTokenFromRequest(t *testing.T) {
 		require.Equal(t, tc.expectedLocation, location, "Wrong location on test "+strconv.Itoa(testnum))
 	}
 }
+
+func TestCheckPasswordAndAllCriteriaPasswordExpiry(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user, err := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, err)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.ExpiryDays = 90 })
+
+	require.Nil(t, th.App.CheckPasswordAndAllCriteria(user, "Password1", ""))
+
+	user.LastPasswordUpdate = model.GetMillis() - 91*DAY_MILLISECONDS
+
+	err = th.App.CheckPasswordAndAllCriteria(user, "Password1", "")
+	require.NotNil(t, err)
+	assert.Equal(t, "api.user.check_user_password.expired.app_error", err.Id)
+	assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+
+	// An expired password is only reported once the password itself has been verified.
+	err = th.App.CheckPasswordAndAllCriteria(user, "wrong", "")
+	require.NotNil(t, err)
+	assert.Equal(t, "api.user.check_user_password.invalid.app_error", err.Id)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.ExpiryDays = 0 })
+
+	require.Nil(t, th.App.CheckPasswordAndAllCriteria(user, "Password1", ""))
+}
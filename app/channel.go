@@ -16,6 +16,12 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 )
 
+const (
+	// CHANNEL_LIMIT_WARNING_PERCENT is how full a team may get, relative to
+	// TeamSettings.MaxChannelsPerTeam, before system admins are warned.
+	CHANNEL_LIMIT_WARNING_PERCENT = 90
+)
+
 // CreateDefaultChannels creates channels in the given team for each channel returned by (*App).DefaultChannelNames.
 //
 func (a *App) CreateDefaultChannels(teamID string) ([]*model.Channel, *model.AppError) {
@@ -159,16 +165,6 @@ func (a *App) CreateChannelWithUser(channel *model.Channel, userId string) (*mod
 		return nil, model.NewAppError("CreateChannelWithUser", "app.channel.create_channel.no_team_id.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	// Get total number of channels on current team
-	count, err := a.GetNumberOfChannelsOnTeam(channel.TeamId, false)
-	if err != nil {
-		return nil, err
-	}
-
-	if int64(count+1) > *a.Config().TeamSettings.MaxChannelsPerTeam {
-		return nil, model.NewAppError("CreateChannelWithUser", "api.channel.create_channel.max_channel_limit.app_error", map[string]interface{}{"MaxChannelsPerTeam": *a.Config().TeamSettings.MaxChannelsPerTeam}, "", http.StatusBadRequest)
-	}
-
 	channel.CreatorId = userId
 
 	rchannel, err := a.CreateChannel(channel, true)
@@ -188,6 +184,8 @@ func (a *App) CreateChannelWithUser(channel *model.Channel, userId string) (*mod
 	message.Add("team_id", channel.TeamId)
 	a.Publish(message)
 
+	a.completeTeamOnboardingStep(channel.TeamId, model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL)
+
 	if a.IsESIndexingEnabled() {
 		a.Srv.Go(func() {
 			if err := a.indexUser(user); err != nil {
@@ -223,11 +221,29 @@ func (a *App) RenameChannel(channel *model.Channel, newChannelName string, newDi
 }
 
 func (a *App) CreateChannel(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
-	sc, err := a.Srv.Store.Channel().Save(channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
+	maxChannels := a.maxChannelsPerTeam()
+
+	var count int64
+	if maxChannels >= 0 && (channel.Type == model.CHANNEL_OPEN || channel.Type == model.CHANNEL_PRIVATE) {
+		var err *model.AppError
+		if count, err = a.Srv.Store.Channel().GetTeamChannelCount(channel.TeamId); err != nil {
+			return nil, err
+		}
+
+		if count+1 > maxChannels {
+			return nil, model.NewAppError("CreateChannel", "api.channel.create_channel.max_channel_limit.app_error", map[string]interface{}{"MaxChannelsPerTeam": maxChannels}, "", http.StatusBadRequest)
+		}
+	}
+
+	sc, err := a.Srv.Store.Channel().Save(channel, maxChannels)
 	if err != nil {
 		return nil, err
 	}
 
+	if maxChannels > 0 && (sc.Type == model.CHANNEL_OPEN || sc.Type == model.CHANNEL_PRIVATE) {
+		a.checkChannelLimitWarning(sc.TeamId, count+1, maxChannels)
+	}
+
 	if addMember {
 		user, err := a.Srv.Store.User().Get(channel.CreatorId)
 		if err != nil {
@@ -283,6 +299,31 @@ func (a *App) CreateChannel(channel *model.Channel, addMember bool) (*model.Chan
 	return sc, nil
 }
 
+// maxChannelsPerTeam returns the channels per team limit in the form expected by the channel
+// store, where a negative value disables the limit.
+func (a *App) maxChannelsPerTeam() int64 {
+	if maxChannels := *a.Config().TeamSettings.MaxChannelsPerTeam; maxChannels > 0 {
+		return maxChannels
+	}
+
+	return -1
+}
+
+// checkChannelLimitWarning notifies system admins once a team reaches
+// CHANNEL_LIMIT_WARNING_PERCENT of the maximum number of channels per team.
+func (a *App) checkChannelLimitWarning(teamId string, count, maxChannels int64) {
+	if count*100 < maxChannels*CHANNEL_LIMIT_WARNING_PERCENT {
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_CHANNEL_LIMIT_WARNING, "", "", "", nil)
+	message.Add("team_id", teamId)
+	message.Add("channel_count", count)
+	message.Add("max_channels_per_team", maxChannels)
+	message.Broadcast.ContainsSensitiveData = true
+	a.Publish(message)
+}
+
 func (a *App) GetOrCreateDirectChannel(userId, otherUserId string) (*model.Channel, *model.AppError) {
 	channel, err := a.Srv.Store.Channel().GetByName("", model.GetDMNameFromIds(userId, otherUserId), true)
 	if err != nil {
@@ -456,7 +497,7 @@ func (a *App) createGroupChannel(userIds []string, creatorId string) (*model.Cha
 		Type:        model.CHANNEL_GROUP,
 	}
 
-	channel, err := a.Srv.Store.Channel().Save(group, *a.Config().TeamSettings.MaxChannelsPerTeam)
+	channel, err := a.Srv.Store.Channel().Save(group, a.maxChannelsPerTeam())
 	if err != nil {
 		if err.Id == store.CHANNEL_EXISTS_ERROR {
 			return channel, err
@@ -619,12 +660,16 @@ func (a *App) PatchChannel(channel *model.Channel, patch *model.ChannelPatch, us
 	}
 
 	if channel.Header != oldChannelHeader {
+		a.saveChannelTopicHistory(userId, channel.Id, model.CHANNEL_TOPIC_TYPE_HEADER, channel.Header)
+
 		if err = a.PostUpdateChannelHeaderMessage(userId, channel, oldChannelHeader, channel.Header); err != nil {
 			mlog.Error(err.Error())
 		}
 	}
 
 	if channel.Purpose != oldChannelPurpose {
+		a.saveChannelTopicHistory(userId, channel.Id, model.CHANNEL_TOPIC_TYPE_PURPOSE, channel.Purpose)
+
 		if err = a.PostUpdateChannelPurposeMessage(userId, channel, oldChannelPurpose, channel.Purpose); err != nil {
 			mlog.Error(err.Error())
 		}
@@ -678,17 +723,36 @@ func (a *App) UpdateChannelMemberRoles(channelId string, userId string, newRoles
 
 	prevSchemeGuestValue := member.SchemeGuest
 
+	if err = a.setChannelMemberRoles(member, newRoles, schemeGuestRole, schemeUserRole, schemeAdminRole); err != nil {
+		return nil, err
+	}
+
+	if prevSchemeGuestValue != member.SchemeGuest {
+		return nil, model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.changing_guest_role.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	member, err = a.Srv.Store.Channel().UpdateMember(member)
+	if err != nil {
+		return nil, err
+	}
+
+	a.InvalidateCacheForUser(userId)
+	return member, nil
+}
+
+// setChannelMemberRoles replaces the scheme and explicit roles of a channel member with the given
+// space separated roles, where the scheme roles are the ones used by the member's channel.
+func (a *App) setChannelMemberRoles(member *model.ChannelMember, newRoles string, schemeGuestRole, schemeUserRole, schemeAdminRole string) *model.AppError {
 	var newExplicitRoles []string
 	member.SchemeGuest = false
 	member.SchemeUser = false
 	member.SchemeAdmin = false
 
 	for _, roleName := range strings.Fields(newRoles) {
-		var role *model.Role
-		role, err = a.GetRoleByName(roleName)
+		role, err := a.GetRoleByName(roleName)
 		if err != nil {
 			err.StatusCode = http.StatusBadRequest
-			return nil, err
+			return err
 		}
 
 		if !role.SchemeManaged {
@@ -705,28 +769,18 @@ func (a *App) UpdateChannelMemberRoles(channelId string, userId string, newRoles
 				member.SchemeGuest = true
 			default:
 				// If not part of the scheme for this channel, then it is not allowed to apply it as an explicit role.
-				return nil, model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.scheme_role.app_error", nil, "role_name="+roleName, http.StatusBadRequest)
+				return model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.scheme_role.app_error", nil, "role_name="+roleName, http.StatusBadRequest)
 			}
 		}
 	}
 
 	if member.SchemeUser && member.SchemeGuest {
-		return nil, model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.guest_and_user.app_error", nil, "", http.StatusBadRequest)
-	}
-
-	if prevSchemeGuestValue != member.SchemeGuest {
-		return nil, model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.changing_guest_role.app_error", nil, "", http.StatusBadRequest)
+		return model.NewAppError("UpdateChannelMemberRoles", "api.channel.update_channel_member_roles.guest_and_user.app_error", nil, "", http.StatusBadRequest)
 	}
 
 	member.ExplicitRoles = strings.Join(newExplicitRoles, " ")
 
-	member, err = a.Srv.Store.Channel().UpdateMember(member)
-	if err != nil {
-		return nil, err
-	}
-
-	a.InvalidateCacheForUser(userId)
-	return member, nil
+	return nil
 }
 
 func (a *App) UpdateChannelMemberSchemeRoles(channelId string, userId string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.ChannelMember, *model.AppError) {
@@ -1021,6 +1075,186 @@ func (a *App) AddChannelMember(userId string, channel *model.Channel, userReques
 	return cm, nil
 }
 
+// AddChannelMembers adds many users to a channel at once with a single insert. Users that are
+// already members of the channel, or that are listed more than once, are skipped, while users that
+// can't be added are reported in the result instead of failing the others. Unlike AddChannelMember,
+// no system messages are posted to the channel.
+func (a *App) AddChannelMembers(channel *model.Channel, membersToAdd []*model.ChannelMemberToAdd, userRequestorId string) (*model.ChannelMembersBulkResult, *model.AppError) {
+	if channel.Type != model.CHANNEL_OPEN && channel.Type != model.CHANNEL_PRIVATE {
+		return nil, model.NewAppError("AddChannelMembers", "api.channel.add_user_to_channel.type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	result := &model.ChannelMembersBulkResult{Errors: []*model.ChannelMemberBulkError{}}
+	addError := func(userId string, err *model.AppError) {
+		err.Translate(a.T)
+		result.Errors = append(result.Errors, &model.ChannelMemberBulkError{UserId: userId, Error: err.Message})
+	}
+
+	var userIds []string
+	rolesByUserId := make(map[string]string, len(membersToAdd))
+	for _, memberToAdd := range membersToAdd {
+		if _, ok := rolesByUserId[memberToAdd.UserId]; ok {
+			result.Skipped++
+			continue
+		}
+		rolesByUserId[memberToAdd.UserId] = memberToAdd.Roles
+		userIds = append(userIds, memberToAdd.UserId)
+	}
+
+	if len(userIds) == 0 {
+		return result, nil
+	}
+
+	existingMembers, err := a.Srv.Store.Channel().GetMembersByIds(channel.Id, userIds)
+	if err != nil {
+		return nil, err
+	}
+	isMember := make(map[string]bool, len(*existingMembers))
+	for _, member := range *existingMembers {
+		isMember[member.UserId] = true
+	}
+
+	users, err := a.Srv.Store.User().GetProfileByIds(userIds, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	usersById := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		usersById[user.Id] = user
+	}
+
+	teamMembers, err := a.Srv.Store.Team().GetMembersByIds(channel.TeamId, userIds, nil)
+	if err != nil {
+		return nil, err
+	}
+	isTeamMember := make(map[string]bool, len(teamMembers))
+	for _, teamMember := range teamMembers {
+		isTeamMember[teamMember.UserId] = teamMember.DeleteAt == 0
+	}
+
+	isDenied := make(map[string]bool)
+	if channel.IsGroupConstrained() {
+		nonMembers, err := a.FilterNonGroupChannelMembers(userIds, channel)
+		if err != nil {
+			return nil, model.NewAppError("AddChannelMembers", "api.channel.add_members.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		for _, userId := range nonMembers {
+			isDenied[userId] = true
+		}
+	}
+
+	schemeGuestRole, schemeUserRole, schemeAdminRole, err := a.GetSchemeRolesForChannel(channel.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var newMembers []*model.ChannelMember
+	for _, userId := range userIds {
+		if isMember[userId] {
+			result.Skipped++
+			continue
+		}
+
+		user, ok := usersById[userId]
+		if !ok || user.DeleteAt > 0 {
+			addError(userId, model.NewAppError("AddChannelMembers", "app.channel.add_members_bulk.user_not_found.app_error", nil, "", http.StatusBadRequest))
+			continue
+		}
+
+		if !isTeamMember[userId] {
+			addError(userId, model.NewAppError("AddChannelMembers", "app.channel.add_members_bulk.not_team_member.app_error", nil, "", http.StatusBadRequest))
+			continue
+		}
+
+		if isDenied[userId] {
+			addError(userId, model.NewAppError("AddChannelMembers", "api.channel.add_members.user_denied", map[string]interface{}{"UserIDs": []string{userId}}, "", http.StatusBadRequest))
+			continue
+		}
+
+		member := &model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			SchemeGuest: user.IsGuest(),
+			SchemeUser:  !user.IsGuest(),
+		}
+
+		if roles := rolesByUserId[userId]; roles != "" {
+			if err := a.setChannelMemberRoles(member, roles, schemeGuestRole, schemeUserRole, schemeAdminRole); err != nil {
+				addError(userId, err)
+				continue
+			}
+
+			if member.SchemeGuest != user.IsGuest() {
+				addError(userId, model.NewAppError("AddChannelMembers", "api.channel.update_channel_member_roles.changing_guest_role.app_error", nil, "", http.StatusBadRequest))
+				continue
+			}
+		}
+
+		newMembers = append(newMembers, member)
+	}
+
+	// Users that joined since the members were read above are skipped rather than failing the others.
+	addedMembers, err := a.Srv.Store.Channel().SaveMultipleMembers(newMembers)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped += len(newMembers) - len(addedMembers)
+	newMembers = addedMembers
+	result.Added = len(newMembers)
+
+	if len(newMembers) == 0 {
+		return result, nil
+	}
+
+	joinTime := model.GetMillis()
+	for _, member := range newMembers {
+		if err := a.Srv.Store.ChannelMemberHistory().LogJoinEvent(member.UserId, channel.Id, joinTime); err != nil {
+			mlog.Warn(fmt.Sprintf("Failed to update ChannelMemberHistory table %v", err))
+		}
+
+		a.InvalidateCacheForUser(member.UserId)
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_USER_ADDED, "", channel.Id, "", nil)
+		message.Add("user_id", member.UserId)
+		message.Add("team_id", channel.TeamId)
+		a.Publish(message)
+	}
+	a.InvalidateCacheForChannelMembers(channel.Id)
+
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		var userRequestor *model.User
+		if userRequestorId != "" {
+			if userRequestor, err = a.GetUser(userRequestorId); err != nil {
+				mlog.Error("Failed to get the user adding members to a channel", mlog.String("user_id", userRequestorId), mlog.Err(err))
+			}
+		}
+
+		a.Srv.Go(func() {
+			pluginContext := a.PluginContext()
+			for _, member := range newMembers {
+				member := member
+				pluginsEnvironment.RunMultiPluginHook(func(hooks plugin.Hooks) bool {
+					hooks.UserHasJoinedChannel(pluginContext, member, userRequestor)
+					return true
+				}, plugin.UserHasJoinedChannelId)
+			}
+		})
+	}
+
+	if a.IsESIndexingEnabled() {
+		a.Srv.Go(func() {
+			for _, member := range newMembers {
+				if err := a.indexUser(usersById[member.UserId]); err != nil {
+					mlog.Error("Encountered error indexing user", mlog.String("user_id", member.UserId), mlog.Err(err))
+				}
+			}
+		})
+	}
+
+	return result, nil
+}
+
 func (a *App) AddDirectChannels(teamId string, user *model.User) *model.AppError {
 	var profiles []*model.User
 	options := &model.UserGetOptions{InTeamId: teamId, Page: 0, PerPage: 100}
@@ -1334,6 +1568,12 @@ func (a *App) GetChannelPinnedPostCount(channelId string) (int64, *model.AppErro
 	return a.Srv.Store.Channel().GetPinnedPostCount(channelId, true)
 }
 
+// GetChannelMessageCount returns the number of posts in the channel created within the given
+// bounds, either of which may be zero to leave that side unbounded.
+func (a *App) GetChannelMessageCount(channelId string, since, until int64) (int64, *model.AppError) {
+	return a.Srv.Store.Post().GetChannelPostCount(channelId, since, until)
+}
+
 func (a *App) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError) {
 	return a.Srv.Store.Channel().GetChannelCounts(teamId, userId)
 }
@@ -1724,6 +1964,35 @@ func (a *App) RemoveUserFromChannel(userIdToRemove string, removerUserId string,
 	return nil
 }
 
+// GetChannelsByIdsForUser returns the requested channels keyed by id. Channels that don't exist,
+// or that the user isn't a member of when includeAll is false, are mapped to nil.
+func (a *App) GetChannelsByIdsForUser(channelIds []string, userId string, includeAll bool) (map[string]*model.Channel, *model.AppError) {
+	channelsById := make(map[string]*model.Channel, len(channelIds))
+	for _, channelId := range channelIds {
+		channelsById[channelId] = nil
+	}
+
+	channels, err := a.Srv.Store.Channel().GetChannelsByIds(channelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships map[string]string
+	if !includeAll {
+		if memberships, err = a.Srv.Store.Channel().GetAllChannelMembersForUser(userId, true, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, channel := range channels {
+		if _, ok := memberships[channel.Id]; includeAll || ok {
+			channelsById[channel.Id] = channel
+		}
+	}
+
+	return channelsById, nil
+}
+
 func (a *App) GetNumberOfChannelsOnTeam(teamId string, includeDeleted bool) (int, *model.AppError) {
 	// Get total number of channels on current team
 	list, err := a.Srv.Store.Channel().GetTeamChannels(teamId)
@@ -1962,7 +2231,28 @@ func (a *App) ViewChannel(view *model.ChannelView, userId string, currentSession
 		return map[string]int64{}, nil
 	}
 
-	return a.MarkChannelsAsViewed(channelIds, userId, currentSessionId)
+	times, err := a.MarkChannelsAsViewed(channelIds, userId, currentSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(view.ChannelId) > 0 {
+		recent := &model.RecentChannel{
+			UserId:       userId,
+			ChannelId:    view.ChannelId,
+			LastViewedAt: model.GetMillis(),
+		}
+		if err := a.Srv.Store.Channel().SaveRecentChannel(recent); err != nil {
+			mlog.Warn("Failed to record recently viewed channel", mlog.String("user_id", userId), mlog.String("channel_id", view.ChannelId), mlog.Err(err))
+		}
+	}
+
+	return times, nil
+}
+
+// GetRecentChannelsForUser returns the channels most recently viewed by the user across all of their devices.
+func (a *App) GetRecentChannelsForUser(userId string, teamId string, limit int) ([]*model.RecentChannel, *model.AppError) {
+	return a.Srv.Store.Channel().GetRecentChannels(userId, teamId, limit)
 }
 
 func (a *App) PermanentDeleteChannel(channel *model.Channel) *model.AppError {
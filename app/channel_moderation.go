@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetChannelModerations returns whether channel members and guests are granted each of the
+// moderated permissions by the channel's effective scheme roles.
+func (a *App) GetChannelModerations(channel *model.Channel) (model.ChannelModerations, *model.AppError) {
+	guestRole, userRole, err := a.getChannelModerationRoles(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildChannelModerations(channel, guestRole, userRole), nil
+}
+
+// PatchChannelModerations updates the channel's scheme roles to grant or revoke the given moderated
+// permissions. If the channel doesn't have its own scheme yet, one is created with the permissions
+// it currently inherits so that only the moderated permissions change. The scheme and all of its
+// roles are saved in a single transaction.
+func (a *App) PatchChannelModerations(channel *model.Channel, patch model.ChannelModerations) (model.ChannelModerations, *model.AppError) {
+	if err := patch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	guestRole, userRole, err := a.getChannelModerationRoles(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, moderation := range patch {
+		permissionIds := model.ChannelModeratedPermissionIds(name, channel.Type)
+		if moderation.Roles.Members != nil {
+			userRole.Permissions = setRolePermissions(userRole.Permissions, permissionIds, *moderation.Roles.Members)
+		}
+		if moderation.Roles.Guests != nil {
+			guestRole.Permissions = setRolePermissions(guestRole.Permissions, permissionIds, *moderation.Roles.Guests)
+		}
+	}
+
+	var updatedRoles []*model.Role
+	if channel.SchemeId == nil || len(*channel.SchemeId) == 0 {
+		var scheme *model.Scheme
+		scheme, updatedRoles, err = a.createChannelModerationScheme(channel, guestRole, userRole)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only switch the channel over to the new scheme once it has been saved with its roles.
+		channel.SchemeId = &scheme.Id
+		if channel, err = a.UpdateChannelScheme(channel); err != nil {
+			return nil, err
+		}
+	} else {
+		updatedRoles, err = a.Srv.Store.Role().UpdateMultiple([]*model.Role{guestRole, userRole})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, role := range updatedRoles {
+		a.sendUpdatedRoleEvent(role)
+	}
+
+	return buildChannelModerations(channel, guestRole, userRole), nil
+}
+
+// createChannelModerationScheme saves a channel scheme for the given channel whose roles are given
+// the permissions of the given guest and user roles and of the channel's current admin role, and
+// returns it along with its roles.
+func (a *App) createChannelModerationScheme(channel *model.Channel, guestRole *model.Role, userRole *model.Role) (*model.Scheme, []*model.Role, *model.AppError) {
+	if err := a.IsPhase2MigrationCompleted(); err != nil {
+		return nil, nil, err
+	}
+
+	_, _, adminRoleName, err := a.GetSchemeRolesForChannel(channel.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adminRole, err := a.GetRoleByName(adminRoleName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheme, err := a.Srv.Store.Scheme().SaveWithRolePermissions(&model.Scheme{
+		Name:        model.NewId(),
+		DisplayName: "Channel Moderation: " + channel.Name,
+		Scope:       model.SCHEME_SCOPE_CHANNEL,
+	}, map[string][]string{
+		model.CHANNEL_GUEST_ROLE_ID: guestRole.Permissions,
+		model.CHANNEL_USER_ROLE_ID:  userRole.Permissions,
+		model.CHANNEL_ADMIN_ROLE_ID: adminRole.Permissions,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := a.GetRolesByNames([]string{scheme.DefaultChannelGuestRole, scheme.DefaultChannelUserRole, scheme.DefaultChannelAdminRole})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return scheme, roles, nil
+}
+
+// getChannelModerationRoles returns the guest and user roles of the channel's effective scheme.
+func (a *App) getChannelModerationRoles(channel *model.Channel) (*model.Role, *model.Role, *model.AppError) {
+	guestRoleName, userRoleName, _, err := a.GetSchemeRolesForChannel(channel.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := a.GetRolesByNames([]string{guestRoleName, userRoleName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Copy the roles since they may be shared with the role cache and are modified when patching.
+	var guestRole, userRole *model.Role
+	for _, role := range roles {
+		roleCopy := *role
+		switch role.Name {
+		case guestRoleName:
+			guestRole = &roleCopy
+		case userRoleName:
+			userRole = &roleCopy
+		}
+	}
+
+	if guestRole == nil || userRole == nil {
+		return nil, nil, model.NewAppError("getChannelModerationRoles", "app.channel.get_channel_moderations.roles.app_error", nil, "channel_id="+channel.Id, http.StatusInternalServerError)
+	}
+
+	return guestRole, userRole, nil
+}
+
+func buildChannelModerations(channel *model.Channel, guestRole *model.Role, userRole *model.Role) model.ChannelModerations {
+	moderations := model.ChannelModerations{}
+	for _, name := range model.ChannelModeratedPermissions {
+		permissionIds := model.ChannelModeratedPermissionIds(name, channel.Type)
+
+		roles := &model.ChannelModeratedRoles{
+			Members: model.NewBool(roleHasPermissions(userRole, permissionIds)),
+		}
+		if model.IsChannelModeratedPermissionForGuests(name) {
+			roles.Guests = model.NewBool(roleHasPermissions(guestRole, permissionIds))
+		}
+
+		moderations[name] = &model.ChannelModeration{Roles: roles}
+	}
+
+	return moderations
+}
+
+func roleHasPermissions(role *model.Role, permissionIds []string) bool {
+	for _, permissionId := range permissionIds {
+		found := false
+		for _, permission := range role.Permissions {
+			if permission == permissionId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setRolePermissions adds or removes the given permissions from the list of role permissions.
+func setRolePermissions(permissions []string, permissionIds []string, granted bool) []string {
+	result := []string{}
+	for _, permission := range permissions {
+		keep := true
+		for _, permissionId := range permissionIds {
+			if permission == permissionId {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, permission)
+		}
+	}
+
+	if granted {
+		result = append(result, permissionIds...)
+	}
+
+	return result
+}
@@ -255,6 +255,45 @@ func TestCreateChannelPrivateCreatesChannelMemberHistoryRecord(t *testing.T) {
 	assert.Equal(t, privateChannel.Id, histories[0].ChannelId)
 }
 
+func TestCreateChannelMaxChannelsPerTeam(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newChannel := func() *model.Channel {
+		id := model.NewId()
+		return &model.Channel{
+			DisplayName: "dn_" + id,
+			Name:        "name_" + id,
+			Type:        model.CHANNEL_OPEN,
+			TeamId:      th.BasicTeam.Id,
+			CreatorId:   th.BasicUser.Id,
+		}
+	}
+
+	count, err := th.App.Srv.Store.Channel().GetTeamChannelCount(th.BasicTeam.Id)
+	require.Nil(t, err)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.MaxChannelsPerTeam = count + 1
+	})
+
+	_, err = th.App.CreateChannel(newChannel(), false)
+	require.Nil(t, err)
+
+	_, err = th.App.CreateChannel(newChannel(), false)
+	require.NotNil(t, err)
+	assert.Equal(t, "api.channel.create_channel.max_channel_limit.app_error", err.Id)
+
+	t.Run("zero is unlimited", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.TeamSettings.MaxChannelsPerTeam = 0
+		})
+
+		_, err = th.App.CreateChannel(newChannel(), false)
+		require.Nil(t, err)
+	})
+}
+
 func TestUpdateChannelPrivacy(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
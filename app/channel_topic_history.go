@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const CHANNEL_TOPIC_HISTORY_CLEANUP_BATCH_SIZE = 1000
+
+func (a *App) GetChannelTopicHistory(channelId string, limit int) ([]*model.ChannelTopicHistory, *model.AppError) {
+	return a.Srv.Store.ChannelTopicHistory().GetForChannel(channelId, limit)
+}
+
+// saveChannelTopicHistory records that the given user changed the header or purpose of a channel.
+// Failing to do so doesn't prevent the change.
+func (a *App) saveChannelTopicHistory(userId string, channelId string, topicType string, topic string) {
+	history := &model.ChannelTopicHistory{
+		ChannelId:   channelId,
+		Type:        topicType,
+		Topic:       topic,
+		SetByUserId: userId,
+	}
+
+	if _, err := a.Srv.Store.ChannelTopicHistory().Save(history); err != nil {
+		mlog.Error("Failed to save channel topic history", mlog.String("channel_id", channelId), mlog.Err(err))
+	}
+}
+
+// PruneChannelTopicHistory deletes the topic history older than
+// DataRetentionSettings.TopicHistoryRetentionDays.
+func (a *App) PruneChannelTopicHistory() *model.AppError {
+	endTime := model.GetMillis() - int64(*a.Config().DataRetentionSettings.TopicHistoryRetentionDays)*24*60*60*1000
+
+	for {
+		deleted, err := a.Srv.Store.ChannelTopicHistory().PermanentDeleteBatch(endTime, CHANNEL_TOPIC_HISTORY_CLEANUP_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+
+		if deleted < CHANNEL_TOPIC_HISTORY_CLEANUP_BATCH_SIZE {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPruneChannelTopicHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.DataRetentionSettings.TopicHistoryRetentionDays = 1
+	})
+
+	day := int64(24 * 60 * 60 * 1000)
+	now := model.GetMillis()
+
+	for _, createAt := range []int64{now - 3*day, now - 2*day} {
+		_, err := th.App.Srv.Store.ChannelTopicHistory().Save(&model.ChannelTopicHistory{
+			ChannelId:   th.BasicChannel.Id,
+			Type:        model.CHANNEL_TOPIC_TYPE_HEADER,
+			Topic:       "old header",
+			SetByUserId: th.BasicUser.Id,
+			CreateAt:    createAt,
+		})
+		require.Nil(t, err)
+	}
+
+	_, err := th.App.PatchChannel(th.BasicChannel, &model.ChannelPatch{Header: model.NewString("new header")}, th.BasicUser.Id)
+	require.Nil(t, err)
+
+	require.Nil(t, th.App.PruneChannelTopicHistory())
+
+	history, err := th.App.GetChannelTopicHistory(th.BasicChannel.Id, 10)
+	require.Nil(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "new header", history[0].Topic)
+	assert.Equal(t, th.BasicUser.Id, history[0].SetByUserId)
+}
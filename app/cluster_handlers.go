@@ -25,6 +25,7 @@ func (a *App) RegisterAllClusterMessageHandlers() {
 	a.Cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_CLEAR_SESSION_CACHE_FOR_ALL_USERS, a.ClusterClearSessionCacheForAllUsersHandler)
 	a.Cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INSTALL_PLUGIN, a.ClusterInstallPluginHandler)
 	a.Cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_REMOVE_PLUGIN, a.ClusterRemovePluginHandler)
+	a.Cluster.RegisterClusterMessageHandler(model.CLUSTER_EVENT_TEAM_MEMBERS_REMOVED, a.ClusterTeamMembersRemovedHandler)
 
 }
 
@@ -78,6 +79,12 @@ func (a *App) ClusterClearSessionCacheForUserHandler(msg *model.ClusterMessage)
 	a.ClearSessionCacheForUserSkipClusterSend(msg.Data)
 }
 
+func (a *App) ClusterTeamMembersRemovedHandler(msg *model.ClusterMessage) {
+	userIds := model.ArrayFromJson(strings.NewReader(msg.Data))
+	channelIds := model.ArrayFromJson(strings.NewReader(msg.Props["channel_ids"]))
+	a.InvalidateCacheForTeamMembersRemovedSkipClusterSend(userIds, channelIds)
+}
+
 func (a *App) ClusterClearSessionCacheForAllUsersHandler(msg *model.ClusterMessage) {
 	a.ClearSessionCacheForAllUsersSkipClusterSend()
 }
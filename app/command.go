@@ -466,7 +466,14 @@ func (a *App) CreateCommand(cmd *model.Command) (*model.Command, *model.AppError
 		}
 	}
 
-	return a.Srv.Store.Command().Save(cmd)
+	command, err := a.Srv.Store.Command().Save(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	a.completeTeamOnboardingStep(command.TeamId, model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION)
+
+	return command, nil
 }
 
 func (a *App) GetCommand(commandId string) (*model.Command, *model.AppError) {
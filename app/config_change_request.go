@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// ProposeConfigChange records a configuration proposed by a system admin for another to review.
+func (a *App) ProposeConfigChange(cfg *model.Config, userId string) (*model.ConfigChangeRequest, *model.AppError) {
+	if err := cfg.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return a.Srv.Store.ConfigChangeRequest().Save(&model.ConfigChangeRequest{
+		ProposedConfig: cfg,
+		ProposedBy:     userId,
+		BaseConfigHash: configHash(a.Config()),
+	})
+}
+
+// configHash identifies the given configuration by the hash of its JSON representation.
+func configHash(cfg *model.Config) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(cfg.ToJson())))
+}
+
+// GetConfigChangeRequest returns the configuration change request with the given id.
+func (a *App) GetConfigChangeRequest(requestId string) (*model.ConfigChangeRequest, *model.AppError) {
+	return a.Srv.Store.ConfigChangeRequest().Get(requestId)
+}
+
+// GetPendingConfigChangeRequests returns the proposed configurations awaiting review, oldest first.
+func (a *App) GetPendingConfigChangeRequests() ([]*model.ConfigChangeRequest, *model.AppError) {
+	return a.Srv.Store.ConfigChangeRequest().GetPending()
+}
+
+// ApproveConfigChangeRequest approves a pending request on behalf of a system admin other than the
+// one who proposed it, and saves the given configuration, which is the proposed configuration as
+// prepared against the active one. A request proposed before the active configuration last changed
+// can't be approved, since saving it would revert the changes made since. A request whose
+// configuration fails to save is returned to pending.
+func (a *App) ApproveConfigChangeRequest(request *model.ConfigChangeRequest, cfg *model.Config, userId string) (*model.ConfigChangeRequest, *model.AppError) {
+	if request.Status != model.CONFIG_CHANGE_REQUEST_STATUS_PENDING {
+		return nil, model.NewAppError("ApproveConfigChangeRequest", "app.config_change_request.not_pending.app_error", nil, "id="+request.Id+", status="+request.Status, http.StatusBadRequest)
+	}
+
+	if request.ProposedBy == userId {
+		return nil, model.NewAppError("ApproveConfigChangeRequest", "app.config_change_request.approve.self.app_error", nil, "id="+request.Id, http.StatusForbidden)
+	}
+
+	if request.BaseConfigHash != configHash(a.Config()) {
+		return nil, model.NewAppError("ApproveConfigChangeRequest", "app.config_change_request.approve.stale.app_error", nil, "id="+request.Id, http.StatusConflict)
+	}
+
+	if err := cfg.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv.Store.ConfigChangeRequest().Review(request.Id, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, userId); err != nil {
+		return nil, err
+	}
+	request.Status = model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED
+	request.ReviewedBy = userId
+
+	if err := a.SaveConfig(cfg, true); err != nil {
+		mlog.Error("Failed to save the configuration of an approved change request", mlog.String("config_change_request_id", request.Id), mlog.Err(err))
+		if reopenErr := a.Srv.Store.ConfigChangeRequest().Reopen(request.Id); reopenErr != nil {
+			mlog.Error("Failed to return a config change request to pending", mlog.String("config_change_request_id", request.Id), mlog.Err(reopenErr))
+		}
+		return nil, err
+	}
+	request.ProposedConfig = cfg
+
+	return request, nil
+}
+
+// RejectConfigChangeRequest rejects a pending request, leaving the configuration unchanged. The
+// system admin who proposed the change may reject it to withdraw it.
+func (a *App) RejectConfigChangeRequest(requestId, userId string) (*model.ConfigChangeRequest, *model.AppError) {
+	request, err := a.getPendingConfigChangeRequest(requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv.Store.ConfigChangeRequest().Review(requestId, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, userId); err != nil {
+		return nil, err
+	}
+	request.Status = model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED
+	request.ReviewedBy = userId
+
+	return request, nil
+}
+
+func (a *App) getPendingConfigChangeRequest(requestId string) (*model.ConfigChangeRequest, *model.AppError) {
+	request, err := a.Srv.Store.ConfigChangeRequest().Get(requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != model.CONFIG_CHANGE_REQUEST_STATUS_PENDING {
+		return nil, model.NewAppError("getPendingConfigChangeRequest", "app.config_change_request.not_pending.app_error", nil, "id="+requestId+", status="+request.Status, http.StatusBadRequest)
+	}
+
+	return request, nil
+}
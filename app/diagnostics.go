@@ -48,6 +48,8 @@ const (
 	TRACK_CONFIG_MESSAGE_EXPORT     = "config_message_export"
 	TRACK_CONFIG_DISPLAY            = "config_display"
 	TRACK_CONFIG_IMAGE_PROXY        = "config_image_proxy"
+	TRACK_CONFIG_SEARCH             = "config_search"
+	TRACK_CONFIG_IMPORT             = "config_import"
 	TRACK_PERMISSIONS_GENERAL       = "permissions_general"
 	TRACK_PERMISSIONS_SYSTEM_SCHEME = "permissions_system_scheme"
 	TRACK_PERMISSIONS_TEAM_SCHEMES  = "permissions_team_schemes"
@@ -300,6 +302,11 @@ func (a *App) trackConfig() {
 		"disable_bots_when_owner_is_deactivated":                  *cfg.ServiceSettings.DisableBotsWhenOwnerIsDeactivated,
 		"enable_bot_account_creation":                             *cfg.ServiceSettings.EnableBotAccountCreation,
 		"enable_svgs":                                             *cfg.ServiceSettings.EnableSVGs,
+		"search_custom_profile_attributes":                        *cfg.ServiceSettings.SearchCustomProfileAttributes,
+		"enable_fuzzy_mention_autocomplete":                       *cfg.ServiceSettings.EnableFuzzyMentionAutocomplete,
+		"link_preview_max_redirects":                              *cfg.ServiceSettings.LinkPreviewMaxRedirects,
+		"link_preview_blocked_domains":                            len(cfg.ServiceSettings.LinkPreviewBlockedDomains),
+		"require_config_change_approval":                          *cfg.ServiceSettings.RequireConfigChangeApproval,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_TEAM, map[string]interface{}{
@@ -345,14 +352,18 @@ func (a *App) trackConfig() {
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_SQL, map[string]interface{}{
-		"driver_name":                    *cfg.SqlSettings.DriverName,
-		"trace":                          cfg.SqlSettings.Trace,
-		"max_idle_conns":                 *cfg.SqlSettings.MaxIdleConns,
-		"conn_max_lifetime_milliseconds": *cfg.SqlSettings.ConnMaxLifetimeMilliseconds,
-		"max_open_conns":                 *cfg.SqlSettings.MaxOpenConns,
-		"data_source_replicas":           len(cfg.SqlSettings.DataSourceReplicas),
-		"data_source_search_replicas":    len(cfg.SqlSettings.DataSourceSearchReplicas),
-		"query_timeout":                  *cfg.SqlSettings.QueryTimeout,
+		"driver_name":                         *cfg.SqlSettings.DriverName,
+		"trace":                               cfg.SqlSettings.Trace,
+		"max_idle_conns":                      *cfg.SqlSettings.MaxIdleConns,
+		"conn_max_lifetime_milliseconds":      *cfg.SqlSettings.ConnMaxLifetimeMilliseconds,
+		"max_open_conns":                      *cfg.SqlSettings.MaxOpenConns,
+		"data_source_replicas":                len(cfg.SqlSettings.DataSourceReplicas),
+		"data_source_search_replicas":         len(cfg.SqlSettings.DataSourceSearchReplicas),
+		"query_timeout":                       *cfg.SqlSettings.QueryTimeout,
+		"isdefault_schema_name":               isDefault(*cfg.SqlSettings.SchemaName, ""),
+		"transaction_max_age_warning_seconds": *cfg.SqlSettings.TransactionMaxAgeWarningSeconds,
+		"read_transaction_isolation":          *cfg.SqlSettings.ReadTransactionIsolation,
+		"write_transaction_isolation":         *cfg.SqlSettings.WriteTransactionIsolation,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_LOG, map[string]interface{}{
@@ -382,6 +393,8 @@ func (a *App) trackConfig() {
 		"number":         *cfg.PasswordSettings.Number,
 		"uppercase":      *cfg.PasswordSettings.Uppercase,
 		"symbol":         *cfg.PasswordSettings.Symbol,
+		"history_count":  *cfg.PasswordSettings.HistoryCount,
+		"expiry_days":    *cfg.PasswordSettings.ExpiryDays,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_FILE, map[string]interface{}{
@@ -397,6 +410,8 @@ func (a *App) trackConfig() {
 		"enable_file_attachments": *cfg.FileSettings.EnableFileAttachments,
 		"enable_mobile_upload":    *cfg.FileSettings.EnableMobileUpload,
 		"enable_mobile_download":  *cfg.FileSettings.EnableMobileDownload,
+		"allowed_mime_types":      len(cfg.FileSettings.AllowedMIMETypes),
+		"blocked_mime_types":      len(cfg.FileSettings.BlockedMIMETypes),
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_EMAIL, map[string]interface{}{
@@ -607,11 +622,12 @@ func (a *App) trackConfig() {
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_DATA_RETENTION, map[string]interface{}{
-		"enable_message_deletion": *cfg.DataRetentionSettings.EnableMessageDeletion,
-		"enable_file_deletion":    *cfg.DataRetentionSettings.EnableFileDeletion,
-		"message_retention_days":  *cfg.DataRetentionSettings.MessageRetentionDays,
-		"file_retention_days":     *cfg.DataRetentionSettings.FileRetentionDays,
-		"deletion_job_start_time": *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"enable_message_deletion":      *cfg.DataRetentionSettings.EnableMessageDeletion,
+		"enable_file_deletion":         *cfg.DataRetentionSettings.EnableFileDeletion,
+		"message_retention_days":       *cfg.DataRetentionSettings.MessageRetentionDays,
+		"file_retention_days":          *cfg.DataRetentionSettings.FileRetentionDays,
+		"deletion_job_start_time":      *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"topic_history_retention_days": *cfg.DataRetentionSettings.TopicHistoryRetentionDays,
 	})
 
 	a.SendDiagnostic(TRACK_CONFIG_MESSAGE_EXPORT, map[string]interface{}{
@@ -637,6 +653,15 @@ func (a *App) trackConfig() {
 		"isdefault_remote_image_proxy_url":     isDefault(*cfg.ImageProxySettings.RemoteImageProxyURL, ""),
 		"isdefault_remote_image_proxy_options": isDefault(*cfg.ImageProxySettings.RemoteImageProxyOptions, ""),
 	})
+
+	a.SendDiagnostic(TRACK_CONFIG_SEARCH, map[string]interface{}{
+		"enable_related_posts": *cfg.SearchSettings.EnableRelatedPosts,
+		"max_search_results":   *cfg.SearchSettings.MaxSearchResults,
+	})
+
+	a.SendDiagnostic(TRACK_CONFIG_IMPORT, map[string]interface{}{
+		"lock_timeout": *cfg.ImportSettings.LockTimeout,
+	})
 }
 
 func (a *App) trackLicense() {
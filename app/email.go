@@ -498,3 +498,9 @@ func (a *App) SendMail(to, subject, htmlBody string) *model.AppError {
 	license := a.License()
 	return mailservice.SendMailUsingConfig(to, subject, htmlBody, a.Config(), license != nil && *license.Features.Compliance)
 }
+
+// GetEmailQueueStats returns the number of emails waiting to be sent by this server, along with how
+// many were sent, how many failed and their average latency over the last minute.
+func (a *App) GetEmailQueueStats() *model.EmailQueueStats {
+	return mailservice.GetQueueStats()
+}
@@ -79,8 +79,24 @@ func (a *App) CreateEmoji(sessionUserId string, emoji *model.Emoji, multiPartIma
 	return emoji, nil
 }
 
-func (a *App) GetEmojiList(page, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
-	return a.Srv.Store.Emoji().GetList(page*perPage, perPage, sort)
+func (a *App) GetEmojiList(page, perPage int, sort string, opts model.EmojiListOpts) ([]*model.Emoji, *model.AppError) {
+	return a.Srv.Store.Emoji().GetList(page*perPage, perPage, sort, opts)
+}
+
+// GetEmojiListPage returns a page of custom emoji and whether or not there are more emoji after that page.
+func (a *App) GetEmojiListPage(page, perPage int, sort string, opts model.EmojiListOpts) (*model.EmojiListPage, *model.AppError) {
+	// Fetch one extra emoji to find out if there's another page without having to count them all
+	emojis, err := a.Srv.Store.Emoji().GetList(page*perPage, perPage+1, sort, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(emojis) > perPage
+	if hasNext {
+		emojis = emojis[:perPage]
+	}
+
+	return &model.EmojiListPage{Emojis: emojis, HasNext: hasNext}, nil
 }
 
 func (a *App) UploadEmojiImage(id string, imageData *multipart.FileHeader) *model.AppError {
@@ -83,6 +83,12 @@ func RegisterJobsPluginsJobInterface(f func(*App) tjobs.PluginsJobInterface) {
 	jobsPluginsInterface = f
 }
 
+var jobsScheduledMessageExportInterface func(*App) tjobs.ScheduledMessageExportJobInterface
+
+func RegisterJobsScheduledMessageExportJobInterface(f func(*App) tjobs.ScheduledMessageExportJobInterface) {
+	jobsScheduledMessageExportInterface = f
+}
+
 var ldapInterface func(*App) einterfaces.LdapInterface
 
 func RegisterLdapInterface(f func(*App) einterfaces.LdapInterface) {
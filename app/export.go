@@ -429,7 +429,7 @@ func (a *App) BuildPostReactions(postId string) (*[]ReactionImportData, *model.A
 func (a *App) ExportCustomEmoji(writer io.Writer, file string, pathToEmojiDir string, dirNameToExportEmoji string) *model.AppError {
 	pageNumber := 0
 	for {
-		customEmojiList, err := a.GetEmojiList(pageNumber, 100, model.EMOJI_SORT_BY_NAME)
+		customEmojiList, err := a.GetEmojiList(pageNumber, 100, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{})
 
 		if err != nil {
 			return err
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"image"
 	"image/color"
@@ -61,6 +62,9 @@ const (
 
 	UploadFileInitialBufferSize = 2 * 1024 * 1024 // 2Mb
 
+	// UploadProgressIncrementPercent is how far an upload must get between upload_progress events.
+	UploadProgressIncrementPercent = 5
+
 	// Deprecated
 	IMAGE_THUMBNAIL_PIXEL_WIDTH  = 120
 	IMAGE_THUMBNAIL_PIXEL_HEIGHT = 100
@@ -559,8 +563,19 @@ func (t *uploadFileTask) init(a *App) {
 		// anyway to avoid extra reslicing.
 		t.buf.Grow(UploadFileInitialBufferSize)
 	}
+	input := t.Input
+	if t.ContentLength > 0 && t.UserId != "" && a.isUserConnected(t.UserId) {
+		input = &uploadProgressReader{
+			Reader:   input,
+			publish:  a.Publish,
+			userId:   t.UserId,
+			clientId: t.ClientId,
+			total:    t.ContentLength,
+		}
+	}
+
 	t.limitedInput = &io.LimitedReader{
-		R: t.Input,
+		R: input,
 		N: t.limit + 1,
 	}
 	t.teeInput = io.TeeReader(t.limitedInput, t.buf)
@@ -570,6 +585,48 @@ func (t *uploadFileTask) init(a *App) {
 	t.saveToDatabase = a.Srv.Store.FileInfo().Save
 }
 
+// isUserConnected reports whether the user is likely to have an open WebSocket connection, going by
+// their cached status.
+func (a *App) isUserConnected(userId string) bool {
+	status := GetStatusFromCache(userId)
+	return status != nil && status.Status != model.STATUS_OFFLINE
+}
+
+// uploadProgressReader sends upload_progress events to the uploading user each time another
+// UploadProgressIncrementPercent of the upload has been read.
+type uploadProgressReader struct {
+	io.Reader
+
+	publish  func(*model.WebSocketEvent)
+	userId   string
+	clientId string
+	total    int64
+	uploaded int64
+	percent  int64
+}
+
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.uploaded += int64(n)
+
+	percent := r.uploaded * 100 / r.total
+	if percent > 100 {
+		percent = 100
+	}
+
+	if percent/UploadProgressIncrementPercent > r.percent/UploadProgressIncrementPercent {
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_UPLOAD_PROGRESS, "", "", r.userId, nil)
+		message.Add("client_id", r.clientId)
+		message.Add("bytes_uploaded", r.uploaded)
+		message.Add("total_bytes", r.total)
+		message.Add("percent", percent)
+		r.publish(message)
+	}
+	r.percent = percent
+
+	return n, err
+}
+
 // UploadFileX uploads a single file as specified in t. It applies the upload
 // constraints, executes plugins and image processing logic as needed. It
 // returns a filled-out FileInfo and an optional error. A plugin may reject the
@@ -610,6 +667,11 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		return t.fileinfo, aerr
 	}
 
+	aerr = a.checkFileTypeAllowed(t.fileinfo, t.buf.Bytes())
+	if aerr != nil {
+		return t.fileinfo, aerr
+	}
+
 	aerr = t.runPlugins()
 	if aerr != nil {
 		return t.fileinfo, aerr
@@ -885,6 +947,10 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 		return nil, data, err
 	}
 
+	if err := a.checkFileTypeAllowed(info, data); err != nil {
+		return nil, data, err
+	}
+
 	if orientation, err := getImageOrientation(bytes.NewReader(data)); err == nil &&
 		(orientation == RotatedCWMirrored ||
 			orientation == RotatedCCW ||
@@ -948,6 +1014,108 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 	return info, data, nil
 }
 
+// checkFileTypeAllowed enforces FileSettings.AllowedMIMETypes and FileSettings.BlockedMIMETypes. Both the type implied
+// by the file's extension and the type detected from its contents are checked against both lists, and the blocklist
+// takes precedence over the allowlist, so that renaming a file is not enough to get around either of them.
+func (a *App) checkFileTypeAllowed(info *model.FileInfo, data []byte) *model.AppError {
+	settings := a.Config().FileSettings
+
+	mimeType := baseMIMEType(info.MimeType)
+	detectedType := detectContentType(data)
+
+	for _, blocked := range settings.BlockedMIMETypes {
+		if mimeTypeMatches(blocked, mimeType) || mimeTypeMatches(blocked, detectedType) {
+			return model.NewAppError("checkFileTypeAllowed", "api.file.upload_file.blocked_type.app_error",
+				map[string]interface{}{"Filename": info.Name}, "mime_type="+mimeType+", detected_type="+detectedType, http.StatusBadRequest)
+		}
+	}
+
+	if len(settings.AllowedMIMETypes) == 0 {
+		return nil
+	}
+
+	if !mimeTypeAllowed(settings.AllowedMIMETypes, mimeType) || !(isGenericContentType(detectedType) || mimeTypeAllowed(settings.AllowedMIMETypes, detectedType)) {
+		return model.NewAppError("checkFileTypeAllowed", "api.file.upload_file.type_not_allowed.app_error",
+			map[string]interface{}{"Filename": info.Name, "AllowedTypes": strings.Join(settings.AllowedMIMETypes, ", ")}, "mime_type="+mimeType+", detected_type="+detectedType, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// executableSignatures are the magic bytes at the start of native executables, which http.DetectContentType doesn't
+// recognize and reports as application/octet-stream.
+var executableSignatures = []struct {
+	magic    []byte
+	mimeType string
+}{
+	{[]byte("\x7fELF"), "application/x-executable"},
+	{[]byte{0xfe, 0xed, 0xfa, 0xce}, "application/x-mach-binary"},
+	{[]byte{0xfe, 0xed, 0xfa, 0xcf}, "application/x-mach-binary"},
+	{[]byte{0xce, 0xfa, 0xed, 0xfe}, "application/x-mach-binary"},
+	{[]byte{0xcf, 0xfa, 0xed, 0xfe}, "application/x-mach-binary"},
+	{[]byte{0xca, 0xfe, 0xba, 0xbe}, "application/x-mach-binary"},
+}
+
+// detectContentType returns the base MIME type of the given file contents, including native executables in the ELF,
+// Mach-O and PE formats.
+func detectContentType(data []byte) string {
+	for _, signature := range executableSignatures {
+		if bytes.HasPrefix(data, signature.magic) {
+			return signature.mimeType
+		}
+	}
+
+	// A PE file starts with an MS-DOS header whose last field is the offset of the PE signature
+	if len(data) >= 0x40 && bytes.HasPrefix(data, []byte("MZ")) {
+		offset := int(binary.LittleEndian.Uint32(data[0x3c:0x40]))
+		if offset >= 0x40 && offset <= len(data)-4 && bytes.Equal(data[offset:offset+4], []byte("PE\x00\x00")) {
+			return "application/x-msdownload"
+		}
+	}
+
+	return baseMIMEType(http.DetectContentType(data))
+}
+
+// isGenericContentType returns true for the types that http.DetectContentType falls back to when it can't identify
+// contents more precisely, which therefore say nothing about whether the file's real type is allowed.
+func isGenericContentType(mimeType string) bool {
+	return mimeType == "application/octet-stream" || mimeType == "text/plain" || mimeType == "application/zip"
+}
+
+// mimeTypeAllowed returns true if mimeType matches any of the allowed patterns.
+func mimeTypeAllowed(allowed []string, mimeType string) bool {
+	for _, pattern := range allowed {
+		if mimeTypeMatches(pattern, mimeType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// baseMIMEType strips any parameters, such as the charset, from a MIME type.
+func baseMIMEType(mimeType string) string {
+	if index := strings.Index(mimeType, ";"); index != -1 {
+		mimeType = mimeType[:index]
+	}
+
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// mimeTypeMatches returns true if mimeType matches pattern, which is either a full MIME type or a wildcard such as image/*.
+func mimeTypeMatches(pattern, mimeType string) bool {
+	pattern = baseMIMEType(pattern)
+	if pattern == "" || mimeType == "" {
+		return false
+	}
+
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mimeType, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == mimeType
+}
+
 func (a *App) HandleImages(previewPathList []string, thumbnailPathList []string, fileData [][]byte) {
 	wg := new(sync.WaitGroup)
 
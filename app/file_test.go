@@ -4,7 +4,9 @@
 package app
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -191,3 +193,36 @@ func TestCopyFileInfos(t *testing.T) {
 	assert.NotEqual(t, info1.Id, info2.Id, "should not be equal")
 	assert.Equal(t, info2.PostId, "", "should be empty string")
 }
+
+func TestUploadProgressReader(t *testing.T) {
+	data := make([]byte, 1000)
+
+	var events []*model.WebSocketEvent
+	reader := &uploadProgressReader{
+		Reader: bytes.NewReader(data),
+		publish: func(message *model.WebSocketEvent) {
+			events = append(events, message)
+		},
+		userId:   model.NewId(),
+		clientId: "client",
+		total:    int64(len(data)),
+	}
+
+	// Read in 1% steps so that every increment is crossed exactly once.
+	buf := make([]byte, 10)
+	for {
+		if _, err := reader.Read(buf); err == io.EOF {
+			break
+		}
+	}
+
+	require.Len(t, events, 100/UploadProgressIncrementPercent)
+	for i, event := range events {
+		assert.Equal(t, model.WEBSOCKET_EVENT_UPLOAD_PROGRESS, event.Event)
+		assert.Equal(t, reader.userId, event.Broadcast.UserId)
+		assert.Equal(t, "client", event.Data["client_id"])
+		assert.Equal(t, int64(len(data)), event.Data["total_bytes"])
+		assert.Equal(t, int64((i+1)*UploadProgressIncrementPercent), event.Data["percent"])
+	}
+	assert.Equal(t, int64(len(data)), events[len(events)-1].Data["bytes_uploaded"])
+}
@@ -1029,8 +1029,6 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 	}
 
 	if data.FlaggedBy != nil {
-		var preferences model.Preferences
-
 		for _, username := range *data.FlaggedBy {
 			var user *model.User
 			user, err = a.Srv.Store.User().GetByUsername(username)
@@ -1038,16 +1036,7 @@ func (a *App) ImportPost(data *PostImportData, dryRun bool) *model.AppError {
 				return model.NewAppError("BulkImport", "app.import.import_post.user_not_found.error", map[string]interface{}{"Username": username}, err.Error(), http.StatusBadRequest)
 			}
 
-			preferences = append(preferences, model.Preference{
-				UserId:   user.Id,
-				Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-				Name:     post.Id,
-				Value:    "true",
-			})
-		}
-
-		if len(preferences) > 0 {
-			if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+			if _, _, err := a.saveSavedPost(user.Id, post.Id); err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_post.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 			}
 		}
@@ -1258,8 +1247,6 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 	}
 
 	if data.FlaggedBy != nil {
-		var preferences model.Preferences
-
 		for _, username := range *data.FlaggedBy {
 			var user *model.User
 			user, err = a.Srv.Store.User().GetByUsername(username)
@@ -1267,16 +1254,7 @@ func (a *App) ImportDirectPost(data *DirectPostImportData, dryRun bool) *model.A
 				return model.NewAppError("BulkImport", "app.import.import_direct_post.user_not_found.error", map[string]interface{}{"Username": username}, "", http.StatusBadRequest)
 			}
 
-			preferences = append(preferences, model.Preference{
-				UserId:   user.Id,
-				Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-				Name:     post.Id,
-				Value:    "true",
-			})
-		}
-
-		if len(preferences) > 0 {
-			if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
+			if _, _, err := a.saveSavedPost(user.Id, post.Id); err != nil {
 				return model.NewAppError("BulkImport", "app.import.import_direct_post.save_preferences.error", nil, err.Error(), http.StatusInternalServerError)
 			}
 		}
@@ -1984,8 +1984,8 @@ func TestImportImportPost(t *testing.T) {
 			t.Fatal("Post properties not as expected")
 		}
 
-		checkPreference(t, th.App, user.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
-		checkPreference(t, th.App, user2.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
+		checkSavedPost(t, th.App, user.Id, post.Id)
+		checkSavedPost(t, th.App, user2.Id, post.Id)
 	}
 
 	// Post with reaction.
@@ -2459,8 +2459,8 @@ func TestImportImportDirectPost(t *testing.T) {
 	require.Equal(t, len(posts), 1)
 
 	post = posts[0]
-	checkPreference(t, th.App, th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
-	checkPreference(t, th.App, th.BasicUser2.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
+	checkSavedPost(t, th.App, th.BasicUser.Id, post.Id)
+	checkSavedPost(t, th.App, th.BasicUser2.Id, post.Id)
 
 	// ------------------ Group Channel -------------------------
 
@@ -2631,8 +2631,8 @@ func TestImportImportDirectPost(t *testing.T) {
 	require.Equal(t, len(posts), 1)
 
 	post = posts[0]
-	checkPreference(t, th.App, th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
-	checkPreference(t, th.App, th.BasicUser2.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id, "true")
+	checkSavedPost(t, th.App, th.BasicUser.Id, post.Id)
+	checkSavedPost(t, th.App, th.BasicUser2.Id, post.Id)
 
 }
 
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// importLockHolder identifies this node in the import locks it holds.
+func (a *App) importLockHolder() string {
+	if clusterId := a.GetClusterId(); clusterId != "" {
+		return clusterId
+	}
+
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// AcquireImportLock takes the named import lock, which is stored in the Systems table so that only
+// one node of a cluster runs the import at a time. A lock held by another node for longer than
+// ImportSettings.LockTimeout is considered stale and is only taken over if force is set. The
+// returned function releases the lock.
+func (a *App) AcquireImportLock(name string, force bool) (func(), *model.AppError) {
+	lock := &model.SystemImportLock{
+		Holder:     a.importLockHolder(),
+		Token:      model.NewId(),
+		AcquiredAt: model.GetMillis(),
+	}
+
+	if err := a.Srv.Store.System().Save(&model.System{Name: name, Value: lock.ToJson()}); err != nil {
+		system, getErr := a.Srv.Store.System().GetByName(name)
+		if getErr != nil {
+			return nil, getErr
+		}
+
+		current := model.SystemImportLockFromJson(system.Value)
+		if current == nil {
+			current = &model.SystemImportLock{}
+		}
+
+		timeout := time.Duration(*a.Config().ImportSettings.LockTimeout) * time.Minute
+		heldFor := time.Duration(model.GetMillis()-current.AcquiredAt) * time.Millisecond
+		params := map[string]interface{}{"Holder": current.Holder, "Minutes": int(heldFor / time.Minute)}
+
+		if heldFor <= timeout {
+			return nil, model.NewAppError("AcquireImportLock", "app.import.lock.held.app_error", params, "name="+name, http.StatusConflict)
+		}
+
+		mlog.Warn("Import lock is stale", mlog.String("name", name), mlog.String("holder", current.Holder), mlog.Int64("acquired_at", current.AcquiredAt), mlog.Bool("force", force))
+
+		if !force {
+			return nil, model.NewAppError("AcquireImportLock", "app.import.lock.stale.app_error", params, "name="+name, http.StatusConflict)
+		}
+
+		// Only take over the lock if it is still held by the stale holder, so that only one of
+		// several nodes forcing the lock at the same time gets it.
+		updated, err := a.Srv.Store.System().UpdateOptimistically(&model.System{Name: name, Value: lock.ToJson()}, system.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			return nil, model.NewAppError("AcquireImportLock", "app.import.lock.held.app_error", params, "name="+name, http.StatusConflict)
+		}
+	}
+
+	release := func() {
+		system, err := a.Srv.Store.System().GetByName(name)
+		if err != nil {
+			mlog.Warn("Failed to read import lock", mlog.String("name", name), mlog.Err(err))
+			return
+		}
+
+		// The lock may have been forced by another node in the meantime.
+		if current := model.SystemImportLockFromJson(system.Value); current == nil || current.Token != lock.Token {
+			return
+		}
+
+		if _, err := a.Srv.Store.System().PermanentDeleteByName(name); err != nil {
+			mlog.Warn("Failed to release import lock", mlog.String("name", name), mlog.Err(err))
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestAcquireImportLock(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ImportSettings.LockTimeout = 30 })
+
+	t.Run("held", func(t *testing.T) {
+		name := model.NewId()
+
+		release, err := th.App.AcquireImportLock(name, false)
+		require.Nil(t, err)
+
+		_, err = th.App.AcquireImportLock(name, true)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.import.lock.held.app_error", err.Id)
+		assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+		release()
+
+		release, err = th.App.AcquireImportLock(name, false)
+		require.Nil(t, err)
+		release()
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		name := model.NewId()
+
+		stale := &model.SystemImportLock{
+			Holder:     "otherhost",
+			Token:      model.NewId(),
+			AcquiredAt: model.GetMillis() - int64(time.Hour/time.Millisecond),
+		}
+		require.Nil(t, th.App.Srv.Store.System().Save(&model.System{Name: name, Value: stale.ToJson()}))
+
+		_, err := th.App.AcquireImportLock(name, false)
+		require.NotNil(t, err)
+		assert.Equal(t, "app.import.lock.stale.app_error", err.Id)
+
+		release, err := th.App.AcquireImportLock(name, true)
+		require.Nil(t, err)
+
+		system, err := th.App.Srv.Store.System().GetByName(name)
+		require.Nil(t, err)
+		assert.NotEqual(t, stale.Token, model.SystemImportLockFromJson(system.Value).Token)
+
+		release()
+
+		_, err = th.App.Srv.Store.System().GetByName(name)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("release after takeover", func(t *testing.T) {
+		name := model.NewId()
+
+		release, err := th.App.AcquireImportLock(name, false)
+		require.Nil(t, err)
+
+		other := &model.SystemImportLock{Holder: "otherhost", Token: model.NewId(), AcquiredAt: model.GetMillis()}
+		require.Nil(t, th.App.Srv.Store.System().Update(&model.System{Name: name, Value: other.ToJson()}))
+
+		release()
+
+		system, err := th.App.Srv.Store.System().GetByName(name)
+		require.Nil(t, err)
+		assert.Equal(t, other.Token, model.SystemImportLockFromJson(system.Value).Token)
+	})
+}
@@ -56,6 +56,13 @@ func checkPreference(t *testing.T, a *App, userId string, category string, name
 	}
 }
 
+func checkSavedPost(t *testing.T, a *App, userId string, postId string) {
+	if _, err := a.Srv.Store.SavedPost().Get(userId, postId); err != nil {
+		debug.PrintStack()
+		t.Fatalf("Post %v is not saved by user %v", postId, userId)
+	}
+}
+
 func checkNotifyProp(t *testing.T, user *model.User, key string, value string) {
 	if actual, ok := user.NotifyProps[key]; !ok {
 		debug.PrintStack()
@@ -0,0 +1,247 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/filesstore"
+	"github.com/mattermost/mattermost-server/services/mailservice"
+	"github.com/pkg/errors"
+)
+
+const INTEGRATIONS_HEALTH_CHECK_TIMEOUT = 5 * time.Second
+
+// integrationHealthCheck tests the connection to an integration, giving up once ctx is done. It returns
+// false if the integration isn't enabled, in which case the error is ignored.
+type integrationHealthCheck func(ctx context.Context) (bool, error)
+
+// GetIntegrationsHealth checks the connection to each external integration concurrently. Checks that
+// don't complete within INTEGRATIONS_HEALTH_CHECK_TIMEOUT are reported as down without waiting for
+// them.
+func (a *App) GetIntegrationsHealth() *model.IntegrationsHealth {
+	ctx, cancel := context.WithTimeout(context.Background(), INTEGRATIONS_HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+
+	health := &model.IntegrationsHealth{}
+	checks := map[**model.IntegrationHealth]integrationHealthCheck{
+		&health.Ldap:          a.checkLdapHealth,
+		&health.Smtp:          a.checkSmtpHealth,
+		&health.S3:            a.checkS3Health,
+		&health.Elasticsearch: a.checkElasticsearchHealth,
+		&health.Push:          a.checkPushProxyHealth,
+	}
+
+	var wg sync.WaitGroup
+	for result, check := range checks {
+		wg.Add(1)
+		go func(result **model.IntegrationHealth, check integrationHealthCheck) {
+			defer wg.Done()
+
+			*result = runIntegrationHealthCheck(ctx, check)
+		}(result, check)
+	}
+	wg.Wait()
+
+	health.SetAggregateStatus()
+
+	return health
+}
+
+// runCheckUntilDone runs the check in its own goroutine and waits for it to complete or for ctx to be
+// done, whichever comes first, so that a check calling into a client that ignores ctx can't hold up
+// the caller past the deadline. It returns how long the check took and whether it timed out. A check
+// that timed out is left to finish in the background, and its results must not be read.
+func runCheckUntilDone(ctx context.Context, check func(ctx context.Context)) (time.Duration, bool) {
+	start := time.Now()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		check(ctx)
+	}()
+
+	select {
+	case <-done:
+		return time.Since(start), ctx.Err() != nil
+	case <-ctx.Done():
+		return time.Since(start), true
+	}
+}
+
+func runIntegrationHealthCheck(ctx context.Context, check integrationHealthCheck) *model.IntegrationHealth {
+	var enabled bool
+	var err error
+	elapsed, timedOut := runCheckUntilDone(ctx, func(ctx context.Context) {
+		enabled, err = check(ctx)
+	})
+
+	// Disabled integrations are skipped without any I/O, so a check that timed out was enabled.
+	if timedOut {
+		return &model.IntegrationHealth{
+			Status:    model.INTEGRATION_HEALTH_DOWN,
+			Error:     "timed out",
+			LatencyMs: int64(elapsed / time.Millisecond),
+		}
+	}
+
+	if !enabled {
+		return &model.IntegrationHealth{Status: model.INTEGRATION_HEALTH_DISABLED}
+	}
+
+	health := &model.IntegrationHealth{
+		Status:    model.INTEGRATION_HEALTH_OK,
+		LatencyMs: int64(elapsed / time.Millisecond),
+	}
+	if err != nil {
+		health.Status = model.INTEGRATION_HEALTH_DOWN
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// dialIntegration opens a TCP connection to the given address, giving up once ctx is done. Checks use it
+// to find unreachable servers before calling into clients that don't accept a context.
+func dialIntegration(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func (a *App) checkLdapHealth(ctx context.Context) (bool, error) {
+	license := a.License()
+	cfg := a.Config()
+	if a.Ldap == nil || license == nil || !*license.Features.LDAP || !(*cfg.LdapSettings.Enable || *cfg.LdapSettings.EnableSync) {
+		return false, nil
+	}
+
+	// The LDAP test doesn't accept a context, but once the server is known to be reachable its queries are
+	// bounded by LdapSettings.QueryTimeout.
+	if err := dialIntegration(ctx, net.JoinHostPort(*cfg.LdapSettings.LdapServer, strconv.Itoa(*cfg.LdapSettings.LdapPort))); err != nil {
+		return true, err
+	}
+
+	if err := a.Ldap.RunTest(); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func (a *App) checkSmtpHealth(ctx context.Context) (bool, error) {
+	cfg := a.Config()
+	if !*cfg.EmailSettings.SendEmailNotifications || *cfg.EmailSettings.SMTPServer == "" {
+		return false, nil
+	}
+
+	return true, testSmtpConnection(ctx, cfg)
+}
+
+// testSmtpConnection connects to the configured SMTP server and, when SMTP authentication is enabled,
+// authenticates with the configured credentials, giving up once ctx is done.
+func testSmtpConnection(ctx context.Context, cfg *model.Config) error {
+	conn, err := mailservice.ConnectToSMTPServerContext(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := mailservice.NewSMTPClient(conn, cfg)
+	if err != nil {
+		return err
+	}
+	client.Quit()
+
+	return nil
+}
+
+func (a *App) checkS3Health(ctx context.Context) (bool, error) {
+	cfg := a.Config()
+	if *cfg.FileSettings.DriverName != model.IMAGE_DRIVER_S3 {
+		return false, nil
+	}
+
+	if err := dialIntegration(ctx, s3Address(&cfg.FileSettings)); err != nil {
+		return true, err
+	}
+
+	license := a.License()
+	backend, err := filesstore.NewFileBackend(&cfg.FileSettings, license != nil && *license.Features.Compliance)
+	if err != nil {
+		return true, err
+	}
+
+	if err := backend.TestConnection(); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// s3Address returns the host and port of the configured S3 endpoint.
+func s3Address(settings *model.FileSettings) string {
+	endpoint := *settings.AmazonS3Endpoint
+	if _, _, err := net.SplitHostPort(endpoint); err == nil {
+		return endpoint
+	}
+
+	if *settings.AmazonS3SSL {
+		return net.JoinHostPort(endpoint, "443")
+	}
+	return net.JoinHostPort(endpoint, "80")
+}
+
+func (a *App) checkElasticsearchHealth(ctx context.Context) (bool, error) {
+	cfg := a.Config()
+	if a.Elasticsearch == nil || !*cfg.ElasticsearchSettings.EnableIndexing {
+		return false, nil
+	}
+
+	if err := a.Elasticsearch.TestConfig(cfg); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func (a *App) checkPushProxyHealth(ctx context.Context) (bool, error) {
+	cfg := a.Config()
+	if !*cfg.EmailSettings.SendPushNotifications || *cfg.EmailSettings.PushNotificationServer == "" {
+		return false, nil
+	}
+
+	request, err := http.NewRequest("GET", strings.TrimRight(*cfg.EmailSettings.PushNotificationServer, "/")+"/version", nil)
+	if err != nil {
+		return true, err
+	}
+
+	resp, err := a.HTTPService.MakeClient(true).Do(request.WithContext(ctx))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return true, errors.Errorf("push proxy responded with status code %v", resp.StatusCode)
+	}
+
+	return true, nil
+}
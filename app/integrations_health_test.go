@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestRunIntegrationHealthCheck(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		health := runIntegrationHealthCheck(context.Background(), func(ctx context.Context) (bool, error) {
+			return false, errors.New("ignored")
+		})
+		assert.Equal(t, model.INTEGRATION_HEALTH_DISABLED, health.Status)
+		assert.Empty(t, health.Error)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		health := runIntegrationHealthCheck(context.Background(), func(ctx context.Context) (bool, error) {
+			return true, nil
+		})
+		assert.Equal(t, model.INTEGRATION_HEALTH_OK, health.Status)
+	})
+
+	t.Run("down", func(t *testing.T) {
+		health := runIntegrationHealthCheck(context.Background(), func(ctx context.Context) (bool, error) {
+			return true, errors.New("connection refused")
+		})
+		assert.Equal(t, model.INTEGRATION_HEALTH_DOWN, health.Status)
+		assert.Equal(t, "connection refused", health.Error)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		health := runIntegrationHealthCheck(ctx, func(ctx context.Context) (bool, error) {
+			<-ctx.Done()
+			return true, ctx.Err()
+		})
+		assert.Equal(t, model.INTEGRATION_HEALTH_DOWN, health.Status)
+		assert.Equal(t, "timed out", health.Error)
+	})
+
+	t.Run("ignoring the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		start := time.Now()
+		health := runIntegrationHealthCheck(ctx, func(ctx context.Context) (bool, error) {
+			<-release
+			return true, nil
+		})
+		assert.True(t, time.Since(start) < time.Second, "the check should have been abandoned at the deadline")
+		assert.Equal(t, model.INTEGRATION_HEALTH_DOWN, health.Status)
+		assert.Equal(t, "timed out", health.Error)
+	})
+}
+
+func TestDialIntegration(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	assert.NoError(t, dialIntegration(context.Background(), address))
+
+	listener.Close()
+	assert.Error(t, dialIntegration(context.Background(), address))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, dialIntegration(ctx, address))
+}
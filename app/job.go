@@ -4,6 +4,8 @@
 package app
 
 import (
+	"net/http"
+
 	"github.com/mattermost/mattermost-server/model"
 )
 
@@ -34,3 +36,48 @@ func (a *App) CreateJob(job *model.Job) (*model.Job, *model.AppError) {
 func (a *App) CancelJob(jobId string) *model.AppError {
 	return a.Srv.Jobs.RequestCancellation(jobId)
 }
+
+// ScheduleMessageExport queues a message export job for the given request.
+func (a *App) ScheduleMessageExport(request *model.MessageExportRequest) (*model.Job, *model.AppError) {
+	if license := a.License(); license == nil || !*license.Features.MessageExport {
+		return nil, model.NewAppError("ScheduleMessageExport", "app.message_export.license.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if request.Format == "" {
+		request.Format = model.MESSAGE_EXPORT_FORMAT_ZIP
+	}
+
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// Only CSV exports can be produced by the scheduled export worker.
+	if request.Type != model.COMPLIANCE_EXPORT_TYPE_CSV {
+		return nil, model.NewAppError("ScheduleMessageExport", "app.message_export.type_not_supported.app_error", nil, "type="+request.Type, http.StatusNotImplemented)
+	}
+
+	return a.Srv.Jobs.CreateJob(model.JOB_TYPE_SCHEDULED_MESSAGE_EXPORT, request.ToJobData())
+}
+
+// GetMessageExportJob returns the message export job with the given id, treating jobs of any other
+// type as not found.
+func (a *App) GetMessageExportJob(jobId string) (*model.Job, *model.AppError) {
+	job, err := a.GetJob(jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != model.JOB_TYPE_SCHEDULED_MESSAGE_EXPORT {
+		return nil, model.NewAppError("GetMessageExportJob", "app.message_export.job_not_found.app_error", nil, "job_id="+jobId, http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+func (a *App) CancelMessageExportJob(jobId string) *model.AppError {
+	if _, err := a.GetMessageExportJob(jobId); err != nil {
+		return err
+	}
+
+	return a.CancelJob(jobId)
+}
@@ -47,7 +47,7 @@ func (a *App) LoadLicense() {
 }
 
 func (a *App) SaveLicense(licenseBytes []byte) (*model.License, *model.AppError) {
-	success, licenseStr := utils.ValidateLicense(licenseBytes)
+	success, licenseStr := a.Srv.licenseValidator.ValidateLicense(licenseBytes)
 	if !success {
 		return nil, model.NewAppError("addLicense", model.INVALID_LICENSE_ERROR, nil, "", http.StatusBadRequest)
 	}
@@ -109,6 +109,28 @@ func (a *App) License() *model.License {
 	return a.Srv.License()
 }
 
+// PreviewLicense validates the given license without activating it and describes how the
+// licensed features would change if it were applied.
+func (a *App) PreviewLicense(licenseBytes []byte) (*model.LicensePreview, *model.AppError) {
+	success, licenseStr := a.Srv.licenseValidator.ValidateLicense(licenseBytes)
+	if !success {
+		return nil, model.NewAppError("PreviewLicense", model.INVALID_LICENSE_ERROR, nil, "", http.StatusBadRequest)
+	}
+
+	license := model.LicenseFromJson(strings.NewReader(licenseStr))
+	if license == nil || license.Features == nil {
+		return nil, model.NewAppError("PreviewLicense", model.INVALID_LICENSE_ERROR, nil, "", http.StatusBadRequest)
+	}
+
+	if license.IsExpired() {
+		return nil, model.NewAppError("PreviewLicense", model.EXPIRED_LICENSE_ERROR, nil, "", http.StatusBadRequest)
+	}
+
+	license.Features.SetDefaults()
+
+	return model.NewLicensePreview(a.License(), license), nil
+}
+
 func (a *App) SetLicense(license *model.License) bool {
 	defer func() {
 		for _, listener := range a.Srv.licenseListeners {
@@ -130,7 +152,7 @@ func (a *App) SetLicense(license *model.License) bool {
 }
 
 func (a *App) ValidateAndSetLicenseBytes(b []byte) {
-	if success, licenseStr := utils.ValidateLicense(b); success {
+	if success, licenseStr := a.Srv.licenseValidator.ValidateLicense(b); success {
 		license := model.LicenseFromJson(strings.NewReader(licenseStr))
 		a.SetLicense(license)
 		return
@@ -146,6 +146,8 @@ func (a *App) DoLogin(w http.ResponseWriter, r *http.Request, user *model.User,
 	bname := getBrowserName(ua, r.UserAgent())
 	bversion := getBrowserVersion(ua, r.UserAgent())
 
+	session.Platform = plat
+	session.IpAddress = utils.GetIpAddress(r, a.Config().ServiceSettings.TrustedProxyIPHeader)
 	session.AddProp(model.SESSION_PROP_PLATFORM, plat)
 	session.AddProp(model.SESSION_PROP_OS, os)
 	session.AddProp(model.SESSION_PROP_BROWSER, fmt.Sprintf("%v/%v", bname, bversion))
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetMentionsForUser returns a page of the posts that mentioned the given user, newest first. If
+// teamId is set, only mentions on that team and in direct and group messages are returned.
+func (a *App) GetMentionsForUser(userId string, teamId string, page int, perPage int) (*model.PostList, *model.AppError) {
+	return a.Srv.Store.Mention().GetPostsForUser(userId, teamId, page*perPage, perPage)
+}
+
+// saveMentions records that the given post mentioned each of the given users.
+func (a *App) saveMentions(post *model.Post, channel *model.Channel, userIds []string) {
+	mentions := make([]*model.Mention, 0, len(userIds))
+	for _, userId := range userIds {
+		mentions = append(mentions, &model.Mention{
+			UserId:    userId,
+			PostId:    post.Id,
+			ChannelId: channel.Id,
+			TeamId:    channel.TeamId,
+			CreateAt:  post.CreateAt,
+		})
+	}
+
+	if err := a.Srv.Store.Mention().SaveMultiple(mentions); err != nil {
+		mlog.Error("Failed to save mentions", mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
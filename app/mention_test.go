@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetMentionsForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+	// BasicUser2 is notified of replies to the thread, but isn't mentioned by them.
+	notifyProps := th.BasicUser2.NotifyProps
+	notifyProps[model.COMMENTS_NOTIFY_PROP] = THREAD_ANY
+	_, err := th.App.UpdateUserNotifyProps(th.BasicUser2.Id, notifyProps)
+	require.Nil(t, err)
+
+	rootPost, err := th.App.CreatePostMissingChannel(&model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "root post",
+	}, false)
+	require.Nil(t, err)
+
+	mentionPost, err := th.App.CreatePostMissingChannel(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "@" + th.BasicUser2.Username + " hello",
+	}, false)
+	require.Nil(t, err)
+
+	_, err = th.App.CreatePostMissingChannel(&model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		RootId:    rootPost.Id,
+		Message:   "reply",
+	}, false)
+	require.Nil(t, err)
+
+	// Mentions are saved asynchronously after the posts are created.
+	var posts *model.PostList
+	for i := 0; i < 50; i++ {
+		posts, err = th.App.GetMentionsForUser(th.BasicUser2.Id, "", 0, 60)
+		require.Nil(t, err)
+		if len(posts.Order) > 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Give a mention for the reply, which there shouldn't be, a chance to be saved.
+	time.Sleep(500 * time.Millisecond)
+
+	posts, err = th.App.GetMentionsForUser(th.BasicUser2.Id, "", 0, 60)
+	require.Nil(t, err)
+	assert.Equal(t, []string{mentionPost.Id}, posts.Order)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	MESSAGE_EXPORT_BATCH_SIZE = 1000
+	MESSAGE_EXPORT_FILE_NAME  = "messages.csv"
+)
+
+var messageExportCsvHeader = []string{
+	"Post Creation Time",
+	"Team Id",
+	"Team Name",
+	"Team Display Name",
+	"Channel Id",
+	"Channel Name",
+	"Channel Display Name",
+	"Channel Type",
+	"User Id",
+	"User Email",
+	"Username",
+	"Post Id",
+	"Edited By Post Id",
+	"Replied to Post Id",
+	"Post Message",
+	"Post Type",
+	"User Type",
+}
+
+// RunMessageExport writes the posts selected by the given scheduled message export job to the file
+// store, and records where in the job data. If ctx is done before the export completes, the partial
+// export is removed.
+func (a *App) RunMessageExport(ctx context.Context, job *model.Job) *model.AppError {
+	request, err := model.MessageExportRequestFromJobData(job.Data)
+	if err != nil {
+		return err
+	}
+
+	if request.Type != model.COMPLIANCE_EXPORT_TYPE_CSV {
+		return model.NewAppError("RunMessageExport", "app.message_export.type_not_supported.app_error", nil, "type="+request.Type, http.StatusNotImplemented)
+	}
+
+	// An open ended export covers the posts made before it was scheduled.
+	until := request.To
+	if until == 0 {
+		until = job.CreateAt
+	}
+
+	exportPath := path.Join("export", job.Id, MESSAGE_EXPORT_FILE_NAME)
+	if request.Format == model.MESSAGE_EXPORT_FORMAT_ZIP {
+		exportPath = path.Join("export", job.Id, "messages.zip")
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(a.writeMessageExport(ctx, writer, request, until))
+	}()
+
+	if _, err := a.WriteFile(reader, exportPath); err != nil {
+		// Unblock the writer if the file store stopped reading early.
+		reader.CloseWithError(err)
+
+		if removeErr := a.RemoveFile(exportPath); removeErr != nil {
+			mlog.Warn("Failed to remove partial message export", mlog.String("path", exportPath), mlog.Err(removeErr))
+		}
+		return err
+	}
+
+	job.Data[model.JOB_DATA_EXPORT_FILE_PATH] = exportPath
+	return a.Srv.Jobs.UpdateInProgressJobData(job)
+}
+
+// writeMessageExport writes the posts selected by the given request as CSV, zipped unless the
+// stream format was requested, reading them from the store a batch at a time.
+func (a *App) writeMessageExport(ctx context.Context, w io.Writer, request *model.MessageExportRequest, until int64) error {
+	out := w
+
+	var zipWriter *zip.Writer
+	if request.Format == model.MESSAGE_EXPORT_FORMAT_ZIP {
+		zipWriter = zip.NewWriter(w)
+
+		var err error
+		if out, err = zipWriter.Create(MESSAGE_EXPORT_FILE_NAME); err != nil {
+			return err
+		}
+	}
+
+	csvWriter := csv.NewWriter(out)
+	if err := csvWriter.Write(messageExportCsvHeader); err != nil {
+		return err
+	}
+
+	afterCreateAt, afterPostId := request.From, ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		posts, appErr := a.Srv.Store.Compliance().ChannelMessageExport(request.Channels, afterCreateAt, afterPostId, until, MESSAGE_EXPORT_BATCH_SIZE)
+		if appErr != nil {
+			return appErr
+		}
+
+		for _, post := range posts {
+			if err := csvWriter.Write(messageExportCsvRecord(post)); err != nil {
+				return err
+			}
+		}
+
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+
+		if len(posts) < MESSAGE_EXPORT_BATCH_SIZE {
+			break
+		}
+
+		lastPost := posts[len(posts)-1]
+		afterCreateAt, afterPostId = *lastPost.PostCreateAt, *lastPost.PostId
+	}
+
+	if zipWriter != nil {
+		return zipWriter.Close()
+	}
+
+	return nil
+}
+
+func messageExportCsvRecord(post *model.MessageExport) []string {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	userType := "user"
+	if post.IsBot {
+		userType = "bot"
+	}
+
+	return []string{
+		strconv.FormatInt(*post.PostCreateAt, 10),
+		value(post.TeamId),
+		value(post.TeamName),
+		value(post.TeamDisplayName),
+		value(post.ChannelId),
+		value(post.ChannelName),
+		value(post.ChannelDisplayName),
+		value(post.ChannelType),
+		value(post.UserId),
+		value(post.UserEmail),
+		value(post.Username),
+		value(post.PostId),
+		value(post.PostOriginalId),
+		value(post.PostRootId),
+		value(post.PostMessage),
+		value(post.PostType),
+		userType,
+	}
+}
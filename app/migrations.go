@@ -15,6 +15,7 @@ import (
 const ADVANCED_PERMISSIONS_MIGRATION_KEY = "AdvancedPermissionsMigrationComplete"
 const EMOJIS_PERMISSIONS_MIGRATION_KEY = "EmojisPermissionsMigrationComplete"
 const GUEST_ROLES_CREATION_MIGRATION_KEY = "GuestRolesCreationMigrationComplete"
+const SAVED_POSTS_MIGRATION_KEY = "SavedPostsMigrationComplete"
 
 // This function migrates the default built in roles from code/config to the database.
 func (a *App) DoAdvancedPermissionsMigration() {
@@ -238,10 +239,38 @@ func (a *App) DoGuestRolesCreationMigration() {
 	}
 }
 
+// DoSavedPostsMigration copies the posts flagged through the flagged_post preferences into the
+// SavedPosts table. The preferences are kept, so that a server downgraded to a version without saved
+// posts still has them.
+func (a *App) DoSavedPostsMigration() {
+	// If the migration is already marked as completed, don't do it again.
+	if _, err := a.Srv.Store.System().GetByName(SAVED_POSTS_MIGRATION_KEY); err == nil {
+		return
+	}
+
+	saved, err := a.Srv.Store.SavedPost().MigrateFlaggedPostPreferences()
+	if err != nil {
+		mlog.Critical("Failed to migrate flagged posts to saved posts.", mlog.Err(err))
+		return
+	}
+
+	mlog.Info("Migrated flagged posts to saved posts.", mlog.Int64("saved_posts", saved))
+
+	system := model.System{
+		Name:  SAVED_POSTS_MIGRATION_KEY,
+		Value: "true",
+	}
+
+	if err := a.Srv.Store.System().Save(&system); err != nil {
+		mlog.Critical("Failed to mark saved posts migration as completed.", mlog.Err(err))
+	}
+}
+
 func (a *App) DoAppMigrations() {
 	a.DoAdvancedPermissionsMigration()
 	a.DoEmojisPermissionsMigration()
 	a.DoGuestRolesCreationMigration()
+	a.DoSavedPostsMigration()
 	// This migration always must be the last, because can be based on previous
 	// migrations. For example, it needs the guest roles migration.
 	a.DoPermissionsMigrations()
@@ -141,8 +141,12 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 	}
 
 	mentionedUsersList := make([]string, 0, len(mentionedUserIds))
-	for id := range mentionedUserIds {
+	explicitlyMentionedUsersList := make([]string, 0, len(mentionedUserIds))
+	for id, explicit := range mentionedUserIds {
 		mentionedUsersList = append(mentionedUsersList, id)
+		if explicit {
+			explicitlyMentionedUsersList = append(explicitlyMentionedUsersList, id)
+		}
 		umc := make(chan *model.AppError, 1)
 		go func(userId string) {
 			umc <- a.Srv.Store.Channel().IncrementMentionCount(post.ChannelId, userId)
@@ -151,6 +155,12 @@ func (a *App) SendNotifications(post *model.Post, team *model.Team, channel *mod
 		updateMentionChans = append(updateMentionChans, umc)
 	}
 
+	if len(explicitlyMentionedUsersList) > 0 {
+		a.Srv.Go(func() {
+			a.saveMentions(post, channel, explicitlyMentionedUsersList)
+		})
+	}
+
 	notification := &postNotification{
 		post:       post,
 		channel:    channel,
@@ -4,9 +4,11 @@
 package app
 
 import (
+	"fmt"
 	"hash/fnv"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -21,6 +23,8 @@ type NotificationType string
 const NOTIFICATION_TYPE_CLEAR NotificationType = "clear"
 const NOTIFICATION_TYPE_MESSAGE NotificationType = "message"
 
+const PUSH_NOTIFICATION_TEST_MESSAGE = "This is a test notification"
+
 const PUSH_NOTIFICATION_HUB_WORKERS = 1000
 const PUSH_NOTIFICATIONS_HUB_BUFFER_PER_WORKER = 50
 
@@ -44,6 +48,16 @@ type PushNotification struct {
 	replyToThreadType  string
 }
 
+// pushProxyUnavailableError is returned when a notification could not be delivered because the push proxy, or the
+// service behind it, could not be reached. Notifications that fail this way are queued to be retried later.
+type pushProxyUnavailableError struct {
+	err error
+}
+
+func (e *pushProxyUnavailableError) Error() string {
+	return e.err.Error()
+}
+
 func (hub *PushNotificationsHub) GetGoChannelFromUserId(userId string) chan PushNotification {
 	h := fnv.New32a()
 	h.Write([]byte(userId))
@@ -82,6 +96,10 @@ func (a *App) sendPushNotificationSync(post *model.Post, user *model.User, chann
 				mlog.String("status", err.Error()),
 			)
 
+			if _, ok := err.(*pushProxyUnavailableError); ok {
+				a.queuePushNotification(tmpMessage, session)
+			}
+
 			continue
 		}
 
@@ -209,6 +227,10 @@ func (a *App) ClearPushNotificationSync(currentSessionId, userId, channelId stri
 					mlog.String("status", err.Error()),
 				)
 
+				if _, ok := err.(*pushProxyUnavailableError); ok {
+					a.queuePushNotification(tmpMessage, session)
+				}
+
 				continue
 			}
 
@@ -302,11 +324,15 @@ func (a *App) sendToPushProxy(msg model.PushNotification, session *model.Session
 
 	resp, err := a.HTTPService.MakeClient(true).Do(request)
 	if err != nil {
-		return err
+		return &pushProxyUnavailableError{err}
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return &pushProxyUnavailableError{errors.Errorf("push proxy responded with status code %v", resp.StatusCode)}
+	}
+
 	pushResponse := model.PushResponseFromJson(resp.Body)
 
 	if pushResponse[model.PUSH_STATUS] == model.PUSH_STATUS_REMOVE {
@@ -355,6 +381,84 @@ func (a *App) SendAckToPushProxy(ack *model.PushNotificationAck) error {
 
 }
 
+// SendTestPushNotification sends a synthetic notification through the configured push proxy to the
+// device token in the request or, failing that, to the most recently active mobile session of the
+// requested user. Failures to reach the proxy or to deliver the notification are reported in the
+// result rather than as an error.
+func (a *App) SendTestPushNotification(testRequest *model.PushNotificationTestRequest) (*model.PushNotificationTestResult, *model.AppError) {
+	cfg := a.Config()
+	if !*cfg.EmailSettings.SendPushNotifications || *cfg.EmailSettings.PushNotificationServer == "" {
+		return nil, model.NewAppError("SendTestPushNotification", "api.push_notification.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	deviceId := testRequest.Token
+	if deviceId == "" {
+		sessions, err := a.getMobileAppSessions(testRequest.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		var latest *model.Session
+		for _, session := range sessions {
+			if session.IsExpired() {
+				continue
+			}
+			if latest == nil || session.LastActivityAt > latest.LastActivityAt {
+				latest = session
+			}
+		}
+
+		if latest == nil {
+			return nil, model.NewAppError("SendTestPushNotification", "app.push_notification.test.no_device.app_error", nil, "user_id="+testRequest.UserId, http.StatusBadRequest)
+		}
+		deviceId = latest.DeviceId
+	}
+
+	msg := &model.PushNotification{
+		AckId:    model.NewId(),
+		ServerId: a.DiagnosticId(),
+		Type:     model.PUSH_TYPE_MESSAGE,
+		Message:  PUSH_NOTIFICATION_TEST_MESSAGE,
+		Version:  model.PUSH_MESSAGE_V2,
+	}
+	msg.SetDeviceIdAndPlatform(deviceId)
+	if msg.DeviceId == "" {
+		return nil, model.NewAppError("SendTestPushNotification", "app.push_notification.test.invalid_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	request, err := http.NewRequest("POST", strings.TrimRight(*cfg.EmailSettings.PushNotificationServer, "/")+model.API_URL_SUFFIX_V1+"/send_push", strings.NewReader(msg.ToJson()))
+	if err != nil {
+		return nil, model.NewAppError("SendTestPushNotification", "app.push_notification.test.request.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	result := &model.PushNotificationTestResult{}
+	start := time.Now()
+
+	resp, err := a.HTTPService.MakeClient(true).Do(request)
+	result.LatencyMs = int64(time.Since(start) / time.Millisecond)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		result.Error = fmt.Sprintf("push proxy responded with status code %v", resp.StatusCode)
+		return result, nil
+	}
+
+	pushResponse := model.PushResponseFromJson(resp.Body)
+	switch pushResponse[model.PUSH_STATUS] {
+	case model.PUSH_STATUS_REMOVE:
+		result.Error = "Device was reported as removed"
+	case model.PUSH_STATUS_FAIL:
+		result.Error = pushResponse[model.PUSH_STATUS_ERROR_MSG]
+	}
+
+	return result, nil
+}
+
 func (a *App) getMobileAppSessions(userId string) ([]*model.Session, *model.AppError) {
 	return a.Srv.Store.Session().GetSessionsWithActiveDeviceIds(userId)
 }
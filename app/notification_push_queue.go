@@ -0,0 +1,155 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const PUSH_NOTIFICATION_QUEUE_BATCH_SIZE = 1000
+
+// queuePushNotification stores a notification that could not reach the push proxy so that it can be retried by
+// ProcessPushNotificationQueue.
+func (a *App) queuePushNotification(msg *model.PushNotification, session *model.Session) {
+	item := &model.PushNotificationQueueItem{
+		SessionId: session.Id,
+		UserId:    session.UserId,
+		Message:   pushNotificationQueueMessage(msg),
+	}
+
+	if _, err := a.Srv.Store.PushNotificationQueue().Save(item); err != nil {
+		mlog.Error("Unable to queue push notification for retry", mlog.String("ackId", msg.AckId), mlog.Err(err))
+		return
+	}
+
+	a.NotificationsLog.Info("Notification queued for retry",
+		mlog.String("ackId", msg.AckId),
+		mlog.String("type", msg.Type),
+		mlog.String("userId", session.UserId),
+		mlog.String("postId", msg.PostId),
+		mlog.String("channelId", msg.ChannelId),
+		mlog.String("deviceId", msg.DeviceId),
+	)
+}
+
+// pushNotificationQueueMessage serializes a notification to be queued, shortening the text of its
+// message as needed to fit within PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH.
+func pushNotificationQueueMessage(msg *model.PushNotification) string {
+	queued := *msg
+	data := queued.ToJson()
+	for len(data) > model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH && queued.Message != "" {
+		keep := len(queued.Message) - (len(data) - model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH) - len("...")
+		for keep > 0 && !utf8.RuneStart(queued.Message[keep]) {
+			keep--
+		}
+		if keep > 0 {
+			queued.Message = queued.Message[:keep] + "..."
+		} else {
+			queued.Message = ""
+		}
+		data = queued.ToJson()
+	}
+
+	if queued.Message != msg.Message {
+		mlog.Debug("Truncated push notification message to queue it for retry", mlog.String("ackId", msg.AckId), mlog.Int("length", len(msg.Message)))
+	}
+
+	return data
+}
+
+// ProcessPushNotificationQueue retries the push notifications that previously failed to reach the push proxy.
+// Notifications are dropped once they have been retried PUSH_NOTIFICATION_QUEUE_MAX_RETRIES times or have been
+// queued for longer than PUSH_NOTIFICATION_QUEUE_LIFETIME.
+func (a *App) ProcessPushNotificationQueue() {
+	if _, err := a.Srv.Store.PushNotificationQueue().DeleteExpired(model.GetMillis() - model.PUSH_NOTIFICATION_QUEUE_LIFETIME); err != nil {
+		mlog.Error("Unable to delete expired push notifications", mlog.Err(err))
+	}
+
+	items, err := a.Srv.Store.PushNotificationQueue().GetBatch(PUSH_NOTIFICATION_QUEUE_BATCH_SIZE)
+	if err != nil {
+		mlog.Error("Unable to get queued push notifications", mlog.Err(err))
+		return
+	}
+
+	for _, item := range items {
+		a.retryQueuedPushNotification(item)
+	}
+
+	a.updatePushNotificationQueueDepth()
+}
+
+func (a *App) retryQueuedPushNotification(item *model.PushNotificationQueueItem) {
+	session, err := a.Srv.Store.Session().Get(item.SessionId)
+	if err != nil || session.IsExpired() || !*a.Config().EmailSettings.SendPushNotifications {
+		a.deleteQueuedPushNotification(item)
+		return
+	}
+
+	msg := model.PushNotificationFromJson(strings.NewReader(item.Message))
+
+	if sendErr := a.sendToPushProxy(*msg, session); sendErr != nil {
+		a.NotificationsLog.Error("Notification retry error",
+			mlog.String("ackId", msg.AckId),
+			mlog.String("type", msg.Type),
+			mlog.String("userId", item.UserId),
+			mlog.String("postId", msg.PostId),
+			mlog.String("channelId", msg.ChannelId),
+			mlog.String("deviceId", msg.DeviceId),
+			mlog.Int("retries", item.Retries+1),
+			mlog.String("status", sendErr.Error()),
+		)
+
+		if _, ok := sendErr.(*pushProxyUnavailableError); !ok || item.Retries+1 >= model.PUSH_NOTIFICATION_QUEUE_MAX_RETRIES {
+			a.deleteQueuedPushNotification(item)
+			return
+		}
+
+		item.Retries++
+		item.LastAttemptAt = model.GetMillis()
+		if _, err := a.Srv.Store.PushNotificationQueue().Update(item); err != nil {
+			mlog.Error("Unable to update queued push notification", mlog.String("id", item.Id), mlog.Err(err))
+		}
+		return
+	}
+
+	a.NotificationsLog.Info("Notification sent",
+		mlog.String("ackId", msg.AckId),
+		mlog.String("type", msg.Type),
+		mlog.String("userId", item.UserId),
+		mlog.String("postId", msg.PostId),
+		mlog.String("channelId", msg.ChannelId),
+		mlog.String("deviceId", msg.DeviceId),
+		mlog.String("status", model.PUSH_SEND_SUCCESS),
+	)
+
+	if a.Metrics != nil {
+		a.Metrics.IncrementPostSentPush()
+	}
+
+	a.deleteQueuedPushNotification(item)
+}
+
+func (a *App) deleteQueuedPushNotification(item *model.PushNotificationQueueItem) {
+	if err := a.Srv.Store.PushNotificationQueue().Delete(item.Id); err != nil {
+		mlog.Error("Unable to delete queued push notification", mlog.String("id", item.Id), mlog.Err(err))
+	}
+}
+
+func (a *App) updatePushNotificationQueueDepth() {
+	if a.Metrics == nil {
+		return
+	}
+
+	depth, err := a.Srv.Store.PushNotificationQueue().Count()
+	if err != nil {
+		mlog.Error("Unable to count queued push notifications", mlog.Err(err))
+		return
+	}
+
+	a.Metrics.SetPushNotificationQueueDepth(depth)
+}
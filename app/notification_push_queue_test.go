@@ -0,0 +1,109 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestPushNotificationQueue(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	statusCode := http.StatusServiceUnavailable
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(statusCode)
+		w.Write([]byte(model.MapToJson(map[string]string{model.STATUS: model.STATUS_OK})))
+	}))
+	defer server.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.SendPushNotifications = true
+		*cfg.EmailSettings.PushNotificationServer = server.URL
+	})
+
+	session, err := th.App.CreateSession(&model.Session{
+		UserId:   th.BasicUser.Id,
+		DeviceId: model.PUSH_NOTIFY_ANDROID_REACT_NATIVE + ":" + model.NewId(),
+	})
+	require.Nil(t, err)
+
+	getQueue := func() []*model.PushNotificationQueueItem {
+		items, err := th.App.Srv.Store.PushNotificationQueue().GetBatch(PUSH_NOTIFICATION_QUEUE_BATCH_SIZE)
+		require.Nil(t, err)
+
+		var queued []*model.PushNotificationQueueItem
+		for _, item := range items {
+			if item.SessionId == session.Id {
+				queued = append(queued, item)
+			}
+		}
+		return queued
+	}
+
+	t.Run("should queue notifications when the push proxy is unavailable", func(t *testing.T) {
+		th.App.ClearPushNotificationSync("", th.BasicUser.Id, th.BasicChannel.Id)
+
+		queued := getQueue()
+		require.Len(t, queued, 1)
+		assert.Equal(t, 0, queued[0].Retries)
+	})
+
+	t.Run("should count retries while the push proxy is unavailable", func(t *testing.T) {
+		th.App.ProcessPushNotificationQueue()
+
+		queued := getQueue()
+		require.Len(t, queued, 1)
+		assert.Equal(t, 1, queued[0].Retries)
+	})
+
+	t.Run("should send and remove notifications once the push proxy is available", func(t *testing.T) {
+		statusCode = http.StatusOK
+		requestsBefore := requests
+
+		th.App.ProcessPushNotificationQueue()
+
+		assert.Equal(t, requestsBefore+1, requests)
+		assert.Len(t, getQueue(), 0)
+	})
+
+	t.Run("should drop notifications after the maximum number of retries", func(t *testing.T) {
+		statusCode = http.StatusBadGateway
+
+		th.App.ClearPushNotificationSync("", th.BasicUser.Id, th.BasicChannel.Id)
+		require.Len(t, getQueue(), 1)
+
+		for i := 0; i < model.PUSH_NOTIFICATION_QUEUE_MAX_RETRIES; i++ {
+			th.App.ProcessPushNotificationQueue()
+		}
+
+		assert.Len(t, getQueue(), 0)
+	})
+}
+
+func TestPushNotificationQueueMessage(t *testing.T) {
+	msg := &model.PushNotification{AckId: model.NewId(), Message: "short"}
+	assert.Equal(t, msg.ToJson(), pushNotificationQueueMessage(msg))
+
+	msg.Message = strings.Repeat("é", model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH)
+	data := pushNotificationQueueMessage(msg)
+	assert.True(t, len(data) <= model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH)
+	assert.Equal(t, strings.Repeat("é", model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH), msg.Message, "the original notification shouldn't be modified")
+
+	queued := model.PushNotificationFromJson(strings.NewReader(data))
+	require.NotNil(t, queued)
+	assert.Equal(t, msg.AckId, queued.AckId)
+	assert.True(t, strings.HasSuffix(queued.Message, "..."))
+	assert.True(t, strings.HasPrefix(msg.Message, strings.TrimSuffix(queued.Message, "...")))
+}
@@ -5,11 +5,14 @@ package app
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDoesNotifyPropsAllowPushNotification(t *testing.T) {
@@ -947,3 +950,59 @@ func TestBuildPushNotificationMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSendTestPushNotification(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var response string
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.SendPushNotifications = true
+		*cfg.EmailSettings.PushNotificationServer = server.URL
+	})
+
+	testRequest := &model.PushNotificationTestRequest{Token: model.PUSH_NOTIFY_ANDROID_REACT_NATIVE + ":" + model.NewId()}
+
+	t.Run("delivered", func(t *testing.T) {
+		ok := model.NewOkPushResponse()
+		response = ok.ToJson()
+
+		result, err := th.App.SendTestPushNotification(testRequest)
+		require.Nil(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("rejected by the proxy", func(t *testing.T) {
+		fail := model.NewErrorPushResponse("bad token")
+		response = fail.ToJson()
+
+		result, err := th.App.SendTestPushNotification(testRequest)
+		require.Nil(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "bad token", result.Error)
+	})
+
+	t.Run("proxy unavailable", func(t *testing.T) {
+		statusCode = http.StatusBadGateway
+		defer func() { statusCode = http.StatusOK }()
+
+		result, err := th.App.SendTestPushNotification(testRequest)
+		require.Nil(t, err)
+		assert.Equal(t, http.StatusBadGateway, result.StatusCode)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := th.App.SendTestPushNotification(&model.PushNotificationTestRequest{Token: model.NewId()})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.push_notification.test.invalid_token.app_error", err.Id)
+	})
+}
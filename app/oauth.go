@@ -304,7 +304,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(clientId, grantType, redirectUri, c
 
 func (a *App) newSession(appName string, user *model.User) (*model.Session, *model.AppError) {
 	// Set new token an session
-	session := &model.Session{UserId: user.Id, Roles: user.Roles, IsOAuth: true}
+	session := &model.Session{UserId: user.Id, Roles: user.Roles, IsOAuth: true, Platform: appName, IpAddress: a.IpAddress}
 	session.GenerateCSRF()
 	session.SetExpireInDays(*a.Config().ServiceSettings.SessionLengthSSOInDays)
 	session.AddProp(model.SESSION_PROP_PLATFORM, appName)
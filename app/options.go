@@ -9,6 +9,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/config"
 	"github.com/mattermost/mattermost-server/store"
+	"github.com/mattermost/mattermost-server/utils"
 )
 
 type Option func(s *Server) error
@@ -60,6 +61,15 @@ func ConfigStore(configStore config.Store) Option {
 	}
 }
 
+// LicenseValidator applies the given license validator, typically to accept licenses not signed with the production key for testing.
+func LicenseValidator(licenseValidator utils.LicenseValidatorIface) Option {
+	return func(s *Server) error {
+		s.licenseValidator = licenseValidator
+
+		return nil
+	}
+}
+
 func RunJobs(s *Server) error {
 	s.runjobs = true
 
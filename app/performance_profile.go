@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/services/filesstore"
+)
+
+const (
+	PERFORMANCE_PROFILE_DIRECTORY   = "performance_profiles/"
+	PERFORMANCE_PROFILE_LINK_EXPIRY = 60 * 60 * 1000 // 1 hour
+)
+
+var performanceProfileFileNameRegexp = regexp.MustCompile(`^(cpu|heap|goroutine)_[a-z0-9]{26}\.pprof$`)
+
+// CapturePerformanceProfile captures a pprof profile of the given type and stores it in the file
+// store. CPU profiles are sampled for the given duration, while heap and goroutine profiles are
+// snapshots taken immediately. Only one profile may be captured at a time.
+func (a *App) CapturePerformanceProfile(profileType string, duration time.Duration) (*model.PerformanceProfile, *model.AppError) {
+	if !model.IsValidPerformanceProfileType(profileType) {
+		return nil, model.NewAppError("CapturePerformanceProfile", "app.system.performance_profile.type.app_error", nil, "type="+profileType, http.StatusBadRequest)
+	}
+
+	if !atomic.CompareAndSwapInt32(&a.Srv.capturingPerformanceProfile, 0, 1) {
+		return nil, model.NewAppError("CapturePerformanceProfile", "app.system.performance_profile.in_progress.app_error", nil, "", http.StatusTooManyRequests)
+	}
+	defer atomic.StoreInt32(&a.Srv.capturingPerformanceProfile, 0)
+
+	if profileType != model.PERFORMANCE_PROFILE_TYPE_CPU {
+		duration = 0
+	}
+
+	var buf bytes.Buffer
+	if err := capturePerformanceProfile(&buf, profileType, duration); err != nil {
+		return nil, model.NewAppError("CapturePerformanceProfile", "app.system.performance_profile.capture.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	profile := &model.PerformanceProfile{
+		Type:            profileType,
+		DurationSeconds: int(duration / time.Second),
+		FileName:        fmt.Sprintf("%v_%v.pprof", profileType, model.NewId()),
+		ExpireAt:        model.GetMillis() + PERFORMANCE_PROFILE_LINK_EXPIRY,
+	}
+
+	if _, err := a.WriteFile(&buf, PERFORMANCE_PROFILE_DIRECTORY+profile.FileName); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func capturePerformanceProfile(w io.Writer, profileType string, duration time.Duration) error {
+	if profileType != model.PERFORMANCE_PROFILE_TYPE_CPU {
+		return pprof.Lookup(profileType).WriteTo(w, 0)
+	}
+
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	time.Sleep(duration)
+	pprof.StopCPUProfile()
+
+	return nil
+}
+
+// PerformanceProfileReader returns a reader for a stored profile after checking that the link used
+// to download it was signed by this server and hasn't expired.
+func (a *App) PerformanceProfileReader(fileName string, expireAt int64, hash string) (filesstore.ReadCloseSeeker, *model.AppError) {
+	if !performanceProfileFileNameRegexp.MatchString(fileName) {
+		return nil, model.NewAppError("PerformanceProfileReader", "app.system.performance_profile.invalid_link.app_error", nil, "file_name="+fileName, http.StatusBadRequest)
+	}
+
+	expectedHash := GeneratePerformanceProfileLinkHash(fileName, expireAt, *a.Config().FileSettings.PublicLinkSalt)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(expectedHash)) != 1 || expireAt < model.GetMillis() {
+		return nil, model.NewAppError("PerformanceProfileReader", "app.system.performance_profile.invalid_link.app_error", nil, "file_name="+fileName, http.StatusForbidden)
+	}
+
+	return a.FileReader(PERFORMANCE_PROFILE_DIRECTORY + fileName)
+}
+
+func GeneratePerformanceProfileLinkHash(fileName string, expireAt int64, salt string) string {
+	hash := sha256.New()
+	hash.Write([]byte(salt))
+	hash.Write([]byte(fileName))
+	hash.Write([]byte(strconv.FormatInt(expireAt, 10)))
+
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
+}
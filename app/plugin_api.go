@@ -513,7 +513,7 @@ func (api *PluginAPI) SetProfileImage(userId string, data []byte) *model.AppErro
 }
 
 func (api *PluginAPI) GetEmojiList(sortBy string, page, perPage int) ([]*model.Emoji, *model.AppError) {
-	return api.app.GetEmojiList(page, perPage, sortBy)
+	return api.app.GetEmojiList(page, perPage, sortBy, model.EmojiListOpts{})
 }
 
 func (api *PluginAPI) GetEmojiByName(name string) (*model.Emoji, *model.AppError) {
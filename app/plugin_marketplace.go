@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/blang/semver"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LOCAL_MARKETPLACE_ROUTE is the path, relative to the site URL, at which the bundled
+	// marketplace is served. Setting PluginSettings.MarketplaceUrl to the site URL followed by
+	// this path points the marketplace client at the local bundle.
+	LOCAL_MARKETPLACE_ROUTE = "/plugins/marketplace"
+
+	// LOCAL_MARKETPLACE_BUNDLE_PATH is the location of the marketplace bundle in the file store.
+	LOCAL_MARKETPLACE_BUNDLE_PATH = "marketplace/marketplace.json"
+)
+
+// GetLocalMarketplacePlugins returns the plugins of the uploaded marketplace bundle that match the
+// given filter, paginated the same way as the marketplace server.
+func (a *App) GetLocalMarketplacePlugins(filter *model.MarketplacePluginFilter) ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	var serverVersion *semver.Version
+	if filter.ServerVersion != "" {
+		version, err := semver.Parse(filter.ServerVersion)
+		if err != nil {
+			return nil, model.NewAppError("GetLocalMarketplacePlugins", "app.plugin.marketplace_bundle.server_version.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+		serverVersion = &version
+	}
+
+	exists, appErr := a.FileExists(LOCAL_MARKETPLACE_BUNDLE_PATH)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !exists {
+		return nil, model.NewAppError("GetLocalMarketplacePlugins", "app.plugin.marketplace_bundle.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	data, appErr := a.ReadFile(LOCAL_MARKETPLACE_BUNDLE_PATH)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	bundle, err := model.BaseMarketplacePluginsFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, model.NewAppError("GetLocalMarketplacePlugins", "app.plugin.marketplace_bundle.invalid.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	plugins := []*model.BaseMarketplacePlugin{}
+	for _, p := range bundle {
+		if p.Manifest == nil || !pluginMatchesFilter(p.Manifest, filter.Filter) {
+			continue
+		}
+
+		if serverVersion != nil && p.Manifest.MinServerVersion != "" {
+			if meets, err := p.Manifest.MeetMinServerVersion(serverVersion.String()); err != nil || !meets {
+				continue
+			}
+		}
+
+		plugins = append(plugins, p)
+	}
+
+	if filter.PerPage <= 0 {
+		return plugins, nil
+	}
+
+	start := filter.Page * filter.PerPage
+	if start >= len(plugins) {
+		return []*model.BaseMarketplacePlugin{}, nil
+	}
+	end := start + filter.PerPage
+	if end > len(plugins) {
+		end = len(plugins)
+	}
+
+	return plugins[start:end], nil
+}
+
+// SaveLocalMarketplaceBundle validates the given marketplace.json bundle and replaces the one
+// served by the local marketplace.
+func (a *App) SaveLocalMarketplaceBundle(bundle io.Reader) ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	plugins, err := model.BaseMarketplacePluginsFromReader(bundle)
+	if err != nil {
+		return nil, model.NewAppError("SaveLocalMarketplaceBundle", "app.plugin.marketplace_bundle.invalid.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	for _, p := range plugins {
+		if p == nil || p.Manifest == nil || p.Manifest.Id == "" || p.Manifest.Version == "" {
+			return nil, model.NewAppError("SaveLocalMarketplaceBundle", "app.plugin.marketplace_bundle.invalid_plugin.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	data, err := json.Marshal(plugins)
+	if err != nil {
+		return nil, model.NewAppError("SaveLocalMarketplaceBundle", "app.plugin.marshal.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	if _, appErr := a.WriteFile(bytes.NewReader(data), LOCAL_MARKETPLACE_BUNDLE_PATH); appErr != nil {
+		return nil, appErr
+	}
+
+	return plugins, nil
+}
+
+// ServeLocalMarketplaceRequest serves the uploaded marketplace bundle using the same API as the
+// marketplace server, so that the standard marketplace client can be pointed at it.
+func (a *App) ServeLocalMarketplaceRequest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	writeError := func(err *model.AppError) {
+		w.WriteHeader(err.StatusCode)
+		w.Write([]byte(err.ToJson()))
+	}
+
+	if !*a.Config().PluginSettings.Enable {
+		writeError(model.NewAppError("ServeLocalMarketplaceRequest", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented))
+		return
+	}
+
+	query := r.URL.Query()
+	filter := &model.MarketplacePluginFilter{
+		Filter:        query.Get("filter"),
+		ServerVersion: query.Get("server_version"),
+	}
+
+	var err error
+	if filter.Page, err = parseLocalMarketplaceInt(query.Get("page")); err != nil {
+		writeError(model.NewAppError("ServeLocalMarketplaceRequest", "app.plugin.marketplace_bundle.query.app_error", map[string]interface{}{"Name": "page"}, err.Error(), http.StatusBadRequest))
+		return
+	}
+	if filter.PerPage, err = parseLocalMarketplaceInt(query.Get("per_page")); err != nil {
+		writeError(model.NewAppError("ServeLocalMarketplaceRequest", "app.plugin.marketplace_bundle.query.app_error", map[string]interface{}{"Name": "per_page"}, err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	plugins, appErr := a.GetLocalMarketplacePlugins(filter)
+	if appErr != nil {
+		writeError(appErr)
+		return
+	}
+
+	data, err := json.Marshal(plugins)
+	if err != nil {
+		writeError(model.NewAppError("ServeLocalMarketplaceRequest", "app.plugin.marshal.app_error", nil, err.Error(), http.StatusInternalServerError))
+		return
+	}
+
+	w.Write(data)
+}
+
+// parseLocalMarketplaceInt parses a non-negative pagination parameter, treating an empty value as zero.
+func parseLocalMarketplaceInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if parsed < 0 {
+		return 0, errors.New("must not be negative")
+	}
+
+	return parsed, nil
+}
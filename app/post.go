@@ -21,7 +21,14 @@ import (
 const (
 	PENDING_POST_IDS_CACHE_SIZE = 25000
 	PENDING_POST_IDS_CACHE_TTL  = 30 * time.Second
+	RELATED_POST_IDS_CACHE_SIZE = 10000
+	RELATED_POST_IDS_CACHE_TTL  = 10 * time.Minute
+	RELATED_POSTS_CANDIDATES    = 50
 	PAGE_DEFAULT                = 0
+
+	// The number of hashtag matches, which are loaded to be matched exactly, beyond which a post
+	// search count stops counting them.
+	SEARCH_COUNT_MAX_HASHTAG_MATCHES = 1000
 )
 
 func (a *App) CreatePostAsUser(post *model.Post, currentSessionId string) (*model.Post, *model.AppError) {
@@ -859,6 +866,12 @@ func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppErro
 }
 
 func (a *App) DeleteFlaggedPosts(postId string) {
+	if err := a.Srv.Store.SavedPost().PermanentDeleteByPost(postId); err != nil {
+		mlog.Warn("Unable to delete saved posts when deleting post.", mlog.Err(err))
+		return
+	}
+
+	// Also removes the flagged_post preferences left behind by the saved posts migration.
 	if err := a.Srv.Store.Preference().DeleteCategoryAndName(model.PREFERENCE_CATEGORY_FLAGGED_POST, postId); err != nil {
 		mlog.Warn("Unable to delete flagged post preference when deleting post.", mlog.Err(err))
 		return
@@ -912,7 +925,18 @@ func (a *App) parseAndFetchChannelIdByNameFromInFilter(channelName, userId, team
 	return channel, nil
 }
 
-func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*model.SearchParams, modifierFun func(*model.SearchParams)) (*model.PostList, *model.AppError) {
+// searchPostsInTeam runs each of the given searches against the database and merges the results,
+// returning at most SearchSettings.MaxSearchResults posts and whether any had to be left out.
+func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*model.SearchParams, modifierFun func(*model.SearchParams)) (*model.PostList, bool, *model.AppError) {
+	maxResults := *a.Config().SearchSettings.MaxSearchResults
+
+	// Ask for one more than the maximum to find out whether the results will be truncated, unless that
+	// is more than a single search returns anyway. A search that fills its limit may have left posts out.
+	limit := maxResults + 1
+	if limit > model.SEARCH_PARAMS_MAX_LIMIT {
+		limit = model.SEARCH_PARAMS_MAX_LIMIT
+	}
+
 	var wg sync.WaitGroup
 
 	pchan := make(chan store.StoreResult, len(paramsList))
@@ -923,6 +947,7 @@ func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*mode
 			continue
 		}
 		modifierFun(params)
+		params.Limit = limit
 		wg.Add(1)
 
 		go func(params *model.SearchParams) {
@@ -936,17 +961,32 @@ func (a *App) searchPostsInTeam(teamId string, userId string, paramsList []*mode
 	close(pchan)
 
 	posts := model.NewPostList()
+	truncated := false
 
 	for result := range pchan {
 		if result.Err != nil {
-			return nil, result.Err
+			return nil, false, result.Err
 		}
 		data := result.Data.(*model.PostList)
 		posts.Extend(data)
+
+		if len(data.Order) >= limit {
+			truncated = true
+		}
 	}
 
 	posts.SortByCreateAt()
-	return posts, nil
+
+	if len(posts.Order) <= maxResults {
+		return posts, truncated, nil
+	}
+
+	for _, postId := range posts.Order[maxResults:] {
+		delete(posts.Posts, postId)
+	}
+	posts.Order = posts.Order[:maxResults]
+
+	return posts, true, nil
 }
 
 func (a *App) convertChannelNamesToChannelIds(channels []string, userId string, teamId string, includeDeletedChannels bool) []string {
@@ -976,14 +1016,16 @@ func (a *App) SearchPostsInTeam(teamId string, paramsList []*model.SearchParams)
 	if !*a.Config().ServiceSettings.EnablePostSearch {
 		return nil, model.NewAppError("SearchPostsInTeam", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v", teamId), http.StatusNotImplemented)
 	}
-	return a.searchPostsInTeam(teamId, "", paramsList, func(params *model.SearchParams) {
+	posts, _, err := a.searchPostsInTeam(teamId, "", paramsList, func(params *model.SearchParams) {
 		params.SearchWithoutUserId = true
 	})
+	return posts, err
 }
 
-func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+// esSearchParamsForUser converts the channel names and usernames in the given search params to the
+// ids used by Elasticsearch, leaving out searches for everything.
+func (a *App) esSearchParamsForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool) []*model.SearchParams {
 	finalParamsList := []*model.SearchParams{}
-	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
 
 	for _, params := range paramsList {
 		params.OrTerms = isOrSearch
@@ -1001,11 +1043,26 @@ func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userI
 		}
 	}
 
+	return finalParamsList
+}
+
+func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+	finalParamsList := a.esSearchParamsForUser(paramsList, userId, teamId, isOrSearch, includeDeletedChannels)
+
 	// If the processed search params are empty, return empty search results.
 	if len(finalParamsList) == 0 {
 		return model.MakePostSearchResults(model.NewPostList(), nil), nil
 	}
 
+	// Results past SearchSettings.MaxSearchResults are never returned, no matter the page.
+	remaining := *a.Config().SearchSettings.MaxSearchResults - page*perPage
+	if remaining <= 0 {
+		results := model.MakePostSearchResults(model.NewPostList(), nil)
+		results.Truncated = true
+		return results, nil
+	}
+
 	// We only allow the user to search in channels they are a member of.
 	userChannels, err := a.GetChannelsForUser(teamId, userId, includeDeleted)
 	if err != nil {
@@ -1018,6 +1075,12 @@ func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userI
 		return nil, err
 	}
 
+	truncated := false
+	if len(postIds) > remaining {
+		postIds = postIds[:remaining]
+		truncated = true
+	}
+
 	// Get the posts
 	postList := model.NewPostList()
 	if len(postIds) > 0 {
@@ -1033,7 +1096,78 @@ func (a *App) esSearchPostsInTeamForUser(paramsList []*model.SearchParams, userI
 		}
 	}
 
-	return model.MakePostSearchResults(postList, matches), nil
+	results := model.MakePostSearchResults(postList, matches)
+	results.Truncated = truncated
+	return results, nil
+}
+
+// GetRelatedPostsForUser returns up to limit posts from the same team whose messages are similar to
+// the given post, ranked by similarity and restricted to the channels the user is a member of.
+func (a *App) GetRelatedPostsForUser(postId, userId string, limit int) ([]*model.Post, *model.AppError) {
+	if !*a.Config().SearchSettings.EnableRelatedPosts || !a.IsESSearchEnabled() {
+		return nil, model.NewAppError("GetRelatedPostsForUser", "app.post.get_related_posts.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The candidates are cached per post rather than per user, so more are requested than will be
+	// returned to leave room for the ones filtered out by channel membership below.
+	var relatedPostIds []string
+	if cached, ok := a.Srv.relatedPostIdsCache.Get(post.Id); ok {
+		relatedPostIds = cached.([]string)
+	} else {
+		relatedPostIds, err = a.Elasticsearch.SearchRelatedPosts(post, channel.TeamId, RELATED_POSTS_CANDIDATES)
+		if err != nil {
+			return nil, err
+		}
+		a.Srv.relatedPostIdsCache.AddWithExpiresInSecs(post.Id, relatedPostIds, int64(RELATED_POST_IDS_CACHE_TTL.Seconds()))
+	}
+
+	if len(relatedPostIds) == 0 {
+		return []*model.Post{}, nil
+	}
+
+	members, err := a.Srv.Store.Channel().GetAllChannelMembersForUser(userId, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := a.Srv.Store.Post().GetPostsByIds(relatedPostIds)
+	if err != nil {
+		return nil, err
+	}
+
+	postsById := make(map[string]*model.Post, len(posts))
+	for _, p := range posts {
+		postsById[p.Id] = p
+	}
+
+	related := []*model.Post{}
+	for _, id := range relatedPostIds {
+		if len(related) >= limit {
+			break
+		}
+
+		p, ok := postsById[id]
+		if !ok || p.Id == post.Id || p.DeleteAt != 0 {
+			continue
+		}
+		if _, isMember := members[p.ChannelId]; !isMember {
+			continue
+		}
+
+		related = append(related, a.PreparePostForClient(p, false, false))
+	}
+
+	return related, nil
 }
 
 func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
@@ -1058,39 +1192,119 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 			return model.MakePostSearchResults(model.NewPostList(), nil), nil
 		}
 
-		includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
-		posts, err := a.searchPostsInTeam(teamId, userId, paramsList, func(params *model.SearchParams) {
-			params.IncludeDeletedChannels = includeDeleted
-			params.OrTerms = isOrSearch
-			for idx, channelName := range params.InChannels {
-				if strings.HasPrefix(channelName, "@") {
-					channel, err := a.parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId, includeDeletedChannels)
-					if err != nil {
-						mlog.Error("error getting channel_id by name from in filter", mlog.Err(err))
-						continue
-					}
-					params.InChannels[idx] = channel.Name
+		posts, truncated, err := a.searchPostsInTeam(teamId, userId, paramsList, a.dbSearchParamsModifierForUser(userId, teamId, isOrSearch, includeDeletedChannels))
+		if err != nil {
+			return nil, err
+		}
+
+		postSearchResults = model.MakePostSearchResults(posts, nil)
+		postSearchResults.Truncated = truncated
+	}
+
+	return postSearchResults, nil
+}
+
+// dbSearchParamsModifierForUser returns a function preparing search params for a database search
+// on behalf of the given user.
+func (a *App) dbSearchParamsModifierForUser(userId, teamId string, isOrSearch, includeDeletedChannels bool) func(*model.SearchParams) {
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+
+	return func(params *model.SearchParams) {
+		params.IncludeDeletedChannels = includeDeleted
+		params.OrTerms = isOrSearch
+		for idx, channelName := range params.InChannels {
+			if strings.HasPrefix(channelName, "@") {
+				channel, err := a.parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId, includeDeletedChannels)
+				if err != nil {
+					mlog.Error("error getting channel_id by name from in filter", mlog.Err(err))
+					continue
 				}
+				params.InChannels[idx] = channel.Name
 			}
-			for idx, channelName := range params.ExcludedChannels {
-				if strings.HasPrefix(channelName, "@") {
-					channel, err := a.parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId, includeDeletedChannels)
-					if err != nil {
-						mlog.Error("error getting channel_id by name from in filter", mlog.Err(err))
-						continue
-					}
-					params.ExcludedChannels[idx] = channel.Name
+		}
+		for idx, channelName := range params.ExcludedChannels {
+			if strings.HasPrefix(channelName, "@") {
+				channel, err := a.parseAndFetchChannelIdByNameFromInFilter(channelName, userId, teamId, includeDeletedChannels)
+				if err != nil {
+					mlog.Error("error getting channel_id by name from in filter", mlog.Err(err))
+					continue
 				}
+				params.ExcludedChannels[idx] = channel.Name
 			}
-		})
+		}
+	}
+}
+
+// CountPostsInTeamForUser returns the number of posts the user would find by searching for the
+// given terms, without the limit of SearchSettings.MaxSearchResults. Posts matching hashtags are
+// only counted up to SEARCH_COUNT_MAX_HASHTAG_MATCHES. When the terms combine hashtags with other
+// words, a post matching both searches is counted once.
+func (a *App) CountPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int) (int64, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return 0, model.NewAppError("CountPostsInTeamForUser", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamId, userId), http.StatusNotImplemented)
+	}
+
+	if a.IsESSearchEnabled() {
+		paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
+		count, err := a.esCountPostsInTeamForUser(paramsList, userId, teamId, isOrSearch, includeDeletedChannels)
+		if err == nil {
+			return count, nil
+		}
+		mlog.Error("Encountered error on CountPostsInTeamForUser through Elasticsearch. Falling back to default search.", mlog.Err(err))
+	}
+
+	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
+	modifierFun := a.dbSearchParamsModifierForUser(userId, teamId, isOrSearch, includeDeletedChannels)
+
+	// Hashtags have to be matched exactly outside the database, so the matching posts are loaded and
+	// left out of the counts of the other searches.
+	hashtagPostIds := []string{}
+	countParamsList := []*model.SearchParams{}
+	for _, params := range paramsList {
+		// Don't allow users to search for everything.
+		if params.Terms == "*" {
+			continue
+		}
+		modifierFun(params)
+
+		if !params.IsHashtag {
+			countParamsList = append(countParamsList, params)
+			continue
+		}
+
+		ids, err := a.Srv.Store.Post().SearchPostIds(teamId, userId, params, SEARCH_COUNT_MAX_HASHTAG_MATCHES)
 		if err != nil {
-			return nil, err
+			return 0, err
+		}
+		hashtagPostIds = append(hashtagPostIds, ids...)
+	}
+
+	count := int64(len(hashtagPostIds))
+	for _, params := range countParamsList {
+		paramsCount, err := a.Srv.Store.Post().SearchCount(teamId, userId, params, hashtagPostIds)
+		if err != nil {
+			return 0, err
 		}
+		count += paramsCount
+	}
 
-		postSearchResults = model.MakePostSearchResults(posts, nil)
+	return count, nil
+}
+
+func (a *App) esCountPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, isOrSearch, includeDeletedChannels bool) (int64, *model.AppError) {
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+	finalParamsList := a.esSearchParamsForUser(paramsList, userId, teamId, isOrSearch, includeDeletedChannels)
+	if len(finalParamsList) == 0 {
+		return 0, nil
 	}
 
-	return postSearchResults, nil
+	// We only allow the user to search in channels they are a member of.
+	userChannels, err := a.GetChannelsForUser(teamId, userId, includeDeleted)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.Elasticsearch.SearchPostsCount(userChannels, finalParamsList)
 }
 
 func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo, *model.AppError) {
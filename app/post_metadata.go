@@ -5,6 +5,7 @@ package app
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"io"
 	"net/http"
@@ -18,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost-server/utils"
 	"github.com/mattermost/mattermost-server/utils/imgutils"
 	"github.com/mattermost/mattermost-server/utils/markdown"
+	"golang.org/x/net/html"
 )
 
 const LINK_CACHE_SIZE = 10000
@@ -351,6 +353,11 @@ func getImagesInMessageAttachments(post *model.Post) []string {
 func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool) (*opengraph.OpenGraph, *model.PostImage, error) {
 	requestURL = resolveMetadataURL(requestURL, a.GetSiteURL())
 
+	blockedDomains := a.Config().ServiceSettings.LinkPreviewBlockedDomains
+	if parsed, err := url.Parse(requestURL); err == nil && isLinkPreviewDomainBlocked(parsed.Hostname(), blockedDomains) {
+		return nil, nil, nil
+	}
+
 	timestamp = model.FloorToNearestHour(timestamp)
 
 	// Check cache
@@ -377,6 +384,7 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 
 	var body io.ReadCloser
 	var contentType string
+	var noIndex bool
 
 	if (request.URL.Scheme+"://"+request.URL.Host) == a.GetSiteURL() && request.URL.Path == "/api/v4/image" {
 		// /api/v4/image requires authentication, so bypass the API by hitting the proxy directly
@@ -385,8 +393,21 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 		request.Header.Add("Accept", "image/*")
 		request.Header.Add("Accept", "text/html;q=0.8")
 
+		maxRedirects := *a.Config().ServiceSettings.LinkPreviewMaxRedirects
+
 		client := a.HTTPService.MakeClient(false)
 		client.Timeout = time.Duration(*a.Config().ExperimentalSettings.LinkMetadataTimeoutMilliseconds) * time.Millisecond
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			if isLinkPreviewDomainBlocked(req.URL.Hostname(), blockedDomains) {
+				return fmt.Errorf("redirected to blocked domain %s", req.URL.Hostname())
+			}
+
+			return nil
+		}
 
 		var res *http.Response
 		res, err = client.Do(request)
@@ -394,6 +415,7 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 		if res != nil {
 			body = res.Body
 			contentType = res.Header.Get("Content-Type")
+			noIndex = hasNoIndexRobotsHeader(res.Header)
 		}
 	}
 
@@ -401,9 +423,22 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 		defer body.Close()
 	}
 
+	var reader io.Reader = body
+	if err == nil && !noIndex && strings.HasPrefix(contentType, "text/html") {
+		noIndex, reader = hasNoIndexRobotsMetaTag(body)
+	}
+
+	if noIndex {
+		// The publisher doesn't want the page indexed, so don't keep a preview of it beyond the in-memory
+		// cache that stops us from requesting the page again for every post.
+		cacheLinkMetadata(requestURL, timestamp, nil, nil)
+
+		return nil, nil, nil
+	}
+
 	if err == nil {
 		// Parse the data
-		og, image, err = a.parseLinkMetadata(requestURL, body, contentType)
+		og, image, err = a.parseLinkMetadata(requestURL, reader, contentType)
 	}
 	og = model.TruncateOpenGraph(og) // remove unwanted length of texts
 
@@ -415,6 +450,84 @@ func (a *App) getLinkMetadata(requestURL string, timestamp int64, isNewPost bool
 	return og, image, err
 }
 
+// isLinkPreviewDomainBlocked returns true if host is one of the blocked domains or a subdomain of one.
+func isLinkPreviewDomainBlocked(host string, blockedDomains []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	for _, domain := range blockedDomains {
+		domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), "."))
+		if domain == "" {
+			continue
+		}
+
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasNoIndexRobotsHeader returns true if the X-Robots-Tag headers of a response contain the noindex
+// directive.
+func hasNoIndexRobotsHeader(header http.Header) bool {
+	for _, value := range header[http.CanonicalHeaderKey("X-Robots-Tag")] {
+		if strings.Contains(strings.ToLower(value), "noindex") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasNoIndexRobotsMetaTag returns true if the head of an HTML document contains a robots meta tag with
+// the noindex directive. Since this consumes the start of the body, it also returns a reader for the
+// whole document.
+func hasNoIndexRobotsMetaTag(body io.Reader) (bool, io.Reader) {
+	buf := &bytes.Buffer{}
+	tokenizer := html.NewTokenizer(io.TeeReader(io.LimitReader(body, MaxOpenGraphResponseSize), buf))
+
+	noIndex := false
+	for !noIndex {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		name, hasAttr := tokenizer.TagName()
+		if tokenType == html.EndTagToken && string(name) == "head" {
+			break
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+		if string(name) == "body" {
+			break
+		}
+		if string(name) != "meta" || !hasAttr {
+			continue
+		}
+
+		var metaName, content string
+		for {
+			key, value, more := tokenizer.TagAttr()
+			switch strings.ToLower(string(key)) {
+			case "name":
+				metaName = strings.ToLower(string(value))
+			case "content":
+				content = strings.ToLower(string(value))
+			}
+			if !more {
+				break
+			}
+		}
+
+		noIndex = metaName == "robots" && strings.Contains(content, "noindex")
+	}
+
+	return noIndex, io.MultiReader(buf, body)
+}
+
 // resolveMetadataURL resolves a given URL relative to the server's site URL.
 func resolveMetadataURL(requestURL string, siteURL string) string {
 	base, err := url.Parse(siteURL)
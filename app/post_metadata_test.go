@@ -9,6 +9,7 @@ import (
 	"image"
 	"image/png"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -1499,6 +1500,42 @@ func TestGetLinkMetadata(t *testing.T) {
 			writeImage(int(height), int(width))
 		} else if strings.HasPrefix(r.URL.Path, "/opengraph") {
 			writeHTML(params["title"][0])
+		} else if strings.HasPrefix(r.URL.Path, "/noindex-meta") {
+			w.Header().Set("Content-Type", "text/html")
+
+			w.Write([]byte(`
+				<html prefix="og:http://ogp.me/ns#">
+				<head>
+				<meta name="ROBOTS" content="NOARCHIVE, NOINDEX" />
+				<meta property="og:title" content="noindex" />
+				</head>
+				<body>
+				</body>
+				</html>`))
+		} else if strings.HasPrefix(r.URL.Path, "/noindex-header") {
+			w.Header().Set("X-Robots-Tag", "googlebot: noindex, nofollow")
+			writeHTML("noindex")
+		} else if strings.HasPrefix(r.URL.Path, "/index-body") {
+			w.Header().Set("Content-Type", "text/html")
+
+			// Robots meta tags are only read from the head
+			w.Write([]byte(`
+				<html prefix="og:http://ogp.me/ns#">
+				<head>
+				<meta property="og:title" content="indexed" />
+				</head>
+				<body>
+				<meta name="robots" content="noindex" />
+				</body>
+				</html>`))
+		} else if strings.HasPrefix(r.URL.Path, "/redirect") {
+			count, _ := strconv.Atoi(params.Get("count"))
+			if count == 0 {
+				writeHTML("redirected")
+				return
+			}
+
+			http.Redirect(w, r, "/redirect?count="+strconv.Itoa(count-1)+"&name="+params.Get("name"), http.StatusFound)
 		} else if strings.HasPrefix(r.URL.Path, "/json") {
 			w.Header().Set("Content-Type", "application/json")
 
@@ -1996,6 +2033,128 @@ func TestGetLinkMetadata(t *testing.T) {
 		assert.NotNil(t, img)
 		assert.Nil(t, err)
 	})
+
+	t.Run("should not store metadata for pages with a noindex robots meta tag", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		requestURL := server.URL + "/noindex-meta?name=" + t.Name()
+		timestamp := int64(1547510400000)
+
+		og, img, err := th.App.getLinkMetadata(requestURL, timestamp, false)
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		assert.Nil(t, err)
+
+		_, _, ok := th.App.getLinkMetadataFromDatabase(requestURL, timestamp)
+		assert.False(t, ok, "data should not exist in database")
+	})
+
+	t.Run("should not store metadata for pages with a noindex X-Robots-Tag header", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		requestURL := server.URL + "/noindex-header?name=" + t.Name()
+		timestamp := int64(1547510400000)
+
+		og, img, err := th.App.getLinkMetadata(requestURL, timestamp, false)
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		assert.Nil(t, err)
+
+		_, _, ok := th.App.getLinkMetadataFromDatabase(requestURL, timestamp)
+		assert.False(t, ok, "data should not exist in database")
+	})
+
+	t.Run("should ignore robots meta tags outside of the head", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		requestURL := server.URL + "/index-body?name=" + t.Name()
+		timestamp := int64(1547510400000)
+
+		og, img, err := th.App.getLinkMetadata(requestURL, timestamp, false)
+		require.NotNil(t, og)
+		assert.Nil(t, img)
+		assert.Nil(t, err)
+		assert.Equal(t, "indexed", og.Title)
+	})
+
+	t.Run("should follow up to the maximum number of redirects", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.LinkPreviewMaxRedirects = 2 })
+
+		og, _, err := th.App.getLinkMetadata(server.URL+"/redirect?count=2&name="+t.Name(), 1547510400000, false)
+		require.Nil(t, err)
+		require.NotNil(t, og)
+		assert.Equal(t, "redirected", og.Title)
+
+		og, _, err = th.App.getLinkMetadata(server.URL+"/redirect?count=3&name="+t.Name(), 1547510400000, false)
+		assert.NotNil(t, err)
+		assert.Nil(t, og)
+	})
+
+	t.Run("should not fetch metadata for blocked domains", func(t *testing.T) {
+		th := setup()
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { cfg.ServiceSettings.LinkPreviewBlockedDomains = []string{"127.0.0.1"} })
+
+		requestURL := server.URL + "/opengraph?title=blocked&name=" + t.Name()
+		timestamp := int64(1547510400000)
+
+		og, img, err := th.App.getLinkMetadata(requestURL, timestamp, false)
+		assert.Nil(t, og)
+		assert.Nil(t, img)
+		assert.Nil(t, err)
+
+		_, _, ok := th.App.getLinkMetadataFromDatabase(requestURL, timestamp)
+		assert.False(t, ok, "data should not exist in database")
+	})
+}
+
+func TestIsLinkPreviewDomainBlocked(t *testing.T) {
+	blockedDomains := []string{"example.com", " .Slow.Example.org ", ""}
+
+	for host, expected := range map[string]bool{
+		"example.com":          true,
+		"EXAMPLE.com.":         true,
+		"wiki.example.com":     true,
+		"notexample.com":       false,
+		"example.com.evil.net": false,
+		"slow.example.org":     true,
+		"a.slow.example.org":   true,
+		"example.org":          false,
+		"":                     false,
+	} {
+		assert.Equal(t, expected, isLinkPreviewDomainBlocked(host, blockedDomains), host)
+	}
+}
+
+func TestHasNoIndexRobotsMetaTag(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		HTML     string
+		Expected bool
+	}{
+		"noindex":             {`<html><head><meta name="robots" content="noindex"></head></html>`, true},
+		"noindex with others": {`<html><head><meta content="nofollow,noindex" name="Robots" /></head></html>`, true},
+		"index":               {`<html><head><meta name="robots" content="index, follow"></head></html>`, false},
+		"other meta tag":      {`<html><head><meta name="description" content="noindex"></head></html>`, false},
+		"no head":             {`<p>noindex</p>`, false},
+		"empty":               {``, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			noIndex, reader := hasNoIndexRobotsMetaTag(strings.NewReader(testCase.HTML))
+			assert.Equal(t, testCase.Expected, noIndex)
+
+			// The whole document should still be readable
+			body, err := ioutil.ReadAll(reader)
+			require.Nil(t, err)
+			assert.Equal(t, testCase.HTML, string(body))
+		})
+	}
 }
 
 func TestResolveMetadataURL(t *testing.T) {
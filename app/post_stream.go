@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const POST_STREAM_QUEUE_SIZE = 256
+
+// PostStream receives the posts created in a channel for clients that can't use the websocket.
+// Posts is closed by the hub if the stream falls too far behind or the hub stops.
+type PostStream struct {
+	UserId    string
+	ChannelId string
+	Posts     chan *model.Post
+}
+
+// OpenPostStream registers a stream of the posts created in the given channel. Each user may only
+// have one stream open at a time, and must close it with ClosePostStream.
+func (a *App) OpenPostStream(userId, channelId string) (*PostStream, *model.AppError) {
+	if _, alreadyOpen := a.Srv.postStreamUsers.LoadOrStore(userId, true); alreadyOpen {
+		return nil, model.NewAppError("OpenPostStream", "app.post.open_post_stream.too_many.app_error", nil, "user_id="+userId, http.StatusTooManyRequests)
+	}
+
+	hub := a.GetHubForUserId(userId)
+	if hub == nil {
+		a.Srv.postStreamUsers.Delete(userId)
+		return nil, model.NewAppError("OpenPostStream", "app.post.open_post_stream.hub.app_error", nil, "user_id="+userId, http.StatusServiceUnavailable)
+	}
+
+	stream := &PostStream{
+		UserId:    userId,
+		ChannelId: channelId,
+		Posts:     make(chan *model.Post, POST_STREAM_QUEUE_SIZE),
+	}
+	hub.RegisterStream(stream)
+
+	return stream, nil
+}
+
+func (a *App) ClosePostStream(stream *PostStream) {
+	if hub := a.GetHubForUserId(stream.UserId); hub != nil {
+		hub.UnregisterStream(stream)
+	}
+
+	a.Srv.postStreamUsers.Delete(stream.UserId)
+}
+
+// isPostStreamChannelMember returns true if the user is still a member of the channel of their post
+// stream, using the same cached channel memberships as the websocket connections.
+func (a *App) isPostStreamChannelMember(userId, channelId string) bool {
+	members, err := a.Srv.Store.Channel().GetAllChannelMembersForUser(userId, true, false)
+	if err != nil {
+		mlog.Error("webhub.broadcast: failed to get channel members for post stream", mlog.String("user_id", userId), mlog.Err(err))
+		return false
+	}
+
+	_, ok := members[channelId]
+	return ok
+}
+
+// hubPostStreamIndex tracks the post streams registered with a hub by channel.
+type hubPostStreamIndex struct {
+	streamsByChannelId map[string]map[*PostStream]bool
+	isChannelMember    func(userId, channelId string) bool
+}
+
+func newHubPostStreamIndex(isChannelMember func(userId, channelId string) bool) *hubPostStreamIndex {
+	return &hubPostStreamIndex{
+		streamsByChannelId: make(map[string]map[*PostStream]bool),
+		isChannelMember:    isChannelMember,
+	}
+}
+
+func (i *hubPostStreamIndex) Add(stream *PostStream) {
+	if i.streamsByChannelId[stream.ChannelId] == nil {
+		i.streamsByChannelId[stream.ChannelId] = make(map[*PostStream]bool)
+	}
+	i.streamsByChannelId[stream.ChannelId][stream] = true
+}
+
+func (i *hubPostStreamIndex) Remove(stream *PostStream) {
+	delete(i.streamsByChannelId[stream.ChannelId], stream)
+	if len(i.streamsByChannelId[stream.ChannelId]) == 0 {
+		delete(i.streamsByChannelId, stream.ChannelId)
+	}
+}
+
+// Broadcast sends the post from a posted event to the streams for its channel. Streams that
+// can't keep up, or whose user has since left the channel, are closed.
+func (i *hubPostStreamIndex) Broadcast(msg *model.WebSocketEvent) {
+	if msg.Event != model.WEBSOCKET_EVENT_POSTED {
+		return
+	}
+
+	streams := i.streamsByChannelId[msg.Broadcast.ChannelId]
+	if len(streams) == 0 {
+		return
+	}
+
+	postJson, ok := msg.Data["post"].(string)
+	if !ok {
+		return
+	}
+
+	post := model.PostFromJson(strings.NewReader(postJson))
+	if post == nil {
+		return
+	}
+
+	for stream := range streams {
+		if msg.Broadcast.OmitUsers[stream.UserId] {
+			continue
+		}
+
+		if !i.isChannelMember(stream.UserId, stream.ChannelId) {
+			mlog.Debug("webhub.broadcast: closing post stream for user no longer in channel", mlog.String("user_id", stream.UserId), mlog.String("channel_id", stream.ChannelId))
+			close(stream.Posts)
+			i.Remove(stream)
+			continue
+		}
+
+		select {
+		case stream.Posts <- post:
+		default:
+			mlog.Error("webhub.broadcast: cannot send, closing post stream for user", mlog.String("user_id", stream.UserId))
+			close(stream.Posts)
+			i.Remove(stream)
+		}
+	}
+}
+
+func (i *hubPostStreamIndex) CloseAll() {
+	for _, streams := range i.streamsByChannelId {
+		for stream := range streams {
+			close(stream.Posts)
+		}
+	}
+	i.streamsByChannelId = make(map[string]map[*PostStream]bool)
+}
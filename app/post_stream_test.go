@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestHubPostStreamIndex(t *testing.T) {
+	channelId := model.NewId()
+	post := &model.Post{Id: model.NewId(), ChannelId: channelId, Message: "message"}
+
+	newPostedEvent := func(channelId string) *model.WebSocketEvent {
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POSTED, "", channelId, "", nil)
+		message.Add("post", post.ToJson())
+		return message
+	}
+
+	isChannelMember := func(userId, channelId string) bool { return true }
+
+	t.Run("sends posts to streams for the channel", func(t *testing.T) {
+		index := newHubPostStreamIndex(isChannelMember)
+		stream := &PostStream{UserId: model.NewId(), ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		otherStream := &PostStream{UserId: model.NewId(), ChannelId: model.NewId(), Posts: make(chan *model.Post, 1)}
+		index.Add(stream)
+		index.Add(otherStream)
+
+		index.Broadcast(newPostedEvent(channelId))
+
+		require.Len(t, stream.Posts, 1)
+		assert.Equal(t, post.Id, (<-stream.Posts).Id)
+		assert.Len(t, otherStream.Posts, 0)
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		index := newHubPostStreamIndex(isChannelMember)
+		stream := &PostStream{UserId: model.NewId(), ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		index.Add(stream)
+
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_POST_EDITED, "", channelId, "", nil)
+		message.Add("post", post.ToJson())
+		index.Broadcast(message)
+
+		assert.Len(t, stream.Posts, 0)
+	})
+
+	t.Run("closes streams that fall behind", func(t *testing.T) {
+		index := newHubPostStreamIndex(isChannelMember)
+		stream := &PostStream{UserId: model.NewId(), ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		index.Add(stream)
+
+		index.Broadcast(newPostedEvent(channelId))
+		index.Broadcast(newPostedEvent(channelId))
+
+		<-stream.Posts
+		_, ok := <-stream.Posts
+		assert.False(t, ok)
+		assert.Empty(t, index.streamsByChannelId)
+	})
+
+	t.Run("closes streams of users who left the channel", func(t *testing.T) {
+		leftUserId := model.NewId()
+		index := newHubPostStreamIndex(func(userId, channelId string) bool { return userId != leftUserId })
+		stream := &PostStream{UserId: model.NewId(), ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		leftStream := &PostStream{UserId: leftUserId, ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		index.Add(stream)
+		index.Add(leftStream)
+
+		index.Broadcast(newPostedEvent(channelId))
+
+		assert.Len(t, stream.Posts, 1)
+		_, ok := <-leftStream.Posts
+		assert.False(t, ok)
+		assert.Len(t, index.streamsByChannelId[channelId], 1)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		index := newHubPostStreamIndex(isChannelMember)
+		stream := &PostStream{UserId: model.NewId(), ChannelId: channelId, Posts: make(chan *model.Post, 1)}
+		index.Add(stream)
+		index.Remove(stream)
+
+		index.Broadcast(newPostedEvent(channelId))
+
+		assert.Len(t, stream.Posts, 0)
+		assert.Empty(t, index.streamsByChannelId)
+	})
+}
+
+func TestOpenPostStream(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	stream, err := th.App.OpenPostStream(th.BasicUser.Id, th.BasicChannel.Id)
+	require.Nil(t, err)
+
+	_, err = th.App.OpenPostStream(th.BasicUser.Id, th.BasicChannel.Id)
+	require.NotNil(t, err)
+	assert.Equal(t, "app.post.open_post_stream.too_many.app_error", err.Id)
+
+	th.App.ClosePostStream(stream)
+
+	stream, err = th.App.OpenPostStream(th.BasicUser.Id, th.BasicChannel.Id)
+	require.Nil(t, err)
+	th.App.ClosePostStream(stream)
+}
+
+func TestPostStreamStopsForRemovedMember(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	stream, err := th.App.OpenPostStream(th.BasicUser.Id, th.BasicChannel.Id)
+	require.Nil(t, err)
+	defer th.App.ClosePostStream(stream)
+
+	post := th.CreatePost(th.BasicChannel)
+	select {
+	case received := <-stream.Posts:
+		require.NotNil(t, received)
+		assert.Equal(t, post.Id, received.Id)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "post was not streamed")
+	}
+
+	err = th.App.RemoveUserFromChannel(th.BasicUser.Id, th.SystemAdminUser.Id, th.BasicChannel)
+	require.Nil(t, err)
+
+	th.CreatePost(th.BasicChannel)
+	select {
+	case received, ok := <-stream.Posts:
+		assert.False(t, ok, "post %v was streamed after the user left the channel", received)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "stream was not closed")
+	}
+}
@@ -908,4 +908,238 @@ func TestSearchPostsInTeamForUser(t *testing.T) {
 		assert.Equal(t, []string{}, results.Order)
 		es.AssertExpectations(t)
 	})
+
+	t.Run("should truncate results from database to MaxSearchResults", func(t *testing.T) {
+		th, posts := setup(t, false)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.MaxSearchResults = 3 })
+
+		results, err := th.App.SearchPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+
+		assert.Nil(t, err)
+		assert.True(t, results.Truncated)
+		assert.Equal(t, []string{
+			posts[6].Id,
+			posts[5].Id,
+			posts[4].Id,
+		}, results.Order)
+		assert.Len(t, results.Posts, 3)
+	})
+
+	t.Run("should not mark results from database as truncated when under MaxSearchResults", func(t *testing.T) {
+		th, _ := setup(t, false)
+		defer th.TearDown()
+
+		results, err := th.App.SearchPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+
+		assert.Nil(t, err)
+		assert.False(t, results.Truncated)
+		assert.Len(t, results.Order, 7)
+	})
+
+	t.Run("should truncate results from ElasticSearch to MaxSearchResults", func(t *testing.T) {
+		th, posts := setup(t, true)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.MaxSearchResults = 7 })
+
+		page := 1
+		es := &mocks.ElasticsearchInterface{}
+		es.On("SearchPosts", mock.Anything, mock.Anything, page, perPage).Return([]string{posts[1].Id, posts[0].Id, posts[2].Id}, nil, nil)
+		th.App.Elasticsearch = es
+
+		results, err := th.App.SearchPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, page, perPage)
+
+		assert.Nil(t, err)
+		assert.True(t, results.Truncated)
+		assert.Len(t, results.Order, 2)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("should not query ElasticSearch past MaxSearchResults", func(t *testing.T) {
+		th, _ := setup(t, true)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.MaxSearchResults = 5 })
+
+		es := &mocks.ElasticsearchInterface{}
+		th.App.Elasticsearch = es
+
+		results, err := th.App.SearchPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 1, perPage)
+
+		assert.Nil(t, err)
+		assert.True(t, results.Truncated)
+		assert.Empty(t, results.Order)
+		es.AssertNotCalled(t, "SearchPosts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCountPostsInTeamForUser(t *testing.T) {
+	searchTerm := "countTerm"
+
+	setup := func(t *testing.T, enableElasticsearch bool) *TestHelper {
+		th := Setup(t).InitBasic()
+
+		for i := 0; i < 3; i++ {
+			_, err := th.App.CreatePost(&model.Post{
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   searchTerm,
+			}, th.BasicChannel, false)
+			require.Nil(t, err)
+		}
+
+		if enableElasticsearch {
+			th.App.SetLicense(model.NewTestLicense("elastic_search"))
+
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ElasticsearchSettings.EnableIndexing = true
+				*cfg.ElasticsearchSettings.EnableSearching = true
+			})
+		} else {
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ElasticsearchSettings.EnableSearching = false
+			})
+		}
+
+		return th
+	}
+
+	t.Run("should count posts from database", func(t *testing.T) {
+		th := setup(t, false)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.MaxSearchResults = 2 })
+
+		count, err := th.App.CountPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0)
+		require.Nil(t, err)
+		assert.EqualValues(t, 3, count)
+	})
+
+	t.Run("should count posts matching both words and hashtags once", func(t *testing.T) {
+		th := setup(t, false)
+		defer th.TearDown()
+
+		_, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   searchTerm + " #countTag",
+		}, th.BasicChannel, false)
+		require.Nil(t, err)
+
+		count, err := th.App.CountPostsInTeamForUser(searchTerm+" #countTag", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0)
+		require.Nil(t, err)
+		assert.EqualValues(t, 4, count)
+	})
+
+	t.Run("should count posts from ElasticSearch", func(t *testing.T) {
+		th := setup(t, true)
+		defer th.TearDown()
+
+		es := &mocks.ElasticsearchInterface{}
+		es.On("SearchPostsCount", mock.Anything, mock.Anything).Return(int64(42), nil)
+		th.App.Elasticsearch = es
+
+		count, err := th.App.CountPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0)
+		require.Nil(t, err)
+		assert.EqualValues(t, 42, count)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("should fall back to database if ElasticSearch fails", func(t *testing.T) {
+		th := setup(t, true)
+		defer th.TearDown()
+
+		es := &mocks.ElasticsearchInterface{}
+		es.On("SearchPostsCount", mock.Anything, mock.Anything).Return(int64(0), &model.AppError{})
+		th.App.Elasticsearch = es
+
+		count, err := th.App.CountPostsInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0)
+		require.Nil(t, err)
+		assert.EqualValues(t, 3, count)
+		es.AssertExpectations(t)
+	})
+}
+
+func TestGetRelatedPostsForUser(t *testing.T) {
+	setup := func(t *testing.T) (*TestHelper, []*model.Post) {
+		th := Setup(t).InitBasic()
+
+		posts := make([]*model.Post, 4)
+		for i := 0; i < cap(posts); i++ {
+			post, err := th.App.CreatePost(&model.Post{
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "how do I reset my password",
+			}, th.BasicChannel, false)
+			require.Nil(t, err)
+
+			posts[i] = post
+		}
+
+		th.App.SetLicense(model.NewTestLicense("elastic_search"))
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ElasticsearchSettings.EnableIndexing = true
+			*cfg.ElasticsearchSettings.EnableSearching = true
+			*cfg.SearchSettings.EnableRelatedPosts = true
+		})
+
+		return th, posts
+	}
+
+	t.Run("should be disabled by default", func(t *testing.T) {
+		th, posts := setup(t)
+		defer th.TearDown()
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.SearchSettings.EnableRelatedPosts = false })
+
+		_, err := th.App.GetRelatedPostsForUser(posts[0].Id, th.BasicUser.Id, 5)
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+	})
+
+	t.Run("should return posts in the order given by ElasticSearch", func(t *testing.T) {
+		th, posts := setup(t)
+		defer th.TearDown()
+
+		es := &mocks.ElasticsearchInterface{}
+		es.On("SearchRelatedPosts", mock.Anything, th.BasicTeam.Id, RELATED_POSTS_CANDIDATES).Return([]string{posts[0].Id, posts[3].Id, posts[1].Id, posts[2].Id}, nil).Once()
+		th.App.Elasticsearch = es
+
+		related, err := th.App.GetRelatedPostsForUser(posts[0].Id, th.BasicUser.Id, 2)
+		require.Nil(t, err)
+		require.Len(t, related, 2)
+		assert.Equal(t, posts[3].Id, related[0].Id)
+		assert.Equal(t, posts[1].Id, related[1].Id)
+
+		// The second request is served from the cache.
+		related, err = th.App.GetRelatedPostsForUser(posts[0].Id, th.BasicUser.Id, 5)
+		require.Nil(t, err)
+		assert.Len(t, related, 3)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("should omit posts from channels the user is not a member of", func(t *testing.T) {
+		th, posts := setup(t)
+		defer th.TearDown()
+
+		privateChannel := th.CreatePrivateChannel(th.BasicTeam)
+		privatePost, err := th.App.CreatePost(&model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: privateChannel.Id,
+			Message:   "how do I reset my password",
+		}, privateChannel, false)
+		require.Nil(t, err)
+		th.AddUserToChannel(th.BasicUser2, th.BasicChannel)
+
+		es := &mocks.ElasticsearchInterface{}
+		es.On("SearchRelatedPosts", mock.Anything, th.BasicTeam.Id, RELATED_POSTS_CANDIDATES).Return([]string{privatePost.Id, posts[1].Id}, nil)
+		th.App.Elasticsearch = es
+
+		related, err := th.App.GetRelatedPostsForUser(posts[0].Id, th.BasicUser2.Id, 5)
+		require.Nil(t, err)
+		require.Len(t, related, 1)
+		assert.Equal(t, posts[1].Id, related[0].Id)
+	})
 }
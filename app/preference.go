@@ -15,7 +15,13 @@ func (a *App) GetPreferencesForUser(userId string) (model.Preferences, *model.Ap
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
 	}
-	return preferences, nil
+
+	flagged, err := a.getSavedPostsAsFlaggedPreferences(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(withoutSavedPostPreferences(preferences), flagged...), nil
 }
 
 func (a *App) GetPreferenceByCategoryForUser(userId string, category string) (model.Preferences, *model.AppError) {
@@ -24,6 +30,15 @@ func (a *App) GetPreferenceByCategoryForUser(userId string, category string) (mo
 		err.StatusCode = http.StatusBadRequest
 		return nil, err
 	}
+
+	if category == model.PREFERENCE_CATEGORY_FLAGGED_POST {
+		flagged, err := a.getSavedPostsAsFlaggedPreferences(userId)
+		if err != nil {
+			err.StatusCode = http.StatusBadRequest
+			return nil, err
+		}
+		preferences = append(withoutSavedPostPreferences(preferences), flagged...)
+	}
 	if len(preferences) == 0 {
 		err := model.NewAppError("getPreferenceCategory", "api.preference.preferences_category.get.app_error", nil, "", http.StatusNotFound)
 		return nil, err
@@ -32,6 +47,16 @@ func (a *App) GetPreferenceByCategoryForUser(userId string, category string) (mo
 }
 
 func (a *App) GetPreferenceByCategoryAndNameForUser(userId string, category string, preferenceName string) (*model.Preference, *model.AppError) {
+	if isSavedPostPreference(category, preferenceName) {
+		savedPost, err := a.Srv.Store.SavedPost().Get(userId, preferenceName)
+		if err != nil {
+			err.StatusCode = http.StatusBadRequest
+			return nil, err
+		}
+		preference := savedPostToFlaggedPreference(savedPost)
+		return &preference, nil
+	}
+
 	res, err := a.Srv.Store.Preference().Get(userId, category, preferenceName)
 	if err != nil {
 		err.StatusCode = http.StatusBadRequest
@@ -48,9 +73,25 @@ func (a *App) UpdatePreferences(userId string, preferences model.Preferences) *m
 		}
 	}
 
-	if err := a.Srv.Store.Preference().Save(&preferences); err != nil {
-		err.StatusCode = http.StatusBadRequest
-		return err
+	// Flagged posts are stored as saved posts rather than as preferences.
+	var stored model.Preferences
+	for _, preference := range preferences {
+		if !isSavedPostPreference(preference.Category, preference.Name) {
+			stored = append(stored, preference)
+			continue
+		}
+
+		if _, err := a.AddSavedPost(userId, preference.Name); err != nil {
+			err.StatusCode = http.StatusBadRequest
+			return err
+		}
+	}
+
+	if len(stored) > 0 {
+		if err := a.Srv.Store.Preference().Save(&stored); err != nil {
+			err.StatusCode = http.StatusBadRequest
+			return err
+		}
 	}
 
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_PREFERENCES_CHANGED, "", "", userId, nil)
@@ -70,6 +111,15 @@ func (a *App) DeletePreferences(userId string, preferences model.Preferences) *m
 	}
 
 	for _, preference := range preferences {
+		// The flagged_post preference left behind by the saved posts migration, if any, is deleted
+		// along with the saved post.
+		if isSavedPostPreference(preference.Category, preference.Name) {
+			if err := a.RemoveSavedPost(userId, preference.Name); err != nil && err.StatusCode != http.StatusNotFound {
+				err.StatusCode = http.StatusBadRequest
+				return err
+			}
+		}
+
 		if err := a.Srv.Store.Preference().Delete(userId, preference.Category, preference.Name); err != nil {
 			err.StatusCode = http.StatusBadRequest
 			return err
@@ -82,3 +132,23 @@ func (a *App) DeletePreferences(userId string, preferences model.Preferences) *m
 
 	return nil
 }
+
+// isSavedPostPreference returns whether the preference flags a post, and so is stored as a saved post
+// rather than as a preference. Flagged post preferences with other names are stored as they were
+// before saved posts.
+func isSavedPostPreference(category, name string) bool {
+	return category == model.PREFERENCE_CATEGORY_FLAGGED_POST && model.IsValidId(name)
+}
+
+// withoutSavedPostPreferences leaves out the flagged_post preferences superseded by saved posts, which
+// the saved posts migration copies rather than moves.
+func withoutSavedPostPreferences(preferences model.Preferences) model.Preferences {
+	filtered := make(model.Preferences, 0, len(preferences))
+	for _, preference := range preferences {
+		if !isSavedPostPreference(preference.Category, preference.Name) {
+			filtered = append(filtered, preference)
+		}
+	}
+
+	return filtered
+}
@@ -51,6 +51,80 @@ func (a *App) SaveReactionForPost(reaction *model.Reaction) (*model.Reaction, *m
 	return reaction, nil
 }
 
+// SaveReactionsForPost saves the given reactions to a post at once. Every reaction must be by an
+// existing member of the post's channel. A single reaction_added event is sent for each distinct
+// emoji saved rather than for each reaction.
+func (a *App) SaveReactionsForPost(postId string, reactions []*model.Reaction) (*model.BulkReactionsResult, *model.AppError) {
+	post, err := a.GetSinglePost(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := a.GetChannel(post.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("SaveReactionsForPost", "api.reaction.save.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	townSquareIsReadOnly := a.License() != nil && *a.Config().TeamSettings.ExperimentalTownSquareIsReadOnly && channel.Name == model.DEFAULT_CHANNEL
+
+	checked := make(map[string]bool)
+	for _, reaction := range reactions {
+		if checked[reaction.UserId] {
+			continue
+		}
+		checked[reaction.UserId] = true
+
+		user, err := a.Srv.Store.User().Get(reaction.UserId)
+		if err != nil {
+			if err.StatusCode == http.StatusNotFound {
+				return nil, model.NewAppError("SaveReactionsForPost", "api.reaction.save_bulk_reactions.unknown_user.app_error", map[string]interface{}{"UserId": reaction.UserId}, err.Error(), http.StatusBadRequest)
+			}
+			return nil, err
+		}
+
+		if _, err := a.Srv.Store.Channel().GetMember(channel.Id, user.Id); err != nil {
+			if err.StatusCode == http.StatusNotFound {
+				return nil, model.NewAppError("SaveReactionsForPost", "api.reaction.save_bulk_reactions.not_member.app_error", map[string]interface{}{"UserId": user.Id}, err.Error(), http.StatusBadRequest)
+			}
+			return nil, err
+		}
+
+		if townSquareIsReadOnly && !a.RolesGrantPermission(user.GetRoles(), model.PERMISSION_MANAGE_SYSTEM.Id) {
+			return nil, model.NewAppError("SaveReactionsForPost", "api.reaction.town_square_read_only", nil, "", http.StatusForbidden)
+		}
+	}
+
+	for _, reaction := range reactions {
+		reaction.PostId = post.Id
+	}
+
+	result, err := a.Srv.Store.Reaction().SaveMultiple(reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	// The post is always modified since the UpdateAt always changes
+	a.InvalidateCacheForChannelPosts(post.ChannelId)
+
+	a.Srv.Go(func() {
+		sent := make(map[string]bool)
+		for _, reaction := range result.Saved {
+			if sent[reaction.EmojiName] {
+				continue
+			}
+			sent[reaction.EmojiName] = true
+
+			a.sendReactionEvent(model.WEBSOCKET_EVENT_REACTION_ADDED, reaction, post, true)
+		}
+	})
+
+	return result, nil
+}
+
 func (a *App) GetReactionsForPost(postId string) ([]*model.Reaction, *model.AppError) {
 	return a.Srv.Store.Reaction().GetForPost(postId, true)
 }
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const SAVED_POSTS_PAGE_SIZE = 1000
+
+// AddSavedPost saves a post for later on behalf of a user. Saving a post that is already saved
+// returns the existing saved post.
+func (a *App) AddSavedPost(userId, postId string) (*model.SavedPost, *model.AppError) {
+	savedPost, created, err := a.saveSavedPost(userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_SAVED_POST_ADDED, "", "", userId, nil)
+		message.Add("saved_post", savedPost.ToJson())
+		a.Publish(message)
+	}
+
+	return savedPost, nil
+}
+
+// saveSavedPost saves a post for a user unless it already is, and reports whether it was saved now.
+func (a *App) saveSavedPost(userId, postId string) (*model.SavedPost, bool, *model.AppError) {
+	if savedPost, err := a.Srv.Store.SavedPost().Get(userId, postId); err == nil {
+		return savedPost, false, nil
+	} else if err.StatusCode != http.StatusNotFound {
+		return nil, false, err
+	}
+
+	savedPost, err := a.Srv.Store.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: postId})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return savedPost, true, nil
+}
+
+// RemoveSavedPost removes a post from the posts a user has saved for later.
+func (a *App) RemoveSavedPost(userId, postId string) *model.AppError {
+	if _, err := a.Srv.Store.SavedPost().Get(userId, postId); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.SavedPost().Delete(userId, postId); err != nil {
+		return err
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_SAVED_POST_REMOVED, "", "", userId, nil)
+	message.Add("post_id", postId)
+	a.Publish(message)
+
+	return nil
+}
+
+// GetSavedPosts returns a page of the posts saved by a user, most recently saved first.
+func (a *App) GetSavedPosts(userId string, page, perPage int) ([]*model.SavedPost, *model.AppError) {
+	return a.Srv.Store.SavedPost().GetForUser(userId, page*perPage, perPage)
+}
+
+// getSavedPostsAsFlaggedPreferences returns all the posts saved by a user as the flagged_post
+// preferences that were used to store them before SavedPosts, for clients that still read those.
+func (a *App) getSavedPostsAsFlaggedPreferences(userId string) (model.Preferences, *model.AppError) {
+	var preferences model.Preferences
+	for page := 0; ; page++ {
+		savedPosts, err := a.GetSavedPosts(userId, page, SAVED_POSTS_PAGE_SIZE)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, savedPost := range savedPosts {
+			preferences = append(preferences, savedPostToFlaggedPreference(savedPost))
+		}
+
+		if len(savedPosts) < SAVED_POSTS_PAGE_SIZE {
+			return preferences, nil
+		}
+	}
+}
+
+func savedPostToFlaggedPreference(savedPost *model.SavedPost) model.Preference {
+	return model.Preference{
+		UserId:   savedPost.UserId,
+		Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
+		Name:     savedPost.PostId,
+		Value:    "true",
+	}
+}
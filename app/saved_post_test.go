@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestSavedPostsAsFlaggedPreferences(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	userId := th.BasicUser.Id
+	post := th.CreatePost(th.BasicChannel)
+
+	flagged := model.Preference{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: post.Id, Value: "true"}
+	require.Nil(t, th.App.UpdatePreferences(userId, model.Preferences{flagged}))
+
+	_, err := th.App.Srv.Store.SavedPost().Get(userId, post.Id)
+	require.Nil(t, err)
+
+	stored, err := th.App.Srv.Store.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	require.Nil(t, err)
+	assert.Empty(t, stored)
+
+	preferences, err := th.App.GetPreferencesForUser(userId)
+	require.Nil(t, err)
+	assert.Contains(t, preferences, flagged)
+
+	preferences, err = th.App.GetPreferenceByCategoryForUser(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	require.Nil(t, err)
+	assert.Equal(t, model.Preferences{flagged}, preferences)
+
+	require.Nil(t, th.App.DeletePreferences(userId, model.Preferences{flagged}))
+	_, err = th.App.Srv.Store.SavedPost().Get(userId, post.Id)
+	assert.NotNil(t, err)
+
+	// Deleting a flag that isn't set isn't an error, as with other preferences.
+	assert.Nil(t, th.App.DeletePreferences(userId, model.Preferences{flagged}))
+
+	t.Run("name that isn't a post id", func(t *testing.T) {
+		other := model.Preference{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: "not a post id", Value: "true"}
+		require.Nil(t, th.App.UpdatePreferences(userId, model.Preferences{other}))
+
+		preferences, err := th.App.GetPreferenceByCategoryForUser(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+		require.Nil(t, err)
+		assert.Equal(t, model.Preferences{other}, preferences)
+
+		received, err := th.App.GetPreferenceByCategoryAndNameForUser(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST, other.Name)
+		require.Nil(t, err)
+		assert.Equal(t, &other, received)
+
+		require.Nil(t, th.App.DeletePreferences(userId, model.Preferences{other}))
+		_, err = th.App.GetPreferenceByCategoryForUser(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDeleteFlaggedPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+	_, err := th.App.AddSavedPost(th.BasicUser.Id, post.Id)
+	require.Nil(t, err)
+
+	th.App.DeleteFlaggedPosts(post.Id)
+
+	savedPosts, err := th.App.GetSavedPosts(th.BasicUser.Id, 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, savedPosts)
+}
+
+func TestDoSavedPostsMigration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreatePost(th.BasicChannel)
+
+	_, err := th.App.Srv.Store.System().PermanentDeleteByName(SAVED_POSTS_MIGRATION_KEY)
+	require.Nil(t, err)
+
+	preferences := model.Preferences{{UserId: th.BasicUser.Id, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: post.Id, Value: "true"}}
+	require.Nil(t, th.App.Srv.Store.Preference().Save(&preferences))
+
+	th.App.DoSavedPostsMigration()
+
+	_, err = th.App.Srv.Store.SavedPost().Get(th.BasicUser.Id, post.Id)
+	assert.Nil(t, err)
+
+	// The preference is kept, but is only returned once.
+	_, err = th.App.Srv.Store.Preference().Get(th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST, post.Id)
+	assert.Nil(t, err)
+
+	flagged, err := th.App.GetPreferenceByCategoryForUser(th.BasicUser.Id, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	require.Nil(t, err)
+	assert.Equal(t, preferences, flagged)
+
+	_, err = th.App.Srv.Store.System().GetByName(SAVED_POSTS_MIGRATION_KEY)
+	assert.Nil(t, err)
+}
@@ -59,6 +59,9 @@ type Server struct {
 	goroutineCount      int32
 	goroutineExitSignal chan struct{}
 
+	// capturingPerformanceProfile is set while a pprof profile is being captured.
+	capturingPerformanceProfile int32
+
 	PluginsEnvironment     *plugin.Environment
 	PluginConfigListenerId string
 	PluginsLock            sync.RWMutex
@@ -76,9 +79,13 @@ type Server struct {
 
 	clusterLeaderListeners sync.Map
 
+	// postStreamUsers holds the ids of users with an open post stream.
+	postStreamUsers sync.Map
+
 	licenseValue       atomic.Value
 	clientLicenseValue atomic.Value
 	licenseListeners   map[string]func()
+	licenseValidator   utils.LicenseValidatorIface
 
 	timezones *timezones.Timezones
 
@@ -87,6 +94,8 @@ type Server struct {
 	htmlTemplateWatcher     *utils.HTMLTemplateWatcher
 	sessionCache            *utils.Cache
 	seenPendingPostIdsCache *utils.Cache
+	relatedPostIdsCache     *utils.Cache
+	teamOnboardingCache     *utils.Cache
 	configListenerId        string
 	licenseListenerId       string
 	logListenerId           string
@@ -136,8 +145,11 @@ func NewServer(options ...Option) (*Server, error) {
 		goroutineExitSignal:     make(chan struct{}, 1),
 		RootRouter:              rootRouter,
 		licenseListeners:        map[string]func(){},
+		licenseValidator:        &utils.LicenseValidatorImpl{},
 		sessionCache:            utils.NewLru(model.SESSION_CACHE_SIZE),
 		seenPendingPostIdsCache: utils.NewLru(PENDING_POST_IDS_CACHE_SIZE),
+		relatedPostIdsCache:     utils.NewLru(RELATED_POST_IDS_CACHE_SIZE),
+		teamOnboardingCache:     utils.NewLru(TEAM_ONBOARDING_CACHE_SIZE),
 		clientConfig:            make(map[string]string),
 	}
 	for _, option := range options {
@@ -191,6 +203,10 @@ func NewServer(options ...Option) (*Server, error) {
 		return nil, err
 	}
 
+	if databaseStore, ok := s.configStore.(*config.DatabaseStore); ok && s.Metrics != nil {
+		databaseStore.WithReplicationMetrics(s.Metrics)
+	}
+
 	model.AppErrorInit(utils.T)
 
 	s.timezones = timezones.New()
@@ -217,7 +233,7 @@ func NewServer(options ...Option) (*Server, error) {
 	mlog.Info(fmt.Sprintf("Enterprise Enabled: %v", model.BuildEnterpriseReady))
 	pwd, _ := os.Getwd()
 	mlog.Info(fmt.Sprintf("Current working directory is %v", pwd))
-	mlog.Info("Loaded config", mlog.String("source", s.configStore.String()))
+	logStartupBanner(s.Config(), s.configStore, s.License())
 
 	s.checkPushNotificationServerUrl()
 
@@ -278,6 +294,21 @@ func NewServer(options ...Option) (*Server, error) {
 		s.Go(func() {
 			runCommandWebhookCleanupJob(s)
 		})
+		s.Go(func() {
+			runPushNotificationQueueJob(s)
+		})
+		s.Go(func() {
+			runChannelTopicHistoryCleanupJob(s)
+		})
+		s.Go(func() {
+			runPasswordExpiryJob(s)
+		})
+		s.Go(func() {
+			runDatabaseTransactionCheckJob(s)
+		})
+		s.Go(func() {
+			runEmailQueueMetricsJob(s)
+		})
 
 		if complianceI := s.Compliance; complianceI != nil {
 			complianceI.StartComplianceDailyJob()
@@ -637,6 +668,45 @@ func (s *Server) checkPushNotificationServerUrl() {
 	}
 }
 
+// logStartupBanner logs a summary of the settings most useful when diagnosing a misconfigured
+// server, including where the configuration was loaded from and how many settings are
+// overridden by environment variables.
+func logStartupBanner(cfg *model.Config, store config.Store, license *model.License) {
+	licenseTier := "none"
+	if license != nil {
+		licenseTier = license.SkuShortName
+		if licenseTier == "" {
+			licenseTier = "unknown"
+		}
+	}
+
+	mlog.Info("Loaded config",
+		mlog.String("source", store.String()),
+		mlog.String("site_url", *cfg.ServiceSettings.SiteURL),
+		mlog.String("database_driver", *cfg.SqlSettings.DriverName),
+		mlog.String("file_driver", *cfg.FileSettings.DriverName),
+		mlog.Bool("ldap_enabled", *cfg.LdapSettings.Enable),
+		mlog.Bool("cluster_enabled", *cfg.ClusterSettings.Enable),
+		mlog.String("license", licenseTier),
+		mlog.Int("environment_overrides", countEnvironmentOverrides(store.GetEnvironmentOverrides())),
+	)
+}
+
+// countEnvironmentOverrides returns the number of settings in the nested map of environment
+// overrides returned by config.Store.
+func countEnvironmentOverrides(overrides map[string]interface{}) int {
+	count := 0
+	for _, value := range overrides {
+		if nested, ok := value.(map[string]interface{}); ok {
+			count += countEnvironmentOverrides(nested)
+		} else {
+			count++
+		}
+	}
+
+	return count
+}
+
 func runSecurityJob(s *Server) {
 	doSecurity(s)
 	model.CreateRecurringTask("Security", func() {
@@ -672,6 +742,43 @@ func runSessionCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runPushNotificationQueueJob(s *Server) {
+	model.CreateRecurringTask("Push Notification Queue", func() {
+		doPushNotificationQueue(s)
+	}, time.Minute*5)
+}
+
+func runChannelTopicHistoryCleanupJob(s *Server) {
+	doChannelTopicHistoryCleanup(s)
+	model.CreateRecurringTask("Channel Topic History Cleanup", func() {
+		doChannelTopicHistoryCleanup(s)
+	}, time.Hour*24)
+}
+
+func runPasswordExpiryJob(s *Server) {
+	doPasswordExpiry(s)
+	model.CreateRecurringTask("Password Expiry", func() {
+		doPasswordExpiry(s)
+	}, time.Hour*24)
+}
+
+func runDatabaseTransactionCheckJob(s *Server) {
+	doDatabaseTransactionCheck(s)
+	model.CreateRecurringTask("Database Transaction Check", func() {
+		doDatabaseTransactionCheck(s)
+	}, time.Minute)
+}
+
+func runEmailQueueMetricsJob(s *Server) {
+	if s.Metrics == nil {
+		return
+	}
+
+	model.CreateRecurringTask("Email Queue Metrics", func() {
+		doEmailQueueMetrics(s)
+	}, 5*time.Second)
+}
+
 func doSecurity(s *Server) {
 	s.DoSecurityUpdateCheck()
 }
@@ -690,6 +797,16 @@ func doCommandWebhookCleanup(s *Server) {
 	s.Store.CommandWebhook().Cleanup()
 }
 
+// doPushNotificationQueue retries the queued push notifications on the cluster leader only, so that
+// each notification is sent once rather than once per node.
+func doPushNotificationQueue(s *Server) {
+	if !s.FakeApp().IsLeader() {
+		return
+	}
+
+	s.FakeApp().ProcessPushNotificationQueue()
+}
+
 const (
 	SESSIONS_CLEANUP_BATCH_SIZE = 1000
 )
@@ -698,6 +815,34 @@ func doSessionCleanup(s *Server) {
 	s.Store.Session().Cleanup(model.GetMillis(), SESSIONS_CLEANUP_BATCH_SIZE)
 }
 
+func doChannelTopicHistoryCleanup(s *Server) {
+	if err := s.FakeApp().PruneChannelTopicHistory(); err != nil {
+		mlog.Error("Failed to prune channel topic history", mlog.Err(err))
+	}
+}
+
+// doPasswordExpiry revokes the sessions of users with expired passwords on the cluster leader only,
+// so that the users aren't scanned once per node.
+func doPasswordExpiry(s *Server) {
+	if !s.FakeApp().IsLeader() {
+		return
+	}
+
+	if err := s.FakeApp().RevokeSessionsForExpiredPasswords(); err != nil {
+		mlog.Error("Failed to revoke sessions for expired passwords", mlog.Err(err))
+	}
+}
+
+func doDatabaseTransactionCheck(s *Server) {
+	if err := s.FakeApp().CheckLongRunningDatabaseTransactions(); err != nil {
+		mlog.Error("Failed to check for long running database transactions", mlog.Err(err))
+	}
+}
+
+func doEmailQueueMetrics(s *Server) {
+	s.Metrics.SetEmailQueueDepth(s.FakeApp().GetEmailQueueStats().QueueDepth)
+}
+
 func (s *Server) StartElasticsearch() {
 	s.Go(func() {
 		if err := s.Elasticsearch.Start(); err != nil {
@@ -101,6 +101,9 @@ func (s *Server) RunOldAppInitalization() error {
 		return errors.Wrap(err, "failed to parse SiteURL subpath")
 	}
 	s.FakeApp().Srv.Router = s.FakeApp().Srv.RootRouter.PathPrefix(subpath).Subrouter()
+	// The local marketplace must be registered ahead of the plugin routes, which would otherwise
+	// treat "marketplace" as a plugin id.
+	s.FakeApp().Srv.Router.HandleFunc(LOCAL_MARKETPLACE_ROUTE+"/api/v1/plugins", s.FakeApp().ServeLocalMarketplaceRequest).Methods("GET")
 	pluginsRoute := s.FakeApp().Srv.Router.PathPrefix("/plugins/{plugin_id:[A-Za-z0-9\\_\\-\\.]+}").Subrouter()
 	pluginsRoute.HandleFunc("", s.FakeApp().ServePluginRequest)
 	pluginsRoute.HandleFunc("/public/{public_file:.*}", s.FakeApp().ServePluginPublicRequest)
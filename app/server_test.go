@@ -284,3 +284,16 @@ func TestPanicLog(t *testing.T) {
 		t.Error("Panic was supposed to be logged")
 	}
 }
+
+func TestCountEnvironmentOverrides(t *testing.T) {
+	require.Equal(t, 0, countEnvironmentOverrides(nil))
+	require.Equal(t, 3, countEnvironmentOverrides(map[string]interface{}{
+		"ServiceSettings": map[string]interface{}{
+			"SiteURL":       true,
+			"ListenAddress": true,
+		},
+		"SqlSettings": map[string]interface{}{
+			"DataSource": true,
+		},
+	}))
+}
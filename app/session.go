@@ -93,6 +93,26 @@ func (a *App) GetSessions(userId string) ([]*model.Session, *model.AppError) {
 	return a.Srv.Store.Session().GetSessions(userId)
 }
 
+// GetSessionActivity returns the details of the user's active sessions, most recently used first.
+// Sessions backing personal access tokens aren't included since they aren't tied to a device.
+func (a *App) GetSessionActivity(userId string) ([]*model.SessionActivity, *model.AppError) {
+	sessions, err := a.GetSessions(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := []*model.SessionActivity{}
+	for _, session := range sessions {
+		if session.IsExpired() || session.Props[model.SESSION_PROP_TYPE] == model.SESSION_TYPE_USER_ACCESS_TOKEN {
+			continue
+		}
+
+		activity = append(activity, session.ToActivity())
+	}
+
+	return activity, nil
+}
+
 func (a *App) UpdateSessionsIsGuest(userId string, isGuest bool) {
 	sessions, err := a.Srv.Store.Session().GetSessions(userId)
 	if err != nil {
@@ -134,6 +154,28 @@ func (a *App) RevokeAllSessions(userId string) *model.AppError {
 	return nil
 }
 
+// RevokeSessionsForExpiredPasswords signs out the users whose password is older than
+// PasswordSettings.ExpiryDays so that they have to reset it before they can log in again.
+func (a *App) RevokeSessionsForExpiredPasswords() *model.AppError {
+	expiryDays := *a.Config().PasswordSettings.ExpiryDays
+	if expiryDays <= 0 {
+		return nil
+	}
+
+	userIds, err := a.Srv.Store.Session().GetUserIdsWithPasswordUpdatedBefore(model.GetMillis() - int64(expiryDays)*DAY_MILLISECONDS)
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range userIds {
+		if err := a.RevokeAllSessions(userId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RevokeSessionsFromAllUsers will go through all the sessions active
 // in the server and revoke them
 func (a *App) RevokeSessionsFromAllUsers() *model.AppError {
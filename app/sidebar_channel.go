@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetSidebarChannelOrder returns the order of the channels in the given user's sidebar on the given
+// team. Channels the user has left since saving the order are omitted.
+func (a *App) GetSidebarChannelOrder(userId string, teamId string) (*model.SidebarChannelOrder, *model.AppError) {
+	sidebarChannels, err := a.Srv.Store.SidebarChannel().GetForTeam(userId, teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &model.SidebarChannelOrder{
+		UserId: userId,
+		TeamId: teamId,
+		Order:  make([]string, 0, len(sidebarChannels)),
+	}
+	for _, sidebarChannel := range sidebarChannels {
+		order.Order = append(order.Order, sidebarChannel.ChannelId)
+	}
+
+	return order, nil
+}
+
+// UpdateSidebarChannelOrder saves the order of the channels in the given user's sidebar on the given
+// team and notifies the user's other sessions. Every channel must be one of the user's channels on
+// the team, including direct and group messages.
+func (a *App) UpdateSidebarChannelOrder(userId string, teamId string, channelIds []string) (*model.SidebarChannelOrder, *model.AppError) {
+	memberOf := map[string]bool{}
+	if len(channelIds) > 0 {
+		channels, err := a.GetChannelsForUser(teamId, userId, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, channel := range *channels {
+			memberOf[channel.Id] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(channelIds))
+	for _, channelId := range channelIds {
+		if seen[channelId] {
+			return nil, model.NewAppError("UpdateSidebarChannelOrder", "app.sidebar_channel.update_order.duplicate.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+		}
+		seen[channelId] = true
+
+		if !memberOf[channelId] {
+			return nil, model.NewAppError("UpdateSidebarChannelOrder", "app.sidebar_channel.update_order.not_member.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+		}
+	}
+
+	if err := a.Srv.Store.SidebarChannel().SaveOrder(userId, teamId, channelIds); err != nil {
+		return nil, err
+	}
+
+	order := &model.SidebarChannelOrder{
+		UserId: userId,
+		TeamId: teamId,
+		Order:  channelIds,
+	}
+
+	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_SIDEBAR_ORDER_UPDATED, teamId, "", userId, nil)
+	message.Add("order", model.ArrayToJson(channelIds))
+	a.Publish(message)
+
+	return order, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSidebarChannelOrder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	otherTeam := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, otherTeam)
+	otherTeamChannel := th.CreateChannel(otherTeam)
+	th.AddUserToChannel(th.BasicUser, otherTeamChannel)
+
+	t.Run("should save the order per team", func(t *testing.T) {
+		order, err := th.App.UpdateSidebarChannelOrder(th.BasicUser.Id, otherTeam.Id, []string{otherTeamChannel.Id})
+		require.Nil(t, err)
+		assert.Equal(t, []string{otherTeamChannel.Id}, order.Order)
+
+		order, err = th.App.GetSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id)
+		require.Nil(t, err)
+		assert.Empty(t, order.Order)
+	})
+
+	t.Run("should reject channels on other teams", func(t *testing.T) {
+		_, err := th.App.UpdateSidebarChannelOrder(th.BasicUser.Id, th.BasicTeam.Id, []string{th.BasicChannel.Id, otherTeamChannel.Id})
+		require.NotNil(t, err)
+		assert.Equal(t, "app.sidebar_channel.update_order.not_member.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
@@ -676,10 +676,19 @@ func SlackConvertPostsMarkup(posts map[string][]SlackPost) map[string][]SlackPos
 	return posts
 }
 
-func (a *App) SlackImport(fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
+// SlackImport imports the given Slack export into the team. Only one Slack import runs at a time
+// across the cluster; forceLock takes over the import lock if it has gone stale.
+func (a *App) SlackImport(fileData multipart.File, fileSize int64, teamID string, forceLock bool) (*model.AppError, *bytes.Buffer) {
 	// Create log file
 	log := bytes.NewBufferString(utils.T("api.slackimport.slack_import.log"))
 
+	releaseLock, appErr := a.AcquireImportLock(model.SYSTEM_SLACK_IMPORT_LOCK, forceLock)
+	if appErr != nil {
+		log.WriteString(appErr.Message)
+		return appErr, log
+	}
+	defer releaseLock()
+
 	zipreader, err := zip.NewReader(fileData, fileSize)
 	if err != nil || zipreader.File == nil {
 		log.WriteString(utils.T("api.slackimport.slack_import.zip.app_error"))
@@ -871,7 +880,7 @@ func (a *App) OldImportChannel(channel *model.Channel, sChannel SlackChannel, us
 		return sc
 	}
 
-	sc, err := a.Srv.Store.Channel().Save(channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
+	sc, err := a.Srv.Store.Channel().Save(channel, a.maxChannelsPerTeam())
 	if err != nil {
 		return nil
 	}
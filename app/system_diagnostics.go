@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	SYSTEM_DIAGNOSTICS_TIMEOUT = 10 * time.Second
+
+	// The share of SqlSettings.MaxOpenConns in use above which the connection pool is reported as
+	// at risk of being exhausted.
+	SYSTEM_DIAGNOSTICS_DB_CONNECTIONS_WARN_RATIO  = 0.75
+	SYSTEM_DIAGNOSTICS_DB_CONNECTIONS_ERROR_RATIO = 0.9
+
+	SYSTEM_DIAGNOSTICS_TLS_EXPIRY_WARN_DAYS  = 30
+	SYSTEM_DIAGNOSTICS_TLS_EXPIRY_ERROR_DAYS = 7
+)
+
+// systemDiagnosticCheck looks for one kind of misconfiguration and returns the status and a human
+// readable detail.
+type systemDiagnosticCheck func(ctx context.Context) (string, string)
+
+// RunSystemDiagnostics checks the server for common misconfigurations. The checks run concurrently
+// and those that don't complete within SYSTEM_DIAGNOSTICS_TIMEOUT are reported as errors without
+// waiting for them. None of them change the state of the server.
+func (a *App) RunSystemDiagnostics() []*model.SystemDiagnostic {
+	ctx, cancel := context.WithTimeout(context.Background(), SYSTEM_DIAGNOSTICS_TIMEOUT)
+	defer cancel()
+
+	checks := []struct {
+		name  string
+		check systemDiagnosticCheck
+	}{
+		{model.SYSTEM_DIAGNOSTIC_SITE_URL, a.diagnoseSiteURL},
+		{model.SYSTEM_DIAGNOSTIC_SMTP, a.diagnoseSmtp},
+		{model.SYSTEM_DIAGNOSTIC_FILE_STORAGE, a.diagnoseFileStorage},
+		{model.SYSTEM_DIAGNOSTIC_DATABASE_CONNECTIONS, a.diagnoseDatabaseConnections},
+		{model.SYSTEM_DIAGNOSTIC_TLS_CERTIFICATE, a.diagnoseTLSCertificate},
+	}
+
+	diagnostics := make([]*model.SystemDiagnostic, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, name string, check systemDiagnosticCheck) {
+			defer wg.Done()
+
+			diagnostics[i] = runSystemDiagnosticCheck(ctx, name, check)
+		}(i, check.name, check.check)
+	}
+	wg.Wait()
+
+	return diagnostics
+}
+
+func runSystemDiagnosticCheck(ctx context.Context, name string, check systemDiagnosticCheck) *model.SystemDiagnostic {
+	var status, detail string
+	if _, timedOut := runCheckUntilDone(ctx, func(ctx context.Context) {
+		status, detail = check(ctx)
+	}); timedOut {
+		return &model.SystemDiagnostic{Name: name, Status: model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, Detail: "timed out"}
+	}
+
+	return &model.SystemDiagnostic{Name: name, Status: status, Detail: detail}
+}
+
+func (a *App) diagnoseSiteURL(ctx context.Context) (string, string) {
+	siteURL := strings.TrimRight(*a.Config().ServiceSettings.SiteURL, "/")
+	if siteURL == "" {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, "SiteURL is not set"
+	}
+
+	request, err := http.NewRequest("GET", siteURL+"/api/v4/system/ping", nil)
+	if err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	resp, err := a.HTTPService.MakeClient(true).Do(request.WithContext(ctx))
+	if err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, fmt.Sprintf("%v is not reachable from the server: %v", siteURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, fmt.Sprintf("%v responded with status code %v", siteURL, resp.StatusCode)
+	}
+
+	return model.SYSTEM_DIAGNOSTIC_STATUS_OK, fmt.Sprintf("%v is reachable from the server", siteURL)
+}
+
+func (a *App) diagnoseSmtp(ctx context.Context) (string, string) {
+	cfg := a.Config()
+	if *cfg.EmailSettings.SMTPServer == "" {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, "No SMTP server is configured, so no emails can be sent"
+	}
+
+	if err := testSmtpConnection(ctx, cfg); err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	if !*cfg.EmailSettings.SendEmailNotifications {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, "The SMTP server is reachable but email notifications are disabled"
+	}
+
+	return model.SYSTEM_DIAGNOSTIC_STATUS_OK, "Connected to the SMTP server"
+}
+
+func (a *App) diagnoseFileStorage(ctx context.Context) (string, string) {
+	// The file backend doesn't accept a context, so report an unreachable S3 endpoint before calling
+	// into it. A write that hangs regardless is abandoned at the deadline.
+	if settings := &a.Config().FileSettings; *settings.DriverName == model.IMAGE_DRIVER_S3 {
+		if err := dialIntegration(ctx, s3Address(settings)); err != nil {
+			return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+		}
+	}
+
+	backend, err := a.FileBackend()
+	if err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	path := "diagnostics/" + model.NewId()
+	if _, err := backend.WriteFile(bytes.NewReader([]byte("diagnostics")), path); err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	if err := backend.RemoveFile(path); err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, fmt.Sprintf("Wrote a file but failed to remove it: %v", err.Error())
+	}
+
+	return model.SYSTEM_DIAGNOSTIC_STATUS_OK, fmt.Sprintf("The %v file storage is writable", *a.Config().FileSettings.DriverName)
+}
+
+func (a *App) diagnoseDatabaseConnections(ctx context.Context) (string, string) {
+	return diagnoseDatabaseConnectionCount(a.Srv.Store.TotalMasterDbConnections(), *a.Config().SqlSettings.MaxOpenConns)
+}
+
+func diagnoseDatabaseConnectionCount(open, max int) (string, string) {
+	detail := fmt.Sprintf("%v of %v connections are open", open, max)
+
+	ratio := float64(open) / float64(max)
+	switch {
+	case ratio >= SYSTEM_DIAGNOSTICS_DB_CONNECTIONS_ERROR_RATIO:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, detail
+	case ratio >= SYSTEM_DIAGNOSTICS_DB_CONNECTIONS_WARN_RATIO:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, detail
+	default:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_OK, detail
+	}
+}
+
+// diagnoseTLSCertificate checks the certificate the server is configured with or, when TLS is
+// terminated in front of the server, the one presented for the SiteURL.
+func (a *App) diagnoseTLSCertificate(ctx context.Context) (string, string) {
+	cfg := a.Config()
+
+	if *cfg.ServiceSettings.ConnectionSecurity == model.CONN_SECURITY_TLS && !*cfg.ServiceSettings.UseLetsEncrypt {
+		certificate, err := readTLSCertificate(*cfg.ServiceSettings.TLSCertFile)
+		if err != nil {
+			return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+		}
+
+		return diagnoseTLSCertificateExpiry(certificate.NotAfter, time.Now())
+	}
+
+	siteURL, err := url.Parse(*cfg.ServiceSettings.SiteURL)
+	if err != nil || siteURL.Scheme != "https" {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, "TLS is not used"
+	}
+
+	host := siteURL.Host
+	if siteURL.Port() == "" {
+		host = net.JoinHostPort(siteURL.Hostname(), "443")
+	}
+
+	dialer := &net.Dialer{}
+	rawConn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+	defer rawConn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		rawConn.SetDeadline(deadline)
+	}
+
+	// The certificate is verified separately so that an invalid one can be reported.
+	conn := tls.Client(rawConn, &tls.Config{ServerName: siteURL.Hostname(), InsecureSkipVerify: true})
+	if err := conn.Handshake(); err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, "No certificate was presented for " + siteURL.Hostname()
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	if _, err := certificates[0].Verify(x509.VerifyOptions{DNSName: siteURL.Hostname(), Intermediates: intermediates}); err != nil {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, err.Error()
+	}
+
+	return diagnoseTLSCertificateExpiry(certificates[0].NotAfter, time.Now())
+}
+
+func readTLSCertificate(path string) (*x509.Certificate, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%v does not contain a PEM encoded certificate", path)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func diagnoseTLSCertificateExpiry(notAfter time.Time, now time.Time) (string, string) {
+	days := int(notAfter.Sub(now).Hours() / 24)
+	if notAfter.Before(now) {
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, fmt.Sprintf("The certificate expired on %v", notAfter.Format(time.RFC3339))
+	}
+
+	detail := fmt.Sprintf("The certificate expires in %v days", days)
+	switch {
+	case days < SYSTEM_DIAGNOSTICS_TLS_EXPIRY_ERROR_DAYS:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, detail
+	case days < SYSTEM_DIAGNOSTICS_TLS_EXPIRY_WARN_DAYS:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, detail
+	default:
+		return model.SYSTEM_DIAGNOSTIC_STATUS_OK, detail
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestDiagnoseDatabaseConnectionCount(t *testing.T) {
+	for _, tc := range []struct {
+		open, max int
+		status    string
+	}{
+		{10, 300, model.SYSTEM_DIAGNOSTIC_STATUS_OK},
+		{225, 300, model.SYSTEM_DIAGNOSTIC_STATUS_WARN},
+		{270, 300, model.SYSTEM_DIAGNOSTIC_STATUS_ERROR},
+		{300, 300, model.SYSTEM_DIAGNOSTIC_STATUS_ERROR},
+	} {
+		status, detail := diagnoseDatabaseConnectionCount(tc.open, tc.max)
+		assert.Equal(t, tc.status, status, detail)
+	}
+}
+
+func TestDiagnoseTLSCertificateExpiry(t *testing.T) {
+	now := time.Now()
+
+	for _, tc := range []struct {
+		notAfter time.Time
+		status   string
+	}{
+		{now.AddDate(0, 6, 0), model.SYSTEM_DIAGNOSTIC_STATUS_OK},
+		{now.AddDate(0, 0, 20), model.SYSTEM_DIAGNOSTIC_STATUS_WARN},
+		{now.AddDate(0, 0, 3), model.SYSTEM_DIAGNOSTIC_STATUS_ERROR},
+		{now.AddDate(0, 0, -1), model.SYSTEM_DIAGNOSTIC_STATUS_ERROR},
+	} {
+		status, detail := diagnoseTLSCertificateExpiry(tc.notAfter, now)
+		assert.Equal(t, tc.status, status, detail)
+	}
+}
+
+func TestRunSystemDiagnosticCheck(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		diagnostic := runSystemDiagnosticCheck(context.Background(), model.SYSTEM_DIAGNOSTIC_SMTP, func(ctx context.Context) (string, string) {
+			return model.SYSTEM_DIAGNOSTIC_STATUS_WARN, "detail"
+		})
+		assert.Equal(t, &model.SystemDiagnostic{Name: model.SYSTEM_DIAGNOSTIC_SMTP, Status: model.SYSTEM_DIAGNOSTIC_STATUS_WARN, Detail: "detail"}, diagnostic)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		diagnostic := runSystemDiagnosticCheck(ctx, model.SYSTEM_DIAGNOSTIC_SMTP, func(ctx context.Context) (string, string) {
+			<-ctx.Done()
+			return model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, ctx.Err().Error()
+		})
+		assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, diagnostic.Status)
+		assert.Equal(t, "timed out", diagnostic.Detail)
+	})
+
+	t.Run("ignoring the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		start := time.Now()
+		diagnostic := runSystemDiagnosticCheck(ctx, model.SYSTEM_DIAGNOSTIC_FILE_STORAGE, func(ctx context.Context) (string, string) {
+			<-release
+			return model.SYSTEM_DIAGNOSTIC_STATUS_OK, "detail"
+		})
+		assert.True(t, time.Since(start) < time.Second, "the check should have been abandoned at the deadline")
+		assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, diagnostic.Status)
+		assert.Equal(t, "timed out", diagnostic.Detail)
+	})
+}
+
+func TestRunSystemDiagnostics(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "" })
+
+	diagnostics := th.App.RunSystemDiagnostics()
+	assert.Len(t, diagnostics, 5)
+	assert.Equal(t, model.SYSTEM_DIAGNOSTIC_SITE_URL, diagnostics[0].Name)
+	assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_ERROR, diagnostics[0].Status)
+	assert.Equal(t, model.SYSTEM_DIAGNOSTIC_FILE_STORAGE, diagnostics[2].Name)
+	assert.Equal(t, model.SYSTEM_DIAGNOSTIC_STATUS_OK, diagnostics[2].Status)
+}
@@ -555,6 +555,9 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 		if err != nil {
 			return nil, false, err
 		}
+		if histErr := a.Srv.Store.TeamMemberHistory().LogJoinEvent(tm.UserId, tm.TeamId, model.GetMillis()); histErr != nil {
+			mlog.Warn("Failed to update TeamMemberHistory table", mlog.Err(histErr))
+		}
 		return tmr, false, nil
 	}
 
@@ -577,6 +580,9 @@ func (a *App) joinUserToTeam(team *model.Team, user *model.User) (*model.TeamMem
 	if err != nil {
 		return nil, false, err
 	}
+	if histErr := a.Srv.Store.TeamMemberHistory().LogJoinEvent(tm.UserId, tm.TeamId, model.GetMillis()); histErr != nil {
+		mlog.Warn("Failed to update TeamMemberHistory table", mlog.Err(histErr))
+	}
 
 	return member, false, nil
 }
@@ -635,6 +641,18 @@ func (a *App) JoinUserToTeam(team *model.Team, user *model.User, userRequestorId
 	message.Add("user_id", user.Id)
 	a.Publish(message)
 
+	// The member creating the team doesn't count as an invited one. Once a member has been invited,
+	// there's no need to count the members.
+	if !a.isTeamOnboardingStepComplete(team.Id, model.TEAM_ONBOARDING_STEP_INVITE_MEMBER) {
+		a.Srv.Go(func() {
+			if count, err := a.Srv.Store.Team().GetActiveMemberCount(team.Id, nil); err != nil {
+				mlog.Error("Failed to count team members", mlog.String("team_id", team.Id), mlog.Err(err))
+			} else if count > 1 {
+				a.completeTeamOnboardingStep(team.Id, model.TEAM_ONBOARDING_STEP_INVITE_MEMBER)
+			}
+		})
+	}
+
 	return nil
 }
 
@@ -880,7 +898,80 @@ func (a *App) RemoveUserFromTeam(teamId string, userId string, requestorId strin
 	return nil
 }
 
+// teamMembersRemoved collects the cache invalidations for a batch of users removed from a team, so that they can be
+// sent to the rest of the cluster as a single event instead of several per user.
+type teamMembersRemoved struct {
+	userIds    []string
+	channelIds map[string]bool
+}
+
+// RemoveUsersFromTeam removes each of the given users from the team. A failure to remove one user doesn't stop the
+// others from being removed, and is reported in the result instead.
+func (a *App) RemoveUsersFromTeam(teamId string, userIds []string, requestorId string) (*model.TeamMembersBulkRemoveResult, *model.AppError) {
+	team, err := a.GetTeam(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.TeamMembersBulkRemoveResult{Errors: []*model.TeamMemberBulkRemoveError{}}
+	removed := &teamMembersRemoved{channelIds: map[string]bool{}}
+
+	for _, userId := range userIds {
+		user, err := a.GetUser(userId)
+		if err == nil {
+			err = a.leaveTeam(team, user, requestorId, removed)
+		}
+
+		if err != nil {
+			err.Translate(a.T)
+			result.Errors = append(result.Errors, &model.TeamMemberBulkRemoveError{UserId: userId, Error: err.Message})
+			continue
+		}
+
+		result.Removed++
+	}
+
+	if a.Cluster != nil && len(removed.userIds) > 0 {
+		channelIds := make([]string, 0, len(removed.channelIds))
+		for channelId := range removed.channelIds {
+			channelIds = append(channelIds, channelId)
+		}
+
+		msg := &model.ClusterMessage{
+			Event:    model.CLUSTER_EVENT_TEAM_MEMBERS_REMOVED,
+			SendType: model.CLUSTER_SEND_RELIABLE,
+			Data:     model.ArrayToJson(removed.userIds),
+			Props: map[string]string{
+				"channel_ids": model.ArrayToJson(channelIds),
+			},
+		}
+		a.Cluster.SendClusterMessage(msg)
+	}
+
+	return result, nil
+}
+
+// InvalidateCacheForTeamMembersRemovedSkipClusterSend performs the cache invalidations for users removed from a team
+// by RemoveUsersFromTeam on another node in the cluster.
+func (a *App) InvalidateCacheForTeamMembersRemovedSkipClusterSend(userIds []string, channelIds []string) {
+	for _, channelId := range channelIds {
+		a.InvalidateCacheForChannelMembersSkipClusterSend(channelId)
+	}
+
+	for _, userId := range userIds {
+		a.ClearSessionCacheForUserSkipClusterSend(userId)
+		a.InvalidateCacheForUserSkipClusterSend(userId)
+		a.InvalidateCacheForUserTeamsSkipClusterSend(userId)
+	}
+}
+
 func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId string) *model.AppError {
+	return a.removeTeamMemberFromTeam(teamMember, requestorId, nil)
+}
+
+// removeTeamMemberFromTeam removes the member from the team. If removed is not nil, cache invalidations are not sent
+// to the rest of the cluster but recorded in removed, so that the caller can send them along with those for others.
+func (a *App) removeTeamMemberFromTeam(teamMember *model.TeamMember, requestorId string, removed *teamMembersRemoved) *model.AppError {
 	// Send the websocket message before we actually do the remove so the user being removed gets it.
 	message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_LEAVE_TEAM, teamMember.TeamId, "", "", nil)
 	message.Add("user_id", teamMember.UserId)
@@ -899,6 +990,10 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 		return err
 	}
 
+	if err := a.Srv.Store.TeamMemberHistory().LogLeaveEvent(teamMember.UserId, teamMember.TeamId, teamMember.DeleteAt); err != nil {
+		mlog.Warn("Failed to update TeamMemberHistory table", mlog.Err(err))
+	}
+
 	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
 		var actor *model.User
 		if requestorId != "" {
@@ -932,14 +1027,25 @@ func (a *App) RemoveTeamMemberFromTeam(teamMember *model.TeamMember, requestorId
 		return err
 	}
 
-	a.ClearSessionCacheForUser(user.Id)
-	a.InvalidateCacheForUser(user.Id)
-	a.InvalidateCacheForUserTeams(user.Id)
+	if removed != nil {
+		a.ClearSessionCacheForUserSkipClusterSend(user.Id)
+		a.InvalidateCacheForUserSkipClusterSend(user.Id)
+		a.InvalidateCacheForUserTeamsSkipClusterSend(user.Id)
+		removed.userIds = append(removed.userIds, user.Id)
+	} else {
+		a.ClearSessionCacheForUser(user.Id)
+		a.InvalidateCacheForUser(user.Id)
+		a.InvalidateCacheForUserTeams(user.Id)
+	}
 
 	return nil
 }
 
 func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string) *model.AppError {
+	return a.leaveTeam(team, user, requestorId, nil)
+}
+
+func (a *App) leaveTeam(team *model.Team, user *model.User, requestorId string, removed *teamMembersRemoved) *model.AppError {
 	teamMember, err := a.GetTeamMember(team.Id, user.Id)
 	if err != nil {
 		return model.NewAppError("LeaveTeam", "api.team.remove_user_from_team.missing.app_error", nil, err.Error(), http.StatusBadRequest)
@@ -957,7 +1063,12 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 
 	for _, channel := range *channelList {
 		if !channel.IsGroupOrDirect() {
-			a.InvalidateCacheForChannelMembers(channel.Id)
+			if removed != nil {
+				a.InvalidateCacheForChannelMembersSkipClusterSend(channel.Id)
+				removed.channelIds[channel.Id] = true
+			} else {
+				a.InvalidateCacheForChannelMembers(channel.Id)
+			}
 			if err = a.Srv.Store.Channel().RemoveMember(channel.Id, user.Id); err != nil {
 				return err
 			}
@@ -981,7 +1092,7 @@ func (a *App) LeaveTeam(team *model.Team, user *model.User, requestorId string)
 		}
 	}
 
-	err = a.RemoveTeamMemberFromTeam(teamMember, requestorId)
+	err = a.removeTeamMemberFromTeam(teamMember, requestorId, removed)
 	if err != nil {
 		return err
 	}
@@ -1230,6 +1341,12 @@ func (a *App) PermanentDeleteTeam(team *model.Team) *model.AppError {
 		return err
 	}
 
+	// Removes the team onboarding checklist, which is stored under the team id.
+	if err := a.Srv.Store.Preference().PermanentDeleteByUser(team.Id); err != nil {
+		return err
+	}
+	a.clearTeamOnboardingCache(team.Id)
+
 	if err := a.Srv.Store.Team().PermanentDelete(team.Id); err != nil {
 		return err
 	}
@@ -1283,6 +1400,12 @@ func (a *App) GetTeamStats(teamId string, restrictions *model.ViewUsersRestricti
 		achan <- store.StoreResult{Data: memberCount, Err: err}
 		close(achan)
 	}()
+	cchan := make(chan store.StoreResult, 1)
+	go func() {
+		channelCount, err := a.Srv.Store.Channel().GetTeamChannelCount(teamId)
+		cchan <- store.StoreResult{Data: channelCount, Err: err}
+		close(cchan)
+	}()
 
 	stats := &model.TeamStats{}
 	stats.TeamId = teamId
@@ -1299,6 +1422,13 @@ func (a *App) GetTeamStats(teamId string, restrictions *model.ViewUsersRestricti
 	}
 	stats.ActiveMemberCount = result.Data.(int64)
 
+	result = <-cchan
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	stats.ChannelCount = result.Data.(int64)
+	stats.MaxChannels = *a.Config().TeamSettings.MaxChannelsPerTeam
+
 	return stats, nil
 }
 
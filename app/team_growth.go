@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+// GetTeamGrowth counts the users who joined and left a team in each week or month, from the period
+// containing from through the period containing to. If from is 0, the last
+// TEAM_GROWTH_DEFAULT_PERIODS periods are counted. Membership changes made before team member
+// history was recorded aren't counted.
+func (a *App) GetTeamGrowth(teamId string, granularity string, from int64, to int64) ([]*model.TeamGrowthPeriod, *model.AppError) {
+	if from > to {
+		return nil, model.NewAppError("GetTeamGrowth", "app.team.get_team_growth.invalid_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	lastStart := model.TeamGrowthPeriodStart(time.Unix(0, to*int64(time.Millisecond)), granularity)
+	firstStart := model.AddTeamGrowthPeriods(lastStart, granularity, -(model.TEAM_GROWTH_DEFAULT_PERIODS - 1))
+	if from != 0 {
+		firstStart = model.TeamGrowthPeriodStart(time.Unix(0, from*int64(time.Millisecond)), granularity)
+	}
+
+	var periods []*model.TeamGrowthPeriod
+	periodsByStart := map[int64]*model.TeamGrowthPeriod{}
+	for start := firstStart; !start.After(lastStart); start = model.AddTeamGrowthPeriods(start, granularity, 1) {
+		if len(periods) == model.TEAM_GROWTH_MAX_PERIODS {
+			return nil, model.NewAppError("GetTeamGrowth", "app.team.get_team_growth.too_many_periods.app_error", map[string]interface{}{"Max": model.TEAM_GROWTH_MAX_PERIODS}, "", http.StatusBadRequest)
+		}
+
+		period := &model.TeamGrowthPeriod{Period: start.Format(model.TEAM_GROWTH_PERIOD_FORMAT)}
+		periods = append(periods, period)
+		periodsByStart[model.GetMillisForTime(start)] = period
+	}
+
+	startTime := model.GetMillisForTime(firstStart)
+	endTime := model.GetMillisForTime(model.AddTeamGrowthPeriods(lastStart, granularity, 1))
+
+	histories, err := a.Srv.Store.TeamMemberHistory().GetForTeamDuring(teamId, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	periodContaining := func(millis int64) *model.TeamGrowthPeriod {
+		if millis < startTime || millis >= endTime {
+			return nil
+		}
+		start := model.TeamGrowthPeriodStart(time.Unix(0, millis*int64(time.Millisecond)), granularity)
+		return periodsByStart[model.GetMillisForTime(start)]
+	}
+
+	for _, history := range histories {
+		if period := periodContaining(history.JoinTime); period != nil {
+			period.NewMembers++
+		}
+		if history.LeaveTime != nil {
+			if period := periodContaining(*history.LeaveTime); period != nil {
+				period.DepartedMembers++
+			}
+		}
+	}
+
+	for _, period := range periods {
+		period.Net = period.NewMembers - period.DepartedMembers
+	}
+
+	return periods, nil
+}
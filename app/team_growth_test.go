@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestGetTeamGrowth(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	teamId := model.NewId()
+	millis := func(year int, month time.Month, day int) int64 {
+		return model.GetMillisForTime(time.Date(year, month, day, 12, 0, 0, 0, time.UTC))
+	}
+
+	// 2019-07-01 and 2019-07-08 are Mondays.
+	stayed := model.NewId()
+	require.Nil(t, th.App.Srv.Store.TeamMemberHistory().LogJoinEvent(stayed, teamId, millis(2019, 7, 1)))
+
+	left := model.NewId()
+	require.Nil(t, th.App.Srv.Store.TeamMemberHistory().LogJoinEvent(left, teamId, millis(2019, 6, 20)))
+	require.Nil(t, th.App.Srv.Store.TeamMemberHistory().LogLeaveEvent(left, teamId, millis(2019, 7, 9)))
+
+	late := model.NewId()
+	require.Nil(t, th.App.Srv.Store.TeamMemberHistory().LogJoinEvent(late, teamId, millis(2019, 7, 14)))
+
+	t.Run("weekly", func(t *testing.T) {
+		growth, err := th.App.GetTeamGrowth(teamId, model.TEAM_GROWTH_GRANULARITY_WEEK, millis(2019, 7, 3), millis(2019, 7, 10))
+		require.Nil(t, err)
+
+		assert.Equal(t, []*model.TeamGrowthPeriod{
+			{Period: "2019-07-01", NewMembers: 1, DepartedMembers: 0, Net: 1},
+			{Period: "2019-07-08", NewMembers: 1, DepartedMembers: 1, Net: 0},
+		}, growth)
+	})
+
+	t.Run("monthly", func(t *testing.T) {
+		growth, err := th.App.GetTeamGrowth(teamId, model.TEAM_GROWTH_GRANULARITY_MONTH, millis(2019, 6, 1), millis(2019, 7, 1))
+		require.Nil(t, err)
+
+		assert.Equal(t, []*model.TeamGrowthPeriod{
+			{Period: "2019-06-01", NewMembers: 1, DepartedMembers: 0, Net: 1},
+			{Period: "2019-07-01", NewMembers: 2, DepartedMembers: 1, Net: 1},
+		}, growth)
+	})
+
+	t.Run("default range", func(t *testing.T) {
+		growth, err := th.App.GetTeamGrowth(teamId, model.TEAM_GROWTH_GRANULARITY_MONTH, 0, millis(2019, 7, 1))
+		require.Nil(t, err)
+
+		require.Len(t, growth, model.TEAM_GROWTH_DEFAULT_PERIODS)
+		assert.Equal(t, "2018-08-01", growth[0].Period)
+		assert.Equal(t, "2019-07-01", growth[len(growth)-1].Period)
+	})
+
+	t.Run("too many periods", func(t *testing.T) {
+		_, err := th.App.GetTeamGrowth(teamId, model.TEAM_GROWTH_GRANULARITY_WEEK, millis(2010, 1, 1), millis(2019, 7, 1))
+		require.NotNil(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+}
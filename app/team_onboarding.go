@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/utils"
+)
+
+const (
+	TEAM_ONBOARDING_ADMINS_PAGE_SIZE = 200
+	TEAM_ONBOARDING_CACHE_SIZE       = 10000
+	TEAM_ONBOARDING_CACHE_TTL        = 30 * time.Minute
+)
+
+// GetTeamOnboardingChecklist returns the onboarding checklist of a team with the completion state of
+// each step.
+func (a *App) GetTeamOnboardingChecklist(teamId string) (*model.TeamOnboardingChecklist, *model.AppError) {
+	preferences, err := a.Srv.Store.Preference().GetCategory(teamId, model.PREFERENCE_CATEGORY_TEAM_ONBOARDING)
+	if err != nil {
+		return nil, err
+	}
+
+	completedAt := make(map[string]int64, len(preferences))
+	for _, preference := range preferences {
+		completedAt[preference.Name], _ = strconv.ParseInt(preference.Value, 10, 64)
+	}
+
+	checklist := &model.TeamOnboardingChecklist{
+		TeamId: teamId,
+		Steps:  make([]*model.TeamOnboardingStep, 0, len(model.TeamOnboardingSteps)),
+	}
+
+	for _, stepId := range model.TeamOnboardingSteps {
+		at, completed := completedAt[stepId]
+		checklist.Steps = append(checklist.Steps, &model.TeamOnboardingStep{
+			Id:          stepId,
+			Completed:   completed,
+			CompletedAt: at,
+		})
+	}
+
+	return checklist, nil
+}
+
+// UpdateTeamOnboardingChecklist marks the steps of a team's onboarding checklist as completed or not.
+// Steps that are left out of the given checklist are not changed.
+func (a *App) UpdateTeamOnboardingChecklist(teamId string, checklist *model.TeamOnboardingChecklist) (*model.TeamOnboardingChecklist, *model.AppError) {
+	current, err := a.GetTeamOnboardingChecklist(teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[string]bool, len(current.Steps))
+	for _, step := range current.Steps {
+		completed[step.Id] = step.Completed
+	}
+
+	for _, step := range checklist.Steps {
+		if step.Completed == completed[step.Id] {
+			continue
+		}
+
+		if step.Completed {
+			if err := a.saveTeamOnboardingStep(teamId, step.Id); err != nil {
+				return nil, err
+			}
+		} else if err := a.Srv.Store.Preference().Delete(teamId, model.PREFERENCE_CATEGORY_TEAM_ONBOARDING, step.Id); err != nil {
+			return nil, err
+		}
+
+		completed[step.Id] = step.Completed
+	}
+
+	// Other nodes may still consider the checklist complete until their cached flags expire.
+	a.clearTeamOnboardingCache(teamId)
+
+	return a.GetTeamOnboardingChecklist(teamId)
+}
+
+// isTeamOnboardingComplete returns whether the team's onboarding checklist is known to be complete,
+// without reading it from the database.
+func (a *App) isTeamOnboardingComplete(teamId string) bool {
+	_, ok := a.Srv.teamOnboardingCache.Get(teamId)
+	return ok
+}
+
+func (a *App) setTeamOnboardingComplete(teamId string) {
+	a.Srv.teamOnboardingCache.AddWithExpiresInSecs(teamId, true, int64(TEAM_ONBOARDING_CACHE_TTL.Seconds()))
+}
+
+// isTeamOnboardingStepComplete returns whether a step of the team's onboarding checklist is known to
+// be complete, without reading it from the database.
+func (a *App) isTeamOnboardingStepComplete(teamId string, stepId string) bool {
+	if a.isTeamOnboardingComplete(teamId) {
+		return true
+	}
+
+	_, ok := a.Srv.teamOnboardingCache.Get(teamOnboardingStepCacheKey(teamId, stepId))
+	return ok
+}
+
+func (a *App) setTeamOnboardingStepComplete(teamId string, stepId string) {
+	a.Srv.teamOnboardingCache.AddWithExpiresInSecs(teamOnboardingStepCacheKey(teamId, stepId), true, int64(TEAM_ONBOARDING_CACHE_TTL.Seconds()))
+}
+
+// clearTeamOnboardingCache forgets which steps of the team's onboarding checklist are complete on
+// this node.
+func (a *App) clearTeamOnboardingCache(teamId string) {
+	a.Srv.teamOnboardingCache.Remove(teamId)
+	for _, stepId := range model.TeamOnboardingSteps {
+		a.Srv.teamOnboardingCache.Remove(teamOnboardingStepCacheKey(teamId, stepId))
+	}
+}
+
+func teamOnboardingStepCacheKey(teamId string, stepId string) string {
+	return teamId + ":" + stepId
+}
+
+// completeTeamOnboardingStep completes a step of a team's onboarding checklist once the corresponding
+// action has been taken. Steps that are already completed are left alone.
+func (a *App) completeTeamOnboardingStep(teamId string, stepId string) {
+	if teamId == "" || a.isTeamOnboardingStepComplete(teamId, stepId) {
+		return
+	}
+
+	checklist, err := a.GetTeamOnboardingChecklist(teamId)
+	if err != nil {
+		mlog.Error("Failed to get team onboarding checklist", mlog.String("team_id", teamId), mlog.Err(err))
+		return
+	}
+
+	alreadyCompleted := false
+	remaining := 0
+	for _, step := range checklist.Steps {
+		if step.Id == stepId {
+			alreadyCompleted = step.Completed
+		} else if !step.Completed {
+			remaining++
+		}
+	}
+
+	if !alreadyCompleted {
+		if err := a.saveTeamOnboardingStep(teamId, stepId); err != nil {
+			mlog.Error("Failed to complete team onboarding step", mlog.String("team_id", teamId), mlog.String("step", stepId), mlog.Err(err))
+			return
+		}
+	}
+
+	a.setTeamOnboardingStepComplete(teamId, stepId)
+	if remaining == 0 {
+		a.setTeamOnboardingComplete(teamId)
+	}
+}
+
+func (a *App) saveTeamOnboardingStep(teamId string, stepId string) *model.AppError {
+	completedAt := model.GetMillis()
+
+	preference := model.Preference{
+		UserId:   teamId,
+		Category: model.PREFERENCE_CATEGORY_TEAM_ONBOARDING,
+		Name:     stepId,
+		Value:    strconv.FormatInt(completedAt, 10),
+	}
+	if err := a.Srv.Store.Preference().Save(&model.Preferences{preference}); err != nil {
+		return err
+	}
+
+	a.Srv.Go(func() {
+		a.sendTeamOnboardingStepCompletedEvent(teamId, &model.TeamOnboardingStep{Id: stepId, Completed: true, CompletedAt: completedAt})
+	})
+
+	return nil
+}
+
+// sendTeamOnboardingStepCompletedEvent lets the admins of a team know that a step of its onboarding
+// checklist was completed.
+func (a *App) sendTeamOnboardingStepCompletedEvent(teamId string, step *model.TeamOnboardingStep) {
+	for page := 0; ; page++ {
+		members, err := a.Srv.Store.Team().GetMembers(teamId, page*TEAM_ONBOARDING_ADMINS_PAGE_SIZE, TEAM_ONBOARDING_ADMINS_PAGE_SIZE, nil)
+		if err != nil {
+			mlog.Error("Failed to get team members for onboarding event", mlog.String("team_id", teamId), mlog.Err(err))
+			return
+		}
+
+		for _, member := range members {
+			if !member.SchemeAdmin && !utils.StringInSlice(model.TEAM_ADMIN_ROLE_ID, strings.Fields(member.Roles)) {
+				continue
+			}
+
+			message := model.NewWebSocketEvent(model.WEBSOCKET_EVENT_ONBOARDING_STEP_COMPLETED, teamId, "", member.UserId, nil)
+			message.Add("step_id", step.Id)
+			message.Add("completed_at", step.CompletedAt)
+			a.Publish(message)
+		}
+
+		if len(members) < TEAM_ONBOARDING_ADMINS_PAGE_SIZE {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestTeamOnboardingChecklist(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	team := th.CreateTeam()
+	th.LinkUserToTeam(th.BasicUser, team)
+
+	stepCompleted := func(stepId string) bool {
+		checklist, err := th.App.GetTeamOnboardingChecklist(team.Id)
+		require.Nil(t, err)
+		for _, step := range checklist.Steps {
+			if step.Id == stepId {
+				return step.Completed
+			}
+		}
+		return false
+	}
+
+	// waitForStepCompleted waits for a step completed in the background.
+	waitForStepCompleted := func(stepId string) bool {
+		for i := 0; i < 50; i++ {
+			if stepCompleted(stepId) {
+				return true
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return false
+	}
+
+	assert.False(t, stepCompleted(model.TEAM_ONBOARDING_STEP_INVITE_MEMBER), "first member shouldn't count as invited")
+
+	t.Run("invite member", func(t *testing.T) {
+		th.LinkUserToTeam(th.BasicUser2, team)
+		assert.True(t, waitForStepCompleted(model.TEAM_ONBOARDING_STEP_INVITE_MEMBER))
+		assert.True(t, th.App.isTeamOnboardingStepComplete(team.Id, model.TEAM_ONBOARDING_STEP_INVITE_MEMBER))
+		assert.False(t, th.App.isTeamOnboardingComplete(team.Id))
+	})
+
+	t.Run("create channel", func(t *testing.T) {
+		assert.False(t, stepCompleted(model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL))
+		_, err := th.App.CreateChannelWithUser(&model.Channel{TeamId: team.Id, Name: "name" + model.NewId(), DisplayName: "Onboarding", Type: model.CHANNEL_OPEN}, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.True(t, stepCompleted(model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL))
+	})
+
+	t.Run("add integration", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCommands = true })
+
+		assert.False(t, stepCompleted(model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION))
+		_, err := th.App.CreateCommand(&model.Command{CreatorId: th.BasicUser.Id, TeamId: team.Id, URL: "http://nowhere.com", Method: model.COMMAND_METHOD_POST, Trigger: "onboarding"})
+		require.Nil(t, err)
+		assert.True(t, stepCompleted(model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION))
+	})
+
+	t.Run("complete onboarding is cached", func(t *testing.T) {
+		assert.True(t, th.App.isTeamOnboardingComplete(team.Id))
+
+		// The cached flag is cleared when a step is marked as not completed again.
+		_, err := th.App.UpdateTeamOnboardingChecklist(team.Id, &model.TeamOnboardingChecklist{
+			TeamId: team.Id,
+			Steps:  []*model.TeamOnboardingStep{{Id: model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL, Completed: false}},
+		})
+		require.Nil(t, err)
+		assert.False(t, th.App.isTeamOnboardingComplete(team.Id))
+		assert.False(t, th.App.isTeamOnboardingStepComplete(team.Id, model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL))
+
+		_, err = th.App.CreateChannelWithUser(&model.Channel{TeamId: team.Id, Name: "name" + model.NewId(), DisplayName: "Onboarding", Type: model.CHANNEL_OPEN}, th.BasicUser.Id)
+		require.Nil(t, err)
+		assert.True(t, stepCompleted(model.TEAM_ONBOARDING_STEP_CREATE_CHANNEL))
+		assert.True(t, th.App.isTeamOnboardingComplete(team.Id))
+	})
+
+	t.Run("permanent delete team", func(t *testing.T) {
+		require.Nil(t, th.App.PermanentDeleteTeam(team))
+
+		preferences, err := th.App.Srv.Store.Preference().GetCategory(team.Id, model.PREFERENCE_CATEGORY_TEAM_ONBOARDING)
+		require.Nil(t, err)
+		assert.Empty(t, preferences)
+		assert.False(t, th.App.isTeamOnboardingComplete(team.Id))
+	})
+}
@@ -329,6 +329,12 @@ func (a *App) createUser(user *model.User) (*model.User, *model.AppError) {
 		mlog.Error("Encountered error saving tutorial preference", mlog.Err(err))
 	}
 
+	if themePref := a.Config().ThemeSettings.DefaultThemePreference(ruser.Id); themePref != nil {
+		if err := a.Srv.Store.Preference().Save(&model.Preferences{*themePref}); err != nil {
+			mlog.Error("Encountered error saving default theme preference", mlog.Err(err))
+		}
+	}
+
 	ruser.Sanitize(map[string]bool{})
 	return ruser, nil
 }
@@ -685,6 +691,22 @@ func (a *App) ActivateMfa(userId, token string) *model.AppError {
 	return nil
 }
 
+// VerifyMfaToken checks the given token against the user's MFA secret without creating a session,
+// so that administrators can confirm a user's authenticator is working.
+func (a *App) VerifyMfaToken(userId, token string) (bool, *model.AppError) {
+	user, err := a.Srv.Store.User().Get(userId)
+	if err != nil {
+		return false, err
+	}
+
+	if !user.MfaActive {
+		return false, model.NewAppError("VerifyMfaToken", "api.user.verify_mfa_token.not_active.app_error", nil, "user_id="+userId, http.StatusBadRequest)
+	}
+
+	mfaService := mfa.New(a, a.Srv.Store)
+	return mfaService.ValidateToken(user.MfaSecret, token)
+}
+
 func (a *App) DeactivateMfa(userId string) *model.AppError {
 	mfaService := mfa.New(a, a.Srv.Store)
 	if err := mfaService.Deactivate(userId); err != nil {
@@ -1240,12 +1262,61 @@ func (a *App) UpdatePassword(user *model.User, newPassword string) *model.AppErr
 		return err
 	}
 
+	historyCount := *a.Config().PasswordSettings.HistoryCount
+	if historyCount > 0 && user.Password != "" {
+		if err := a.checkPasswordNotReused(user, newPassword, historyCount); err != nil {
+			return err
+		}
+	}
+
 	hashedPassword := model.HashPassword(newPassword)
 
 	if err := a.Srv.Store.User().UpdatePassword(user.Id, hashedPassword); err != nil {
 		return model.NewAppError("UpdatePassword", "api.user.update_password.failed.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	if historyCount > 0 && user.Password != "" {
+		history := &model.PasswordHistory{
+			UserId:   user.Id,
+			Password: user.Password,
+			CreateAt: model.GetMillis(),
+		}
+		if err := a.Srv.Store.PasswordHistory().Save(history); err != nil {
+			mlog.Error("Failed to save password history", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+
+		// Only the passwords replaced most recently are checked, with the current password making up
+		// the rest of the history.
+		if err := a.Srv.Store.PasswordHistory().PruneForUser(user.Id, historyCount-1); err != nil {
+			mlog.Error("Failed to prune password history", mlog.String("user_id", user.Id), mlog.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// checkPasswordNotReused rejects a new password that matches the user's current password or any of
+// the passwords they replaced most recently, historyCount passwords in total.
+func (a *App) checkPasswordNotReused(user *model.User, newPassword string, historyCount int) *model.AppError {
+	hashes := []string{user.Password}
+
+	if historyCount > 1 {
+		histories, err := a.Srv.Store.PasswordHistory().GetForUser(user.Id, historyCount-1)
+		if err != nil {
+			return err
+		}
+
+		for _, history := range histories {
+			hashes = append(hashes, history.Password)
+		}
+	}
+
+	for _, hash := range hashes {
+		if model.ComparePassword(hash, newPassword) {
+			return model.NewAppError("UpdatePassword", "api.user.update_password.reused.app_error", map[string]interface{}{"HistoryCount": historyCount}, "user_id="+user.Id, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -1452,6 +1523,22 @@ func (a *App) PermanentDeleteUser(user *model.User) *model.AppError {
 		return err
 	}
 
+	if err := a.Srv.Store.SidebarChannel().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.Mention().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.PasswordHistory().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
+	if err := a.Srv.Store.SavedPost().PermanentDeleteByUser(user.Id); err != nil {
+		return err
+	}
+
 	if err := a.Srv.Store.Channel().PermanentDeleteMembersByUser(user.Id); err != nil {
 		return err
 	}
@@ -1659,6 +1746,8 @@ func (a *App) VerifyUserEmail(userId, email string) *model.AppError {
 }
 
 func (a *App) SearchUsers(props *model.UserSearch, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	a.limitUserSearchResults(options)
+
 	if props.WithoutTeam {
 		return a.SearchUsersWithoutTeam(props.Term, options)
 	}
@@ -1727,10 +1816,18 @@ func (a *App) esSearchUsersInTeam(teamId, term string, options *model.UserSearch
 	return users, nil
 }
 
+// limitUserSearchResults caps the number of users a search can return at SearchSettings.MaxSearchResults.
+func (a *App) limitUserSearchResults(options *model.UserSearchOptions) {
+	if maxResults := *a.Config().SearchSettings.MaxSearchResults; options.Limit > maxResults {
+		options.Limit = maxResults
+	}
+}
+
 func (a *App) SearchUsersInTeam(teamId, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	var users []*model.User
 	var err *model.AppError
 	term = strings.TrimSpace(term)
+	a.limitUserSearchResults(options)
 
 	if a.IsESAutocompletionEnabled() {
 		users, err = a.esSearchUsersInTeam(teamId, term, options)
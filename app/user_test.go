@@ -9,6 +9,7 @@ import (
 	"image"
 	"image/color"
 	"math/rand"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -718,6 +719,32 @@ func TestCreateUserWithToken(t *testing.T) {
 	})
 }
 
+func TestCreateUserDefaultTheme(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("built-in default theme", func(t *testing.T) {
+		user := th.CreateUser()
+
+		_, err := th.App.GetPreferenceByCategoryAndNameForUser(user.Id, model.PREFERENCE_CATEGORY_THEME, "")
+		require.NotNil(t, err)
+	})
+
+	t.Run("custom default theme", func(t *testing.T) {
+		theme := `{"centerChannelBg":"#ffffff","sidebarBg":"#1543a3"}`
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ThemeSettings.DefaultTheme = theme })
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ThemeSettings.DefaultTheme = model.TEAM_SETTINGS_DEFAULT_TEAM_TEXT
+		})
+
+		user := th.CreateUser()
+
+		pref, err := th.App.GetPreferenceByCategoryAndNameForUser(user.Id, model.PREFERENCE_CATEGORY_THEME, "")
+		require.Nil(t, err)
+		assert.JSONEq(t, theme, pref.Value)
+	})
+}
+
 func TestPermanentDeleteUser(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -788,6 +815,45 @@ func TestPermanentDeleteUser(t *testing.T) {
 	}
 }
 
+func TestUpdatePasswordHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.HistoryCount = 3 })
+
+	updatePassword := func(password string) *model.AppError {
+		user, err := th.App.GetUser(th.BasicUser.Id)
+		require.Nil(t, err)
+
+		return th.App.UpdatePassword(user, password)
+	}
+
+	require.Nil(t, updatePassword("Password2"))
+	require.Nil(t, updatePassword("Password3"))
+
+	for _, password := range []string{"Password1", "Password2", "Password3"} {
+		err := updatePassword(password)
+		require.NotNil(t, err, password)
+		assert.Equal(t, "api.user.update_password.reused.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	}
+
+	require.Nil(t, updatePassword("Password4"))
+
+	// Password1 has dropped out of the three most recent passwords.
+	require.Nil(t, updatePassword("Password1"))
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.HistoryCount = 0 })
+
+	require.Nil(t, updatePassword("Password1"))
+
+	require.Nil(t, th.App.PermanentDeleteUser(th.BasicUser))
+
+	histories, err := th.App.Srv.Store.PasswordHistory().GetForUser(th.BasicUser.Id, model.PASSWORD_MAXIMUM_HISTORY_COUNT)
+	require.Nil(t, err)
+	assert.Empty(t, histories)
+}
+
 func TestPasswordRecovery(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -32,31 +32,35 @@ type WebConnActivityMessage struct {
 type Hub struct {
 	// connectionCount should be kept first.
 	// See https://github.com/mattermost/mattermost-server/pull/7281
-	connectionCount int64
-	app             *App
-	connectionIndex int
-	register        chan *WebConn
-	unregister      chan *WebConn
-	broadcast       chan *model.WebSocketEvent
-	stop            chan struct{}
-	didStop         chan struct{}
-	invalidateUser  chan string
-	activity        chan *WebConnActivityMessage
-	ExplicitStop    bool
-	goroutineId     int
+	connectionCount  int64
+	app              *App
+	connectionIndex  int
+	register         chan *WebConn
+	unregister       chan *WebConn
+	registerStream   chan *PostStream
+	unregisterStream chan *PostStream
+	broadcast        chan *model.WebSocketEvent
+	stop             chan struct{}
+	didStop          chan struct{}
+	invalidateUser   chan string
+	activity         chan *WebConnActivityMessage
+	ExplicitStop     bool
+	goroutineId      int
 }
 
 func (a *App) NewWebHub() *Hub {
 	return &Hub{
-		app:            a,
-		register:       make(chan *WebConn, 1),
-		unregister:     make(chan *WebConn, 1),
-		broadcast:      make(chan *model.WebSocketEvent, BROADCAST_QUEUE_SIZE),
-		stop:           make(chan struct{}),
-		didStop:        make(chan struct{}),
-		invalidateUser: make(chan string),
-		activity:       make(chan *WebConnActivityMessage),
-		ExplicitStop:   false,
+		app:              a,
+		register:         make(chan *WebConn, 1),
+		unregister:       make(chan *WebConn, 1),
+		registerStream:   make(chan *PostStream, 1),
+		unregisterStream: make(chan *PostStream, 1),
+		broadcast:        make(chan *model.WebSocketEvent, BROADCAST_QUEUE_SIZE),
+		stop:             make(chan struct{}),
+		didStop:          make(chan struct{}),
+		invalidateUser:   make(chan string),
+		activity:         make(chan *WebConnActivityMessage),
+		ExplicitStop:     false,
 	}
 }
 
@@ -392,6 +396,21 @@ func (h *Hub) Unregister(webConn *WebConn) {
 	}
 }
 
+func (h *Hub) RegisterStream(stream *PostStream) {
+	select {
+	case h.registerStream <- stream:
+	case <-h.didStop:
+		close(stream.Posts)
+	}
+}
+
+func (h *Hub) UnregisterStream(stream *PostStream) {
+	select {
+	case h.unregisterStream <- stream:
+	case <-h.stop:
+	}
+}
+
 func (h *Hub) Broadcast(message *model.WebSocketEvent) {
 	if h != nil && h.broadcast != nil && message != nil {
 		select {
@@ -441,6 +460,7 @@ func (h *Hub) Start() {
 		mlog.Debug("Hub for index is starting with goroutine", mlog.Int("index", h.connectionIndex), mlog.Int("goroutine", h.goroutineId))
 
 		connections := newHubConnectionIndex()
+		streams := newHubPostStreamIndex(h.app.isPostStreamChannelMember)
 
 		for {
 			select {
@@ -473,6 +493,10 @@ func (h *Hub) Start() {
 						})
 					}
 				}
+			case stream := <-h.registerStream:
+				streams.Add(stream)
+			case stream := <-h.unregisterStream:
+				streams.Remove(stream)
 			case userId := <-h.invalidateUser:
 				for _, webCon := range connections.ForUser(userId) {
 					webCon.InvalidateCache()
@@ -500,6 +524,7 @@ func (h *Hub) Start() {
 						}
 					}
 				}
+				streams.Broadcast(msg)
 			case <-h.stop:
 				userIds := make(map[string]bool)
 
@@ -512,6 +537,8 @@ func (h *Hub) Start() {
 					h.app.SetStatusOffline(userId, false)
 				}
 
+				streams.CloseAll()
+
 				h.ExplicitStop = true
 				close(h.didStop)
 
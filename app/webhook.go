@@ -92,7 +92,18 @@ func (a *App) handleWebhookEvents(post *model.Post, team *model.Team, channel *m
 func (a *App) TriggerWebhook(payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
 	var body io.Reader
 	var contentType string
-	if hook.ContentType == "application/json" {
+	if hook.PayloadTemplate != "" {
+		output, err := payload.ExecuteTemplate(hook.PayloadTemplate)
+		if err != nil {
+			mlog.Error("Failed to execute outgoing webhook payload template.", mlog.String("hook_id", hook.Id), mlog.Err(err))
+			return
+		}
+		body = strings.NewReader(output)
+		contentType = hook.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+	} else if hook.ContentType == "application/json" {
 		body = strings.NewReader(payload.ToJSON())
 		contentType = "application/json"
 	} else {
@@ -323,7 +334,14 @@ func (a *App) CreateIncomingWebhookForChannel(creatorId string, channel *model.C
 		return nil, model.NewAppError("CreateIncomingWebhookForChannel", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	return a.Srv.Store.Webhook().SaveIncoming(hook)
+	webhook, err := a.Srv.Store.Webhook().SaveIncoming(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	a.completeTeamOnboardingStep(webhook.TeamId, model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION)
+
+	return webhook, nil
 }
 
 func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
@@ -444,6 +462,8 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		return nil, err
 	}
 
+	a.completeTeamOnboardingStep(webhook.TeamId, model.TEAM_ONBOARDING_STEP_ADD_INTEGRATION)
+
 	return webhook, nil
 }
 
@@ -492,6 +512,29 @@ func (a *App) UpdateOutgoingWebhook(oldHook, updatedHook *model.OutgoingWebhook)
 	return a.Srv.Store.Webhook().UpdateOutgoing(updatedHook)
 }
 
+// TestOutgoingWebhookPayloadTemplate executes the given payload template against
+// model.SampleOutgoingWebhookPayload, returning both the sample payload and the output.
+func (a *App) TestOutgoingWebhookPayloadTemplate(payloadTemplate string) (*model.OutgoingWebhookPayloadTemplateTest, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("TestOutgoingWebhookPayloadTemplate", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if err := model.IsValidOutgoingWebhookPayloadTemplate(payloadTemplate); err != nil {
+		return nil, err
+	}
+
+	payload := model.SampleOutgoingWebhookPayload()
+	output, err := payload.ExecuteTemplate(payloadTemplate)
+	if err != nil {
+		return nil, model.NewAppError("TestOutgoingWebhookPayloadTemplate", "model.outgoing_hook.is_valid.payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	return &model.OutgoingWebhookPayloadTemplateTest{
+		Payload: payload,
+		Output:  output,
+	}, nil
+}
+
 func (a *App) GetOutgoingWebhook(hookId string) (*model.OutgoingWebhook, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
 		return nil, model.NewAppError("GetOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -520,6 +563,40 @@ func (a *App) GetOutgoingWebhooksForChannelPageByUser(channelId string, userId s
 	return a.Srv.Store.Webhook().GetOutgoingByChannelByUser(channelId, userId, page*perPage, perPage)
 }
 
+// GetWebhooksForChannel returns all of the webhooks of a channel. The incoming and outgoing webhooks are
+// limited to those created by the given users, unless the corresponding user id is empty. Webhooks of a
+// kind that is disabled are left out.
+func (a *App) GetWebhooksForChannel(channelId string, incomingUserId string, outgoingUserId string) (*model.ChannelWebhooks, *model.AppError) {
+	webhooks := &model.ChannelWebhooks{
+		Incoming: []*model.IncomingWebhook{},
+		Outgoing: []*model.OutgoingWebhook{},
+	}
+
+	if *a.Config().ServiceSettings.EnableIncomingWebhooks {
+		incoming, err := a.Srv.Store.Webhook().GetIncomingByChannel(channelId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hook := range incoming {
+			if incomingUserId == "" || hook.UserId == incomingUserId {
+				webhooks.Incoming = append(webhooks.Incoming, hook)
+			}
+		}
+	}
+
+	if *a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		outgoing, err := a.Srv.Store.Webhook().GetOutgoingByChannelByUser(channelId, outgoingUserId, -1, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks.Outgoing = append(webhooks.Outgoing, outgoing...)
+	}
+
+	return webhooks, nil
+}
+
 func (a *App) GetOutgoingWebhooksForTeamPage(teamId string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
 	return a.GetOutgoingWebhooksForTeamPageByUser(teamId, "", page, perPage)
 }
@@ -6,6 +6,7 @@ package app
 import (
 	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -631,6 +632,66 @@ func TestTriggerOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 
 }
 
+func TestTriggerOutgoingWebhookWithPayloadTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	type request struct {
+		contentType string
+		body        string
+	}
+	requests := make(chan request, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{r.Header.Get("Content-Type"), string(body)}
+	}))
+	defer ts.Close()
+
+	hook, err := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:       th.BasicChannel.Id,
+		TeamId:          th.BasicTeam.Id,
+		CallbackURLs:    []string{ts.URL},
+		CreatorId:       th.BasicUser.Id,
+		TriggerWords:    []string{"Abracadabra"},
+		PayloadTemplate: `{"summary": {{json .Text}}, "source": {{json .UserName}}}`,
+	})
+	require.Nil(t, err)
+
+	payload := &model.OutgoingWebhookPayload{
+		Token:    hook.Token,
+		TeamId:   hook.TeamId,
+		UserName: th.BasicUser.Username,
+		Text:     `Abracadabra "now"`,
+	}
+	th.App.TriggerWebhook(payload, hook, th.BasicPost, th.BasicChannel)
+
+	select {
+	case received := <-requests:
+		assert.Equal(t, "application/json", received.contentType)
+		assert.Equal(t, `{"summary": "Abracadabra \"now\"", "source": "`+th.BasicUser.Username+`"}`, received.body)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout, webhook request not received")
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			ChannelId:       th.BasicChannel.Id,
+			TeamId:          th.BasicTeam.Id,
+			CallbackURLs:    []string{ts.URL + "/other"},
+			CreatorId:       th.BasicUser.Id,
+			TriggerWords:    []string{"Other"},
+			PayloadTemplate: `{{.Unknown}}`,
+		})
+		require.NotNil(t, err)
+		assert.Equal(t, "model.outgoing_hook.is_valid.payload_template.app_error", err.Id)
+	})
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
@@ -143,9 +143,30 @@ func getConfigStore(command *cobra.Command) (config.Store, error) {
 		return nil, errors.Wrap(err, "failed to initialize config store")
 	}
 
+	if err := setConfigFollower(configStore); err != nil {
+		return nil, err
+	}
+
 	return configStore, nil
 }
 
+// setConfigFollower makes a database-backed config store also write its changes to the follower
+// database given by the configfollower flag, if any.
+func setConfigFollower(configStore config.Store) error {
+	followerDSN := viper.GetString("configfollower")
+	if followerDSN == "" {
+		return nil
+	}
+
+	databaseStore, ok := configStore.(*config.DatabaseStore)
+	if !ok {
+		return errors.New("a configuration follower requires the configuration to be stored in a database")
+	}
+	databaseStore.WithFollower(followerDSN)
+
+	return nil
+}
+
 func configGetCmdF(command *cobra.Command, args []string) error {
 	configStore, err := getConfigStore(command)
 	if err != nil {
@@ -34,6 +34,8 @@ var BulkImportCmd = &cobra.Command{
 }
 
 func init() {
+	SlackImportCmd.Flags().Bool("force-lock", false, "Take over the import lock if another node has held it for longer than ImportSettings.LockTimeout.")
+
 	BulkImportCmd.Flags().Bool("apply", false, "Save the import data to the database. Use with caution - this cannot be reverted.")
 	BulkImportCmd.Flags().Bool("validate", false, "Validate the import data without making any changes to the system.")
 	BulkImportCmd.Flags().Int("workers", 2, "How many workers to run whilst doing the import.")
@@ -72,12 +74,17 @@ func slackImportCmdF(command *cobra.Command, args []string) error {
 		return err
 	}
 
+	forceLock, err := command.Flags().GetBool("force-lock")
+	if err != nil {
+		return errors.New("Force lock flag error")
+	}
+
 	CommandPrettyPrintln("Running Slack Import. This may take a long time for large teams or teams with many messages.")
 
-	importErr, log := a.SlackImport(fileReader, fileInfo.Size(), team.Id)
+	importErr, log := a.SlackImport(fileReader, fileInfo.Size(), team.Id, forceLock)
 
 	if importErr != nil {
-		return err
+		return importErr
 	}
 
 	CommandPrettyPrintln("")
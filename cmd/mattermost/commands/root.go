@@ -23,6 +23,7 @@ var RootCmd = &cobra.Command{
 
 func init() {
 	RootCmd.PersistentFlags().StringP("config", "c", "config.json", "Configuration file to use.")
+	RootCmd.PersistentFlags().String("configfollower", "", "Database to which changes to a database-backed configuration are also written, such as one in another region.")
 	RootCmd.PersistentFlags().Bool("disableconfigwatch", false, "When set config.json will not be loaded from disk when the file is changed.")
 	RootCmd.PersistentFlags().Bool("platform", false, "This flag signifies that the user tried to start the command from the platform binary, so we can log a mssage")
 	RootCmd.PersistentFlags().MarkHidden("platform")
@@ -30,4 +31,6 @@ func init() {
 	viper.SetEnvPrefix("mm")
 	viper.BindEnv("config")
 	viper.BindPFlag("config", RootCmd.PersistentFlags().Lookup("config"))
+	viper.BindEnv("configfollower")
+	viper.BindPFlag("configfollower", RootCmd.PersistentFlags().Lookup("configfollower"))
 }
@@ -50,6 +50,10 @@ func serverCmdF(command *cobra.Command, args []string) error {
 		return errors.Wrap(err, "failed to load configuration")
 	}
 
+	if err := setConfigFollower(configStore); err != nil {
+		return err
+	}
+
 	return runServer(configStore, disableConfigWatch, usedPlatform, interruptChan)
 }
 
@@ -118,6 +118,7 @@ func GenerateClientConfig(c *model.Config, diagnosticId string, license *model.L
 	props["AllowBannerDismissal"] = "false"
 	props["EnableThemeSelection"] = "true"
 	props["DefaultTheme"] = ""
+	props["DefaultCustomTheme"] = ""
 	props["AllowCustomThemes"] = "true"
 	props["AllowedThemes"] = ""
 	props["DataRetentionEnableMessageDeletion"] = "false"
@@ -179,6 +180,11 @@ func GenerateClientConfig(c *model.Config, diagnosticId string, license *model.L
 		if *license.Features.ThemeManagement {
 			props["EnableThemeSelection"] = strconv.FormatBool(*c.ThemeSettings.EnableThemeSelection)
 			props["DefaultTheme"] = *c.ThemeSettings.DefaultTheme
+			props["DefaultCustomTheme"] = c.ThemeSettings.DefaultCustomTheme()
+			if props["DefaultCustomTheme"] != "" {
+				// Clients expect DefaultTheme to name one of the built-in themes.
+				props["DefaultTheme"] = model.TEAM_SETTINGS_DEFAULT_TEAM_TEXT
+			}
 			props["AllowCustomThemes"] = strconv.FormatBool(*c.ThemeSettings.AllowCustomThemes)
 			props["AllowedThemes"] = strings.Join(c.ThemeSettings.AllowedThemes, ",")
 		}
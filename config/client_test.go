@@ -93,6 +93,44 @@ func TestGetClientConfig(t *testing.T) {
 				"DiagnosticId":                  "tag2",
 				"EmailNotificationContentsType": "full",
 				"AllowCustomThemes":             "false",
+				"DefaultTheme":                  "default",
+				"DefaultCustomTheme":            "",
+			},
+		},
+		{
+			"licensed for theme management, with a built-in default theme",
+			&model.Config{
+				ThemeSettings: model.ThemeSettings{
+					DefaultTheme: sToP("mattermostDark"),
+				},
+			},
+			"tag2",
+			&model.License{
+				Features: &model.Features{
+					ThemeManagement: bToP(true),
+				},
+			},
+			map[string]string{
+				"DefaultTheme":       "mattermostDark",
+				"DefaultCustomTheme": "",
+			},
+		},
+		{
+			"licensed for theme management, with a custom default theme",
+			&model.Config{
+				ThemeSettings: model.ThemeSettings{
+					DefaultTheme: sToP(`{"sidebarBg":"#ffffff"}`),
+				},
+			},
+			"tag2",
+			&model.License{
+				Features: &model.Features{
+					ThemeManagement: bToP(true),
+				},
+			},
+			map[string]string{
+				"DefaultTheme":       "default",
+				"DefaultCustomTheme": `{"sidebarBg":"#ffffff"}`,
 			},
 		},
 		{
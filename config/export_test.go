@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"io"
 
 	"github.com/jmoiron/sqlx"
@@ -19,7 +20,17 @@ func UnmarshalConfig(r io.Reader, allowEnvironmentOverrides bool) (*model.Config
 
 // InitializeConfigurationsTable exposes the internal initializeConfigurationsTable to test only.
 func InitializeConfigurationsTable(db *sqlx.DB) error {
-	return initializeConfigurationsTable(db)
+	return initializeConfigurationsTable(context.Background(), db, "")
+}
+
+// ParseDSN exposes the internal parseDSN to test only.
+func ParseDSN(dsn string) (string, string, error) {
+	return parseDSN(dsn)
+}
+
+// ParseSchemaName exposes the internal parseSchemaName to test only.
+func ParseSchemaName(driverName, dataSourceName string) (string, error) {
+	return parseSchemaName(driverName, dataSourceName)
 }
 
 // ResolveConfigFilePath exposes the internal resolveConfigFilePath to test only.
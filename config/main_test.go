@@ -2,16 +2,38 @@ package config_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/testlib"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 )
 
 var mainHelper *testlib.MainHelper
 
+// sqliteTestDSN is a shared, in-memory SQLite database against which the DatabaseStore tests also
+// run, in addition to the test helper's database.
+const sqliteTestDSN = "sqlite://file::memory:?cache=shared"
+
+// testDatabase is a database against which the DatabaseStore tests run.
+type testDatabase struct {
+	dsn string
+	db  *sqlx.DB
+}
+
+var testDatabases []testDatabase
+
+// testDSN and testDB identify the database against which the current test runs, as set by
+// forEachTestDatabase.
+var (
+	testDSN string
+	testDB  *sqlx.DB
+)
+
 func TestMain(m *testing.M) {
 	var options = testlib.HelperOptions{
 		EnableStore: true,
@@ -20,18 +42,47 @@ func TestMain(m *testing.M) {
 	mainHelper = testlib.NewMainHelperWithOptions(&options)
 	defer mainHelper.Close()
 
+	sqlSettings := mainHelper.GetSqlSettings()
+	testDatabases = append(testDatabases, testDatabase{
+		dsn: fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource),
+		db:  sqlx.NewDb(mainHelper.GetSqlSupplier().GetMaster().Db, *sqlSettings.DriverName),
+	})
+
+	// The in-memory database is discarded once its last connection closes, so hold one open for
+	// the duration of the tests.
+	sqliteDB, err := sqlx.Connect("sqlite3", strings.TrimPrefix(sqliteTestDSN, "sqlite://"))
+	if err != nil {
+		panic("failed to open test database: " + err.Error())
+	}
+	defer sqliteDB.Close()
+
+	testDatabases = append(testDatabases, testDatabase{
+		dsn: sqliteTestDSN,
+		db:  sqliteDB,
+	})
+
 	mainHelper.Main(m)
 }
 
+// forEachTestDatabase runs the given test as a subtest against each test database in turn.
+func forEachTestDatabase(t *testing.T, test func(t *testing.T)) {
+	t.Helper()
+
+	for _, database := range testDatabases {
+		testDSN = database.dsn
+		testDB = database.db
+
+		t.Run(database.db.DriverName(), test)
+	}
+}
+
 // truncateTable clears the given table
 func truncateTable(t *testing.T, table string) {
 	t.Helper()
-	sqlSetting := mainHelper.GetSqlSettings()
-	sqlSupplier := mainHelper.GetSqlSupplier()
 
-	switch *sqlSetting.DriverName {
+	switch testDB.DriverName() {
 	case model.DATABASE_DRIVER_MYSQL:
-		_, err := sqlSupplier.GetMaster().Db.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
+		_, err := testDB.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
 		if err != nil {
 			if driverErr, ok := err.(*mysql.MySQLError); ok {
 				// Ignore if the Configurations table does not exist.
@@ -43,11 +94,19 @@ func truncateTable(t *testing.T, table string) {
 		require.NoError(t, err)
 
 	case model.DATABASE_DRIVER_POSTGRES:
-		_, err := sqlSupplier.GetMaster().Db.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
+		_, err := testDB.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
+		require.NoError(t, err)
+
+	case model.DATABASE_DRIVER_SQLITE:
+		_, err := testDB.Exec(fmt.Sprintf("DELETE FROM %s", table))
+		if err != nil && strings.HasPrefix(err.Error(), "no such table") {
+			// Ignore if the Configurations table does not exist.
+			return
+		}
 		require.NoError(t, err)
 
 	default:
-		t.Fatalf("unsupported driver name: %s", *sqlSetting.DriverName)
+		t.Fatalf("unsupported driver name: %s", testDB.DriverName())
 	}
 }
 
@@ -2,17 +2,54 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
 	"github.com/mattermost/mattermost-server/testlib"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
-func TestMigrateDatabaseToFile(t *testing.T) {
+// tempConfigFile returns the path to a config.json in a new temporary directory, removed by the
+// returned function, so that migrating to a file doesn't write to the working directory.
+func tempConfigFile(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "config-migrate")
+	require.NoError(t, err)
+
+	return filepath.Join(dir, "config.json"), func() { os.RemoveAll(dir) }
+}
+
+// forEachMigrateTestDatabase runs the given test as a subtest against the test helper's database
+// and against an in-memory SQLite database.
+func forEachMigrateTestDatabase(t *testing.T, test func(t *testing.T, sqlDSN string)) {
+	t.Helper()
+
 	helper := testlib.NewMainHelper()
 	sqlSettings := helper.GetSqlSettings()
-	sqlDSN := fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource)
-	fileDSN := "config.json"
+
+	sqlDSNs := []string{
+		fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource),
+		"sqlite://file::memory:?cache=shared",
+	}
+	for _, sqlDSN := range sqlDSNs {
+		t.Run(strings.SplitN(sqlDSN, "://", 2)[0], func(t *testing.T) {
+			test(t, sqlDSN)
+		})
+	}
+}
+
+func TestMigrateDatabaseToFile(t *testing.T) {
+	forEachMigrateTestDatabase(t, testMigrateDatabaseToFile)
+}
+
+func testMigrateDatabaseToFile(t *testing.T, sqlDSN string) {
+	fileDSN, cleanup := tempConfigFile(t)
+	defer cleanup()
 	files := []string{"IdpCertificateFile", "PublicCertificateFile", "PrivateKeyFile"}
 	data := make([]byte, 5)
 	ds, err := NewDatabaseStore(sqlDSN)
@@ -47,10 +84,12 @@ func TestMigrateDatabaseToFile(t *testing.T) {
 }
 
 func TestMigrateFileToDatabaseWhenFilePathIsNotSpecified(t *testing.T) {
-	helper := testlib.NewMainHelper()
-	sqlSettings := helper.GetSqlSettings()
-	sqlDSN := fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource)
-	fileDSN := "config.json"
+	forEachMigrateTestDatabase(t, testMigrateFileToDatabaseWhenFilePathIsNotSpecified)
+}
+
+func testMigrateFileToDatabaseWhenFilePathIsNotSpecified(t *testing.T, sqlDSN string) {
+	fileDSN, cleanup := tempConfigFile(t)
+	defer cleanup()
 
 	_, err := NewFileStore(fileDSN, true)
 	require.NoError(t, err)
@@ -1,7 +1,6 @@
 package config_test
 
 import (
-	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,8 +11,6 @@ import (
 )
 
 func TestNewStore(t *testing.T) {
-	sqlSettings := mainHelper.GetSqlSettings()
-
 	tempDir, err := ioutil.TempDir("", "TestNewStore")
 	require.NoError(t, err)
 
@@ -22,14 +19,22 @@ func TestNewStore(t *testing.T) {
 
 	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "config"), 0700))
 
-	t.Run("database dsn", func(t *testing.T) {
-		ds, err := config.NewStore(fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource), false)
-		require.NoError(t, err)
-		ds.Close()
+	forEachTestDatabase(t, func(t *testing.T) {
+		t.Run("database dsn", func(t *testing.T) {
+			ds, err := config.NewStore(testDSN, false)
+			require.NoError(t, err)
+			ds.Close()
+		})
+
+		t.Run("database dsn, watch ignored", func(t *testing.T) {
+			ds, err := config.NewStore(testDSN, true)
+			require.NoError(t, err)
+			ds.Close()
+		})
 	})
 
-	t.Run("database dsn, watch ignored", func(t *testing.T) {
-		ds, err := config.NewStore(fmt.Sprintf("%s://%s", *sqlSettings.DriverName, *sqlSettings.DataSource), true)
+	t.Run("sqlite dsn", func(t *testing.T) {
+		ds, err := config.NewStore("sqlite://:memory:", false)
 		require.NoError(t, err)
 		ds.Close()
 	})
@@ -14,6 +14,8 @@ type ElasticsearchInterface interface {
 	Stop() *model.AppError
 	IndexPost(post *model.Post, teamId string) *model.AppError
 	SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError)
+	SearchPostsCount(channels *model.ChannelList, searchParams []*model.SearchParams) (int64, *model.AppError)
+	SearchRelatedPosts(post *model.Post, teamId string, limit int) ([]string, *model.AppError)
 	DeletePost(post *model.Post) *model.AppError
 	IndexChannel(channel *model.Channel) *model.AppError
 	SearchChannels(teamId, term string) ([]string, *model.AppError)
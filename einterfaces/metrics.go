@@ -11,6 +11,7 @@ type MetricsInterface interface {
 	IncrementWebhookPost()
 	IncrementPostSentEmail()
 	IncrementPostSentPush()
+	SetPushNotificationQueueDepth(depth int64)
 	IncrementPostBroadcast()
 	IncrementPostFileAttachment(count int)
 
@@ -44,5 +45,8 @@ type MetricsInterface interface {
 	IncrementPostsSearchCounter()
 	ObservePostsSearchDuration(elapsed float64)
 	ObserveStoreMethodDuration(method string, success string, elapsed float64)
+	SetDatabaseLongRunningTransactionCount(count int64)
+	SetEmailQueueDepth(depth int64)
+	IncrementConfigReplicationFailure()
 	ObserveApiEndpointDuration(endpoint string, elapsed float64)
 }
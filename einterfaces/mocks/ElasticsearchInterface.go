@@ -200,6 +200,54 @@ func (_m *ElasticsearchInterface) SearchPosts(channels *model.ChannelList, searc
 	return r0, r1, r2
 }
 
+// SearchPostsCount provides a mock function with given fields: channels, searchParams
+func (_m *ElasticsearchInterface) SearchPostsCount(channels *model.ChannelList, searchParams []*model.SearchParams) (int64, *model.AppError) {
+	ret := _m.Called(channels, searchParams)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*model.ChannelList, []*model.SearchParams) int64); ok {
+		r0 = rf(channels, searchParams)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.ChannelList, []*model.SearchParams) *model.AppError); ok {
+		r1 = rf(channels, searchParams)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SearchRelatedPosts provides a mock function with given fields: post, teamId, limit
+func (_m *ElasticsearchInterface) SearchRelatedPosts(post *model.Post, teamId string, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(post, teamId, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(*model.Post, string, int) []string); ok {
+		r0 = rf(post, teamId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.Post, string, int) *model.AppError); ok {
+		r1 = rf(post, teamId, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SearchUsersInChannel provides a mock function with given fields: teamId, channelId, restrictedToChannels, term, options
 func (_m *ElasticsearchInterface) SearchUsersInChannel(teamId string, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
 	ret := _m.Called(teamId, channelId, restrictedToChannels, term, options)
@@ -31,6 +31,11 @@ func (_m *MetricsInterface) IncrementClusterRequest() {
 	_m.Called()
 }
 
+// IncrementConfigReplicationFailure provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementConfigReplicationFailure() {
+	_m.Called()
+}
+
 // IncrementEtagHitCounter provides a mock function with given fields: route
 func (_m *MetricsInterface) IncrementEtagHitCounter(route string) {
 	_m.Called(route)
@@ -136,6 +141,11 @@ func (_m *MetricsInterface) IncrementWebsocketEvent(eventType string) {
 	_m.Called(eventType)
 }
 
+// ObserveApiEndpointDuration provides a mock function with given fields: endpoint, elapsed
+func (_m *MetricsInterface) ObserveApiEndpointDuration(endpoint string, elapsed float64) {
+	_m.Called(endpoint, elapsed)
+}
+
 // ObserveClusterRequestDuration provides a mock function with given fields: elapsed
 func (_m *MetricsInterface) ObserveClusterRequestDuration(elapsed float64) {
 	_m.Called(elapsed)
@@ -151,6 +161,26 @@ func (_m *MetricsInterface) ObservePostsSearchDuration(elapsed float64) {
 	_m.Called(elapsed)
 }
 
+// ObserveStoreMethodDuration provides a mock function with given fields: method, success, elapsed
+func (_m *MetricsInterface) ObserveStoreMethodDuration(method string, success string, elapsed float64) {
+	_m.Called(method, success, elapsed)
+}
+
+// SetDatabaseLongRunningTransactionCount provides a mock function with given fields: count
+func (_m *MetricsInterface) SetDatabaseLongRunningTransactionCount(count int64) {
+	_m.Called(count)
+}
+
+// SetEmailQueueDepth provides a mock function with given fields: depth
+func (_m *MetricsInterface) SetEmailQueueDepth(depth int64) {
+	_m.Called(depth)
+}
+
+// SetPushNotificationQueueDepth provides a mock function with given fields: depth
+func (_m *MetricsInterface) SetPushNotificationQueueDepth(depth int64) {
+	_m.Called(depth)
+}
+
 // StartServer provides a mock function with given fields:
 func (_m *MetricsInterface) StartServer() {
 	_m.Called()
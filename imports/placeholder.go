@@ -6,6 +6,7 @@ package imports
 // This is a placeholder so this package can be imported in Team Edition when it will be otherwise empty
 
 import (
+	_ "github.com/mattermost/mattermost-server/messageexport"
 	_ "github.com/mattermost/mattermost-server/migrations"
 	_ "github.com/mattermost/mattermost-server/plugin/scheduler"
 )
@@ -121,6 +121,13 @@ func (watcher *Watcher) PollAndNotify() {
 				default:
 				}
 			}
+		} else if job.Type == model.JOB_TYPE_SCHEDULED_MESSAGE_EXPORT {
+			if watcher.workers.ScheduledMessageExport != nil {
+				select {
+				case watcher.workers.ScheduledMessageExport.JobChannel() <- *job:
+				default:
+				}
+			}
 		}
 	}
 }
@@ -24,6 +24,7 @@ type JobServer struct {
 	LdapSync                ejobs.LdapSyncInterface
 	Migrations              tjobs.MigrationsJobInterface
 	Plugins                 tjobs.PluginsJobInterface
+	ScheduledMessageExport  tjobs.ScheduledMessageExportJobInterface
 }
 
 func NewJobServer(configService configservice.ConfigService, store store.Store) *JobServer {
@@ -23,6 +23,7 @@ type Workers struct {
 	LdapSync                 model.Worker
 	Migrations               model.Worker
 	Plugins                  model.Worker
+	ScheduledMessageExport   model.Worker
 
 	listenerId string
 }
@@ -61,6 +62,10 @@ func (srv *JobServer) InitWorkers() *Workers {
 		workers.Plugins = pluginsInterface.MakeWorker()
 	}
 
+	if scheduledMessageExportInterface := srv.ScheduledMessageExport; scheduledMessageExportInterface != nil {
+		workers.ScheduledMessageExport = scheduledMessageExportInterface.MakeWorker()
+	}
+
 	return workers
 }
 
@@ -96,6 +101,10 @@ func (workers *Workers) Start() *Workers {
 			go workers.Plugins.Run()
 		}
 
+		if workers.ScheduledMessageExport != nil {
+			go workers.ScheduledMessageExport.Run()
+		}
+
 		go workers.Watcher.Start()
 	})
 
@@ -181,6 +190,10 @@ func (workers *Workers) Stop() *Workers {
 		workers.Plugins.Stop()
 	}
 
+	if workers.ScheduledMessageExport != nil {
+		workers.ScheduledMessageExport.Stop()
+	}
+
 	mlog.Info("Stopped workers")
 
 	return workers
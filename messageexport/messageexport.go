@@ -0,0 +1,19 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package messageexport
+
+import (
+	"github.com/mattermost/mattermost-server/app"
+	tjobs "github.com/mattermost/mattermost-server/jobs/interfaces"
+)
+
+type ScheduledMessageExportJobInterfaceImpl struct {
+	App *app.App
+}
+
+func init() {
+	app.RegisterJobsScheduledMessageExportJobInterface(func(a *app.App) tjobs.ScheduledMessageExportJobInterface {
+		return &ScheduledMessageExportJobInterfaceImpl{a}
+	})
+}
@@ -139,6 +139,17 @@ func ChannelPatchFromJson(data io.Reader) *ChannelPatch {
 	return o
 }
 
+func ChannelMapToJson(channels map[string]*Channel) string {
+	b, _ := json.Marshal(channels)
+	return string(b)
+}
+
+func ChannelMapFromJson(data io.Reader) map[string]*Channel {
+	var channels map[string]*Channel
+	json.NewDecoder(data).Decode(&channels)
+	return channels
+}
+
 func (o *Channel) Etag() string {
 	return Etag(o.Id, o.UpdateAt)
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const CHANNEL_MEMBERS_BULK_MAX = 500
+
+// ChannelMemberToAdd is a user to add to a channel in bulk, along with the space separated roles
+// to give them in the channel. The default channel roles are used if Roles is empty.
+type ChannelMemberToAdd struct {
+	UserId string `json:"user_id"`
+	Roles  string `json:"roles"`
+}
+
+type ChannelMemberBulkError struct {
+	UserId string `json:"user_id"`
+	Error  string `json:"error"`
+}
+
+// ChannelMembersBulkResult describes the outcome of adding members to a channel in bulk. Users
+// that were already members of the channel are skipped, while users that couldn't be added are
+// listed in Errors.
+type ChannelMembersBulkResult struct {
+	Added   int                       `json:"added"`
+	Skipped int                       `json:"skipped"`
+	Errors  []*ChannelMemberBulkError `json:"errors"`
+}
+
+func ChannelMembersToAddToJson(members []*ChannelMemberToAdd) string {
+	b, _ := json.Marshal(members)
+	return string(b)
+}
+
+func ChannelMembersToAddFromJson(data io.Reader) []*ChannelMemberToAdd {
+	var o []*ChannelMemberToAdd
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *ChannelMembersBulkResult) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMembersBulkResultFromJson(data io.Reader) *ChannelMembersBulkResult {
+	var o *ChannelMembersBulkResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
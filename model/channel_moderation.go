@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	CHANNEL_MODERATED_PERMISSION_CREATE_POST      = "create_post"
+	CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS = "create_reactions"
+	CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS   = "manage_members"
+)
+
+var ChannelModeratedPermissions = []string{
+	CHANNEL_MODERATED_PERMISSION_CREATE_POST,
+	CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS,
+	CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS,
+}
+
+// ChannelModeratedRoles holds whether channel members and guests are granted a moderated
+// permission. A nil value is left unchanged when patching and is omitted for permissions that
+// can't be granted to guests.
+type ChannelModeratedRoles struct {
+	Members *bool `json:"members,omitempty"`
+	Guests  *bool `json:"guests,omitempty"`
+}
+
+type ChannelModeration struct {
+	Roles *ChannelModeratedRoles `json:"roles"`
+}
+
+// ChannelModerations maps the name of each moderated permission to the roles that are granted it.
+type ChannelModerations map[string]*ChannelModeration
+
+func (o ChannelModerations) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelModerationsFromJson(data io.Reader) ChannelModerations {
+	var o ChannelModerations
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// ChannelModeratedPermissionIds returns the ids of the role permissions controlled by the given
+// moderated permission in a channel of the given type.
+func ChannelModeratedPermissionIds(name string, channelType string) []string {
+	switch name {
+	case CHANNEL_MODERATED_PERMISSION_CREATE_POST:
+		return []string{PERMISSION_CREATE_POST.Id}
+	case CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS:
+		return []string{PERMISSION_ADD_REACTION.Id, PERMISSION_REMOVE_REACTION.Id}
+	case CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS:
+		if channelType == CHANNEL_PRIVATE {
+			return []string{PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS.Id}
+		}
+		return []string{PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id}
+	}
+
+	return nil
+}
+
+// IsChannelModeratedPermissionForGuests returns true if the given moderated permission may be
+// granted to channel guests.
+func IsChannelModeratedPermissionForGuests(name string) bool {
+	return name != CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS
+}
+
+// IsValid checks that every moderated permission is known and only sets the roles it applies to.
+func (o ChannelModerations) IsValid() *AppError {
+	for name, moderation := range o {
+		if ChannelModeratedPermissionIds(name, CHANNEL_OPEN) == nil {
+			return NewAppError("ChannelModerations.IsValid", "model.channel_moderations.is_valid.name.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+		}
+
+		if moderation == nil || moderation.Roles == nil {
+			return NewAppError("ChannelModerations.IsValid", "model.channel_moderations.is_valid.roles.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+		}
+
+		if moderation.Roles.Guests != nil && !IsChannelModeratedPermissionForGuests(name) {
+			return NewAppError("ChannelModerations.IsValid", "model.channel_moderations.is_valid.guests.app_error", map[string]interface{}{"Name": name}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
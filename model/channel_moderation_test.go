@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelModerationsJson(t *testing.T) {
+	moderations := ChannelModerations{
+		CHANNEL_MODERATED_PERMISSION_CREATE_POST:    {Roles: &ChannelModeratedRoles{Members: NewBool(true), Guests: NewBool(false)}},
+		CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS: {Roles: &ChannelModeratedRoles{Members: NewBool(false)}},
+	}
+
+	json := moderations.ToJson()
+	assert.Contains(t, json, `"manage_members":{"roles":{"members":false}}`)
+
+	result := ChannelModerationsFromJson(strings.NewReader(json))
+	require.NotNil(t, result)
+	assert.Equal(t, moderations, result)
+}
+
+func TestChannelModeratedPermissionIds(t *testing.T) {
+	assert.Equal(t, []string{PERMISSION_CREATE_POST.Id}, ChannelModeratedPermissionIds(CHANNEL_MODERATED_PERMISSION_CREATE_POST, CHANNEL_OPEN))
+	assert.Equal(t, []string{PERMISSION_MANAGE_PUBLIC_CHANNEL_MEMBERS.Id}, ChannelModeratedPermissionIds(CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS, CHANNEL_OPEN))
+	assert.Equal(t, []string{PERMISSION_MANAGE_PRIVATE_CHANNEL_MEMBERS.Id}, ChannelModeratedPermissionIds(CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS, CHANNEL_PRIVATE))
+	assert.Nil(t, ChannelModeratedPermissionIds("delete_channel", CHANNEL_OPEN))
+}
+
+func TestChannelModerationsIsValid(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		moderations := ChannelModerations{
+			CHANNEL_MODERATED_PERMISSION_CREATE_REACTIONS: {Roles: &ChannelModeratedRoles{Guests: NewBool(false)}},
+			CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS:   {Roles: &ChannelModeratedRoles{Members: NewBool(true)}},
+		}
+		assert.Nil(t, moderations.IsValid())
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		moderations := ChannelModerations{"delete_channel": {Roles: &ChannelModeratedRoles{Members: NewBool(true)}}}
+		assert.NotNil(t, moderations.IsValid())
+	})
+
+	t.Run("missing roles", func(t *testing.T) {
+		moderations := ChannelModerations{CHANNEL_MODERATED_PERMISSION_CREATE_POST: {}}
+		assert.NotNil(t, moderations.IsValid())
+	})
+
+	t.Run("guests not applicable", func(t *testing.T) {
+		moderations := ChannelModerations{CHANNEL_MODERATED_PERMISSION_MANAGE_MEMBERS: {Roles: &ChannelModeratedRoles{Guests: NewBool(true)}}}
+		assert.NotNil(t, moderations.IsValid())
+	})
+}
@@ -25,3 +25,18 @@ func ChannelStatsFromJson(data io.Reader) *ChannelStats {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+type ChannelMessageCount struct {
+	Count int64 `json:"count"`
+}
+
+func (o *ChannelMessageCount) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelMessageCountFromJson(data io.Reader) *ChannelMessageCount {
+	var o *ChannelMessageCount
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
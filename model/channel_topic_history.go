@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	CHANNEL_TOPIC_TYPE_HEADER  = "header"
+	CHANNEL_TOPIC_TYPE_PURPOSE = "purpose"
+
+	CHANNEL_TOPIC_HISTORY_DEFAULT_LIMIT = 10
+	CHANNEL_TOPIC_HISTORY_MAX_LIMIT     = 200
+)
+
+// ChannelTopicHistory records a change to the header or purpose of a channel.
+type ChannelTopicHistory struct {
+	Id          string `json:"id"`
+	ChannelId   string `json:"channel_id"`
+	Type        string `json:"type"`
+	Topic       string `json:"topic"`
+	SetByUserId string `json:"set_by_user_id"`
+	CreateAt    int64  `json:"create_at"`
+}
+
+func (o *ChannelTopicHistory) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *ChannelTopicHistory) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.ChannelId) != 26 {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Type != CHANNEL_TOPIC_TYPE_HEADER && o.Type != CHANNEL_TOPIC_TYPE_PURPOSE {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.Topic) > CHANNEL_HEADER_MAX_RUNES {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.topic.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.SetByUserId) != 26 {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.set_by_user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ChannelTopicHistory.IsValid", "model.channel_topic_history.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ChannelTopicHistoryListToJson(list []*ChannelTopicHistory) string {
+	b, _ := json.Marshal(list)
+	return string(b)
+}
+
+func ChannelTopicHistoryListFromJson(data io.Reader) []*ChannelTopicHistory {
+	var list []*ChannelTopicHistory
+	json.NewDecoder(data).Decode(&list)
+	return list
+}
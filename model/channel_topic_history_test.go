@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelTopicHistoryIsValid(t *testing.T) {
+	history := &ChannelTopicHistory{
+		ChannelId:   NewId(),
+		Type:        CHANNEL_TOPIC_TYPE_HEADER,
+		Topic:       "topic",
+		SetByUserId: NewId(),
+	}
+
+	require.NotNil(t, history.IsValid())
+
+	history.PreSave()
+	require.NotEmpty(t, history.Id)
+	require.NotZero(t, history.CreateAt)
+	require.Nil(t, history.IsValid())
+
+	history.Topic = ""
+	require.Nil(t, history.IsValid(), "clearing the topic should be recorded")
+
+	history.Topic = strings.Repeat("a", CHANNEL_HEADER_MAX_RUNES+1)
+	require.NotNil(t, history.IsValid())
+	history.Topic = "topic"
+
+	history.Type = "display_name"
+	require.NotNil(t, history.IsValid())
+	history.Type = CHANNEL_TOPIC_TYPE_PURPOSE
+
+	history.SetByUserId = ""
+	require.NotNil(t, history.IsValid())
+	history.SetByUserId = NewId()
+
+	history.ChannelId = "junk"
+	require.NotNil(t, history.IsValid())
+}
+
+func TestChannelTopicHistoryListJson(t *testing.T) {
+	list := []*ChannelTopicHistory{
+		{Id: NewId(), ChannelId: NewId(), Type: CHANNEL_TOPIC_TYPE_HEADER, Topic: "header", SetByUserId: NewId(), CreateAt: 1},
+		{Id: NewId(), ChannelId: NewId(), Type: CHANNEL_TOPIC_TYPE_PURPOSE, Topic: "purpose", SetByUserId: NewId(), CreateAt: 2},
+	}
+
+	assert.Equal(t, list, ChannelTopicHistoryListFromJson(strings.NewReader(ChannelTopicHistoryListToJson(list))))
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ChannelWebhooks lists the incoming webhooks posting to a channel and the outgoing webhooks
+// triggered by posts in it.
+type ChannelWebhooks struct {
+	Incoming []*IncomingWebhook `json:"incoming"`
+	Outgoing []*OutgoingWebhook `json:"outgoing"`
+}
+
+func (o *ChannelWebhooks) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ChannelWebhooksFromJson(data io.Reader) *ChannelWebhooks {
+	var o *ChannelWebhooks
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -32,6 +32,7 @@ const (
 	HEADER_AUTH               = "Authorization"
 	HEADER_REQUESTED_WITH     = "X-Requested-With"
 	HEADER_REQUESTED_WITH_XML = "XMLHttpRequest"
+	HEADER_SEARCH_TRUNCATED   = "X-Search-Truncated"
 	STATUS                    = "status"
 	STATUS_OK                 = "OK"
 	STATUS_FAIL               = "FAIL"
@@ -384,6 +385,10 @@ func (c *Client4) GetJobsRoute() string {
 	return fmt.Sprintf("/jobs")
 }
 
+func (c *Client4) GetExportRoute() string {
+	return fmt.Sprintf("/export")
+}
+
 func (c *Client4) GetRolesRoute() string {
 	return fmt.Sprintf("/roles")
 }
@@ -794,6 +799,18 @@ func (c *Client4) AutocompleteUsersInChannel(teamId string, channelId string, us
 	return UserAutocompleteFromJson(r.Body), BuildResponse(r)
 }
 
+// FuzzyAutocompleteUsersInChannel returns the users in a channel whose names start with or, if fuzzy
+// autocompletion is enabled on the server, are similar to the search term.
+func (c *Client4) FuzzyAutocompleteUsersInChannel(teamId string, channelId string, username string, limit int, etag string) (*UserAutocomplete, *Response) {
+	query := fmt.Sprintf("?in_team=%v&in_channel=%v&name=%v&limit=%d&fuzzy=true", teamId, channelId, username, limit)
+	r, err := c.DoApiGet(c.GetUsersRoute()+"/autocomplete"+query, etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserAutocompleteFromJson(r.Body), BuildResponse(r)
+}
+
 // AutocompleteUsers returns the users in the system based on search term.
 func (c *Client4) AutocompleteUsers(username string, limit int, etag string) (*UserAutocomplete, *Response) {
 	query := fmt.Sprintf("?name=%v&limit=%d", username, limit)
@@ -945,6 +962,17 @@ func (c *Client4) GetUsersByIds(userIds []string) ([]*User, *Response) {
 	return UserListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetUsersBatch returns the users with the given ids, leaving out any that do not exist.
+func (c *Client4) GetUsersBatch(userIds []string) ([]*User, *Response) {
+	batch := &UserIdsBatch{UserIds: userIds}
+	r, err := c.DoApiPost(c.GetUsersRoute()+"/batch", batch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return UserListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetUsersByIds returns a list of users based on the provided user ids.
 func (c *Client4) GetUsersByIdsWithOptions(userIds []string, options *UserGetByIdsOptions) ([]*User, *Response) {
 	v := url.Values{}
@@ -1076,6 +1104,24 @@ func (c *Client4) GenerateMfaSecret(userId string) (*MfaSecret, *Response) {
 	return MfaSecretFromJson(r.Body), BuildResponse(r)
 }
 
+// VerifyUserMfaToken checks whether the given token is valid for a user's MFA device without
+// logging in as them. Must be a system administrator.
+func (c *Client4) VerifyUserMfaToken(userId, token string) (bool, *Response) {
+	requestBody := map[string]string{"token": token}
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/mfa/totp/verify", MapToJson(requestBody))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data := StringInterfaceFromJson(r.Body)
+	isValid, ok := data["is_valid"].(bool)
+	if !ok {
+		return false, BuildResponse(r)
+	}
+	return isValid, BuildResponse(r)
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(userId, currentPassword, newPassword string) (bool, *Response) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -1174,6 +1220,16 @@ func (c *Client4) GetSessions(userId, etag string) ([]*Session, *Response) {
 	return SessionsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetSessionActivity returns the device, platform and activity details of a user's active sessions.
+func (c *Client4) GetSessionActivity(userId string) ([]*SessionActivity, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/sessions/activity", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SessionActivityListFromJson(r.Body), BuildResponse(r)
+}
+
 // RevokeSession revokes a user session based on the provided user id and session id strings.
 func (c *Client4) RevokeSession(userId, sessionId string) (bool, *Response) {
 	requestBody := map[string]string{"session_id": sessionId}
@@ -1850,6 +1906,18 @@ func (c *Client4) RemoveTeamMember(teamId, userId string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// BulkRemoveTeamMembers removes each of the given users from the team, returning the number of users
+// removed and the reasons any others could not be.
+func (c *Client4) BulkRemoveTeamMembers(teamId string, userIds []string) (*TeamMembersBulkRemoveResult, *Response) {
+	bulkRemove := &TeamMembersBulkRemove{UserIds: userIds}
+	r, err := c.DoApiPost(c.GetTeamMembersRoute(teamId)+"/bulk_remove", bulkRemove.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TeamMembersBulkRemoveResultFromJson(r.Body), BuildResponse(r)
+}
+
 // GetTeamStats returns a team stats based on the team id string.
 // Must be authenticated.
 func (c *Client4) GetTeamStats(teamId, etag string) (*TeamStats, *Response) {
@@ -1861,6 +1929,45 @@ func (c *Client4) GetTeamStats(teamId, etag string) (*TeamStats, *Response) {
 	return TeamStatsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetTeamGrowth returns the number of users who joined and left a team in each week or month from
+// the period containing from through the period containing to. A from of 0 returns the last 12
+// periods.
+func (c *Client4) GetTeamGrowth(teamId, granularity string, from, to int64) ([]*TeamGrowthPeriod, *Response) {
+	v := url.Values{}
+	v.Set("granularity", granularity)
+	if from != 0 {
+		v.Set("from", strconv.FormatInt(from, 10))
+	}
+	v.Set("to", strconv.FormatInt(to, 10))
+	r, err := c.DoApiGet(c.GetTeamStatsRoute(teamId)+"/growth?"+v.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TeamGrowthPeriodListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetTeamOnboardingChecklist returns the onboarding checklist of a team.
+func (c *Client4) GetTeamOnboardingChecklist(teamId string) (*TeamOnboardingChecklist, *Response) {
+	r, err := c.DoApiGet(c.GetTeamRoute(teamId)+"/onboarding/checklist", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TeamOnboardingChecklistFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateTeamOnboardingChecklist marks the given steps of a team's onboarding checklist as completed
+// or not and returns the updated checklist.
+func (c *Client4) UpdateTeamOnboardingChecklist(teamId string, checklist *TeamOnboardingChecklist) (*TeamOnboardingChecklist, *Response) {
+	r, err := c.DoApiPut(c.GetTeamRoute(teamId)+"/onboarding/checklist", checklist.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return TeamOnboardingChecklistFromJson(r.Body), BuildResponse(r)
+}
+
 // GetTotalUsersStats returns a total system user stats.
 // Must be authenticated.
 func (c *Client4) GetTotalUsersStats(etag string) (*UsersStats, *Response) {
@@ -2143,6 +2250,17 @@ func (c *Client4) CreateGroupChannel(userIds []string) (*Channel, *Response) {
 	return ChannelFromJson(r.Body), BuildResponse(r)
 }
 
+// GetOrCreateGroupChannel returns the group message channel for the provided user ids, creating it
+// if it does not exist yet.
+func (c *Client4) GetOrCreateGroupChannel(userIds []string) (*Channel, *Response) {
+	r, err := c.DoApiPost(c.GetChannelsRoute()+"/group?get_if_exists=true", ArrayToJson(userIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelFromJson(r.Body), BuildResponse(r)
+}
+
 // GetChannel returns a channel based on the provided channel id string.
 func (c *Client4) GetChannel(channelId, etag string) (*Channel, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelId), etag)
@@ -2163,6 +2281,56 @@ func (c *Client4) GetChannelStats(channelId string, etag string) (*ChannelStats,
 	return ChannelStatsFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelMessageCount returns the number of posts in a channel created at or after since and
+// before until. Either bound may be zero to leave that side unbounded.
+func (c *Client4) GetChannelMessageCount(channelId string, since, until int64) (int64, *Response) {
+	query := fmt.Sprintf("?since=%v&until=%v", since, until)
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/messages/count"+query, "")
+	if err != nil {
+		return 0, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	messageCount := ChannelMessageCountFromJson(r.Body)
+	if messageCount == nil {
+		return 0, BuildResponse(r)
+	}
+	return messageCount.Count, BuildResponse(r)
+}
+
+// GetChannelTopicHistory returns up to limit of the most recent changes to the header and purpose
+// of a channel, newest first.
+func (c *Client4) GetChannelTopicHistory(channelId string, limit int) ([]*ChannelTopicHistory, *Response) {
+	query := fmt.Sprintf("?limit=%v", limit)
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/topics/history"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelTopicHistoryListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetChannelModerations returns whether channel members and guests are granted each of the
+// moderated permissions in a channel.
+func (c *Client4) GetChannelModerations(channelId string) (ChannelModerations, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/moderation", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelModerationsFromJson(r.Body), BuildResponse(r)
+}
+
+// PatchChannelModerations grants or revokes moderated permissions for channel members and guests
+// and returns the resulting moderations.
+func (c *Client4) PatchChannelModerations(channelId string, patch ChannelModerations) (ChannelModerations, *Response) {
+	r, err := c.DoApiPut(c.GetChannelRoute(channelId)+"/moderation", patch.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelModerationsFromJson(r.Body), BuildResponse(r)
+}
+
 // GetChannelMembersTimezones gets a list of timezones for a channel.
 func (c *Client4) GetChannelMembersTimezones(channelId string) ([]string, *Response) {
 	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/timezones", "")
@@ -2215,6 +2383,17 @@ func (c *Client4) GetPublicChannelsByIdsForTeam(teamId string, channelIds []stri
 	return ChannelSliceFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelsByIds returns the channels with the given ids, keyed by id. Channels that the
+// user cannot read are returned as nil.
+func (c *Client4) GetChannelsByIds(channelIds []string) (map[string]*Channel, *Response) {
+	r, err := c.DoApiPost(c.GetChannelsRoute()+"/ids", ArrayToJson(channelIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMapFromJson(r.Body), BuildResponse(r)
+}
+
 // GetChannelsForTeamForUser returns a list channels of on a team for a user.
 func (c *Client4) GetChannelsForTeamForUser(teamId, userId, etag string) ([]*Channel, *Response) {
 	r, err := c.DoApiGet(c.GetUserRoute(userId)+c.GetTeamRoute(teamId)+"/channels", etag)
@@ -2225,6 +2404,41 @@ func (c *Client4) GetChannelsForTeamForUser(teamId, userId, etag string) ([]*Cha
 	return ChannelSliceFromJson(r.Body), BuildResponse(r)
 }
 
+// GetSidebarChannelOrder returns the order of the channels in a user's channel sidebar on a team.
+func (c *Client4) GetSidebarChannelOrder(userId, teamId string) (*SidebarChannelOrder, *Response) {
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+c.GetTeamRoute(teamId)+"/channels/categories", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SidebarChannelOrderFromJson(r.Body), BuildResponse(r)
+}
+
+// UpdateSidebarChannelOrder saves the order of the channels in a user's channel sidebar on a team.
+func (c *Client4) UpdateSidebarChannelOrder(userId, teamId string, channelIds []string) (*SidebarChannelOrder, *Response) {
+	r, err := c.DoApiPut(c.GetUserRoute(userId)+c.GetTeamRoute(teamId)+"/channels/categories/order", ArrayToJson(channelIds))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SidebarChannelOrderFromJson(r.Body), BuildResponse(r)
+}
+
+// GetRecentChannelsForUser returns the channels most recently viewed by the user, newest first. If teamId is set,
+// only channels on that team and direct and group messages are returned.
+func (c *Client4) GetRecentChannelsForUser(userId, teamId string, limit int) ([]*RecentChannel, *Response) {
+	query := fmt.Sprintf("?limit=%v", limit)
+	if teamId != "" {
+		query += "&team_id=" + url.QueryEscape(teamId)
+	}
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/channels/recent"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return RecentChannelsFromJson(r.Body), BuildResponse(r)
+}
+
 // SearchChannels returns the channels on a team matching the provided search term.
 func (c *Client4) SearchChannels(teamId string, search *ChannelSearch) ([]*Channel, *Response) {
 	r, err := c.DoApiPost(c.GetChannelsForTeamRoute(teamId)+"/search", search.ToJson())
@@ -2410,6 +2624,17 @@ func (c *Client4) AddChannelMember(channelId, userId string) (*ChannelMember, *R
 	return ChannelMemberFromJson(r.Body), BuildResponse(r)
 }
 
+// AddChannelMembers adds up to 500 users to a channel at once. Users that are already members of
+// the channel are skipped and users that couldn't be added are listed in the result.
+func (c *Client4) AddChannelMembers(channelId string, members []*ChannelMemberToAdd) (*ChannelMembersBulkResult, *Response) {
+	r, err := c.DoApiPost(c.GetChannelMembersRoute(channelId)+"/bulk", ChannelMembersToAddToJson(members))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelMembersBulkResultFromJson(r.Body), BuildResponse(r)
+}
+
 // AddChannelMemberWithRootId adds user to channel and return a channel member. Post add to channel message has the postRootId.
 func (c *Client4) AddChannelMemberWithRootId(channelId, userId, postRootId string) (*ChannelMember, *Response) {
 	requestBody := map[string]string{"user_id": userId, "post_root_id": postRootId}
@@ -2545,6 +2770,16 @@ func (c *Client4) GetPostThread(postId string, etag string) (*PostList, *Respons
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetRelatedPosts gets up to limit posts similar to the given post, ranked by similarity.
+func (c *Client4) GetRelatedPosts(postId string, limit int) ([]*Post, *Response) {
+	r, err := c.DoApiGet(c.GetPostRoute(postId)+fmt.Sprintf("/related?limit=%v", limit), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostSliceFromJson(r.Body), BuildResponse(r)
+}
+
 // GetPostsForChannel gets a page of posts with an array for ordering for a channel.
 func (c *Client4) GetPostsForChannel(channelId string, page, perPage int, etag string) (*PostList, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -2556,6 +2791,17 @@ func (c *Client4) GetPostsForChannel(channelId string, page, perPage int, etag s
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// StreamPostsForChannel opens a stream of the posts created in a channel, one JSON object per line,
+// interleaved with {"type":"heartbeat"} lines. The caller must close the returned stream.
+func (c *Client4) StreamPostsForChannel(channelId string, heartbeatIntervalSeconds int) (io.ReadCloser, *Response) {
+	query := fmt.Sprintf("?heartbeat_interval_seconds=%v", heartbeatIntervalSeconds)
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/posts/stream"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return r.Body, BuildResponse(r)
+}
+
 // GetFlaggedPostsForUser returns flagged posts of a user based on user id string.
 func (c *Client4) GetFlaggedPostsForUser(userId string, page int, perPage int) (*PostList, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -2567,6 +2813,54 @@ func (c *Client4) GetFlaggedPostsForUser(userId string, page int, perPage int) (
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetSavedPosts returns a page of the posts a user has saved for later, most recently saved first.
+func (c *Client4) GetSavedPosts(userId string, page, perPage int) ([]*SavedPost, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/posts/saved"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostListFromJson(r.Body), BuildResponse(r)
+}
+
+// AddSavedPost saves a post for later on behalf of a user.
+func (c *Client4) AddSavedPost(userId, postId string) (*SavedPost, *Response) {
+	savedPost := &SavedPost{PostId: postId}
+	r, err := c.DoApiPost(c.GetUserRoute(userId)+"/posts/saved", savedPost.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SavedPostFromJson(r.Body), BuildResponse(r)
+}
+
+// RemoveSavedPost removes a post from the posts a user has saved for later.
+func (c *Client4) RemoveSavedPost(userId, postId string) (bool, *Response) {
+	savedPost := &SavedPost{PostId: postId}
+	r, err := c.DoApiRequest(http.MethodDelete, c.ApiUrl+c.GetUserRoute(userId)+"/posts/saved", savedPost.ToJson(), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetMentionsForUser returns a page of the posts that mentioned a user, newest first. If teamId is
+// set, only mentions on that team and in direct and group messages are returned.
+func (c *Client4) GetMentionsForUser(userId, teamId string, page, perPage int) (*PostList, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	if teamId != "" {
+		query += "&team_id=" + teamId
+	}
+	r, err := c.DoApiGet(c.GetUserRoute(userId)+"/mentions"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostListFromJson(r.Body), BuildResponse(r)
+}
+
 // GetFlaggedPostsForUserInTeam returns flagged posts in team of a user based on user id string.
 func (c *Client4) GetFlaggedPostsForUserInTeam(userId string, teamId string, page int, perPage int) (*PostList, *Response) {
 	if len(teamId) == 0 || len(teamId) != 26 {
@@ -2671,6 +2965,21 @@ func (c *Client4) SearchPostsWithMatches(teamId string, terms string, isOrSearch
 	return PostSearchResultsFromJson(r.Body), BuildResponse(r)
 }
 
+// CountSearchPosts returns the number of posts matching the given search, without returning them.
+// Paging params are ignored.
+func (c *Client4) CountSearchPosts(teamId string, params *SearchParameter) (int64, *Response) {
+	r, err := c.DoApiPost(c.GetTeamRoute(teamId)+"/posts/search/count", params.SearchParameterToJson())
+	if err != nil {
+		return 0, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	count := PostSearchCountFromJson(r.Body)
+	if count == nil {
+		return 0, BuildResponse(r)
+	}
+	return count.Count, BuildResponse(r)
+}
+
 // DoPostAction performs a post action.
 func (c *Client4) DoPostAction(postId, actionId string) (bool, *Response) {
 	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/actions/"+actionId, "")
@@ -2949,6 +3258,91 @@ func (c *Client4) TestS3Connection(config *Config) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// GetIntegrationsHealth checks whether the server can reach each of its configured integrations.
+func (c *Client4) GetIntegrationsHealth() (*IntegrationsHealth, *Response) {
+	r, err := c.DoApiGet("/integrations/health", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return IntegrationsHealthFromJson(r.Body), BuildResponse(r)
+}
+
+// TestPushNotification sends a test notification through the server's push proxy to the device
+// selected by the request.
+func (c *Client4) TestPushNotification(testRequest *PushNotificationTestRequest) (*PushNotificationTestResult, *Response) {
+	r, err := c.DoApiPost(c.GetSystemRoute()+"/notifications/test", testRequest.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PushNotificationTestResultFromJson(r.Body), BuildResponse(r)
+}
+
+// RunReportingQuery will run one of the SELECT statements whitelisted in the server's
+// SqlSettings.AllowedReportingQueries and return the resulting rows.
+func (c *Client4) RunReportingQuery(query *ReportingQuery) ([]map[string]interface{}, *Response) {
+	r, err := c.DoApiPost(c.GetSystemRoute()+"/db/query", query.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ReportingQueryResultsFromJson(r.Body), BuildResponse(r)
+}
+
+// GetSchemaMigrationStatus will list the upgrades to the server's database schema and whether
+// each has been applied.
+func (c *Client4) GetSchemaMigrationStatus() ([]*SchemaMigration, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/db/migration/status", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SchemaMigrationListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetDatabaseTransactions will list the transactions open on the server's master database.
+func (c *Client4) GetDatabaseTransactions() ([]*DatabaseTransaction, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/db/transactions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return DatabaseTransactionListFromJson(r.Body), BuildResponse(r)
+}
+
+// DiagnoseSystem will check the server for common misconfigurations.
+func (c *Client4) DiagnoseSystem() ([]*SystemDiagnostic, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/diagnose", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SystemDiagnosticListFromJson(r.Body), BuildResponse(r)
+}
+
+// GetEmailQueueStats will return statistics about the emails being sent by the server.
+func (c *Client4) GetEmailQueueStats() (*EmailQueueStats, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/email/queue/stats", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmailQueueStatsFromJson(r.Body), BuildResponse(r)
+}
+
+// CapturePerformanceProfile will capture a pprof profile of the given type on the server and
+// return a signed link to download it. durationSeconds only applies to CPU profiles.
+func (c *Client4) CapturePerformanceProfile(profileType string, durationSeconds int) (*PerformanceProfile, *Response) {
+	query := fmt.Sprintf("?type=%v&duration_seconds=%v", profileType, durationSeconds)
+	r, err := c.DoApiPost(c.GetSystemRoute()+"/performance/profile"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PerformanceProfileFromJson(r.Body), BuildResponse(r)
+}
+
 // GetConfig will retrieve the server config with some sanitized items.
 func (c *Client4) GetConfig() (*Config, *Response) {
 	r, err := c.DoApiGet(c.GetConfigRoute(), "")
@@ -2966,7 +3360,18 @@ func (c *Client4) ReloadConfig() (bool, *Response) {
 		return false, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
-	return CheckStatusOK(r), BuildResponse(r)
+	return r.StatusCode == http.StatusOK, BuildResponse(r)
+}
+
+// ReloadConfigFromStore will reload the server configuration from its backing store, and return
+// the resulting configuration.
+func (c *Client4) ReloadConfigFromStore() (*Config, *Response) {
+	r, err := c.DoApiPost(c.GetConfigRoute()+"/reload", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigFromJson(r.Body), BuildResponse(r)
 }
 
 // GetOldClientConfig will retrieve the parts of the server configuration needed by the
@@ -3033,6 +3438,46 @@ func (c *Client4) UpdateConfig(config *Config) (*Config, *Response) {
 	return ConfigFromJson(r.Body), BuildResponse(r)
 }
 
+// GetConfigChangeRequests returns the proposed configuration changes awaiting review.
+func (c *Client4) GetConfigChangeRequests() ([]*ConfigChangeRequest, *Response) {
+	r, err := c.DoApiGet(c.GetSystemRoute()+"/config/requests", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigChangeRequestListFromJson(r.Body), BuildResponse(r)
+}
+
+// ProposeConfigChange proposes a new server configuration for another system admin to approve.
+func (c *Client4) ProposeConfigChange(config *Config) (*ConfigChangeRequest, *Response) {
+	r, err := c.DoApiPost(c.GetSystemRoute()+"/config/requests", config.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigChangeRequestFromJson(r.Body), BuildResponse(r)
+}
+
+// ApproveConfigChangeRequest approves a proposed configuration change, which then takes effect.
+func (c *Client4) ApproveConfigChangeRequest(requestId string) (*ConfigChangeRequest, *Response) {
+	r, err := c.DoApiPut(c.GetSystemRoute()+"/config/requests/"+requestId+"/approve", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigChangeRequestFromJson(r.Body), BuildResponse(r)
+}
+
+// RejectConfigChangeRequest rejects a proposed configuration change.
+func (c *Client4) RejectConfigChangeRequest(requestId string) (*ConfigChangeRequest, *Response) {
+	r, err := c.DoApiPut(c.GetSystemRoute()+"/config/requests/"+requestId+"/reject", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ConfigChangeRequestFromJson(r.Body), BuildResponse(r)
+}
+
 // UploadLicenseFile will add a license file to the system.
 func (c *Client4) UploadLicenseFile(data []byte) (bool, *Response) {
 	body := &bytes.Buffer{}
@@ -3074,6 +3519,48 @@ func (c *Client4) UploadLicenseFile(data []byte) (bool, *Response) {
 	return CheckStatusOK(rp), BuildResponse(rp)
 }
 
+// PreviewLicenseFile validates a license file without activating it and reports which features
+// it would enable or disable compared with the current license.
+func (c *Client4) PreviewLicenseFile(data []byte) (*LicensePreview, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("license", "test-license.mattermost-license")
+	if err != nil {
+		return nil, &Response{Error: NewAppError("PreviewLicenseFile", "model.client.set_profile_user.no_file.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	if _, err = io.Copy(part, bytes.NewBuffer(data)); err != nil {
+		return nil, &Response{Error: NewAppError("PreviewLicenseFile", "model.client.set_profile_user.no_file.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, &Response{Error: NewAppError("PreviewLicenseFile", "model.client.set_profile_user.writer.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+
+	rq, err := http.NewRequest("POST", c.ApiUrl+c.GetSystemRoute()+"/license/preview", bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, &Response{Error: NewAppError("PreviewLicenseFile", "model.client.connecting.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+	rq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if len(c.AuthToken) > 0 {
+		rq.Header.Set(HEADER_AUTH, c.AuthType+" "+c.AuthToken)
+	}
+
+	rp, err := c.HttpClient.Do(rq)
+	if err != nil || rp == nil {
+		return nil, &Response{StatusCode: http.StatusForbidden, Error: NewAppError(c.GetSystemRoute()+"/license/preview", "model.client.connecting.app_error", nil, err.Error(), http.StatusForbidden)}
+	}
+	defer closeBody(rp)
+
+	if rp.StatusCode >= 300 {
+		return nil, BuildErrorResponse(rp, AppErrorFromJson(rp.Body))
+	}
+
+	return LicensePreviewFromJson(rp.Body), BuildResponse(rp)
+}
+
 // RemoveLicenseFile will remove the server license it exists. Note that this will
 // disable all enterprise features.
 func (c *Client4) RemoveLicenseFile() (bool, *Response) {
@@ -3173,6 +3660,18 @@ func (c *Client4) CreateOutgoingWebhook(hook *OutgoingWebhook) (*OutgoingWebhook
 	return OutgoingWebhookFromJson(r.Body), BuildResponse(r)
 }
 
+// TestOutgoingWebhookPayloadTemplate executes an outgoing webhook payload template against a sample
+// payload, returning the sample payload and the output of the template.
+func (c *Client4) TestOutgoingWebhookPayloadTemplate(teamId, payloadTemplate string) (*OutgoingWebhookPayloadTemplateTest, *Response) {
+	data := map[string]string{"team_id": teamId, "payload_template": payloadTemplate}
+	r, err := c.DoApiPost(c.GetOutgoingWebhooksRoute()+"/test_payload_template", MapToJson(data))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return OutgoingWebhookPayloadTemplateTestFromJson(r.Body), BuildResponse(r)
+}
+
 // UpdateOutgoingWebhook creates an outgoing webhook for a team or channel.
 func (c *Client4) UpdateOutgoingWebhook(hook *OutgoingWebhook) (*OutgoingWebhook, *Response) {
 	r, err := c.DoApiPut(c.GetOutgoingWebhookRoute(hook.Id), hook.ToJson())
@@ -3215,6 +3714,16 @@ func (c *Client4) GetOutgoingWebhooksForChannel(channelId string, page int, perP
 	return OutgoingWebhookListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetChannelWebhooks returns the incoming and outgoing webhooks of a channel.
+func (c *Client4) GetChannelWebhooks(channelId string) (*ChannelWebhooks, *Response) {
+	r, err := c.DoApiGet(c.GetChannelRoute(channelId)+"/webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return ChannelWebhooksFromJson(r.Body), BuildResponse(r)
+}
+
 // GetOutgoingWebhooksForTeam returns a page of outgoing webhooks for a team. Page counting starts at 0.
 func (c *Client4) GetOutgoingWebhooksForTeam(teamId string, page int, perPage int, etag string) ([]*OutgoingWebhook, *Response) {
 	query := fmt.Sprintf("?page=%v&per_page=%v&team_id=%v", page, perPage, teamId)
@@ -4074,6 +4583,24 @@ func (c *Client4) GetSortedEmojiList(page, perPage int, sort string) ([]*Emoji,
 	return EmojiListFromJson(r.Body), BuildResponse(r)
 }
 
+// GetEmojiListPage returns a page of custom emoji on the system sorted by name, optionally filtered by
+// creator and name prefix, along with whether or not there are more emoji to fetch.
+func (c *Client4) GetEmojiListPage(page, perPage int, creatorId, prefix string) (*EmojiListPage, *Response) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&sort=%v&include_has_next=true", page, perPage, EMOJI_SORT_BY_NAME)
+	if creatorId != "" {
+		query += "&creator_id=" + url.QueryEscape(creatorId)
+	}
+	if prefix != "" {
+		query += "&prefix=" + url.QueryEscape(prefix)
+	}
+	r, err := c.DoApiGet(c.GetEmojisRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return EmojiListPageFromJson(r.Body), BuildResponse(r)
+}
+
 // DeleteEmoji delete an custom emoji on the provided emoji id string.
 func (c *Client4) DeleteEmoji(emojiId string) (bool, *Response) {
 	r, err := c.DoApiDelete(c.GetEmojiRoute(emojiId))
@@ -4153,6 +4680,17 @@ func (c *Client4) SaveReaction(reaction *Reaction) (*Reaction, *Response) {
 	return ReactionFromJson(r.Body), BuildResponse(r)
 }
 
+// SaveBulkReactions saves several reactions to a post at once and returns the reactions saved,
+// along with those skipped because they already existed.
+func (c *Client4) SaveBulkReactions(postId string, reactions []*Reaction) (*BulkReactionsResult, *Response) {
+	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/reactions/bulk", ReactionsToJson(reactions))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return BulkReactionsResultFromJson(r.Body), BuildResponse(r)
+}
+
 // GetReactions returns a list of reactions to a post.
 func (c *Client4) GetReactions(postId string) ([]*Reaction, *Response) {
 	r, err := c.DoApiGet(c.GetPostRoute(postId)+"/reactions", "")
@@ -4264,6 +4802,38 @@ func (c *Client4) CancelJob(jobId string) (bool, *Response) {
 	return CheckStatusOK(r), BuildResponse(r)
 }
 
+// ScheduleMessageExport queues a compliance export job. Must have manage_system permission.
+func (c *Client4) ScheduleMessageExport(request *MessageExportRequest) (*MessageExportJob, *Response) {
+	r, err := c.DoApiPost(c.GetExportRoute()+"/schedule", request.ToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportJobFromJson(r.Body), BuildResponse(r)
+}
+
+// GetMessageExportJob gets the status of a compliance export job, including a download URL once it
+// has completed. Must have manage_system permission.
+func (c *Client4) GetMessageExportJob(jobId string) (*MessageExportJob, *Response) {
+	r, err := c.DoApiGet(c.GetExportRoute()+fmt.Sprintf("/jobs/%v", jobId), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MessageExportJobFromJson(r.Body), BuildResponse(r)
+}
+
+// CancelMessageExportJob requests the cancellation of a compliance export job. Must have
+// manage_system permission.
+func (c *Client4) CancelMessageExportJob(jobId string) (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetExportRoute() + fmt.Sprintf("/jobs/%v", jobId))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
 // Roles Section
 
 // GetRole gets a single role by ID.
@@ -4544,6 +5114,52 @@ func (c *Client4) GetMarketplacePlugins(filter *MarketplacePluginFilter) ([]*Mar
 	return plugins, BuildResponse(r)
 }
 
+// UploadMarketplaceBundle replaces the marketplace.json bundle served by the server's local marketplace.
+func (c *Client4) UploadMarketplaceBundle(bundle io.Reader) ([]*BaseMarketplacePlugin, *Response) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("bundle", "marketplace.json")
+	if err != nil {
+		return nil, &Response{Error: NewAppError("UploadMarketplaceBundle", "model.client.writer.app_error", nil, err.Error(), 0)}
+	}
+
+	if _, err = io.Copy(part, bundle); err != nil {
+		return nil, &Response{Error: NewAppError("UploadMarketplaceBundle", "model.client.writer.app_error", nil, err.Error(), 0)}
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, &Response{Error: NewAppError("UploadMarketplaceBundle", "model.client.writer.app_error", nil, err.Error(), 0)}
+	}
+
+	rq, err := http.NewRequest("POST", c.ApiUrl+c.GetPluginsRoute()+"/marketplace/bundle", body)
+	if err != nil {
+		return nil, &Response{Error: NewAppError("UploadMarketplaceBundle", "model.client.connecting.app_error", nil, err.Error(), http.StatusBadRequest)}
+	}
+	rq.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if len(c.AuthToken) > 0 {
+		rq.Header.Set(HEADER_AUTH, c.AuthType+" "+c.AuthToken)
+	}
+
+	rp, err := c.HttpClient.Do(rq)
+	if err != nil {
+		return nil, BuildErrorResponse(rp, NewAppError("UploadMarketplaceBundle", "model.client.connecting.app_error", nil, err.Error(), 0))
+	}
+	defer closeBody(rp)
+
+	if rp.StatusCode >= 300 {
+		return nil, BuildErrorResponse(rp, AppErrorFromJson(rp.Body))
+	}
+
+	plugins, readerErr := BaseMarketplacePluginsFromReader(rp.Body)
+	if readerErr != nil {
+		return nil, BuildErrorResponse(rp, NewAppError("UploadMarketplaceBundle", "model.client.parse_plugins.app_error", nil, readerErr.Error(), http.StatusBadRequest))
+	}
+
+	return plugins, BuildResponse(rp)
+}
+
 // UpdateChannelScheme will update a channel's scheme.
 func (c *Client4) UpdateChannelScheme(channelId, schemeId string) (bool, *Response) {
 	sip := &SchemeIDPatch{SchemeID: &schemeId}
@@ -101,3 +101,15 @@ func TestClient4MockSession(t *testing.T) {
 	_, resp := client.GetMe("")
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestClient4UploadMarketplaceBundleConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewAPIv4Client(server.URL)
+	plugins, resp := client.UploadMarketplaceBundle(strings.NewReader("[]"))
+	assert.Nil(t, plugins)
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, "model.client.connecting.app_error", resp.Error.Id)
+	}
+}
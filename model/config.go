@@ -37,8 +37,9 @@ const (
 	MINIO_SECRET_KEY = "miniosecretkey"
 	MINIO_BUCKET     = "mattermost-test"
 
-	PASSWORD_MAXIMUM_LENGTH = 64
-	PASSWORD_MINIMUM_LENGTH = 5
+	PASSWORD_MAXIMUM_LENGTH        = 64
+	PASSWORD_MINIMUM_LENGTH        = 5
+	PASSWORD_MAXIMUM_HISTORY_COUNT = 24
 
 	SERVICE_GITLAB    = "gitlab"
 	SERVICE_GOOGLE    = "google"
@@ -98,6 +99,8 @@ const (
 	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_KEY     = "2_KtH_W5"
 	SERVICE_SETTINGS_DEFAULT_GFYCAT_API_SECRET  = "3wLVZPiswc3DnaiaFoLkDvB4X0IV6CpMkj4tf2inJRsBY6-FnkT08zGmppWFgeof"
 
+	SERVICE_SETTINGS_DEFAULT_LINK_PREVIEW_MAX_REDIRECTS = 3
+
 	TEAM_SETTINGS_DEFAULT_SITE_NAME                = "Mattermost"
 	TEAM_SETTINGS_DEFAULT_MAX_USERS_PER_TEAM       = 50
 	TEAM_SETTINGS_DEFAULT_CUSTOM_BRAND_TEXT        = ""
@@ -106,6 +109,11 @@ const (
 
 	SQL_SETTINGS_DEFAULT_DATA_SOURCE = "mmuser:mostest@tcp(localhost:3306)/mattermost_test?charset=utf8mb4,utf8&readTimeout=30s&writeTimeout=30s"
 
+	SQL_TRANSACTION_ISOLATION_READ_UNCOMMITTED = "read-uncommitted"
+	SQL_TRANSACTION_ISOLATION_READ_COMMITTED   = "read-committed"
+	SQL_TRANSACTION_ISOLATION_REPEATABLE_READ  = "repeatable-read"
+	SQL_TRANSACTION_ISOLATION_SERIALIZABLE     = "serializable"
+
 	FILE_SETTINGS_DEFAULT_DIRECTORY = "./data/"
 
 	EMAIL_SETTINGS_DEFAULT_FEEDBACK_ORGANIZATION = ""
@@ -181,9 +189,14 @@ const (
 	ELASTICSEARCH_SETTINGS_DEFAULT_BULK_INDEXING_TIME_WINDOW_SECONDS = 3600
 	ELASTICSEARCH_SETTINGS_DEFAULT_REQUEST_TIMEOUT_SECONDS           = 30
 
-	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS  = 365
-	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS     = 365
-	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME = "02:00"
+	SEARCH_SETTINGS_DEFAULT_MAX_SEARCH_RESULTS = 200
+
+	IMPORT_SETTINGS_DEFAULT_LOCK_TIMEOUT = 30
+
+	DATA_RETENTION_SETTINGS_DEFAULT_MESSAGE_RETENTION_DAYS       = 365
+	DATA_RETENTION_SETTINGS_DEFAULT_FILE_RETENTION_DAYS          = 365
+	DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME      = "02:00"
+	DATA_RETENTION_SETTINGS_DEFAULT_TOPIC_HISTORY_RETENTION_DAYS = 180
 
 	PLUGIN_SETTINGS_DEFAULT_DIRECTORY          = "./plugins"
 	PLUGIN_SETTINGS_DEFAULT_CLIENT_DIRECTORY   = "./client/plugins"
@@ -214,6 +227,23 @@ const (
 	OFFICE365_SETTINGS_DEFAULT_USER_API_ENDPOINT = "https://graph.microsoft.com/v1.0/me"
 )
 
+// FileSettingsDefaultBlockedMIMETypes are the types of files that cannot be uploaded unless an administrator
+// explicitly unblocks them, since they are either executable or can run scripts when opened in a browser.
+var FileSettingsDefaultBlockedMIMETypes = []string{
+	"application/x-executable",
+	"application/x-msdownload",
+	"application/x-msdos-program",
+	"application/x-msi",
+	"application/x-mach-binary",
+	"application/x-sh",
+	"text/x-sh",
+	"application/java-archive",
+	"text/html",
+	"application/xhtml+xml",
+	"text/javascript",
+	"application/javascript",
+}
+
 var ServerTLSSupportedCiphers = map[string]uint16{
 	"TLS_RSA_WITH_RC4_128_SHA":                tls.TLS_RSA_WITH_RC4_128_SHA,
 	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
@@ -324,6 +354,16 @@ type ServiceSettings struct {
 	DisableBotsWhenOwnerIsDeactivated                 *bool `restricted:"true"`
 	EnableBotAccountCreation                          *bool
 	EnableSVGs                                        *bool
+	SearchCustomProfileAttributes                     *bool
+	EnableFuzzyMentionAutocomplete                    *bool
+	// LinkPreviewMaxRedirects is the number of redirects followed when fetching a link preview.
+	LinkPreviewMaxRedirects *int
+	// LinkPreviewBlockedDomains lists the domains, including their subdomains, for which no link
+	// previews are fetched.
+	LinkPreviewBlockedDomains []string
+	// RequireConfigChangeApproval makes changes to the configuration through the API take effect
+	// only once a system admin other than the one who proposed them approves them.
+	RequireConfigChangeApproval *bool
 }
 
 func (s *ServiceSettings) SetDefaults(isUpdate bool) {
@@ -692,6 +732,26 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 			s.EnableSVGs = NewBool(false)
 		}
 	}
+
+	if s.SearchCustomProfileAttributes == nil {
+		s.SearchCustomProfileAttributes = NewBool(false)
+	}
+
+	if s.EnableFuzzyMentionAutocomplete == nil {
+		s.EnableFuzzyMentionAutocomplete = NewBool(false)
+	}
+
+	if s.LinkPreviewMaxRedirects == nil {
+		s.LinkPreviewMaxRedirects = NewInt(SERVICE_SETTINGS_DEFAULT_LINK_PREVIEW_MAX_REDIRECTS)
+	}
+
+	if s.LinkPreviewBlockedDomains == nil {
+		s.LinkPreviewBlockedDomains = []string{}
+	}
+
+	if s.RequireConfigChangeApproval == nil {
+		s.RequireConfigChangeApproval = NewBool(false)
+	}
 }
 
 type ClusterSettings struct {
@@ -880,6 +940,19 @@ type SqlSettings struct {
 	Trace                       *bool    `restricted:"true"`
 	AtRestEncryptKey            *string  `restricted:"true"`
 	QueryTimeout                *int     `restricted:"true"`
+	AllowedReportingQueries     []string `restricted:"true"`
+	// SchemaName is the Postgres schema holding the server's tables, which allows several servers to
+	// share one database. It is added to the search_path of every connection.
+	SchemaName *string `restricted:"true"`
+	// TransactionMaxAgeWarningSeconds is the age after which an open database transaction is logged
+	// and counted in the long running transactions metric. 0 disables the check.
+	TransactionMaxAgeWarningSeconds *int `restricted:"true"`
+	// ReadTransactionIsolation is the isolation level of the transactions run on the read replicas,
+	// while WriteTransactionIsolation is the one of the transactions run on the master. When empty,
+	// the default of the database server is used. Setting a level on MySQL requires MySQL 5.7.20 or
+	// later.
+	ReadTransactionIsolation  *string `restricted:"true"`
+	WriteTransactionIsolation *string `restricted:"true"`
 }
 
 func (s *SqlSettings) SetDefaults(isUpdate bool) {
@@ -928,6 +1001,26 @@ func (s *SqlSettings) SetDefaults(isUpdate bool) {
 	if s.QueryTimeout == nil {
 		s.QueryTimeout = NewInt(30)
 	}
+
+	if s.AllowedReportingQueries == nil {
+		s.AllowedReportingQueries = []string{}
+	}
+
+	if s.SchemaName == nil {
+		s.SchemaName = NewString("")
+	}
+
+	if s.TransactionMaxAgeWarningSeconds == nil {
+		s.TransactionMaxAgeWarningSeconds = NewInt(0)
+	}
+
+	if s.ReadTransactionIsolation == nil {
+		s.ReadTransactionIsolation = NewString("")
+	}
+
+	if s.WriteTransactionIsolation == nil {
+		s.WriteTransactionIsolation = NewString("")
+	}
 }
 
 type LogSettings struct {
@@ -1026,6 +1119,12 @@ type PasswordSettings struct {
 	Number        *bool
 	Uppercase     *bool
 	Symbol        *bool
+	// HistoryCount is the number of most recent passwords, including the current one, that can't be
+	// reused when changing a password. Zero allows any password to be reused.
+	HistoryCount *int
+	// ExpiryDays is the number of days after which a password must be reset before logging in again.
+	// Zero disables password expiry.
+	ExpiryDays *int
 }
 
 func (s *PasswordSettings) SetDefaults() {
@@ -1048,6 +1147,30 @@ func (s *PasswordSettings) SetDefaults() {
 	if s.Symbol == nil {
 		s.Symbol = NewBool(true)
 	}
+
+	if s.HistoryCount == nil {
+		s.HistoryCount = NewInt(0)
+	}
+
+	if s.ExpiryDays == nil {
+		s.ExpiryDays = NewInt(0)
+	}
+}
+
+func (s *PasswordSettings) isValid() *AppError {
+	if *s.MinimumLength < PASSWORD_MINIMUM_LENGTH || *s.MinimumLength > PASSWORD_MAXIMUM_LENGTH {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]interface{}{"MinLength": PASSWORD_MINIMUM_LENGTH, "MaxLength": PASSWORD_MAXIMUM_LENGTH}, "", http.StatusBadRequest)
+	}
+
+	if *s.HistoryCount < 0 || *s.HistoryCount > PASSWORD_MAXIMUM_HISTORY_COUNT {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_history_count.app_error", map[string]interface{}{"MaxHistoryCount": PASSWORD_MAXIMUM_HISTORY_COUNT}, "", http.StatusBadRequest)
+	}
+
+	if *s.ExpiryDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_expiry_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type FileSettings struct {
@@ -1069,6 +1192,8 @@ type FileSettings struct {
 	AmazonS3SignV2          *bool   `restricted:"true"`
 	AmazonS3SSE             *bool   `restricted:"true"`
 	AmazonS3Trace           *bool   `restricted:"true"`
+	AllowedMIMETypes        []string
+	BlockedMIMETypes        []string
 }
 
 func (s *FileSettings) SetDefaults(isUpdate bool) {
@@ -1152,6 +1277,14 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 	if s.AmazonS3Trace == nil {
 		s.AmazonS3Trace = NewBool(false)
 	}
+
+	if s.AllowedMIMETypes == nil {
+		s.AllowedMIMETypes = []string{}
+	}
+
+	if s.BlockedMIMETypes == nil {
+		s.BlockedMIMETypes = append([]string{}, FileSettingsDefaultBlockedMIMETypes...)
+	}
 }
 
 type EmailSettings struct {
@@ -1490,6 +1623,43 @@ func (s *ThemeSettings) SetDefaults() {
 	}
 }
 
+func (s *ThemeSettings) isValid() *AppError {
+	if pref := s.DefaultThemePreference(NewId()); pref != nil {
+		if err := pref.IsValid(); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.default_theme.app_error", nil, err.DetailedError, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// DefaultCustomTheme returns DefaultTheme when it is a JSON-encoded custom theme rather than the
+// name of one of the built-in themes. It returns an empty string otherwise.
+func (s *ThemeSettings) DefaultCustomTheme() string {
+	if s.DefaultTheme == nil || !strings.HasPrefix(strings.TrimSpace(*s.DefaultTheme), "{") {
+		return ""
+	}
+
+	return *s.DefaultTheme
+}
+
+// DefaultThemePreference returns the theme preference given to new users when DefaultTheme is a
+// JSON-encoded custom theme rather than the name of one of the built-in themes. It returns nil
+// otherwise.
+func (s *ThemeSettings) DefaultThemePreference(userId string) *Preference {
+	customTheme := s.DefaultCustomTheme()
+	if customTheme == "" {
+		return nil
+	}
+
+	return &Preference{
+		UserId:   userId,
+		Category: PREFERENCE_CATEGORY_THEME,
+		Name:     "",
+		Value:    customTheme,
+	}
+}
+
 type TeamSettings struct {
 	SiteName                                                  *string
 	MaxUsersPerTeam                                           *int
@@ -2065,6 +2235,49 @@ func (s *NativeAppSettings) SetDefaults() {
 	}
 }
 
+type SearchSettings struct {
+	EnableRelatedPosts *bool
+	MaxSearchResults   *int
+}
+
+func (s *SearchSettings) SetDefaults() {
+	if s.EnableRelatedPosts == nil {
+		s.EnableRelatedPosts = NewBool(false)
+	}
+
+	if s.MaxSearchResults == nil {
+		s.MaxSearchResults = NewInt(SEARCH_SETTINGS_DEFAULT_MAX_SEARCH_RESULTS)
+	}
+}
+
+func (s *SearchSettings) isValid() *AppError {
+	if *s.MaxSearchResults <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.search.max_search_results.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+type ImportSettings struct {
+	// LockTimeout is the number of minutes after which an import lock held by another node is
+	// considered stale.
+	LockTimeout *int
+}
+
+func (s *ImportSettings) SetDefaults() {
+	if s.LockTimeout == nil {
+		s.LockTimeout = NewInt(IMPORT_SETTINGS_DEFAULT_LOCK_TIMEOUT)
+	}
+}
+
+func (s *ImportSettings) isValid() *AppError {
+	if *s.LockTimeout <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.import.lock_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 type ElasticsearchSettings struct {
 	ConnectionUrl                 *string `restricted:"true"`
 	Username                      *string `restricted:"true"`
@@ -2176,11 +2389,12 @@ func (s *ElasticsearchSettings) SetDefaults() {
 }
 
 type DataRetentionSettings struct {
-	EnableMessageDeletion *bool
-	EnableFileDeletion    *bool
-	MessageRetentionDays  *int
-	FileRetentionDays     *int
-	DeletionJobStartTime  *string
+	EnableMessageDeletion     *bool
+	EnableFileDeletion        *bool
+	MessageRetentionDays      *int
+	FileRetentionDays         *int
+	DeletionJobStartTime      *string
+	TopicHistoryRetentionDays *int
 }
 
 func (s *DataRetentionSettings) SetDefaults() {
@@ -2203,6 +2417,10 @@ func (s *DataRetentionSettings) SetDefaults() {
 	if s.DeletionJobStartTime == nil {
 		s.DeletionJobStartTime = NewString(DATA_RETENTION_SETTINGS_DEFAULT_DELETION_JOB_START_TIME)
 	}
+
+	if s.TopicHistoryRetentionDays == nil {
+		s.TopicHistoryRetentionDays = NewInt(DATA_RETENTION_SETTINGS_DEFAULT_TOPIC_HISTORY_RETENTION_DAYS)
+	}
 }
 
 type JobSettings struct {
@@ -2456,6 +2674,7 @@ type Config struct {
 	ExperimentalSettings    ExperimentalSettings
 	AnalyticsSettings       AnalyticsSettings
 	ElasticsearchSettings   ElasticsearchSettings
+	SearchSettings          SearchSettings
 	DataRetentionSettings   DataRetentionSettings
 	MessageExportSettings   MessageExportSettings
 	JobSettings             JobSettings
@@ -2463,6 +2682,7 @@ type Config struct {
 	DisplaySettings         DisplaySettings
 	GuestAccountsSettings   GuestAccountsSettings
 	ImageProxySettings      ImageProxySettings
+	ImportSettings          ImportSettings
 }
 
 func (o *Config) Clone() *Config {
@@ -2537,6 +2757,7 @@ func (o *Config) SetDefaults() {
 	o.ComplianceSettings.SetDefaults()
 	o.LocalizationSettings.SetDefaults()
 	o.ElasticsearchSettings.SetDefaults()
+	o.SearchSettings.SetDefaults()
 	o.NativeAppSettings.SetDefaults()
 	o.DataRetentionSettings.SetDefaults()
 	o.RateLimitSettings.SetDefaults()
@@ -2547,6 +2768,7 @@ func (o *Config) SetDefaults() {
 	o.DisplaySettings.SetDefaults()
 	o.GuestAccountsSettings.SetDefaults()
 	o.ImageProxySettings.SetDefaults(o.ServiceSettings)
+	o.ImportSettings.SetDefaults()
 }
 
 func (o *Config) IsValid() *AppError {
@@ -2586,8 +2808,8 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
-	if *o.PasswordSettings.MinimumLength < PASSWORD_MINIMUM_LENGTH || *o.PasswordSettings.MinimumLength > PASSWORD_MAXIMUM_LENGTH {
-		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]interface{}{"MinLength": PASSWORD_MINIMUM_LENGTH, "MaxLength": PASSWORD_MAXIMUM_LENGTH}, "", http.StatusBadRequest)
+	if err := o.PasswordSettings.isValid(); err != nil {
+		return err
 	}
 
 	if err := o.RateLimitSettings.isValid(); err != nil {
@@ -2602,6 +2824,10 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.SearchSettings.isValid(); err != nil {
+		return err
+	}
+
 	if err := o.DataRetentionSettings.isValid(); err != nil {
 		return err
 	}
@@ -2622,6 +2848,14 @@ func (o *Config) IsValid() *AppError {
 		return err
 	}
 
+	if err := o.ImportSettings.isValid(); err != nil {
+		return err
+	}
+
+	if err := o.ThemeSettings.isValid(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2630,7 +2864,7 @@ func (ts *TeamSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_users.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if *ts.MaxChannelsPerTeam <= 0 {
+	if *ts.MaxChannelsPerTeam < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_channels.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -2657,6 +2891,25 @@ func (ts *TeamSettings) isValid() *AppError {
 	return nil
 }
 
+var sqlSchemaNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// IsValidSqlSchemaName returns true if the given schema name is a lowercase Postgres identifier that
+// can be used without quoting.
+func IsValidSqlSchemaName(name string) bool {
+	return sqlSchemaNamePattern.MatchString(name) && !strings.HasPrefix(name, "pg_")
+}
+
+// IsValidSqlTransactionIsolation returns true if the given transaction isolation level is one of
+// the SQL_TRANSACTION_ISOLATION_* values.
+func IsValidSqlTransactionIsolation(isolation string) bool {
+	switch isolation {
+	case SQL_TRANSACTION_ISOLATION_READ_UNCOMMITTED, SQL_TRANSACTION_ISOLATION_READ_COMMITTED, SQL_TRANSACTION_ISOLATION_REPEATABLE_READ, SQL_TRANSACTION_ISOLATION_SERIALIZABLE:
+		return true
+	default:
+		return false
+	}
+}
+
 func (ss *SqlSettings) isValid() *AppError {
 	if *ss.AtRestEncryptKey != "" && len(*ss.AtRestEncryptKey) < 32 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.encrypt_sql.app_error", nil, "", http.StatusBadRequest)
@@ -2678,6 +2931,10 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_query_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.TransactionMaxAgeWarningSeconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_transaction_max_age_warning_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*ss.DataSource) == 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_data_src.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -2686,6 +2943,28 @@ func (ss *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_max_conn.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.SchemaName != "" {
+		if *ss.DriverName != DATABASE_DRIVER_POSTGRES {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_schema_name_driver.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if !IsValidSqlSchemaName(*ss.SchemaName) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_schema_name.app_error", map[string]interface{}{"SchemaName": *ss.SchemaName}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, isolation := range []string{*ss.ReadTransactionIsolation, *ss.WriteTransactionIsolation} {
+		if isolation != "" && !IsValidSqlTransactionIsolation(isolation) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_transaction_isolation.app_error", map[string]interface{}{"Isolation": isolation}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, query := range ss.AllowedReportingQueries {
+		if !IsReportingQuerySelect(query) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_allowed_reporting_queries.app_error", map[string]interface{}{"Query": query}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -2892,6 +3171,10 @@ func (ss *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *ss.LinkPreviewMaxRedirects < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.link_preview_max_redirects.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if len(*ss.SiteURL) != 0 {
 		if _, err := url.ParseRequestURI(*ss.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, "", http.StatusBadRequest)
@@ -2976,6 +3259,10 @@ func (drs *DataRetentionSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.deletion_job_start_time.app_error", nil, err.Error(), http.StatusBadRequest)
 	}
 
+	if *drs.TopicHistoryRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.topic_history_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
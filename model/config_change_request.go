@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	CONFIG_CHANGE_REQUEST_STATUS_PENDING  = "pending"
+	CONFIG_CHANGE_REQUEST_STATUS_APPROVED = "approved"
+	CONFIG_CHANGE_REQUEST_STATUS_REJECTED = "rejected"
+)
+
+// ConfigChangeRequest is a configuration proposed by a system admin. When
+// ServiceSettings.RequireConfigChangeApproval is enabled, it only takes effect once another system
+// admin approves it.
+//
+// BaseConfigHash identifies the active configuration the change was proposed against, so that a
+// change proposed before another one took effect isn't approved over it.
+type ConfigChangeRequest struct {
+	Id             string  `json:"id"`
+	ProposedConfig *Config `json:"proposed_config"`
+	ProposedBy     string  `json:"proposed_by"`
+	Status         string  `json:"status"`
+	ReviewedBy     string  `json:"reviewed_by"`
+	CreateAt       int64   `json:"create_at"`
+	BaseConfigHash string  `json:"-"`
+}
+
+func (o *ConfigChangeRequest) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.ProposedConfig == nil {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.proposed_config.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ProposedBy) {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.proposed_by.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case CONFIG_CHANGE_REQUEST_STATUS_PENDING, CONFIG_CHANGE_REQUEST_STATUS_APPROVED, CONFIG_CHANGE_REQUEST_STATUS_REJECTED:
+	default:
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ReviewedBy != "" && !IsValidId(o.ReviewedBy) {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.reviewed_by.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.BaseConfigHash) > 64 {
+		return NewAppError("ConfigChangeRequest.IsValid", "model.config_change_request.is_valid.base_config_hash.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *ConfigChangeRequest) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = CONFIG_CHANGE_REQUEST_STATUS_PENDING
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// Sanitize replaces the secrets in the proposed configuration as Config.Sanitize does, without
+// modifying the configuration it was proposed with.
+func (o *ConfigChangeRequest) Sanitize() {
+	if o.ProposedConfig != nil {
+		o.ProposedConfig = o.ProposedConfig.Clone()
+		o.ProposedConfig.Sanitize()
+	}
+}
+
+func (o *ConfigChangeRequest) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func ConfigChangeRequestFromJson(data io.Reader) *ConfigChangeRequest {
+	var o *ConfigChangeRequest
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func ConfigChangeRequestListToJson(requests []*ConfigChangeRequest) string {
+	b, _ := json.Marshal(requests)
+	return string(b)
+}
+
+func ConfigChangeRequestListFromJson(data io.Reader) []*ConfigChangeRequest {
+	var requests []*ConfigChangeRequest
+	json.NewDecoder(data).Decode(&requests)
+	return requests
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigChangeRequestIsValid(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+
+	request := &ConfigChangeRequest{ProposedConfig: cfg, ProposedBy: NewId()}
+	assert.NotNil(t, request.IsValid())
+
+	request.PreSave()
+	assert.Equal(t, CONFIG_CHANGE_REQUEST_STATUS_PENDING, request.Status)
+	assert.Nil(t, request.IsValid())
+
+	request.ReviewedBy = "junk"
+	assert.NotNil(t, request.IsValid())
+
+	request.ReviewedBy = NewId()
+	request.BaseConfigHash = strings.Repeat("a", 65)
+	assert.NotNil(t, request.IsValid())
+
+	request.BaseConfigHash = strings.Repeat("a", 64)
+	assert.Nil(t, request.IsValid())
+
+	request.Status = "merged"
+	assert.NotNil(t, request.IsValid())
+
+	request.Status = CONFIG_CHANGE_REQUEST_STATUS_APPROVED
+	request.ProposedConfig = nil
+	assert.NotNil(t, request.IsValid())
+}
+
+func TestConfigChangeRequestSanitize(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	*cfg.EmailSettings.SMTPPassword = "secret"
+
+	request := &ConfigChangeRequest{ProposedConfig: cfg}
+	request.Sanitize()
+
+	assert.Equal(t, FAKE_SETTING, *request.ProposedConfig.EmailSettings.SMTPPassword)
+	assert.Equal(t, "secret", *cfg.EmailSettings.SMTPPassword)
+}
+
+func TestConfigChangeRequestListJson(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+
+	requests := []*ConfigChangeRequest{{ProposedConfig: cfg, ProposedBy: NewId()}}
+	requests[0].PreSave()
+
+	decoded := ConfigChangeRequestListFromJson(strings.NewReader(ConfigChangeRequestListToJson(requests)))
+	require.Len(t, decoded, 1)
+	assert.Equal(t, requests[0].Id, decoded[0].Id)
+	assert.Equal(t, requests[0].ProposedConfig, decoded[0].ProposedConfig)
+}
@@ -6,6 +6,7 @@ package model
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -276,6 +277,98 @@ func TestConfigDefaultNPSPluginState(t *testing.T) {
 	})
 }
 
+func TestSqlSettingsIsValidSchemaName(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		DriverName  string
+		SchemaName  string
+		ExpectedErr string
+	}{
+		"no schema":            {DATABASE_DRIVER_MYSQL, "", ""},
+		"postgres schema":      {DATABASE_DRIVER_POSTGRES, "tenant_1", ""},
+		"mysql schema":         {DATABASE_DRIVER_MYSQL, "tenant_1", "model.config.is_valid.sql_schema_name_driver.app_error"},
+		"uppercase":            {DATABASE_DRIVER_POSTGRES, "Tenant", "model.config.is_valid.sql_schema_name.app_error"},
+		"quoted":               {DATABASE_DRIVER_POSTGRES, `tenant"; DROP`, "model.config.is_valid.sql_schema_name.app_error"},
+		"leading digit":        {DATABASE_DRIVER_POSTGRES, "1tenant", "model.config.is_valid.sql_schema_name.app_error"},
+		"reserved system name": {DATABASE_DRIVER_POSTGRES, "pg_tenant", "model.config.is_valid.sql_schema_name.app_error"},
+		"longer than 63 chars": {DATABASE_DRIVER_POSTGRES, strings.Repeat("a", 64), "model.config.is_valid.sql_schema_name.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			*c.SqlSettings.DriverName = testCase.DriverName
+			*c.SqlSettings.SchemaName = testCase.SchemaName
+
+			err := c.SqlSettings.isValid()
+			if testCase.ExpectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, testCase.ExpectedErr, err.Id)
+			}
+		})
+	}
+}
+
+func TestSqlSettingsIsValidTransactionIsolation(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		ReadIsolation  string
+		WriteIsolation string
+		ExpectedErr    string
+	}{
+		"defaults":        {"", "", ""},
+		"read committed":  {SQL_TRANSACTION_ISOLATION_READ_COMMITTED, SQL_TRANSACTION_ISOLATION_READ_COMMITTED, ""},
+		"stale reads":     {SQL_TRANSACTION_ISOLATION_READ_UNCOMMITTED, SQL_TRANSACTION_ISOLATION_SERIALIZABLE, ""},
+		"repeatable read": {SQL_TRANSACTION_ISOLATION_REPEATABLE_READ, SQL_TRANSACTION_ISOLATION_REPEATABLE_READ, ""},
+		"invalid read":    {"READ COMMITTED", SQL_TRANSACTION_ISOLATION_READ_COMMITTED, "model.config.is_valid.sql_transaction_isolation.app_error"},
+		"invalid write":   {SQL_TRANSACTION_ISOLATION_READ_COMMITTED, "snapshot", "model.config.is_valid.sql_transaction_isolation.app_error"},
+		"empty read":      {"", SQL_TRANSACTION_ISOLATION_SERIALIZABLE, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			*c.SqlSettings.ReadTransactionIsolation = testCase.ReadIsolation
+			*c.SqlSettings.WriteTransactionIsolation = testCase.WriteIsolation
+
+			err := c.SqlSettings.isValid()
+			if testCase.ExpectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, testCase.ExpectedErr, err.Id)
+			}
+		})
+	}
+}
+
+func TestPasswordSettingsIsValid(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		HistoryCount int
+		ExpiryDays   int
+		ExpectedErr  string
+	}{
+		"defaults":               {0, 0, ""},
+		"history and expiry":     {PASSWORD_MAXIMUM_HISTORY_COUNT, 90, ""},
+		"negative history count": {-1, 0, "model.config.is_valid.password_history_count.app_error"},
+		"history count too high": {PASSWORD_MAXIMUM_HISTORY_COUNT + 1, 0, "model.config.is_valid.password_history_count.app_error"},
+		"negative expiry days":   {0, -1, "model.config.is_valid.password_expiry_days.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			*c.PasswordSettings.HistoryCount = testCase.HistoryCount
+			*c.PasswordSettings.ExpiryDays = testCase.ExpiryDays
+
+			err := c.PasswordSettings.isValid()
+			if testCase.ExpectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				assert.Equal(t, testCase.ExpectedErr, err.Id)
+			}
+		})
+	}
+}
+
 func TestTeamSettingsIsValidSiteNameEmpty(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -1041,3 +1134,48 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FAKE_SETTING, c.SqlSettings.DataSourceReplicas[0])
 	assert.Equal(t, FAKE_SETTING, c.SqlSettings.DataSourceSearchReplicas[0])
 }
+
+func TestThemeSettingsDefaultTheme(t *testing.T) {
+	t.Run("built-in theme", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+
+		require.Nil(t, c.ThemeSettings.isValid())
+		assert.Nil(t, c.ThemeSettings.DefaultThemePreference(NewId()))
+
+		*c.ThemeSettings.DefaultTheme = "mattermostDark"
+		require.Nil(t, c.ThemeSettings.isValid())
+		assert.Nil(t, c.ThemeSettings.DefaultThemePreference(NewId()))
+		assert.Equal(t, "", c.ThemeSettings.DefaultCustomTheme())
+	})
+
+	t.Run("custom theme", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+
+		theme := `{"sidebarBg":"#1543a3","sidebarText":"#ffffff","codeTheme":"github"}`
+		*c.ThemeSettings.DefaultTheme = theme
+		require.Nil(t, c.ThemeSettings.isValid())
+		assert.Equal(t, theme, c.ThemeSettings.DefaultCustomTheme())
+
+		userId := NewId()
+		pref := c.ThemeSettings.DefaultThemePreference(userId)
+		require.NotNil(t, pref)
+		assert.Equal(t, userId, pref.UserId)
+		assert.Equal(t, PREFERENCE_CATEGORY_THEME, pref.Category)
+		assert.Equal(t, "", pref.Name)
+		assert.Equal(t, theme, pref.Value)
+	})
+
+	t.Run("invalid custom theme", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+
+		*c.ThemeSettings.DefaultTheme = `{"sidebarBg":`
+		require.NotNil(t, c.ThemeSettings.isValid())
+
+		*c.ThemeSettings.DefaultTheme = `{"sidebarBg":1}`
+		require.NotNil(t, c.ThemeSettings.isValid())
+		require.NotNil(t, c.IsValid())
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// DatabaseTransaction describes a transaction that is open on the server's database.
+type DatabaseTransaction struct {
+	Pid          int64  `json:"pid"`
+	DurationMs   int64  `json:"duration_ms"`
+	State        string `json:"state"`
+	QuerySnippet string `json:"query_snippet"`
+}
+
+func DatabaseTransactionListToJson(transactions []*DatabaseTransaction) string {
+	b, _ := json.Marshal(transactions)
+	return string(b)
+}
+
+func DatabaseTransactionListFromJson(data io.Reader) []*DatabaseTransaction {
+	var transactions []*DatabaseTransaction
+	json.NewDecoder(data).Decode(&transactions)
+	return transactions
+}
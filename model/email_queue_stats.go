@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// EmailQueueStats describes the emails being sent by the server. QueueDepth is the number of emails
+// waiting to be sent, while the other fields cover the emails sent in the last minute.
+type EmailQueueStats struct {
+	QueueDepth       int64 `json:"queue_depth"`
+	SentLastMinute   int64 `json:"sent_last_minute"`
+	ErrorsLastMinute int64 `json:"errors_last_minute"`
+	AverageLatencyMs int64 `json:"average_latency_ms"`
+}
+
+func (o *EmailQueueStats) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func EmailQueueStatsFromJson(data io.Reader) *EmailQueueStats {
+	var o *EmailQueueStats
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
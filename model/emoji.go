@@ -26,6 +26,20 @@ type Emoji struct {
 	Name      string `json:"name"`
 }
 
+// EmojiListOpts narrows the custom emoji returned when listing them.
+type EmojiListOpts struct {
+	// CreatorId restricts the list to emoji created by the given user.
+	CreatorId string
+	// Prefix restricts the list to emoji whose name starts with the given string.
+	Prefix string
+}
+
+// EmojiListPage is a page of custom emoji along with whether or not more emoji follow it.
+type EmojiListPage struct {
+	Emojis  []*Emoji `json:"emojis"`
+	HasNext bool     `json:"has_next"`
+}
+
 func inSystemEmoji(emojiName string) bool {
 	_, ok := SystemEmojis[emojiName]
 	return ok
@@ -94,3 +108,14 @@ func EmojiListFromJson(data io.Reader) []*Emoji {
 	json.NewDecoder(data).Decode(&emojiList)
 	return emojiList
 }
+
+func (o *EmojiListPage) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func EmojiListPageFromJson(data io.Reader) *EmojiListPage {
+	var o *EmojiListPage
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
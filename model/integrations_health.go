@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	INTEGRATION_HEALTH_OK       = "ok"
+	INTEGRATION_HEALTH_DEGRADED = "degraded"
+	INTEGRATION_HEALTH_DOWN     = "down"
+	INTEGRATION_HEALTH_DISABLED = "disabled"
+)
+
+type IntegrationHealth struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type IntegrationsHealth struct {
+	Status        string             `json:"status"`
+	Ldap          *IntegrationHealth `json:"ldap"`
+	Smtp          *IntegrationHealth `json:"smtp"`
+	S3            *IntegrationHealth `json:"s3"`
+	Elasticsearch *IntegrationHealth `json:"elasticsearch"`
+	Push          *IntegrationHealth `json:"push"`
+}
+
+// SetAggregateStatus sets Status to ok when every enabled integration is reachable, to down when none
+// of them are, and to degraded otherwise. Disabled integrations are ignored.
+func (o *IntegrationsHealth) SetAggregateStatus() {
+	enabled, down := 0, 0
+	for _, health := range []*IntegrationHealth{o.Ldap, o.Smtp, o.S3, o.Elasticsearch, o.Push} {
+		if health == nil || health.Status == INTEGRATION_HEALTH_DISABLED {
+			continue
+		}
+
+		enabled++
+		if health.Status != INTEGRATION_HEALTH_OK {
+			down++
+		}
+	}
+
+	switch {
+	case down == 0:
+		o.Status = INTEGRATION_HEALTH_OK
+	case down == enabled:
+		o.Status = INTEGRATION_HEALTH_DOWN
+	default:
+		o.Status = INTEGRATION_HEALTH_DEGRADED
+	}
+}
+
+func (o *IntegrationsHealth) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func IntegrationsHealthFromJson(data io.Reader) *IntegrationsHealth {
+	var o *IntegrationsHealth
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
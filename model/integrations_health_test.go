@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationsHealthSetAggregateStatus(t *testing.T) {
+	ok := &IntegrationHealth{Status: INTEGRATION_HEALTH_OK}
+	down := &IntegrationHealth{Status: INTEGRATION_HEALTH_DOWN}
+	disabled := &IntegrationHealth{Status: INTEGRATION_HEALTH_DISABLED}
+
+	for name, tc := range map[string]struct {
+		Health   IntegrationsHealth
+		Expected string
+	}{
+		"all disabled": {
+			Health:   IntegrationsHealth{Ldap: disabled, Smtp: disabled, S3: disabled, Elasticsearch: disabled, Push: disabled},
+			Expected: INTEGRATION_HEALTH_OK,
+		},
+		"all enabled are ok": {
+			Health:   IntegrationsHealth{Ldap: ok, Smtp: ok, S3: disabled, Elasticsearch: disabled, Push: ok},
+			Expected: INTEGRATION_HEALTH_OK,
+		},
+		"some enabled are down": {
+			Health:   IntegrationsHealth{Ldap: ok, Smtp: down, S3: disabled, Elasticsearch: disabled, Push: ok},
+			Expected: INTEGRATION_HEALTH_DEGRADED,
+		},
+		"all enabled are down": {
+			Health:   IntegrationsHealth{Ldap: down, Smtp: down, S3: disabled, Elasticsearch: disabled, Push: disabled},
+			Expected: INTEGRATION_HEALTH_DOWN,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.Health.SetAggregateStatus()
+			assert.Equal(t, tc.Expected, tc.Health.Status)
+		})
+	}
+}
+
+func TestIntegrationsHealthJson(t *testing.T) {
+	health := &IntegrationsHealth{
+		Ldap: &IntegrationHealth{Status: INTEGRATION_HEALTH_OK, LatencyMs: 12},
+		Smtp: &IntegrationHealth{Status: INTEGRATION_HEALTH_DOWN, Error: "connection refused"},
+	}
+	health.SetAggregateStatus()
+
+	result := IntegrationsHealthFromJson(strings.NewReader(health.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, health, result)
+}
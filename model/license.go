@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sort"
 )
 
 const (
@@ -205,6 +206,69 @@ func NewTestLicense(features ...string) *License {
 	return ret
 }
 
+// LicenseFeatureDiff describes how a single feature would change if a license were applied.
+type LicenseFeatureDiff struct {
+	Feature       string `json:"feature"`
+	EnabledBefore bool   `json:"enabled_before"`
+	EnabledAfter  bool   `json:"enabled_after"`
+}
+
+// LicensePreview compares the features of the current license with those of a new license.
+type LicensePreview struct {
+	CurrentFeatures map[string]interface{} `json:"current_features"`
+	NewFeatures     map[string]interface{} `json:"new_features"`
+	Diff            []*LicenseFeatureDiff  `json:"diff"`
+}
+
+// NewLicensePreview compares the features of the current license, which may be nil when the
+// server is unlicensed, against the new license. Only features that change are listed in Diff.
+func NewLicensePreview(current, next *License) *LicensePreview {
+	preview := &LicensePreview{
+		NewFeatures: next.Features.ToMap(),
+		Diff:        []*LicenseFeatureDiff{},
+	}
+
+	if current != nil {
+		preview.CurrentFeatures = current.Features.ToMap()
+	} else {
+		preview.CurrentFeatures = make(map[string]interface{}, len(preview.NewFeatures))
+		for feature := range preview.NewFeatures {
+			preview.CurrentFeatures[feature] = false
+		}
+	}
+
+	features := make([]string, 0, len(preview.NewFeatures))
+	for feature := range preview.NewFeatures {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+
+	for _, feature := range features {
+		enabledBefore, _ := preview.CurrentFeatures[feature].(bool)
+		enabledAfter, _ := preview.NewFeatures[feature].(bool)
+		if enabledBefore != enabledAfter {
+			preview.Diff = append(preview.Diff, &LicenseFeatureDiff{
+				Feature:       feature,
+				EnabledBefore: enabledBefore,
+				EnabledAfter:  enabledAfter,
+			})
+		}
+	}
+
+	return preview
+}
+
+func (p *LicensePreview) ToJson() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func LicensePreviewFromJson(data io.Reader) *LicensePreview {
+	var o *LicensePreview
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func LicenseFromJson(data io.Reader) *License {
 	var o *License
 	json.NewDecoder(data).Decode(&o)
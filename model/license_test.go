@@ -6,6 +6,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLicenseFeaturesToMap(t *testing.T) {
@@ -230,3 +232,40 @@ func TestLicenseRecordPreSave(t *testing.T) {
 		t.Fatal("CreateAt should not be zero")
 	}
 }
+
+func TestNewLicensePreview(t *testing.T) {
+	t.Run("unlicensed", func(t *testing.T) {
+		next := NewTestLicense()
+		*next.Features.Cluster = false
+
+		preview := NewLicensePreview(nil, next)
+		assert.Equal(t, next.Features.ToMap(), preview.NewFeatures)
+		assert.Equal(t, false, preview.CurrentFeatures["ldap"])
+		assert.Equal(t, false, preview.CurrentFeatures["cluster"])
+
+		assert.Len(t, preview.Diff, len(preview.NewFeatures)-1)
+		for _, diff := range preview.Diff {
+			assert.NotEqual(t, "cluster", diff.Feature)
+			assert.False(t, diff.EnabledBefore)
+			assert.True(t, diff.EnabledAfter)
+		}
+	})
+
+	t.Run("licensed", func(t *testing.T) {
+		current := NewTestLicense()
+		*current.Features.SAML = false
+
+		next := NewTestLicense()
+		*next.Features.LDAP = false
+
+		preview := NewLicensePreview(current, next)
+		assert.Equal(t, current.Features.ToMap(), preview.CurrentFeatures)
+		assert.Equal(t, next.Features.ToMap(), preview.NewFeatures)
+		assert.Equal(t, []*LicenseFeatureDiff{
+			{Feature: "ldap", EnabledBefore: true, EnabledAfter: false},
+			{Feature: "saml", EnabledBefore: false, EnabledAfter: true},
+		}, preview.Diff)
+
+		assert.Equal(t, preview, LicensePreviewFromJson(strings.NewReader(preview.ToJson())))
+	})
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+// Mention records that a post mentioned a user. TeamId is empty for direct and group messages.
+type Mention struct {
+	UserId    string `json:"user_id"`
+	PostId    string `json:"post_id"`
+	ChannelId string `json:"channel_id"`
+	TeamId    string `json:"team_id"`
+	CreateAt  int64  `json:"create_at"`
+}
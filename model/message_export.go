@@ -3,6 +3,125 @@
 
 package model
 
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	MESSAGE_EXPORT_FORMAT_ZIP    = "zip"
+	MESSAGE_EXPORT_FORMAT_STREAM = "stream"
+
+	// The keys of the job data of a scheduled message export job. The worker reads the request from
+	// them, and sets JOB_DATA_EXPORT_FILE_PATH to the location of the export in the file store once
+	// it completes.
+	JOB_DATA_EXPORT_TYPE           = "export_type"
+	JOB_DATA_EXPORT_CHANNEL_IDS    = "export_channel_ids"
+	JOB_DATA_EXPORT_FROM_TIMESTAMP = "export_from_timestamp"
+	JOB_DATA_EXPORT_TO_TIMESTAMP   = "export_to_timestamp"
+	JOB_DATA_EXPORT_FORMAT         = "export_format"
+	JOB_DATA_EXPORT_FILE_PATH      = "export_file_path"
+)
+
+type MessageExportRequest struct {
+	Type     string   `json:"type"`
+	Channels []string `json:"channels"`
+	From     int64    `json:"from"`
+	To       int64    `json:"to"`
+	Format   string   `json:"format"`
+}
+
+type MessageExportJob struct {
+	JobId       string `json:"job_id"`
+	Status      string `json:"status"`
+	Progress    int64  `json:"progress"`
+	DownloadUrl string `json:"download_url,omitempty"`
+}
+
+func (r *MessageExportRequest) IsValid() *AppError {
+	if r.Type != COMPLIANCE_EXPORT_TYPE_ACTIANCE && r.Type != COMPLIANCE_EXPORT_TYPE_CSV && r.Type != COMPLIANCE_EXPORT_TYPE_GLOBALRELAY {
+		return NewAppError("MessageExportRequest.IsValid", "model.message_export_request.is_valid.type.app_error", nil, "type="+r.Type, http.StatusBadRequest)
+	}
+
+	for _, channelId := range r.Channels {
+		if !IsValidId(channelId) {
+			return NewAppError("MessageExportRequest.IsValid", "model.message_export_request.is_valid.channels.app_error", nil, "channel_id="+channelId, http.StatusBadRequest)
+		}
+	}
+
+	if r.From < 0 || r.To < 0 || (r.To != 0 && r.To <= r.From) {
+		return NewAppError("MessageExportRequest.IsValid", "model.message_export_request.is_valid.time_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Format != MESSAGE_EXPORT_FORMAT_ZIP && r.Format != MESSAGE_EXPORT_FORMAT_STREAM {
+		return NewAppError("MessageExportRequest.IsValid", "model.message_export_request.is_valid.format.app_error", nil, "format="+r.Format, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ToJobData returns the request encoded as the data of a message export job.
+func (r *MessageExportRequest) ToJobData() map[string]string {
+	return map[string]string{
+		JOB_DATA_EXPORT_TYPE:           r.Type,
+		JOB_DATA_EXPORT_CHANNEL_IDS:    strings.Join(r.Channels, ","),
+		JOB_DATA_EXPORT_FROM_TIMESTAMP: strconv.FormatInt(r.From, 10),
+		JOB_DATA_EXPORT_TO_TIMESTAMP:   strconv.FormatInt(r.To, 10),
+		JOB_DATA_EXPORT_FORMAT:         r.Format,
+	}
+}
+
+// MessageExportRequestFromJobData decodes a request encoded by ToJobData.
+func MessageExportRequestFromJobData(data map[string]string) (*MessageExportRequest, *AppError) {
+	r := &MessageExportRequest{
+		Type:   data[JOB_DATA_EXPORT_TYPE],
+		Format: data[JOB_DATA_EXPORT_FORMAT],
+	}
+
+	if channelIds := data[JOB_DATA_EXPORT_CHANNEL_IDS]; channelIds != "" {
+		r.Channels = strings.Split(channelIds, ",")
+	}
+
+	var err error
+	if r.From, err = strconv.ParseInt(data[JOB_DATA_EXPORT_FROM_TIMESTAMP], 10, 64); err != nil {
+		return nil, NewAppError("MessageExportRequestFromJobData", "model.message_export_request.is_valid.time_range.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+	if r.To, err = strconv.ParseInt(data[JOB_DATA_EXPORT_TO_TIMESTAMP], 10, 64); err != nil {
+		return nil, NewAppError("MessageExportRequestFromJobData", "model.message_export_request.is_valid.time_range.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if appErr := r.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	return r, nil
+}
+
+func (r *MessageExportRequest) ToJson() string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
+
+func MessageExportRequestFromJson(data io.Reader) *MessageExportRequest {
+	var r *MessageExportRequest
+	json.NewDecoder(data).Decode(&r)
+	return r
+}
+
+func (j *MessageExportJob) ToJson() string {
+	b, _ := json.Marshal(j)
+	return string(b)
+}
+
+func MessageExportJobFromJson(data io.Reader) *MessageExportJob {
+	var j *MessageExportJob
+	json.NewDecoder(data).Decode(&j)
+	return j
+}
+
 type MessageExport struct {
 	TeamId          *string
 	TeamName        *string
@@ -0,0 +1,92 @@
+// Copyright (c) 2017-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageExportRequestIsValid(t *testing.T) {
+	valid := func() *MessageExportRequest {
+		return &MessageExportRequest{
+			Type:     COMPLIANCE_EXPORT_TYPE_ACTIANCE,
+			Channels: []string{NewId()},
+			From:     1,
+			To:       2,
+			Format:   MESSAGE_EXPORT_FORMAT_STREAM,
+		}
+	}
+
+	assert.Nil(t, valid().IsValid())
+
+	request := valid()
+	request.Type = COMPLIANCE_EXPORT_TYPE_GLOBALRELAY_ZIP
+	assert.NotNil(t, request.IsValid())
+
+	request = valid()
+	request.Channels = []string{"junk"}
+	assert.NotNil(t, request.IsValid())
+
+	request = valid()
+	request.To = request.From
+	assert.NotNil(t, request.IsValid())
+
+	request = valid()
+	request.To = 0
+	assert.Nil(t, request.IsValid())
+
+	request = valid()
+	request.Format = "tar"
+	assert.NotNil(t, request.IsValid())
+}
+
+func TestMessageExportRequestToJobData(t *testing.T) {
+	request := &MessageExportRequest{
+		Type:     COMPLIANCE_EXPORT_TYPE_CSV,
+		Channels: []string{"channel1", "channel2"},
+		From:     100,
+		To:       200,
+		Format:   MESSAGE_EXPORT_FORMAT_ZIP,
+	}
+
+	assert.Equal(t, map[string]string{
+		JOB_DATA_EXPORT_TYPE:           COMPLIANCE_EXPORT_TYPE_CSV,
+		JOB_DATA_EXPORT_CHANNEL_IDS:    "channel1,channel2",
+		JOB_DATA_EXPORT_FROM_TIMESTAMP: "100",
+		JOB_DATA_EXPORT_TO_TIMESTAMP:   "200",
+		JOB_DATA_EXPORT_FORMAT:         MESSAGE_EXPORT_FORMAT_ZIP,
+	}, request.ToJobData())
+}
+
+func TestMessageExportRequestFromJobData(t *testing.T) {
+	request := &MessageExportRequest{
+		Type:     COMPLIANCE_EXPORT_TYPE_CSV,
+		Channels: []string{NewId(), NewId()},
+		From:     100,
+		To:       200,
+		Format:   MESSAGE_EXPORT_FORMAT_STREAM,
+	}
+
+	decoded, err := MessageExportRequestFromJobData(request.ToJobData())
+	require.Nil(t, err)
+	assert.Equal(t, request, decoded)
+
+	request.Channels = nil
+	decoded, err = MessageExportRequestFromJobData(request.ToJobData())
+	require.Nil(t, err)
+	assert.Empty(t, decoded.Channels)
+
+	data := request.ToJobData()
+	data[JOB_DATA_EXPORT_FROM_TIMESTAMP] = "yesterday"
+	_, err = MessageExportRequestFromJobData(data)
+	assert.NotNil(t, err)
+
+	data = request.ToJobData()
+	data[JOB_DATA_EXPORT_FORMAT] = "tar"
+	_, err = MessageExportRequestFromJobData(data)
+	assert.NotNil(t, err)
+}
@@ -4,13 +4,18 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
 )
 
 type OutgoingWebhook struct {
@@ -30,6 +35,9 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+	// PayloadTemplate is an optional text/template executed against the OutgoingWebhookPayload to
+	// produce the body of the request instead of the JSON or form encoded payload.
+	PayloadTemplate string `json:"payload_template"`
 }
 
 type OutgoingWebhookPayload struct {
@@ -57,7 +65,215 @@ type OutgoingWebhookResponse struct {
 	ResponseType string             `json:"response_type"`
 }
 
-const OUTGOING_HOOK_RESPONSE_TYPE_COMMENT = "comment"
+// OutgoingWebhookPayloadTemplateTest is the result of executing a payload template against
+// SampleOutgoingWebhookPayload.
+type OutgoingWebhookPayloadTemplateTest struct {
+	Payload *OutgoingWebhookPayload `json:"payload"`
+	Output  string                  `json:"output"`
+}
+
+const (
+	OUTGOING_HOOK_RESPONSE_TYPE_COMMENT = "comment"
+
+	OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_SIZE        = 4000
+	OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_OUTPUT_SIZE = 64 * 1024
+	OUTGOING_HOOK_PAYLOAD_TEMPLATE_TIMEOUT         = 1 * time.Second
+)
+
+// SampleOutgoingWebhookPayload returns a payload with an example value for every field, against
+// which payload templates are validated and tested.
+func SampleOutgoingWebhookPayload() *OutgoingWebhookPayload {
+	return &OutgoingWebhookPayload{
+		Token:       "hbkqgq3sjfyjfxb8dmkbi1rzfe",
+		TeamId:      "rdc9bgriktyx9p4kowh3dmgqyc",
+		TeamDomain:  "sample-team",
+		ChannelId:   "fds5n3wfwbr6ipz6o4sy5nqeay",
+		ChannelName: "town-square",
+		Timestamp:   1546300800000,
+		UserId:      "p3ehwj6ys7njfqz4ebrk5hs8nr",
+		UserName:    "sample.user",
+		PostId:      "9jgqmnr7ijdh8x1gk1hujsy7yc",
+		Text:        "sample \"trigger\" message",
+		TriggerWord: "sample",
+		FileIds:     "",
+	}
+}
+
+var outgoingWebhookPayloadTemplateFuncs = template.FuncMap{
+	// json encodes a value so that it can be embedded in a JSON payload, including quotes for strings.
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ExecuteTemplate executes the given text/template against the payload. In addition to the payload
+// fields, the template may use the json function to encode values.
+//
+// The template may only print payload fields, optionally encoded with json, and branch on them with
+// if and with. It can't declare variables, call other functions, loop with range or invoke other
+// templates, so it runs in time and memory proportional to its length. Its output is still limited
+// to OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_OUTPUT_SIZE, and execution fails once it has taken longer
+// than OUTGOING_HOOK_PAYLOAD_TEMPLATE_TIMEOUT.
+func (o *OutgoingWebhookPayload) ExecuteTemplate(text string) (string, error) {
+	t, err := template.New("payload").Funcs(outgoingWebhookPayloadTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	for _, tmpl := range t.Templates() {
+		if tmpl.Name() != t.Name() {
+			return "", errors.New("templates can't be defined in a payload template")
+		}
+	}
+
+	if err := checkOutgoingWebhookPayloadTemplateNode(t.Tree.Root); err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(OUTGOING_HOOK_PAYLOAD_TEMPLATE_TIMEOUT)
+	w := &outgoingWebhookPayloadTemplateWriter{deadline: deadline}
+
+	// Buffered so that a template still running past the deadline doesn't leak the goroutine.
+	done := make(chan error, 1)
+	go func() {
+		done <- t.Execute(w, o.templateData())
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return w.buf.String(), nil
+	case <-timer.C:
+		return "", fmt.Errorf("payload template took longer than %v to execute", OUTGOING_HOOK_PAYLOAD_TEMPLATE_TIMEOUT)
+	}
+}
+
+// templateData returns the fields of the payload by name for a payload template.
+func (o *OutgoingWebhookPayload) templateData() map[string]interface{} {
+	return map[string]interface{}{
+		"Token":       o.Token,
+		"TeamId":      o.TeamId,
+		"TeamDomain":  o.TeamDomain,
+		"ChannelId":   o.ChannelId,
+		"ChannelName": o.ChannelName,
+		"Timestamp":   o.Timestamp,
+		"UserId":      o.UserId,
+		"UserName":    o.UserName,
+		"PostId":      o.PostId,
+		"Text":        o.Text,
+		"TriggerWord": o.TriggerWord,
+		"FileIds":     o.FileIds,
+	}
+}
+
+// checkOutgoingWebhookPayloadTemplateNode rejects anything but text and the actions that print or
+// branch on payload fields, so that a payload template can't run for longer or allocate more than
+// its length suggests.
+func checkOutgoingWebhookPayloadTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkOutgoingWebhookPayloadTemplateNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.TextNode:
+	case *parse.ActionNode:
+		return checkOutgoingWebhookPayloadTemplatePipe(n.Pipe)
+	case *parse.IfNode:
+		return checkOutgoingWebhookPayloadTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkOutgoingWebhookPayloadTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return errors.New("range can't be used in a payload template")
+	case *parse.TemplateNode:
+		return errors.New("templates can't be invoked from a payload template")
+	default:
+		return fmt.Errorf("%s can't be used in a payload template", node)
+	}
+
+	return nil
+}
+
+func checkOutgoingWebhookPayloadTemplateBranch(branch *parse.BranchNode) error {
+	if err := checkOutgoingWebhookPayloadTemplatePipe(branch.Pipe); err != nil {
+		return err
+	}
+	if err := checkOutgoingWebhookPayloadTemplateNode(branch.List); err != nil {
+		return err
+	}
+	return checkOutgoingWebhookPayloadTemplateNode(branch.ElseList)
+}
+
+// checkOutgoingWebhookPayloadTemplatePipe accepts a pipeline that yields a payload field or a
+// constant, optionally passed once to json, such as {{.Text}}, {{json .Text}} or {{.Text | json}}.
+func checkOutgoingWebhookPayloadTemplatePipe(pipe *parse.PipeNode) error {
+	if len(pipe.Decl) > 0 {
+		return errors.New("variables can't be declared in a payload template")
+	}
+
+	calls := 0
+	for i, cmd := range pipe.Cmds {
+		args := cmd.Args
+		if identifier, ok := args[0].(*parse.IdentifierNode); ok {
+			if identifier.Ident != "json" {
+				return fmt.Errorf("function %s can't be used in a payload template", identifier.Ident)
+			}
+			calls++
+			args = args[1:]
+		} else if i > 0 {
+			return fmt.Errorf("%s can't be used in a payload template", cmd)
+		}
+
+		if calls > 1 {
+			return errors.New("json can only be applied once in a payload template action")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("%s can't be used in a payload template", cmd)
+		}
+
+		for _, arg := range args {
+			switch a := arg.(type) {
+			case *parse.FieldNode, *parse.DotNode, *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode:
+			case *parse.VariableNode:
+				// Only the variable holding the payload, as no others can be declared.
+				if a.Ident[0] != "$" {
+					return fmt.Errorf("%s can't be used in a payload template", arg)
+				}
+			default:
+				return fmt.Errorf("%s can't be used in a payload template", arg)
+			}
+		}
+	}
+
+	return nil
+}
+
+// outgoingWebhookPayloadTemplateWriter collects the output of a payload template, failing once it
+// grows too large or the template runs past its deadline.
+type outgoingWebhookPayloadTemplateWriter struct {
+	buf      bytes.Buffer
+	deadline time.Time
+}
+
+func (w *outgoingWebhookPayloadTemplateWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_OUTPUT_SIZE {
+		return 0, fmt.Errorf("payload template output is longer than %d bytes", OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_OUTPUT_SIZE)
+	}
+	if time.Now().After(w.deadline) {
+		return 0, fmt.Errorf("payload template took longer than %v to execute", OUTGOING_HOOK_PAYLOAD_TEMPLATE_TIMEOUT)
+	}
+	return w.buf.Write(p)
+}
 
 func (o *OutgoingWebhookPayload) ToJSON() string {
 	b, _ := json.Marshal(o)
@@ -109,6 +325,17 @@ func (o *OutgoingWebhookResponse) ToJson() string {
 	return string(b)
 }
 
+func (o *OutgoingWebhookPayloadTemplateTest) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func OutgoingWebhookPayloadTemplateTestFromJson(data io.Reader) *OutgoingWebhookPayloadTemplateTest {
+	var o *OutgoingWebhookPayloadTemplateTest
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func OutgoingWebhookResponseFromJson(data io.Reader) (*OutgoingWebhookResponse, error) {
 	var o *OutgoingWebhookResponse
 	err := json.NewDecoder(data).Decode(&o)
@@ -191,6 +418,28 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if err := IsValidOutgoingWebhookPayloadTemplate(o.PayloadTemplate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsValidOutgoingWebhookPayloadTemplate checks that the given payload template, if any, executes
+// successfully against SampleOutgoingWebhookPayload.
+func IsValidOutgoingWebhookPayloadTemplate(text string) *AppError {
+	if len(text) > OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_SIZE {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.payload_template_size.app_error", map[string]interface{}{"Max": OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_SIZE}, "", http.StatusBadRequest)
+	}
+
+	if text == "" {
+		return nil
+	}
+
+	if _, err := SampleOutgoingWebhookPayload().ExecuteTemplate(text); err != nil {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.payload_template.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
 	return nil
 }
 
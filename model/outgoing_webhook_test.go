@@ -141,6 +141,102 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 	if err := o.IsValid(); err != nil {
 		t.Fatal(err)
 	}
+
+	o.PayloadTemplate = `{"message": {{.Unknown}}}`
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.PayloadTemplate = `{"message": {{json .Text}`
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.PayloadTemplate = strings.Repeat("1", OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_SIZE+1)
+	if err := o.IsValid(); err == nil {
+		t.Fatal("should be invalid")
+	}
+
+	o.PayloadTemplate = `{"message": {{json .Text}}}`
+	if err := o.IsValid(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutgoingWebhookPayloadExecuteTemplate(t *testing.T) {
+	p := &OutgoingWebhookPayload{
+		ChannelName: "town-square",
+		UserName:    "UserName",
+		Text:        `a "quoted" message`,
+	}
+
+	output, err := p.ExecuteTemplate(`{"summary": {{json .Text}}, "source": "{{.UserName}} in {{.ChannelName}}"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"summary": "a \"quoted\" message", "source": "UserName in town-square"}`; output != want {
+		t.Fatalf("Got %v, wanted %v", output, want)
+	}
+
+	if _, err := p.ExecuteTemplate(`{{.Missing}}`); err == nil {
+		t.Fatal("should fail to execute")
+	}
+
+	for text, want := range map[string]string{
+		`{{.Text | json}}`:                           `"a \"quoted\" message"`,
+		`{{if .Text}}set{{else}}unset{{end}}`:        `set`,
+		`{{with .ChannelName}}{{json .}}{{end}}`:     `"town-square"`,
+		`{{with .UserName}}{{$.ChannelName}}{{end}}`: `town-square`,
+		`{{json "constant"}}`:                        `"constant"`,
+	} {
+		output, err := p.ExecuteTemplate(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != want {
+			t.Fatalf("Got %v, wanted %v", output, want)
+		}
+	}
+}
+
+func TestOutgoingWebhookPayloadExecuteTemplateLimits(t *testing.T) {
+	p := SampleOutgoingWebhookPayload()
+
+	for name, text := range map[string]string{
+		"self-recursive":       `{{$s := "{{$s := %q}}{{.ExecuteTemplate (printf $s $s)}}"}}{{.ExecuteTemplate (printf $s $s)}}`,
+		"methods":              `{{.ToJSON}}`,
+		"range":                `{{range .Timestamp}}{{end}}`,
+		"nested range":         `{{if .Text}}{{else}}{{range 1000000000}}{{end}}{{end}}`,
+		"recursive templates":  `{{define "a"}}{{template "a" .}}{{template "a" .}}{{end}}{{template "a" .}}`,
+		"printf":               `{{printf "%1000000d" 1}}`,
+		"variables":            `{{$a := .Text}}{{$a}}`,
+		"allocating variables": `{{$a := printf "%100000s" ""}}{{$b := printf "%s%s%s%s%s%s%s%s" $a $a $a $a $a $a $a $a}}`,
+		"other functions":      `{{index .Text 0}}{{len .Text}}`,
+		"nested json":          `{{json (json .Text)}}`,
+		"repeated json":        `{{.Text | json | json}}`,
+		"json of many values":  `{{json .Text .Text}}`,
+		"json in branches":     `{{if json (json .Text)}}{{end}}`,
+		"defined templates":    `{{define "a"}}{{.Text}}{{end}}{{.Text}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := p.ExecuteTemplate(text); err == nil {
+				t.Fatal("should fail to execute")
+			}
+		})
+	}
+}
+
+func TestOutgoingWebhookPayloadExecuteTemplateOutputLimit(t *testing.T) {
+	p := SampleOutgoingWebhookPayload()
+	p.Text = strings.Repeat("a", OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_OUTPUT_SIZE/2)
+
+	if _, err := p.ExecuteTemplate(`{{.Text}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ExecuteTemplate(`{{.Text}}{{.Text}}{{.Text}}`); err == nil {
+		t.Fatal("should fail to execute")
+	}
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
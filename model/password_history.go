@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+// PasswordHistory records the hash of a password that a user has replaced. CreateAt is the time at
+// which it was replaced.
+type PasswordHistory struct {
+	UserId   string `json:"user_id"`
+	Password string `json:"-"`
+	CreateAt int64  `json:"create_at"`
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	PERFORMANCE_PROFILE_TYPE_CPU       = "cpu"
+	PERFORMANCE_PROFILE_TYPE_HEAP      = "heap"
+	PERFORMANCE_PROFILE_TYPE_GOROUTINE = "goroutine"
+
+	PERFORMANCE_PROFILE_DEFAULT_DURATION_SECONDS = 30
+	PERFORMANCE_PROFILE_MAX_DURATION_SECONDS     = 120
+)
+
+// PerformanceProfile describes a pprof profile captured by the server and stored in the file store.
+type PerformanceProfile struct {
+	Type            string `json:"type"`
+	DurationSeconds int    `json:"duration_seconds"`
+	FileName        string `json:"file_name"`
+	DownloadUrl     string `json:"download_url"`
+	ExpireAt        int64  `json:"expire_at"`
+}
+
+func IsValidPerformanceProfileType(profileType string) bool {
+	switch profileType {
+	case PERFORMANCE_PROFILE_TYPE_CPU, PERFORMANCE_PROFILE_TYPE_HEAP, PERFORMANCE_PROFILE_TYPE_GOROUTINE:
+		return true
+	}
+	return false
+}
+
+func (o *PerformanceProfile) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PerformanceProfileFromJson(data io.Reader) *PerformanceProfile {
+	var o *PerformanceProfile
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
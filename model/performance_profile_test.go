@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformanceProfileJson(t *testing.T) {
+	profile := &PerformanceProfile{
+		Type:            PERFORMANCE_PROFILE_TYPE_CPU,
+		DurationSeconds: 30,
+		FileName:        "cpu_" + NewId() + ".pprof",
+		DownloadUrl:     "http://localhost:8065/api/v4/system/performance/profile/download",
+		ExpireAt:        GetMillis(),
+	}
+
+	assert.Equal(t, profile, PerformanceProfileFromJson(strings.NewReader(profile.ToJson())))
+}
+
+func TestIsValidPerformanceProfileType(t *testing.T) {
+	assert.True(t, IsValidPerformanceProfileType(PERFORMANCE_PROFILE_TYPE_CPU))
+	assert.True(t, IsValidPerformanceProfileType(PERFORMANCE_PROFILE_TYPE_HEAP))
+	assert.True(t, IsValidPerformanceProfileType(PERFORMANCE_PROFILE_TYPE_GOROUTINE))
+	assert.False(t, IsValidPerformanceProfileType(""))
+	assert.False(t, IsValidPerformanceProfileType("block"))
+}
@@ -190,6 +190,17 @@ func PostFromJson(data io.Reader) *Post {
 	return o
 }
 
+func PostSliceToJson(o []*Post) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostSliceFromJson(data io.Reader) []*Post {
+	var o []*Post
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func (o *Post) Etag() string {
 	return Etag(o.Id, o.UpdateAt)
 }
@@ -13,12 +13,14 @@ type PostSearchMatches map[string][]string
 type PostSearchResults struct {
 	*PostList
 	Matches PostSearchMatches `json:"matches"`
+	// Truncated is set when results were left out because of SearchSettings.MaxSearchResults.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 func MakePostSearchResults(posts *PostList, matches PostSearchMatches) *PostSearchResults {
 	return &PostSearchResults{
-		posts,
-		matches,
+		PostList: posts,
+		Matches:  matches,
 	}
 }
 
@@ -38,3 +40,19 @@ func PostSearchResultsFromJson(data io.Reader) *PostSearchResults {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+// PostSearchCount is the number of posts matching a search.
+type PostSearchCount struct {
+	Count int64 `json:"count"`
+}
+
+func (o *PostSearchCount) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PostSearchCountFromJson(data io.Reader) *PostSearchCount {
+	var o *PostSearchCount
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
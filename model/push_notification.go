@@ -93,3 +93,39 @@ func (ack *PushNotificationAck) ToJson() string {
 	b, _ := json.Marshal(ack)
 	return string(b)
 }
+
+// PushNotificationTestRequest selects the device that receives a test notification, either by its
+// device token or as the most recently active mobile session of a user.
+type PushNotificationTestRequest struct {
+	UserId string `json:"user_id"`
+	Token  string `json:"token"`
+}
+
+func (o *PushNotificationTestRequest) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PushNotificationTestRequestFromJson(data io.Reader) *PushNotificationTestRequest {
+	var o *PushNotificationTestRequest
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+// PushNotificationTestResult describes the push proxy's response to a test notification.
+type PushNotificationTestResult struct {
+	StatusCode int    `json:"status_code"`
+	LatencyMs  int64  `json:"latency_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (o *PushNotificationTestResult) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func PushNotificationTestResultFromJson(data io.Reader) *PushNotificationTestResult {
+	var o *PushNotificationTestResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	PUSH_NOTIFICATION_QUEUE_MAX_RETRIES        = 3
+	PUSH_NOTIFICATION_QUEUE_LIFETIME           = 1000 * 60 * 60 * 24
+	PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH = 4000
+)
+
+// PushNotificationQueueItem is a push notification that could not be delivered to the push proxy
+// and is waiting to be retried.
+type PushNotificationQueueItem struct {
+	Id            string
+	SessionId     string
+	UserId        string
+	Message       string
+	Retries       int
+	CreateAt      int64
+	LastAttemptAt int64
+}
+
+func (o *PushNotificationQueueItem) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+
+	if o.LastAttemptAt == 0 {
+		o.LastAttemptAt = o.CreateAt
+	}
+}
+
+func (o *PushNotificationQueueItem) IsValid() *AppError {
+	if len(o.Id) != 26 {
+		return NewAppError("PushNotificationQueueItem.IsValid", "model.push_notification_queue.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.SessionId) != 26 {
+		return NewAppError("PushNotificationQueueItem.IsValid", "model.push_notification_queue.session_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.UserId) != 26 {
+		return NewAppError("PushNotificationQueueItem.IsValid", "model.push_notification_queue.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Message) == 0 || len(o.Message) > PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH {
+		return NewAppError("PushNotificationQueueItem.IsValid", "model.push_notification_queue.message.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PushNotificationQueueItem.IsValid", "model.push_notification_queue.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the notification has been waiting in the queue for longer than it is useful to deliver it.
+func (o *PushNotificationQueueItem) IsExpired() bool {
+	return o.CreateAt < GetMillis()-PUSH_NOTIFICATION_QUEUE_LIFETIME
+}
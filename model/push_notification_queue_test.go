@@ -0,0 +1,59 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushNotificationQueueItemPreSave(t *testing.T) {
+	item := PushNotificationQueueItem{}
+	item.PreSave()
+
+	assert.Len(t, item.Id, 26)
+	assert.NotZero(t, item.CreateAt)
+	assert.Equal(t, item.CreateAt, item.LastAttemptAt)
+}
+
+func TestPushNotificationQueueItemIsValid(t *testing.T) {
+	item := PushNotificationQueueItem{
+		SessionId: NewId(),
+		UserId:    NewId(),
+		Message:   "{}",
+	}
+	item.PreSave()
+	require.Nil(t, item.IsValid())
+
+	for _, test := range []struct {
+		Transform     func(item *PushNotificationQueueItem)
+		ExpectedError string
+	}{
+		{func(item *PushNotificationQueueItem) { item.Id = "junk" }, "model.push_notification_queue.id.app_error"},
+		{func(item *PushNotificationQueueItem) { item.SessionId = "junk" }, "model.push_notification_queue.session_id.app_error"},
+		{func(item *PushNotificationQueueItem) { item.UserId = "junk" }, "model.push_notification_queue.user_id.app_error"},
+		{func(item *PushNotificationQueueItem) { item.Message = "" }, "model.push_notification_queue.message.app_error"},
+		{func(item *PushNotificationQueueItem) {
+			item.Message = strings.Repeat("a", PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH+1)
+		}, "model.push_notification_queue.message.app_error"},
+		{func(item *PushNotificationQueueItem) { item.CreateAt = 0 }, "model.push_notification_queue.create_at.app_error"},
+	} {
+		invalid := item
+		test.Transform(&invalid)
+		err := invalid.IsValid()
+		require.NotNil(t, err)
+		assert.Equal(t, test.ExpectedError, err.Id)
+	}
+}
+
+func TestPushNotificationQueueItemIsExpired(t *testing.T) {
+	item := PushNotificationQueueItem{CreateAt: GetMillis()}
+	assert.False(t, item.IsExpired())
+
+	item.CreateAt = GetMillis() - PUSH_NOTIFICATION_QUEUE_LIFETIME - 1000
+	assert.True(t, item.IsExpired())
+}
@@ -10,6 +10,10 @@ import (
 	"regexp"
 )
 
+const (
+	REACTIONS_BULK_MAX_COUNT = 1000
+)
+
 type Reaction struct {
 	UserId    string `json:"user_id"`
 	PostId    string `json:"post_id"`
@@ -17,6 +21,24 @@ type Reaction struct {
 	CreateAt  int64  `json:"create_at"`
 }
 
+// BulkReactionsResult lists the reactions saved by a bulk request separately from those that
+// already existed and so were skipped.
+type BulkReactionsResult struct {
+	Saved      []*Reaction `json:"saved"`
+	Duplicates []*Reaction `json:"duplicates"`
+}
+
+func (o *BulkReactionsResult) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func BulkReactionsResultFromJson(data io.Reader) *BulkReactionsResult {
+	var o *BulkReactionsResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func (o *Reaction) ToJson() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -63,6 +85,32 @@ func ReactionsFromJson(data io.Reader) []*Reaction {
 	}
 }
 
+// BulkReactionsFromJson decodes a list of reactions, which may also be given as a list of emoji
+// names, in which case only the EmojiName of each reaction is set.
+func BulkReactionsFromJson(data io.Reader) []*Reaction {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(data).Decode(&raw); err != nil {
+		return nil
+	}
+
+	reactions := make([]*Reaction, 0, len(raw))
+	for _, item := range raw {
+		var emojiName string
+		if err := json.Unmarshal(item, &emojiName); err == nil {
+			reactions = append(reactions, &Reaction{EmojiName: emojiName})
+			continue
+		}
+
+		var reaction Reaction
+		if err := json.Unmarshal(item, &reaction); err != nil {
+			return nil
+		}
+		reactions = append(reactions, &reaction)
+	}
+
+	return reactions
+}
+
 func (o *Reaction) IsValid() *AppError {
 	if len(o.UserId) != 26 {
 		return NewAppError("Reaction.IsValid", "model.reaction.is_valid.user_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
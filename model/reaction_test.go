@@ -6,6 +6,8 @@ package model
 import (
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestReactionIsValid(t *testing.T) {
@@ -82,3 +84,26 @@ func TestReactionIsValid(t *testing.T) {
 		t.Fatal("create at should be invalid")
 	}
 }
+
+func TestBulkReactionsFromJson(t *testing.T) {
+	userId := NewId()
+
+	assert.Equal(t, []*Reaction{{EmojiName: "smile"}, {EmojiName: "+1"}}, BulkReactionsFromJson(strings.NewReader(`["smile", "+1"]`)))
+	assert.Equal(t,
+		[]*Reaction{{UserId: userId, EmojiName: "smile", CreateAt: 1000}, {EmojiName: "+1"}},
+		BulkReactionsFromJson(strings.NewReader(`[{"user_id": "`+userId+`", "emoji_name": "smile", "create_at": 1000}, "+1"]`)),
+	)
+	assert.Empty(t, BulkReactionsFromJson(strings.NewReader(`[]`)))
+	assert.Nil(t, BulkReactionsFromJson(strings.NewReader(`{"emoji_name": "smile"}`)))
+	assert.Nil(t, BulkReactionsFromJson(strings.NewReader(`["smile", 1]`)))
+}
+
+func TestBulkReactionsResultJson(t *testing.T) {
+	result := &BulkReactionsResult{
+		Saved:      []*Reaction{{UserId: NewId(), PostId: NewId(), EmojiName: "smile", CreateAt: 1000}},
+		Duplicates: []*Reaction{},
+	}
+
+	assert.Equal(t, result, BulkReactionsResultFromJson(strings.NewReader(result.ToJson())))
+	assert.Nil(t, BulkReactionsResultFromJson(strings.NewReader("junk")))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	RECENT_CHANNELS_DEFAULT_LIMIT = 20
+	RECENT_CHANNELS_MAX_LIMIT     = 200
+)
+
+// RecentChannel records the last time a user viewed a channel, so that a user's list of recently visited
+// channels can follow them across devices.
+type RecentChannel struct {
+	UserId       string `json:"user_id"`
+	ChannelId    string `json:"channel_id"`
+	LastViewedAt int64  `json:"last_viewed_at"`
+}
+
+func (o *RecentChannel) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("RecentChannel.IsValid", "model.recent_channel.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("RecentChannel.IsValid", "model.recent_channel.is_valid.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.LastViewedAt == 0 {
+		return NewAppError("RecentChannel.IsValid", "model.recent_channel.is_valid.last_viewed_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func RecentChannelsToJson(o []*RecentChannel) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func RecentChannelsFromJson(data io.Reader) []*RecentChannel {
+	var o []*RecentChannel
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
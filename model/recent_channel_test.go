@@ -0,0 +1,40 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentChannelIsValid(t *testing.T) {
+	o := RecentChannel{
+		UserId:       NewId(),
+		ChannelId:    NewId(),
+		LastViewedAt: GetMillis(),
+	}
+	require.Nil(t, o.IsValid())
+
+	o.UserId = "junk"
+	assert.NotNil(t, o.IsValid())
+	o.UserId = NewId()
+
+	o.ChannelId = ""
+	assert.NotNil(t, o.IsValid())
+	o.ChannelId = NewId()
+
+	o.LastViewedAt = 0
+	assert.NotNil(t, o.IsValid())
+}
+
+func TestRecentChannelsJson(t *testing.T) {
+	o := []*RecentChannel{{UserId: NewId(), ChannelId: NewId(), LastViewedAt: GetMillis()}}
+	json := RecentChannelsToJson(o)
+	ro := RecentChannelsFromJson(strings.NewReader(json))
+
+	assert.Equal(t, o, ro)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// ReportingQuery is a request to run one of the SELECT statements whitelisted in
+// SqlSettings.AllowedReportingQueries. Params are bound to the query's named (:Name) parameters.
+type ReportingQuery struct {
+	Query  string                 `json:"query"`
+	Params map[string]interface{} `json:"params"`
+}
+
+func (q *ReportingQuery) ToJson() string {
+	b, _ := json.Marshal(q)
+	return string(b)
+}
+
+func ReportingQueryFromJson(data io.Reader) *ReportingQuery {
+	var q *ReportingQuery
+	json.NewDecoder(data).Decode(&q)
+	return q
+}
+
+// NormalizeReportingQuery collapses whitespace in the given query so that formatting differences
+// don't prevent it from matching a whitelisted template.
+func NormalizeReportingQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// IsReportingQuerySelect returns true if the given query is a single SELECT statement.
+func IsReportingQuerySelect(query string) bool {
+	query = strings.TrimSuffix(NormalizeReportingQuery(query), ";")
+	if strings.Contains(query, ";") {
+		return false
+	}
+
+	fields := strings.Fields(query)
+	return len(fields) > 0 && strings.ToUpper(fields[0]) == "SELECT"
+}
+
+func ReportingQueryResultsToJson(results []map[string]interface{}) string {
+	b, _ := json.Marshal(results)
+	return string(b)
+}
+
+func ReportingQueryResultsFromJson(data io.Reader) []map[string]interface{} {
+	var results []map[string]interface{}
+	json.NewDecoder(data).Decode(&results)
+	return results
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportingQueryJson(t *testing.T) {
+	q := &ReportingQuery{
+		Query:  "SELECT COUNT(*) FROM Posts WHERE ChannelId = :ChannelId",
+		Params: map[string]interface{}{"ChannelId": NewId()},
+	}
+
+	result := ReportingQueryFromJson(strings.NewReader(q.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, q, result)
+}
+
+func TestNormalizeReportingQuery(t *testing.T) {
+	assert.Equal(t, "SELECT Id FROM Users WHERE DeleteAt = 0", NormalizeReportingQuery("  SELECT Id\n\tFROM Users\n  WHERE DeleteAt = 0 "))
+}
+
+func TestIsReportingQuerySelect(t *testing.T) {
+	for query, expected := range map[string]bool{
+		"SELECT Id FROM Users":          true,
+		"  select Id FROM Users;":       true,
+		"\nSELECT\tCOUNT(*) FROM Posts": true,
+		"":                              false,
+		"DELETE FROM Users":             false,
+		"UPDATE Users SET Roles = 'system_admin'": false,
+		"SELECT Id FROM Users; DROP TABLE Users":  false,
+		"SELECTION":                               false,
+	} {
+		assert.Equal(t, expected, IsReportingQuerySelect(query), query)
+	}
+}
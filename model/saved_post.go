@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// SavedPost marks a post that a user has saved for later. Unlike pinned posts, saved posts are only
+// visible to the user who saved them. They replace the flagged_post preferences.
+type SavedPost struct {
+	UserId  string `json:"user_id"`
+	PostId  string `json:"post_id"`
+	SavedAt int64  `json:"saved_at"`
+}
+
+func (o *SavedPost) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.post_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.SavedAt == 0 {
+		return NewAppError("SavedPost.IsValid", "model.saved_post.is_valid.saved_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *SavedPost) PreSave() {
+	if o.SavedAt == 0 {
+		o.SavedAt = GetMillis()
+	}
+}
+
+func (o *SavedPost) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SavedPostFromJson(data io.Reader) *SavedPost {
+	var o *SavedPost
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func SavedPostListToJson(savedPosts []*SavedPost) string {
+	b, _ := json.Marshal(savedPosts)
+	return string(b)
+}
+
+func SavedPostListFromJson(data io.Reader) []*SavedPost {
+	var savedPosts []*SavedPost
+	json.NewDecoder(data).Decode(&savedPosts)
+	return savedPosts
+}
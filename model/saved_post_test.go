@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedPostIsValid(t *testing.T) {
+	savedPost := &SavedPost{UserId: NewId(), PostId: NewId()}
+	assert.NotNil(t, savedPost.IsValid())
+
+	savedPost.PreSave()
+	assert.NotZero(t, savedPost.SavedAt)
+	assert.Nil(t, savedPost.IsValid())
+
+	savedPost.PostId = "junk"
+	assert.NotNil(t, savedPost.IsValid())
+}
+
+func TestSavedPostListJson(t *testing.T) {
+	savedPosts := []*SavedPost{{UserId: NewId(), PostId: NewId(), SavedAt: GetMillis()}}
+
+	assert.Equal(t, savedPosts, SavedPostListFromJson(strings.NewReader(SavedPostListToJson(savedPosts))))
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	SCHEMA_MIGRATION_STATUS_APPLIED = "applied"
+	SCHEMA_MIGRATION_STATUS_PENDING = "pending"
+)
+
+// SchemaMigration describes an upgrade of the database schema to a version of Mattermost. AppliedAt
+// is zero if the upgrade is pending or was applied before upgrade times were recorded.
+type SchemaMigration struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	AppliedAt int64  `json:"applied_at"`
+	Status    string `json:"status"`
+}
+
+func SchemaMigrationListToJson(list []*SchemaMigration) string {
+	b, _ := json.Marshal(list)
+	return string(b)
+}
+
+func SchemaMigrationListFromJson(data io.Reader) []*SchemaMigration {
+	var list []*SchemaMigration
+	json.NewDecoder(data).Decode(&list)
+	return list
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaMigrationListJson(t *testing.T) {
+	list := []*SchemaMigration{
+		{Id: "5.14.0", Name: "UpgradeDatabaseToVersion514", AppliedAt: GetMillis(), Status: SCHEMA_MIGRATION_STATUS_APPLIED},
+		{Id: "5.15.0", Name: "UpgradeDatabaseToVersion515", Status: SCHEMA_MIGRATION_STATUS_PENDING},
+	}
+
+	json := SchemaMigrationListToJson(list)
+	assert.Contains(t, json, `"applied_at":0`)
+	assert.Equal(t, list, SchemaMigrationListFromJson(strings.NewReader(json)))
+}
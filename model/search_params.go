@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// SEARCH_PARAMS_MAX_LIMIT is the most posts that a single database search returns.
+const SEARCH_PARAMS_MAX_LIMIT = 100
+
 var searchTermPuncStart = regexp.MustCompile(`^[^\pL\d\s#"]+`)
 var searchTermPuncEnd = regexp.MustCompile(`[^\pL\d\s*"]+$`)
 
@@ -31,6 +34,9 @@ type SearchParams struct {
 	TimeZoneOffset         int
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool
+	// The maximum number of posts to return, no more than SEARCH_PARAMS_MAX_LIMIT. That is also the
+	// default if it isn't set.
+	Limit int
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
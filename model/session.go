@@ -35,6 +35,8 @@ type Session struct {
 	LastActivityAt int64         `json:"last_activity_at"`
 	UserId         string        `json:"user_id"`
 	DeviceId       string        `json:"device_id"`
+	Platform       string        `json:"platform"`
+	IpAddress      string        `json:"ip_address"`
 	Roles          string        `json:"roles"`
 	IsOAuth        bool          `json:"is_oauth"`
 	Props          StringMap     `json:"props"`
@@ -153,6 +155,29 @@ func (me *Session) GetCSRF() string {
 	return me.Props["csrf"]
 }
 
+// GetPlatform returns the platform the session was created from, falling back to the platform prop
+// recorded for sessions created before it was stored separately.
+func (me *Session) GetPlatform() string {
+	if len(me.Platform) > 0 {
+		return me.Platform
+	}
+
+	return me.Props[SESSION_PROP_PLATFORM]
+}
+
+// ToActivity returns the details of the session that are shown to a user reviewing where they're
+// logged in.
+func (me *Session) ToActivity() *SessionActivity {
+	return &SessionActivity{
+		SessionId:      me.Id,
+		DeviceId:       me.DeviceId,
+		Platform:       me.GetPlatform(),
+		LastActivityAt: me.LastActivityAt,
+		CreateAt:       me.CreateAt,
+		Ip:             me.IpAddress,
+	}
+}
+
 func SessionsToJson(o []*Session) string {
 	if b, err := json.Marshal(o); err != nil {
 		return "[]"
@@ -166,3 +191,23 @@ func SessionsFromJson(data io.Reader) []*Session {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+type SessionActivity struct {
+	SessionId      string `json:"session_id"`
+	DeviceId       string `json:"device_id"`
+	Platform       string `json:"platform"`
+	LastActivityAt int64  `json:"last_activity_at"`
+	CreateAt       int64  `json:"create_at"`
+	Ip             string `json:"ip"`
+}
+
+func SessionActivityListToJson(o []*SessionActivity) string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SessionActivityListFromJson(data io.Reader) []*SessionActivity {
+	var o []*SessionActivity
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
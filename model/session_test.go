@@ -76,3 +76,28 @@ func TestSessionCSRF(t *testing.T) {
 	assert.NotEmpty(t, token2)
 	assert.Equal(t, token, token2)
 }
+
+func TestSessionToActivity(t *testing.T) {
+	session := &Session{
+		Id:             NewId(),
+		DeviceId:       "android:" + NewId(),
+		Platform:       "Android",
+		IpAddress:      "10.0.0.1",
+		CreateAt:       1000,
+		LastActivityAt: 2000,
+		Props:          StringMap{SESSION_PROP_PLATFORM: "Linux"},
+	}
+
+	activity := session.ToActivity()
+	assert.Equal(t, session.Id, activity.SessionId)
+	assert.Equal(t, session.DeviceId, activity.DeviceId)
+	assert.Equal(t, "Android", activity.Platform)
+	assert.Equal(t, "10.0.0.1", activity.Ip)
+	assert.Equal(t, int64(1000), activity.CreateAt)
+	assert.Equal(t, int64(2000), activity.LastActivityAt)
+
+	t.Run("falls back to the platform prop", func(t *testing.T) {
+		session.Platform = ""
+		assert.Equal(t, "Linux", session.ToActivity().Platform)
+	})
+}
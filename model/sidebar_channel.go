@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SidebarChannel is the position of a channel in a user's channel sidebar on a team.
+type SidebarChannel struct {
+	UserId    string `json:"user_id"`
+	TeamId    string `json:"team_id"`
+	ChannelId string `json:"channel_id"`
+	SortOrder int64  `json:"sort_order"`
+}
+
+// SidebarChannelOrder lists the ids of the channels in a user's channel sidebar on a team in the
+// order chosen by the user. Channels the user hasn't ordered aren't included.
+type SidebarChannelOrder struct {
+	UserId string   `json:"user_id"`
+	TeamId string   `json:"team_id"`
+	Order  []string `json:"order"`
+}
+
+func (o *SidebarChannelOrder) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SidebarChannelOrderFromJson(data io.Reader) *SidebarChannelOrder {
+	var o *SidebarChannelOrder
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -18,6 +18,7 @@ const (
 	SYSTEM_ASYMMETRIC_SIGNING_KEY    = "AsymmetricSigningKey"
 	SYSTEM_POST_ACTION_COOKIE_SECRET = "PostActionCookieSecret"
 	SYSTEM_INSTALLATION_DATE_KEY     = "InstallationDate"
+	SYSTEM_SLACK_IMPORT_LOCK         = "SlackImportLock"
 )
 
 type System struct {
@@ -40,6 +41,24 @@ type SystemPostActionCookieSecret struct {
 	Secret []byte `json:"key,omitempty"`
 }
 
+// SystemImportLock is stored in the Systems table while an import holds the lock of the same name.
+type SystemImportLock struct {
+	Holder     string `json:"holder"`
+	Token      string `json:"token"`
+	AcquiredAt int64  `json:"acquired_at"`
+}
+
+func (o *SystemImportLock) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func SystemImportLockFromJson(data string) *SystemImportLock {
+	var o *SystemImportLock
+	json.Unmarshal([]byte(data), &o)
+	return o
+}
+
 type SystemAsymmetricSigningKey struct {
 	ECDSAKey *SystemECDSAKey `json:"ecdsa_key,omitempty"`
 }
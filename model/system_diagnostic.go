@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	SYSTEM_DIAGNOSTIC_STATUS_OK    = "ok"
+	SYSTEM_DIAGNOSTIC_STATUS_WARN  = "warn"
+	SYSTEM_DIAGNOSTIC_STATUS_ERROR = "error"
+
+	SYSTEM_DIAGNOSTIC_SITE_URL             = "site_url"
+	SYSTEM_DIAGNOSTIC_SMTP                 = "smtp"
+	SYSTEM_DIAGNOSTIC_FILE_STORAGE         = "file_storage"
+	SYSTEM_DIAGNOSTIC_DATABASE_CONNECTIONS = "database_connections"
+	SYSTEM_DIAGNOSTIC_TLS_CERTIFICATE      = "tls_certificate"
+)
+
+// SystemDiagnostic is the result of checking the server for a common misconfiguration.
+type SystemDiagnostic struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+func SystemDiagnosticListToJson(diagnostics []*SystemDiagnostic) string {
+	b, _ := json.Marshal(diagnostics)
+	return string(b)
+}
+
+func SystemDiagnosticListFromJson(data io.Reader) []*SystemDiagnostic {
+	var diagnostics []*SystemDiagnostic
+	json.NewDecoder(data).Decode(&diagnostics)
+	return diagnostics
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	TEAM_GROWTH_GRANULARITY_WEEK  = "week"
+	TEAM_GROWTH_GRANULARITY_MONTH = "month"
+
+	TEAM_GROWTH_DEFAULT_PERIODS = 12
+	TEAM_GROWTH_MAX_PERIODS     = 120
+
+	TEAM_GROWTH_PERIOD_FORMAT = "2006-01-02"
+)
+
+// TeamGrowthPeriod counts the users who joined and left a team during a week or month. Period is
+// the date on which the period starts in UTC.
+type TeamGrowthPeriod struct {
+	Period          string `json:"period"`
+	NewMembers      int64  `json:"new_members"`
+	DepartedMembers int64  `json:"departed_members"`
+	Net             int64  `json:"net"`
+}
+
+func IsValidTeamGrowthGranularity(granularity string) bool {
+	return granularity == TEAM_GROWTH_GRANULARITY_WEEK || granularity == TEAM_GROWTH_GRANULARITY_MONTH
+}
+
+// TeamGrowthPeriodStart returns the start of the period containing t. Weeks start on Monday and
+// both weeks and months start at midnight UTC.
+func TeamGrowthPeriodStart(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+	if granularity == TEAM_GROWTH_GRANULARITY_MONTH {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// AddTeamGrowthPeriods moves the given period start forward by n periods, or back if n is negative.
+func AddTeamGrowthPeriods(start time.Time, granularity string, n int) time.Time {
+	if granularity == TEAM_GROWTH_GRANULARITY_MONTH {
+		return start.AddDate(0, n, 0)
+	}
+
+	return start.AddDate(0, 0, 7*n)
+}
+
+func TeamGrowthPeriodListToJson(list []*TeamGrowthPeriod) string {
+	b, _ := json.Marshal(list)
+	return string(b)
+}
+
+func TeamGrowthPeriodListFromJson(data io.Reader) []*TeamGrowthPeriod {
+	var list []*TeamGrowthPeriod
+	json.NewDecoder(data).Decode(&list)
+	return list
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamGrowthPeriodStart(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Time        time.Time
+		Granularity string
+		Expected    string
+	}{
+		"week starting on monday": {
+			Time:        time.Date(2019, 7, 15, 0, 0, 0, 0, time.UTC),
+			Granularity: TEAM_GROWTH_GRANULARITY_WEEK,
+			Expected:    "2019-07-15",
+		},
+		"week ending on sunday": {
+			Time:        time.Date(2019, 7, 21, 23, 59, 59, 0, time.UTC),
+			Granularity: TEAM_GROWTH_GRANULARITY_WEEK,
+			Expected:    "2019-07-15",
+		},
+		"week spanning months": {
+			Time:        time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC),
+			Granularity: TEAM_GROWTH_GRANULARITY_WEEK,
+			Expected:    "2019-07-29",
+		},
+		"week in another time zone": {
+			Time:        time.Date(2019, 7, 22, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			Granularity: TEAM_GROWTH_GRANULARITY_WEEK,
+			Expected:    "2019-07-15",
+		},
+		"month": {
+			Time:        time.Date(2019, 7, 31, 23, 59, 59, 0, time.UTC),
+			Granularity: TEAM_GROWTH_GRANULARITY_MONTH,
+			Expected:    "2019-07-01",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			start := TeamGrowthPeriodStart(tc.Time, tc.Granularity)
+			assert.Equal(t, tc.Expected, start.Format(TEAM_GROWTH_PERIOD_FORMAT))
+			assert.Equal(t, time.UTC, start.Location())
+		})
+	}
+}
+
+func TestAddTeamGrowthPeriods(t *testing.T) {
+	start := time.Date(2019, 1, 28, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2019-02-04", AddTeamGrowthPeriods(start, TEAM_GROWTH_GRANULARITY_WEEK, 1).Format(TEAM_GROWTH_PERIOD_FORMAT))
+	assert.Equal(t, "2019-01-14", AddTeamGrowthPeriods(start, TEAM_GROWTH_GRANULARITY_WEEK, -2).Format(TEAM_GROWTH_PERIOD_FORMAT))
+
+	start = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2019-03-01", AddTeamGrowthPeriods(start, TEAM_GROWTH_GRANULARITY_MONTH, 2).Format(TEAM_GROWTH_PERIOD_FORMAT))
+	assert.Equal(t, "2018-12-01", AddTeamGrowthPeriods(start, TEAM_GROWTH_GRANULARITY_MONTH, -1).Format(TEAM_GROWTH_PERIOD_FORMAT))
+}
+
+func TestTeamGrowthPeriodListJson(t *testing.T) {
+	list := []*TeamGrowthPeriod{
+		{Period: "2019-07-15", NewMembers: 3, DepartedMembers: 1, Net: 2},
+	}
+
+	json := TeamGrowthPeriodListToJson(list)
+	assert.Contains(t, json, `"new_members":3`)
+	assert.Equal(t, list, TeamGrowthPeriodListFromJson(strings.NewReader(json)))
+}
@@ -32,6 +32,22 @@ type TeamMemberForExport struct {
 	TeamName string
 }
 
+// TeamMembersBulkRemove is the request body for removing several users from a team at once.
+type TeamMembersBulkRemove struct {
+	UserIds []string `json:"user_ids"`
+}
+
+// TeamMembersBulkRemoveResult reports how many users were removed from a team, and why any others were not.
+type TeamMembersBulkRemoveResult struct {
+	Removed int                          `json:"removed"`
+	Errors  []*TeamMemberBulkRemoveError `json:"errors"`
+}
+
+type TeamMemberBulkRemoveError struct {
+	UserId string `json:"user_id"`
+	Error  string `json:"error"`
+}
+
 func (o *TeamMember) ToJson() string {
 	b, _ := json.Marshal(o)
 	return string(b)
@@ -68,6 +84,28 @@ func TeamMembersFromJson(data io.Reader) []*TeamMember {
 	return o
 }
 
+func (o *TeamMembersBulkRemove) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func TeamMembersBulkRemoveFromJson(data io.Reader) *TeamMembersBulkRemove {
+	var o *TeamMembersBulkRemove
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
+func (o *TeamMembersBulkRemoveResult) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func TeamMembersBulkRemoveResultFromJson(data io.Reader) *TeamMembersBulkRemoveResult {
+	var o *TeamMembersBulkRemoveResult
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 func TeamsUnreadToJson(o []*TeamUnread) string {
 	if b, err := json.Marshal(o); err != nil {
 		return "[]"
@@ -0,0 +1,11 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+type TeamMemberHistory struct {
+	TeamId    string
+	UserId    string
+	JoinTime  int64
+	LeaveTime *int64
+}
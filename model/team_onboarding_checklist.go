@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	TEAM_ONBOARDING_STEP_INVITE_MEMBER   = "invite_member"
+	TEAM_ONBOARDING_STEP_CREATE_CHANNEL  = "create_channel"
+	TEAM_ONBOARDING_STEP_ADD_INTEGRATION = "add_integration"
+
+	// The checklist of a team is stored as preferences in this category, with the team id in place of
+	// a user id and the step id as the name.
+	PREFERENCE_CATEGORY_TEAM_ONBOARDING = "team_onboarding"
+)
+
+// TeamOnboardingSteps lists the steps of the team onboarding checklist in the order they are shown.
+var TeamOnboardingSteps = []string{
+	TEAM_ONBOARDING_STEP_INVITE_MEMBER,
+	TEAM_ONBOARDING_STEP_CREATE_CHANNEL,
+	TEAM_ONBOARDING_STEP_ADD_INTEGRATION,
+}
+
+type TeamOnboardingStep struct {
+	Id          string `json:"id"`
+	Completed   bool   `json:"completed"`
+	CompletedAt int64  `json:"completed_at"`
+}
+
+// TeamOnboardingChecklist guides team admins through setting up a new team.
+type TeamOnboardingChecklist struct {
+	TeamId string                `json:"team_id"`
+	Steps  []*TeamOnboardingStep `json:"steps"`
+}
+
+func IsValidTeamOnboardingStep(stepId string) bool {
+	for _, id := range TeamOnboardingSteps {
+		if id == stepId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *TeamOnboardingChecklist) IsValid() *AppError {
+	for _, step := range o.Steps {
+		if step == nil || !IsValidTeamOnboardingStep(step.Id) {
+			var stepId string
+			if step != nil {
+				stepId = step.Id
+			}
+			return NewAppError("TeamOnboardingChecklist.IsValid", "model.team_onboarding_checklist.is_valid.step.app_error", map[string]interface{}{"Step": stepId}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *TeamOnboardingChecklist) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func TeamOnboardingChecklistFromJson(data io.Reader) *TeamOnboardingChecklist {
+	var o *TeamOnboardingChecklist
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
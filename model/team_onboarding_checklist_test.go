@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamOnboardingChecklistIsValid(t *testing.T) {
+	checklist := &TeamOnboardingChecklist{Steps: []*TeamOnboardingStep{{Id: TEAM_ONBOARDING_STEP_CREATE_CHANNEL, Completed: true}}}
+	assert.Nil(t, checklist.IsValid())
+
+	checklist.Steps = append(checklist.Steps, &TeamOnboardingStep{Id: "junk"})
+	assert.NotNil(t, checklist.IsValid())
+
+	checklist.Steps = []*TeamOnboardingStep{nil}
+	assert.NotNil(t, checklist.IsValid())
+}
+
+func TestTeamOnboardingChecklistJson(t *testing.T) {
+	checklist := &TeamOnboardingChecklist{TeamId: NewId(), Steps: []*TeamOnboardingStep{{Id: TEAM_ONBOARDING_STEP_INVITE_MEMBER, Completed: true, CompletedAt: GetMillis()}}}
+
+	result := TeamOnboardingChecklistFromJson(strings.NewReader(checklist.ToJson()))
+	require.NotNil(t, result)
+	assert.Equal(t, checklist, result)
+}
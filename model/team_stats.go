@@ -12,6 +12,8 @@ type TeamStats struct {
 	TeamId            string `json:"team_id"`
 	TotalMemberCount  int64  `json:"total_member_count"`
 	ActiveMemberCount int64  `json:"active_member_count"`
+	ChannelCount      int64  `json:"channel_count"`
+	MaxChannels       int64  `json:"max_channels"`
 }
 
 func (o *TeamStats) ToJson() string {
@@ -694,6 +694,22 @@ func UserListFromJson(data io.Reader) []*User {
 	return users
 }
 
+// UserIdsBatch is the body of a request for the profiles of several users at once.
+type UserIdsBatch struct {
+	UserIds []string `json:"user_ids"`
+}
+
+func (o *UserIdsBatch) ToJson() string {
+	b, _ := json.Marshal(o)
+	return string(b)
+}
+
+func UserIdsBatchFromJson(data io.Reader) *UserIdsBatch {
+	var o *UserIdsBatch
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
+
 // HashPassword generates a hash using the bcrypt.GenerateFromPassword
 func HashPassword(password string) string {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
@@ -11,18 +11,23 @@ import (
 const USER_SEARCH_MAX_LIMIT = 1000
 const USER_SEARCH_DEFAULT_LIMIT = 100
 
+// USER_SEARCH_CUSTOM_ATTRIBUTES are the custom profile attributes, stored in User.Props, that a
+// search including custom attributes matches. Other props are never searched.
+var USER_SEARCH_CUSTOM_ATTRIBUTES = []string{"department", "location"}
+
 // UserSearch captures the parameters provided by a client for initiating a user search.
 type UserSearch struct {
-	Term             string `json:"term"`
-	TeamId           string `json:"team_id"`
-	NotInTeamId      string `json:"not_in_team_id"`
-	InChannelId      string `json:"in_channel_id"`
-	NotInChannelId   string `json:"not_in_channel_id"`
-	GroupConstrained bool   `json:"group_constrained"`
-	AllowInactive    bool   `json:"allow_inactive"`
-	WithoutTeam      bool   `json:"without_team"`
-	Limit            int    `json:"limit"`
-	Role             string `json:"role"`
+	Term               string `json:"term"`
+	TeamId             string `json:"team_id"`
+	NotInTeamId        string `json:"not_in_team_id"`
+	InChannelId        string `json:"in_channel_id"`
+	NotInChannelId     string `json:"not_in_channel_id"`
+	GroupConstrained   bool   `json:"group_constrained"`
+	AllowInactive      bool   `json:"allow_inactive"`
+	WithoutTeam        bool   `json:"without_team"`
+	Limit              int    `json:"limit"`
+	Role               string `json:"role"`
+	IncludeCustomAttrs bool   `json:"include_custom_attrs"`
 }
 
 // ToJson convert a User to a json string
@@ -62,4 +67,8 @@ type UserSearchOptions struct {
 	Role string
 	// Restrict to search in a list of teams and channels
 	ViewRestrictions *ViewUsersRestrictions
+	// IncludeCustomAttrs allows search to examine the custom profile attributes stored in the user's props.
+	IncludeCustomAttrs bool
+	// Fuzzy also matches usernames that are similar to, rather than starting with, the search term.
+	Fuzzy bool
 }
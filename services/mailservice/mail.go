@@ -4,6 +4,7 @@
 package mailservice
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"io"
@@ -99,22 +100,29 @@ func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
 }
 
 func ConnectToSMTPServerAdvanced(connectionInfo *SmtpConnectionInfo) (net.Conn, *model.AppError) {
+	return connectToSMTPServer(context.Background(), connectionInfo)
+}
+
+func connectToSMTPServer(ctx context.Context, connectionInfo *SmtpConnectionInfo) (net.Conn, *model.AppError) {
 	var conn net.Conn
 	var err error
 
 	smtpAddress := connectionInfo.SmtpServerHost + ":" + connectionInfo.SmtpPort
 	if connectionInfo.ConnectionSecurity == model.CONN_SECURITY_TLS {
-		tlsconfig := &tls.Config{
-			InsecureSkipVerify: connectionInfo.SkipCertVerification,
-			ServerName:         connectionInfo.SmtpServerName,
+		dialer := &tls.Dialer{
+			Config: &tls.Config{
+				InsecureSkipVerify: connectionInfo.SkipCertVerification,
+				ServerName:         connectionInfo.SmtpServerName,
+			},
 		}
 
-		conn, err = tls.Dial("tcp", smtpAddress, tlsconfig)
+		conn, err = dialer.DialContext(ctx, "tcp", smtpAddress)
 		if err != nil {
 			return nil, model.NewAppError("SendMail", "utils.mail.connect_smtp.open_tls.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
 	} else {
-		conn, err = net.Dial("tcp", smtpAddress)
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", smtpAddress)
 		if err != nil {
 			return nil, model.NewAppError("SendMail", "utils.mail.connect_smtp.open.app_error", nil, err.Error(), http.StatusInternalServerError)
 		}
@@ -124,7 +132,12 @@ func ConnectToSMTPServerAdvanced(connectionInfo *SmtpConnectionInfo) (net.Conn,
 }
 
 func ConnectToSMTPServer(config *model.Config) (net.Conn, *model.AppError) {
-	return ConnectToSMTPServerAdvanced(
+	return ConnectToSMTPServerContext(context.Background(), config)
+}
+
+// ConnectToSMTPServerContext connects to the configured SMTP server, giving up once ctx is done.
+func ConnectToSMTPServerContext(ctx context.Context, config *model.Config) (net.Conn, *model.AppError) {
+	return connectToSMTPServer(ctx,
 		&SmtpConnectionInfo{
 			ConnectionSecurity:   *config.EmailSettings.ConnectionSecurity,
 			SkipCertVerification: *config.EmailSettings.SkipServerCertificateVerification,
@@ -217,6 +230,15 @@ func SendMailUsingConfigAdvanced(mimeTo, smtpTo string, from, replyTo mail.Addre
 		return nil
 	}
 
+	emailQueueStats.enqueue()
+	start := time.Now()
+	err := sendMailUsingConfigAdvanced(mimeTo, smtpTo, from, replyTo, subject, htmlBody, attachments, mimeHeaders, config, enableComplianceFeatures)
+	emailQueueStats.dequeue(time.Now(), time.Since(start), err != nil)
+
+	return err
+}
+
+func sendMailUsingConfigAdvanced(mimeTo, smtpTo string, from, replyTo mail.Address, subject, htmlBody string, attachments []*model.FileInfo, mimeHeaders map[string]string, config *model.Config, enableComplianceFeatures bool) *model.AppError {
 	conn, err := ConnectToSMTPServer(config)
 	if err != nil {
 		return err
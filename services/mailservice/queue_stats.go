@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mailservice
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const QUEUE_STATS_WINDOW_SECONDS = 60
+
+// queueStatsBucket counts the emails that finished sending during one second.
+type queueStatsBucket struct {
+	second    int64
+	sent      int64
+	errors    int64
+	latencyMs int64
+}
+
+// queueStats collects statistics about the emails being sent in a ring buffer of one second buckets
+// covering the last minute. It only uses atomic operations so that sending emails never waits on it.
+// A bucket is reset by the first email to finish in a new second, so an email finishing concurrently
+// with the reset may go uncounted.
+type queueStats struct {
+	depth   int64
+	buckets [QUEUE_STATS_WINDOW_SECONDS]queueStatsBucket
+}
+
+var emailQueueStats = &queueStats{}
+
+// GetQueueStats returns the number of emails waiting to be sent along with how many were sent, how
+// many failed and how long they took on average over the last minute.
+func GetQueueStats() *model.EmailQueueStats {
+	return emailQueueStats.snapshot(time.Now())
+}
+
+func (s *queueStats) enqueue() {
+	atomic.AddInt64(&s.depth, 1)
+}
+
+func (s *queueStats) dequeue(now time.Time, latency time.Duration, failed bool) {
+	atomic.AddInt64(&s.depth, -1)
+
+	second := now.Unix()
+	bucket := &s.buckets[second%QUEUE_STATS_WINDOW_SECONDS]
+	if previous := atomic.LoadInt64(&bucket.second); previous != second && atomic.CompareAndSwapInt64(&bucket.second, previous, second) {
+		atomic.StoreInt64(&bucket.sent, 0)
+		atomic.StoreInt64(&bucket.errors, 0)
+		atomic.StoreInt64(&bucket.latencyMs, 0)
+	}
+
+	if failed {
+		atomic.AddInt64(&bucket.errors, 1)
+	} else {
+		atomic.AddInt64(&bucket.sent, 1)
+	}
+	atomic.AddInt64(&bucket.latencyMs, int64(latency/time.Millisecond))
+}
+
+func (s *queueStats) snapshot(now time.Time) *model.EmailQueueStats {
+	stats := &model.EmailQueueStats{
+		QueueDepth: atomic.LoadInt64(&s.depth),
+	}
+
+	var latencyMs int64
+	for i := range s.buckets {
+		bucket := &s.buckets[i]
+		if age := now.Unix() - atomic.LoadInt64(&bucket.second); age < 0 || age >= QUEUE_STATS_WINDOW_SECONDS {
+			continue
+		}
+
+		stats.SentLastMinute += atomic.LoadInt64(&bucket.sent)
+		stats.ErrorsLastMinute += atomic.LoadInt64(&bucket.errors)
+		latencyMs += atomic.LoadInt64(&bucket.latencyMs)
+	}
+
+	if count := stats.SentLastMinute + stats.ErrorsLastMinute; count > 0 {
+		stats.AverageLatencyMs = latencyMs / count
+	}
+
+	return stats
+}
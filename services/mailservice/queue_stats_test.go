@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package mailservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+func TestQueueStats(t *testing.T) {
+	stats := &queueStats{}
+	now := time.Now()
+
+	stats.enqueue()
+	stats.enqueue()
+	stats.enqueue()
+	assert.Equal(t, &model.EmailQueueStats{QueueDepth: 3}, stats.snapshot(now))
+
+	stats.dequeue(now.Add(-90*time.Second), 500*time.Millisecond, false)
+	stats.dequeue(now.Add(-2*time.Second), 100*time.Millisecond, false)
+	stats.dequeue(now, 300*time.Millisecond, true)
+
+	assert.Equal(t, &model.EmailQueueStats{
+		QueueDepth:       0,
+		SentLastMinute:   1,
+		ErrorsLastMinute: 1,
+		AverageLatencyMs: 200,
+	}, stats.snapshot(now))
+
+	t.Run("bucket reused after a minute", func(t *testing.T) {
+		later := now.Add(QUEUE_STATS_WINDOW_SECONDS * time.Second)
+		stats.enqueue()
+		stats.dequeue(later, 50*time.Millisecond, false)
+
+		assert.Equal(t, &model.EmailQueueStats{SentLastMinute: 1, AverageLatencyMs: 50}, stats.snapshot(later))
+	})
+}
@@ -178,6 +178,38 @@ func (s *LayeredStore) LinkMetadata() LinkMetadataStore {
 	return s.DatabaseLayer.LinkMetadata()
 }
 
+func (s *LayeredStore) PushNotificationQueue() PushNotificationQueueStore {
+	return s.DatabaseLayer.PushNotificationQueue()
+}
+
+func (s *LayeredStore) ChannelTopicHistory() ChannelTopicHistoryStore {
+	return s.DatabaseLayer.ChannelTopicHistory()
+}
+
+func (s *LayeredStore) TeamMemberHistory() TeamMemberHistoryStore {
+	return s.DatabaseLayer.TeamMemberHistory()
+}
+
+func (s *LayeredStore) SidebarChannel() SidebarChannelStore {
+	return s.DatabaseLayer.SidebarChannel()
+}
+
+func (s *LayeredStore) Mention() MentionStore {
+	return s.DatabaseLayer.Mention()
+}
+
+func (s *LayeredStore) PasswordHistory() PasswordHistoryStore {
+	return s.DatabaseLayer.PasswordHistory()
+}
+
+func (s *LayeredStore) SavedPost() SavedPostStore {
+	return s.DatabaseLayer.SavedPost()
+}
+
+func (s *LayeredStore) ConfigChangeRequest() ConfigChangeRequestStore {
+	return s.DatabaseLayer.ConfigChangeRequest()
+}
+
 func (s *LayeredStore) MarkSystemRanUnitTests() {
 	s.DatabaseLayer.MarkSystemRanUnitTests()
 }
@@ -27,6 +27,7 @@ func getMockStore() *mocks.Store {
 	fakeRole := model.Role{Id: "123", Name: "role-name"}
 	mockRolesStore := mocks.RoleStore{}
 	mockRolesStore.On("Save", &fakeRole).Return(&model.Role{}, nil)
+	mockRolesStore.On("UpdateMultiple", []*model.Role{&fakeRole}).Return([]*model.Role{&fakeRole}, nil)
 	mockRolesStore.On("Delete", "123").Return(&fakeRole, nil)
 	mockRolesStore.On("GetByName", "role-name").Return(&fakeRole, nil)
 	mockRolesStore.On("GetByNames", []string{"role-name"}).Return([]*model.Role{&fakeRole}, nil)
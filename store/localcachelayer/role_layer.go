@@ -28,6 +28,15 @@ func (s LocalCacheRoleStore) Save(role *model.Role) (*model.Role, *model.AppErro
 	return s.RoleStore.Save(role)
 }
 
+func (s LocalCacheRoleStore) UpdateMultiple(roles []*model.Role) ([]*model.Role, *model.AppError) {
+	for _, role := range roles {
+		if len(role.Name) != 0 {
+			defer s.rootStore.doInvalidateCacheCluster(s.rootStore.roleCache, role.Name)
+		}
+	}
+	return s.RoleStore.UpdateMultiple(roles)
+}
+
 func (s LocalCacheRoleStore) GetByName(name string) (*model.Role, *model.AppError) {
 	if role := s.rootStore.doStandardReadCache(s.rootStore.roleCache, name); role != nil {
 		return role.(*model.Role), nil
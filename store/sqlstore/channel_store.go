@@ -343,6 +343,10 @@ func NewSqlChannelStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		tablePublicChannels.SetUniqueTogether("Name", "TeamId")
 		tablePublicChannels.ColMap("Header").SetMaxSize(1024)
 		tablePublicChannels.ColMap("Purpose").SetMaxSize(250)
+
+		tableRecentChannels := db.AddTableWithName(model.RecentChannel{}, "RecentChannels").SetKeys(false, "UserId", "ChannelId")
+		tableRecentChannels.ColMap("UserId").SetMaxSize(26)
+		tableRecentChannels.ColMap("ChannelId").SetMaxSize(26)
 	}
 
 	return s
@@ -373,6 +377,9 @@ func (s SqlChannelStore) CreateIndexesIfNotExists() {
 		s.CreateIndexIfNotExists("idx_publicchannels_displayname_lower", "PublicChannels", "lower(DisplayName)")
 	}
 	s.CreateFullTextIndexIfNotExists("idx_publicchannels_search_txt", "PublicChannels", "Name, DisplayName, Purpose")
+
+	s.CreateCompositeIndexIfNotExists("idx_recentchannels_user_id_last_viewed_at", "RecentChannels", []string{"UserId", "LastViewedAt"})
+	s.CreateIndexIfNotExists("idx_recentchannels_channel_id", "RecentChannels", "ChannelId")
 }
 
 // MigratePublicChannels initializes the PublicChannels table with data created before this version
@@ -893,6 +900,10 @@ func (s SqlChannelStore) PermanentDeleteMembersByChannel(channelId string) *mode
 		return model.NewAppError("SqlChannelStore.RemoveAllMembersByChannel", "store.sql_channel.remove_member.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
 	}
 
+	if _, err := s.GetMaster().Exec("DELETE FROM RecentChannels WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return model.NewAppError("SqlChannelStore.RemoveAllMembersByChannel", "store.sql_channel.remove_member.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
 	return nil
 }
 
@@ -1133,6 +1144,17 @@ func (s SqlChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *mo
 	return data, nil
 }
 
+// GetTeamChannelCount returns the number of open and private channels on the given team that
+// have not been archived, which is what the channels per team limit is enforced against.
+func (s SqlChannelStore) GetTeamChannelCount(teamId string) (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(0) FROM Channels WHERE TeamId = :TeamId AND DeleteAt = 0 AND (Type = 'O' OR Type = 'P')", map[string]interface{}{"TeamId": teamId})
+	if err != nil {
+		return 0, model.NewAppError("SqlChannelStore.GetTeamChannelCount", "store.sql_channel.get_team_channel_count.app_error", nil, "teamId="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
 func (s SqlChannelStore) GetByName(teamId string, name string, allowFromCache bool) (*model.Channel, *model.AppError) {
 	return s.getByName(teamId, name, false, allowFromCache)
 }
@@ -1303,6 +1325,109 @@ func (s SqlChannelStore) SaveMember(member *model.ChannelMember) (*model.Channel
 	return newMember, nil
 }
 
+// SaveMultipleMembers adds the given members to their channels with a single INSERT, and returns
+// the members that were added. Unlike SaveMember, the saved members aren't read back. Members that
+// already exist, including those added concurrently, are skipped by retrying the INSERT without them.
+func (s SqlChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, *model.AppError) {
+	for _, member := range members {
+		member.PreSave()
+		if err := member.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+
+	defer func() {
+		for _, member := range members {
+			s.InvalidateAllChannelMembersForUser(member.UserId)
+		}
+	}()
+
+	remaining := members
+	for len(remaining) > 0 {
+		err := s.insertMembers(remaining)
+		if err == nil {
+			break
+		}
+
+		if !IsUniqueConstraintError(err, []string{"ChannelId", "channelmembers_pkey"}) {
+			return nil, model.NewAppError("SqlChannelStore.SaveMultipleMembers", "store.sql_channel.save_member.save.app_error", nil, "channel_id="+remaining[0].ChannelId+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		withoutExisting, appErr := s.withoutExistingMembers(remaining)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		// Every retry drops at least one member, so a conflict that can't be explained by an
+		// existing member is reported rather than retried.
+		if len(withoutExisting) == len(remaining) {
+			return nil, model.NewAppError("SqlChannelStore.SaveMultipleMembers", "store.sql_channel.save_member.exists.app_error", nil, "channel_id="+remaining[0].ChannelId+", "+err.Error(), http.StatusBadRequest)
+		}
+		remaining = withoutExisting
+	}
+
+	return remaining, nil
+}
+
+func (s SqlChannelStore) insertMembers(members []*model.ChannelMember) error {
+	query := s.getQueryBuilder().
+		Insert("ChannelMembers").
+		Columns("ChannelId", "UserId", "Roles", "LastViewedAt", "MsgCount", "MentionCount", "NotifyProps", "LastUpdateAt", "SchemeUser", "SchemeAdmin", "SchemeGuest")
+
+	for _, member := range members {
+		dbMember := NewChannelMemberFromModel(member)
+		query = query.Values(dbMember.ChannelId, dbMember.UserId, dbMember.Roles, dbMember.LastViewedAt, dbMember.MsgCount, dbMember.MentionCount, model.MapToJson(dbMember.NotifyProps), dbMember.LastUpdateAt, dbMember.SchemeUser, dbMember.SchemeAdmin, dbMember.SchemeGuest)
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.GetMaster().Exec(queryString, args...)
+	return err
+}
+
+// withoutExistingMembers returns the given members that aren't yet members of their channels.
+func (s SqlChannelStore) withoutExistingMembers(members []*model.ChannelMember) ([]*model.ChannelMember, *model.AppError) {
+	var channelIds, userIds []string
+	for _, member := range members {
+		channelIds = append(channelIds, member.ChannelId)
+		userIds = append(userIds, member.UserId)
+	}
+
+	queryString, args, err := s.getQueryBuilder().
+		Select("ChannelId", "UserId").
+		From("ChannelMembers").
+		Where(sq.Eq{"ChannelId": channelIds, "UserId": userIds}).
+		ToSql()
+	if err != nil {
+		return nil, model.NewAppError("SqlChannelStore.SaveMultipleMembers", "store.sql_channel.get_members_by_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	var existing []struct {
+		ChannelId string
+		UserId    string
+	}
+	if _, err := s.GetMaster().Select(&existing, queryString, args...); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.SaveMultipleMembers", "store.sql_channel.get_members_by_ids.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	isMember := make(map[string]bool, len(existing))
+	for _, member := range existing {
+		isMember[member.ChannelId+member.UserId] = true
+	}
+
+	var remaining []*model.ChannelMember
+	for _, member := range members {
+		if !isMember[member.ChannelId+member.UserId] {
+			remaining = append(remaining, member)
+		}
+	}
+
+	return remaining, nil
+}
+
 func (s SqlChannelStore) saveMemberT(transaction *gorp.Transaction, member *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, *model.AppError) {
 	member.PreSave()
 	if err := member.IsValid(); err != nil {
@@ -1792,6 +1917,9 @@ func (s SqlChannelStore) PermanentDeleteMembersByUser(userId string) *model.AppE
 	if _, err := s.GetMaster().Exec("DELETE FROM ChannelMembers WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
 		return model.NewAppError("SqlChannelStore.RemoveMember", "store.sql_channel.permanent_delete_members_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
 	}
+	if _, err := s.GetMaster().Exec("DELETE FROM RecentChannels WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlChannelStore.RemoveMember", "store.sql_channel.permanent_delete_members_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
 	return nil
 }
 
@@ -1880,6 +2008,95 @@ func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string)
 	return times, nil
 }
 
+// SaveRecentChannel records that the user viewed the channel, keeping only the most recent visit for each channel.
+func (s SqlChannelStore) SaveRecentChannel(recent *model.RecentChannel) *model.AppError {
+	if err := recent.IsValid(); err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{
+		"UserId":       recent.UserId,
+		"ChannelId":    recent.ChannelId,
+		"LastViewedAt": recent.LastViewedAt,
+	}
+
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		if _, err := s.GetMaster().Exec(
+			`INSERT INTO
+				RecentChannels
+				(UserId, ChannelId, LastViewedAt)
+			VALUES
+				(:UserId, :ChannelId, :LastViewedAt)
+			ON DUPLICATE KEY UPDATE
+				LastViewedAt = GREATEST(LastViewedAt, :LastViewedAt)`, params); err != nil {
+			return model.NewAppError("SqlChannelStore.SaveRecentChannel", "store.sql_channel.save_recent_channel.app_error", nil, "channel_id="+recent.ChannelId+", user_id="+recent.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+		return nil
+	}
+
+	// Postgres 9.4 doesn't support native upsert, so update the existing row and only insert if there was none.
+	updateQuery := `UPDATE
+			RecentChannels
+		SET
+			LastViewedAt = GREATEST(LastViewedAt, :LastViewedAt)
+		WHERE
+			UserId = :UserId
+			AND ChannelId = :ChannelId`
+
+	result, err := s.GetMaster().Exec(updateQuery, params)
+	if err != nil {
+		return model.NewAppError("SqlChannelStore.SaveRecentChannel", "store.sql_channel.save_recent_channel.app_error", nil, "channel_id="+recent.ChannelId+", user_id="+recent.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		return nil
+	}
+
+	if err := s.GetMaster().Insert(recent); err != nil {
+		if !IsUniqueConstraintError(err, []string{"recentchannels_pkey"}) {
+			return model.NewAppError("SqlChannelStore.SaveRecentChannel", "store.sql_channel.save_recent_channel.app_error", nil, "channel_id="+recent.ChannelId+", user_id="+recent.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		// Another request inserted the row first, so update it instead.
+		if _, err := s.GetMaster().Exec(updateQuery, params); err != nil {
+			return model.NewAppError("SqlChannelStore.SaveRecentChannel", "store.sql_channel.save_recent_channel.app_error", nil, "channel_id="+recent.ChannelId+", user_id="+recent.UserId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return nil
+}
+
+// GetRecentChannels returns the channels most recently viewed by the user, newest first. Channels that the user
+// is no longer a member of or that have been deleted are omitted. If teamId is set, only channels on that team
+// and direct and group messages are returned.
+func (s SqlChannelStore) GetRecentChannels(userId string, teamId string, limit int) ([]*model.RecentChannel, *model.AppError) {
+	teamFilter := ""
+	if teamId != "" {
+		teamFilter = "AND (Channels.TeamId = :TeamId OR Channels.TeamId = '')"
+	}
+
+	query := `SELECT
+			RecentChannels.*
+		FROM
+			RecentChannels
+			INNER JOIN ChannelMembers ON ChannelMembers.ChannelId = RecentChannels.ChannelId AND ChannelMembers.UserId = RecentChannels.UserId
+			INNER JOIN Channels ON Channels.Id = RecentChannels.ChannelId
+		WHERE
+			RecentChannels.UserId = :UserId
+			AND Channels.DeleteAt = 0
+			` + teamFilter + `
+		ORDER BY
+			RecentChannels.LastViewedAt DESC
+		LIMIT :Limit`
+
+	recent := []*model.RecentChannel{}
+	if _, err := s.GetReplica().Select(&recent, query, map[string]interface{}{"UserId": userId, "TeamId": teamId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlChannelStore.GetRecentChannels", "store.sql_channel.get_recent_channels.app_error", nil, "user_id="+userId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return recent, nil
+}
+
 func (s SqlChannelStore) IncrementMentionCount(channelId string, userId string) *model.AppError {
 	_, err := s.GetMaster().Exec(
 		`UPDATE
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlChannelTopicHistoryStore struct {
+	SqlStore
+}
+
+func NewSqlChannelTopicHistoryStore(sqlStore SqlStore) store.ChannelTopicHistoryStore {
+	s := &SqlChannelTopicHistoryStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ChannelTopicHistory{}, "ChannelTopicHistory").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("Type").SetMaxSize(16)
+		table.ColMap("Topic").SetMaxSize(model.CHANNEL_HEADER_MAX_RUNES * 4)
+		table.ColMap("SetByUserId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlChannelTopicHistoryStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_channel_topic_history_channel_id_create_at", "ChannelTopicHistory", []string{"ChannelId", "CreateAt"})
+	s.CreateIndexIfNotExists("idx_channel_topic_history_create_at", "ChannelTopicHistory", "CreateAt")
+}
+
+func (s SqlChannelTopicHistoryStore) Save(history *model.ChannelTopicHistory) (*model.ChannelTopicHistory, *model.AppError) {
+	history.PreSave()
+	if err := history.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(history); err != nil {
+		return nil, model.NewAppError("SqlChannelTopicHistoryStore.Save", "store.sql_channel_topic_history.save.app_error", nil, "id="+history.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return history, nil
+}
+
+// GetForChannel returns the most recent changes to the topics of the given channel, newest first.
+func (s SqlChannelTopicHistoryStore) GetForChannel(channelId string, limit int) ([]*model.ChannelTopicHistory, *model.AppError) {
+	var list []*model.ChannelTopicHistory
+
+	query := `
+		SELECT *
+		FROM ChannelTopicHistory
+		WHERE ChannelId = :ChannelId
+		ORDER BY CreateAt DESC
+		LIMIT :Limit`
+
+	if _, err := s.GetReplica().Select(&list, query, map[string]interface{}{"ChannelId": channelId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlChannelTopicHistoryStore.GetForChannel", "store.sql_channel_topic_history.get_for_channel.app_error", nil, "channel_id="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return list, nil
+}
+
+func (s SqlChannelTopicHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query =
+			`DELETE FROM ChannelTopicHistory
+				 WHERE Id IN (
+					SELECT Id FROM ChannelTopicHistory
+					WHERE CreateAt < :EndTime
+					LIMIT :Limit
+				)`
+	} else {
+		query =
+			`DELETE FROM ChannelTopicHistory
+				 WHERE CreateAt < :EndTime
+				 LIMIT :Limit`
+	}
+
+	params := map[string]interface{}{"EndTime": endTime, "Limit": limit}
+	sqlResult, err := s.GetMaster().Exec(query, params)
+	if err != nil {
+		return 0, model.NewAppError("SqlChannelTopicHistoryStore.PermanentDeleteBatch", "store.sql_channel_topic_history.permanent_delete_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlChannelTopicHistoryStore.PermanentDeleteBatch", "store.sql_channel_topic_history.permanent_delete_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected, nil
+}
@@ -206,10 +206,8 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance) ([]*model.Co
 	return cposts, nil
 }
 
-func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
-	props := map[string]interface{}{"StartTime": after, "Limit": limit}
-	query :=
-		`SELECT
+// messageExportSelect is the column list and joins shared by the message export queries.
+const messageExportSelect = `SELECT
 			Posts.Id AS PostId,
 			Posts.CreateAt AS PostCreateAt,
 			Posts.UpdateAt AS PostUpdateAt,
@@ -239,7 +237,11 @@ func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.Mess
 		LEFT OUTER JOIN Channels ON Posts.ChannelId = Channels.Id
 		LEFT OUTER JOIN Teams ON Channels.TeamId = Teams.Id
 		LEFT OUTER JOIN Users ON Posts.UserId = Users.Id
-		LEFT JOIN Bots ON Bots.UserId = Posts.UserId
+		LEFT JOIN Bots ON Bots.UserId = Posts.UserId`
+
+func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	props := map[string]interface{}{"StartTime": after, "Limit": limit}
+	query := messageExportSelect + `
 		WHERE
 			(Posts.CreateAt > :StartTime OR Posts.EditAt > :StartTime) AND
 			Posts.Type = ''
@@ -252,3 +254,31 @@ func (s SqlComplianceStore) MessageExport(after int64, limit int) ([]*model.Mess
 	}
 	return cposts, nil
 }
+
+func (s SqlComplianceStore) ChannelMessageExport(channelIds []string, afterCreateAt int64, afterPostId string, until int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	props := map[string]interface{}{"AfterCreateAt": afterCreateAt, "AfterPostId": afterPostId, "Until": until, "Limit": limit}
+
+	channelFilter := ""
+	if len(channelIds) > 0 {
+		keys, params := MapStringsToQueryParams(channelIds, "ChannelId")
+		for key, value := range params {
+			props[key] = value
+		}
+		channelFilter = "Posts.ChannelId IN " + keys + " AND"
+	}
+
+	query := messageExportSelect + `
+		WHERE
+			` + channelFilter + `
+			(Posts.CreateAt > :AfterCreateAt OR (Posts.CreateAt = :AfterCreateAt AND Posts.Id > :AfterPostId)) AND
+			Posts.CreateAt <= :Until AND
+			Posts.Type = ''
+		ORDER BY Posts.CreateAt, Posts.Id
+		LIMIT :Limit`
+
+	var cposts []*model.MessageExport
+	if _, err := s.GetReplica().Select(&cposts, query, props); err != nil {
+		return nil, model.NewAppError("SqlComplianceStore.ChannelMessageExport", "store.sql_compliance.message_export.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return cposts, nil
+}
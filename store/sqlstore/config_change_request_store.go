@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlConfigChangeRequestStore struct {
+	SqlStore
+}
+
+func NewSqlConfigChangeRequestStore(sqlStore SqlStore) store.ConfigChangeRequestStore {
+	s := &SqlConfigChangeRequestStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.ConfigChangeRequest{}, "ConfigChangeRequests").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		// Widened to MEDIUMTEXT on MySQL and TEXT on Postgres by UpgradeDatabaseToVersion516.
+		table.ColMap("ProposedConfig").SetMaxSize(65535)
+		table.ColMap("ProposedBy").SetMaxSize(26)
+		table.ColMap("Status").SetMaxSize(32)
+		table.ColMap("ReviewedBy").SetMaxSize(26)
+		table.ColMap("BaseConfigHash").SetMaxSize(64)
+	}
+
+	return s
+}
+
+func (s SqlConfigChangeRequestStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_configchangerequests_status", "ConfigChangeRequests", "Status")
+}
+
+func (s SqlConfigChangeRequestStore) Save(request *model.ConfigChangeRequest) (*model.ConfigChangeRequest, *model.AppError) {
+	request.PreSave()
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(request); err != nil {
+		return nil, model.NewAppError("SqlConfigChangeRequestStore.Save", "store.sql_config_change_request.save.app_error", nil, "id="+request.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return request, nil
+}
+
+func (s SqlConfigChangeRequestStore) Get(id string) (*model.ConfigChangeRequest, *model.AppError) {
+	var request *model.ConfigChangeRequest
+	if err := s.GetMaster().SelectOne(&request, "SELECT * FROM ConfigChangeRequests WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlConfigChangeRequestStore.Get", "store.sql_config_change_request.get.app_error", nil, "id="+id, http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlConfigChangeRequestStore.Get", "store.sql_config_change_request.get.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return request, nil
+}
+
+// GetPending returns the requests awaiting review, oldest first.
+func (s SqlConfigChangeRequestStore) GetPending() ([]*model.ConfigChangeRequest, *model.AppError) {
+	var requests []*model.ConfigChangeRequest
+	if _, err := s.GetMaster().Select(&requests, "SELECT * FROM ConfigChangeRequests WHERE Status = :Status ORDER BY CreateAt, Id", map[string]interface{}{"Status": model.CONFIG_CHANGE_REQUEST_STATUS_PENDING}); err != nil {
+		return nil, model.NewAppError("SqlConfigChangeRequestStore.GetPending", "store.sql_config_change_request.get_pending.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return requests, nil
+}
+
+// Review records the outcome of reviewing a pending request. It fails if the request is no longer
+// pending, so that a request is only ever reviewed once.
+func (s SqlConfigChangeRequestStore) Review(id, status, reviewedBy string) *model.AppError {
+	result, err := s.GetMaster().Exec(`
+		UPDATE ConfigChangeRequests
+		SET Status = :Status, ReviewedBy = :ReviewedBy
+		WHERE Id = :Id AND Status = :PendingStatus`,
+		map[string]interface{}{"Id": id, "Status": status, "ReviewedBy": reviewedBy, "PendingStatus": model.CONFIG_CHANGE_REQUEST_STATUS_PENDING})
+	if err != nil {
+		return model.NewAppError("SqlConfigChangeRequestStore.Review", "store.sql_config_change_request.review.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return model.NewAppError("SqlConfigChangeRequestStore.Review", "store.sql_config_change_request.review.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	} else if count == 0 {
+		return model.NewAppError("SqlConfigChangeRequestStore.Review", "store.sql_config_change_request.review.not_pending.app_error", nil, "id="+id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Reopen returns an approved request to pending, such as when its configuration failed to save.
+func (s SqlConfigChangeRequestStore) Reopen(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec(`
+		UPDATE ConfigChangeRequests
+		SET Status = :PendingStatus, ReviewedBy = ''
+		WHERE Id = :Id AND Status = :ApprovedStatus`,
+		map[string]interface{}{"Id": id, "PendingStatus": model.CONFIG_CHANGE_REQUEST_STATUS_PENDING, "ApprovedStatus": model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED}); err != nil {
+		return model.NewAppError("SqlConfigChangeRequestStore.Reopen", "store.sql_config_change_request.reopen.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
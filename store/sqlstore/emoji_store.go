@@ -50,6 +50,7 @@ func (es SqlEmojiStore) CreateIndexesIfNotExists() {
 	es.CreateIndexIfNotExists("idx_emoji_create_at", "Emoji", "CreateAt")
 	es.CreateIndexIfNotExists("idx_emoji_delete_at", "Emoji", "DeleteAt")
 	es.CreateIndexIfNotExists("idx_emoji_name", "Emoji", "Name")
+	es.CreateCompositeIndexIfNotExists("idx_emoji_creator_id_name", "Emoji", []string{"CreatorId", "Name"})
 }
 
 func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, *model.AppError) {
@@ -107,10 +108,21 @@ func (es SqlEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, *mode
 	return emojis, nil
 }
 
-func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+func (es SqlEmojiStore) GetList(offset, limit int, sort string, opts model.EmojiListOpts) ([]*model.Emoji, *model.AppError) {
 	var emoji []*model.Emoji
 
 	query := "SELECT * FROM Emoji WHERE DeleteAt = 0"
+	params := map[string]interface{}{"Offset": offset, "Limit": limit}
+
+	if opts.CreatorId != "" {
+		query += " AND CreatorId = :CreatorId"
+		params["CreatorId"] = opts.CreatorId
+	}
+
+	if opts.Prefix != "" {
+		query += " AND Name LIKE :Prefix"
+		params["Prefix"] = sanitizeSearchTerm(opts.Prefix, "\\") + "%"
+	}
 
 	if sort == model.EMOJI_SORT_BY_NAME {
 		query += " ORDER BY Name"
@@ -118,7 +130,7 @@ func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji,
 
 	query += " LIMIT :Limit OFFSET :Offset"
 
-	if _, err := es.GetReplica().Select(&emoji, query, map[string]interface{}{"Offset": offset, "Limit": limit}); err != nil {
+	if _, err := es.GetReplica().Select(&emoji, query, params); err != nil {
 		return nil, model.NewAppError("SqlEmojiStore.GetList", "store.sql_emoji.get_all.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 	return emoji, nil
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+// MENTION_SAVE_BATCH_SIZE is the number of mentions inserted per statement. With five columns per
+// mention, it keeps a statement well within the 65535 placeholders supported by MySQL and Postgres.
+const MENTION_SAVE_BATCH_SIZE = 1000
+
+type SqlMentionStore struct {
+	SqlStore
+}
+
+func NewSqlMentionStore(sqlStore SqlStore) store.MentionStore {
+	s := &SqlMentionStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.Mention{}, "Mentions").SetKeys(false, "UserId", "PostId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlMentionStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_mentions_user_id_create_at", "Mentions", []string{"UserId", "CreateAt"})
+}
+
+func (s SqlMentionStore) SaveMultiple(mentions []*model.Mention) *model.AppError {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlMentionStore.SaveMultiple", "store.sql_mention.save_multiple.open_transaction.app_error", nil, "post_id="+mentions[0].PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	// Insert in batches to stay within the databases' limit on the number of placeholders.
+	for start := 0; start < len(mentions); start += MENTION_SAVE_BATCH_SIZE {
+		end := start + MENTION_SAVE_BATCH_SIZE
+		if end > len(mentions) {
+			end = len(mentions)
+		}
+
+		query := s.getQueryBuilder().
+			Insert("Mentions").
+			Columns("UserId", "PostId", "ChannelId", "TeamId", "CreateAt")
+
+		for _, mention := range mentions[start:end] {
+			query = query.Values(mention.UserId, mention.PostId, mention.ChannelId, mention.TeamId, mention.CreateAt)
+		}
+
+		queryString, args, err := query.ToSql()
+		if err != nil {
+			return model.NewAppError("SqlMentionStore.SaveMultiple", "store.sql_mention.save_multiple.app_error", nil, "post_id="+mentions[0].PostId+", "+err.Error(), http.StatusInternalServerError)
+		}
+
+		if _, err := transaction.Exec(queryString, args...); err != nil {
+			return model.NewAppError("SqlMentionStore.SaveMultiple", "store.sql_mention.save_multiple.app_error", nil, "post_id="+mentions[0].PostId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlMentionStore.SaveMultiple", "store.sql_mention.save_multiple.commit_transaction.app_error", nil, "post_id="+mentions[0].PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetPostsForUser returns the posts that mentioned the given user, newest first, excluding deleted
+// posts and posts in channels that the user is no longer a member of. If teamId is set, only
+// mentions on that team and in direct and group messages are returned.
+func (s SqlMentionStore) GetPostsForUser(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	var posts []*model.Post
+
+	teamFilter := ""
+	if teamId != "" {
+		teamFilter = "AND (Mentions.TeamId = :TeamId OR Mentions.TeamId = '')"
+	}
+
+	query := `
+		SELECT Posts.*
+		FROM Mentions
+		INNER JOIN Posts ON Posts.Id = Mentions.PostId
+		INNER JOIN ChannelMembers ON ChannelMembers.ChannelId = Mentions.ChannelId
+			AND ChannelMembers.UserId = Mentions.UserId
+		WHERE Mentions.UserId = :UserId
+		AND Posts.DeleteAt = 0
+		` + teamFilter + `
+		ORDER BY Mentions.CreateAt DESC
+		LIMIT :Limit OFFSET :Offset`
+
+	params := map[string]interface{}{"UserId": userId, "TeamId": teamId, "Offset": offset, "Limit": limit}
+	if _, err := s.GetReplica().Select(&posts, query, params); err != nil {
+		return nil, model.NewAppError("SqlMentionStore.GetPostsForUser", "store.sql_mention.get_posts_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	pl := model.NewPostList()
+	for _, post := range posts {
+		pl.AddPost(post)
+		pl.AddOrder(post.Id)
+	}
+
+	return pl, nil
+}
+
+func (s SqlMentionStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM Mentions WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlMentionStore.PermanentDeleteByUser", "store.sql_mention.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPasswordHistoryStore struct {
+	SqlStore
+}
+
+func NewSqlPasswordHistoryStore(sqlStore SqlStore) store.PasswordHistoryStore {
+	s := &SqlPasswordHistoryStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PasswordHistory{}, "PasswordHistory").SetKeys(false, "UserId", "CreateAt")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Password").SetMaxSize(128)
+	}
+
+	return s
+}
+
+func (s SqlPasswordHistoryStore) CreateIndexesIfNotExists() {
+}
+
+func (s SqlPasswordHistoryStore) Save(history *model.PasswordHistory) *model.AppError {
+	if err := s.GetMaster().Insert(history); err != nil {
+		return model.NewAppError("SqlPasswordHistoryStore.Save", "store.sql_password_history.save.app_error", nil, "user_id="+history.UserId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetForUser returns the given number of passwords most recently replaced by the user, newest first.
+func (s SqlPasswordHistoryStore) GetForUser(userId string, limit int) ([]*model.PasswordHistory, *model.AppError) {
+	var histories []*model.PasswordHistory
+
+	query := `
+		SELECT *
+		FROM PasswordHistory
+		WHERE UserId = :UserId
+		ORDER BY CreateAt DESC
+		LIMIT :Limit`
+
+	if _, err := s.GetReplica().Select(&histories, query, map[string]interface{}{"UserId": userId, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPasswordHistoryStore.GetForUser", "store.sql_password_history.get.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return histories, nil
+}
+
+// PruneForUser deletes all but the given number of passwords most recently replaced by the user.
+func (s SqlPasswordHistoryStore) PruneForUser(userId string, keep int) *model.AppError {
+	// MySQL can't delete from a table selected from in a subquery, so find the newest password to
+	// delete first.
+	createAt, err := s.GetMaster().SelectNullInt(`
+		SELECT CreateAt
+		FROM PasswordHistory
+		WHERE UserId = :UserId
+		ORDER BY CreateAt DESC
+		LIMIT 1 OFFSET :Keep`, map[string]interface{}{"UserId": userId, "Keep": keep})
+	if err != nil {
+		return model.NewAppError("SqlPasswordHistoryStore.PruneForUser", "store.sql_password_history.prune_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	if !createAt.Valid {
+		return nil
+	}
+
+	if _, err := s.GetMaster().Exec("DELETE FROM PasswordHistory WHERE UserId = :UserId AND CreateAt <= :CreateAt", map[string]interface{}{"UserId": userId, "CreateAt": createAt.Int64}); err != nil {
+		return model.NewAppError("SqlPasswordHistoryStore.PruneForUser", "store.sql_password_history.prune_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPasswordHistoryStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PasswordHistory WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlPasswordHistoryStore.PermanentDeleteByUser", "store.sql_password_history.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
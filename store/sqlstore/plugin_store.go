@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -107,6 +108,24 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			// Failed to update
 			return false, model.NewAppError("SqlPluginStore.CompareAndSet", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
 		} else if rowsAffected == 0 {
+			if ps.DriverName() == model.DATABASE_DRIVER_MYSQL && bytes.Equal(oldValue, kv.Value) {
+				// MySQL doesn't count rows left unchanged by an update, so check whether the
+				// value actually matched.
+				count, err := ps.GetMaster().SelectInt(
+					`SELECT COUNT(*) FROM PluginKeyValueStore WHERE PluginId = :PluginId AND PKey = :Key AND PValue = :Old`,
+					map[string]interface{}{
+						"PluginId": kv.PluginId,
+						"Key":      kv.Key,
+						"Old":      oldValue,
+					},
+				)
+				if err != nil {
+					return false, model.NewAppError("SqlPluginStore.CompareAndSet", "store.sql_plugin_store.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+				}
+
+				return count == 1, nil
+			}
+
 			// No rows were affected by the update, where condition was not satisfied,
 			// return false, but no error.
 			return false, nil
@@ -191,7 +191,7 @@ func (s *SqlPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*m
 	pl := model.NewPostList()
 
 	var posts []*model.Post
-	if _, err := s.GetReplica().Select(&posts, "SELECT * FROM Posts WHERE Id IN (SELECT Name FROM Preferences WHERE UserId = :UserId AND Category = :Category) AND DeleteAt = 0 ORDER BY CreateAt DESC LIMIT :Limit OFFSET :Offset", map[string]interface{}{"UserId": userId, "Category": model.PREFERENCE_CATEGORY_FLAGGED_POST, "Offset": offset, "Limit": limit}); err != nil {
+	if _, err := s.GetReplica().Select(&posts, "SELECT * FROM Posts WHERE Id IN (SELECT PostId FROM SavedPosts WHERE UserId = :UserId) AND DeleteAt = 0 ORDER BY CreateAt DESC LIMIT :Limit OFFSET :Offset", map[string]interface{}{"UserId": userId, "Offset": offset, "Limit": limit}); err != nil {
 		return nil, model.NewAppError("SqlPostStore.GetFlaggedPosts", "store.sql_post.get_flagged_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
@@ -220,12 +220,11 @@ func (s *SqlPostStore) GetFlaggedPostsForTeam(userId, teamId string, offset int,
                     Id
                 IN
                     (SELECT
-                        Name
+                        PostId
                     FROM
-                        Preferences
+                        SavedPosts
                     WHERE
-                        UserId = :UserId
-                        AND Category = :Category)
+                        UserId = :UserId)
                         AND DeleteAt = 0
                 ) as A
             INNER JOIN Channels as B
@@ -234,7 +233,7 @@ func (s *SqlPostStore) GetFlaggedPostsForTeam(userId, teamId string, offset int,
             ORDER BY CreateAt DESC
             LIMIT :Limit OFFSET :Offset`
 
-	if _, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"UserId": userId, "Category": model.PREFERENCE_CATEGORY_FLAGGED_POST, "Offset": offset, "Limit": limit, "TeamId": teamId}); err != nil {
+	if _, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"UserId": userId, "Offset": offset, "Limit": limit, "TeamId": teamId}); err != nil {
 		return nil, model.NewAppError("SqlPostStore.GetFlaggedPostsForTeam", "store.sql_post.get_flagged_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
@@ -255,13 +254,13 @@ func (s *SqlPostStore) GetFlaggedPostsForChannel(userId, channelId string, offse
 			*
 		FROM Posts
 		WHERE
-			Id IN (SELECT Name FROM Preferences WHERE UserId = :UserId AND Category = :Category)
+			Id IN (SELECT PostId FROM SavedPosts WHERE UserId = :UserId)
 			AND ChannelId = :ChannelId
 			AND DeleteAt = 0
 		ORDER BY CreateAt DESC
 		LIMIT :Limit OFFSET :Offset`
 
-	if _, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"UserId": userId, "Category": model.PREFERENCE_CATEGORY_FLAGGED_POST, "ChannelId": channelId, "Offset": offset, "Limit": limit}); err != nil {
+	if _, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"UserId": userId, "ChannelId": channelId, "Offset": offset, "Limit": limit}); err != nil {
 		return nil, model.NewAppError("SqlPostStore.GetFlaggedPostsForChannel", "store.sql_post.get_flagged_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 	for _, post := range posts {
@@ -947,21 +946,116 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 }
 
 func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError) {
-	queryParams := map[string]interface{}{
-		"TeamId": teamId,
-		"UserId": userId,
-	}
-
 	list := model.NewPostList()
-	if params.Terms == "" && params.ExcludedTerms == "" &&
-		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
-		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+	if isSearchParamsEmpty(params) {
 		return list, nil
 	}
 
 	var posts []*model.Post
 
+	limit := model.SEARCH_PARAMS_MAX_LIMIT
+	if params.Limit > 0 && params.Limit < model.SEARCH_PARAMS_MAX_LIMIT {
+		limit = params.Limit
+	}
+
+	searchQuery, queryParams := s.buildSearchQuery(teamId, userId, params, "*", limit, nil)
+
+	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
+	if err != nil {
+		mlog.Warn("Query error searching posts.", mlog.Err(err))
+		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
+	} else {
+		for _, p := range filterSearchHashtagMatches(posts, params) {
+			list.AddPost(p)
+			list.AddOrder(p.Id)
+		}
+	}
+	list.MakeNonNil()
+	return list, nil
+}
+
+// SearchCount returns the number of posts matching the given search parameters, leaving out the
+// given posts. Unlike Search, the count isn't limited. Hashtags are only matched by the full text
+// index, so hashtag searches should use SearchPostIds to match them exactly.
+func (s *SqlPostStore) SearchCount(teamId string, userId string, params *model.SearchParams, excludedPostIds []string) (int64, *model.AppError) {
+	if isSearchParamsEmpty(params) {
+		return 0, nil
+	}
+
+	searchQuery, queryParams := s.buildSearchQuery(teamId, userId, params, "COUNT(DISTINCT Id)", 0, excludedPostIds)
+
+	count, err := s.GetSearchReplica().SelectInt(searchQuery, queryParams)
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.SearchCount", "store.sql_post.search_count.app_error", nil, "teamId="+teamId+" userId="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
+// SearchPostIds returns the ids of up to limit of the most recent posts matching the given search
+// parameters, matching hashtags exactly as Search does. The limit given in the parameters is ignored.
+func (s *SqlPostStore) SearchPostIds(teamId string, userId string, params *model.SearchParams, limit int) ([]string, *model.AppError) {
+	if isSearchParamsEmpty(params) {
+		return []string{}, nil
+	}
+
+	var posts []*model.Post
+
+	searchQuery, queryParams := s.buildSearchQuery(teamId, userId, params, "Id, Hashtags", limit, nil)
+
+	if _, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams); err != nil {
+		return nil, model.NewAppError("SqlPostStore.SearchPostIds", "store.sql_post.search_post_ids.app_error", nil, "teamId="+teamId+" userId="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	postIds := []string{}
+	for _, p := range filterSearchHashtagMatches(posts, params) {
+		postIds = append(postIds, p.Id)
+	}
+
+	return postIds, nil
+}
+
+// filterSearchHashtagMatches leaves out the posts found by a hashtag search that don't have one of
+// the searched hashtags exactly, since the full text index also matches parts of hashtags.
+func filterSearchHashtagMatches(posts []*model.Post, params *model.SearchParams) []*model.Post {
+	if !params.IsHashtag {
+		return posts
+	}
+
+	termMap := map[string]bool{}
+	for _, term := range strings.Split(params.Terms, " ") {
+		termMap[strings.ToUpper(term)] = true
+	}
+
+	var matches []*model.Post
+	for _, p := range posts {
+		for _, tag := range strings.Split(p.Hashtags, " ") {
+			if termMap[strings.ToUpper(tag)] {
+				matches = append(matches, p)
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+func isSearchParamsEmpty(params *model.SearchParams) bool {
+	return params.Terms == "" && params.ExcludedTerms == "" &&
+		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
+		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
+		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0
+}
+
+// buildSearchQuery returns the query selecting the given columns of the posts matching the given
+// search parameters, along with its parameters. The posts are ordered from the most recent and
+// limited to the given number, unless it is zero, and the given posts are left out.
+func (s *SqlPostStore) buildSearchQuery(teamId string, userId string, params *model.SearchParams, selectPart string, limit int, excludedPostIds []string) (string, map[string]interface{}) {
+	queryParams := map[string]interface{}{
+		"TeamId": teamId,
+		"UserId": userId,
+	}
+
 	deletedQueryPart := "AND DeleteAt = 0"
 	if params.IncludeDeletedChannels {
 		deletedQueryPart = ""
@@ -972,15 +1066,23 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 		userIdPart = ""
 	}
 
+	orderPart := ""
+	if limit > 0 {
+		orderPart = `ORDER BY CreateAt DESC
+			LIMIT :Limit`
+		queryParams["Limit"] = limit
+	}
+
 	searchQuery := `
 			SELECT
-				*
+				` + selectPart + `
 			FROM
 				Posts
 			WHERE
 				DeleteAt = 0
 				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
 				POST_FILTER
+				EXCLUDED_POST_FILTER
 				AND ChannelId IN (
 					SELECT
 						Id
@@ -996,8 +1098,19 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 							EXCLUDED_CHANNEL_FILTER)
 				CREATEDATE_CLAUSE
 				SEARCH_CLAUSE
-				ORDER BY CreateAt DESC
-			LIMIT 100`
+				` + orderPart
+
+	excludedPostClause := ""
+	if len(excludedPostIds) > 0 {
+		clauseSlice := []string{}
+		for i, postId := range excludedPostIds {
+			paramName := "ExcludedPost" + strconv.Itoa(i)
+			clauseSlice = append(clauseSlice, ":"+paramName)
+			queryParams[paramName] = postId
+		}
+		excludedPostClause = "AND Id NOT IN (" + strings.Join(clauseSlice, ", ") + ")"
+	}
+	searchQuery = strings.Replace(searchQuery, "EXCLUDED_POST_FILTER", excludedPostClause, 1)
 
 	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams)
 	searchQuery = strings.Replace(searchQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
@@ -1011,16 +1124,12 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 	createDateFilterClause, queryParams := s.buildCreateDateFilterClause(params, queryParams)
 	searchQuery = strings.Replace(searchQuery, "CREATEDATE_CLAUSE", createDateFilterClause, 1)
 
-	termMap := map[string]bool{}
 	terms := params.Terms
 	excludedTerms := params.ExcludedTerms
 
 	searchType := "Message"
 	if params.IsHashtag {
 		searchType = "Hashtags"
-		for _, term := range strings.Split(terms, " ") {
-			termMap[strings.ToUpper(term)] = true
-		}
 	}
 
 	// these chars have special meaning and can be treated as spaces
@@ -1072,30 +1181,7 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 		}
 	}
 
-	_, err := s.GetSearchReplica().Select(&posts, searchQuery, queryParams)
-	if err != nil {
-		mlog.Warn("Query error searching posts.", mlog.Err(err))
-		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
-	} else {
-		for _, p := range posts {
-			if searchType == "Hashtags" {
-				exactMatch := false
-				for _, tag := range strings.Split(p.Hashtags, " ") {
-					if termMap[strings.ToUpper(tag)] {
-						exactMatch = true
-						break
-					}
-				}
-				if !exactMatch {
-					continue
-				}
-			}
-			list.AddPost(p)
-			list.AddOrder(p.Id)
-		}
-	}
-	list.MakeNonNil()
-	return list, nil
+	return searchQuery, queryParams
 }
 
 func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError) {
@@ -1242,6 +1328,27 @@ func (s *SqlPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, must
 	return v, nil
 }
 
+// GetChannelPostCount returns the number of undeleted posts in the given channel created at or
+// after since and before until. A zero bound is ignored.
+func (s *SqlPostStore) GetChannelPostCount(channelId string, since int64, until int64) (int64, *model.AppError) {
+	query := `SELECT COUNT(*) FROM Posts WHERE ChannelId = :ChannelId AND DeleteAt = 0`
+
+	if since > 0 {
+		query += " AND CreateAt >= :Since"
+	}
+
+	if until > 0 {
+		query += " AND CreateAt < :Until"
+	}
+
+	count, err := s.GetReplica().SelectInt(query, map[string]interface{}{"ChannelId": channelId, "Since": since, "Until": until})
+	if err != nil {
+		return 0, model.NewAppError("SqlPostStore.GetChannelPostCount", "store.sql_post.get_channel_post_count.app_error", nil, "channelId="+channelId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
+
 func (s *SqlPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError) {
 	query := `SELECT * FROM Posts WHERE CreateAt = :CreateAt AND ChannelId = :ChannelId`
 
@@ -1349,7 +1456,8 @@ func (s *SqlPostStore) determineMaxPostSize() int {
 			FROM
 				information_schema.columns
 			WHERE
-				table_name = 'posts'
+				table_schema = current_schema()
+			AND	table_name = 'posts'
 			AND	column_name = 'message'
 		`); err != nil {
 			mlog.Error("Unable to determine the maximum supported post size", mlog.Err(err))
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlPushNotificationQueueStore struct {
+	SqlStore
+}
+
+func NewSqlPushNotificationQueueStore(sqlStore SqlStore) store.PushNotificationQueueStore {
+	s := &SqlPushNotificationQueueStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.PushNotificationQueueItem{}, "PushNotificationQueue").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("SessionId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("Message").SetMaxSize(model.PUSH_NOTIFICATION_QUEUE_MESSAGE_MAX_LENGTH)
+	}
+
+	return s
+}
+
+func (s SqlPushNotificationQueueStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_push_notification_queue_create_at", "PushNotificationQueue", "CreateAt")
+}
+
+func (s SqlPushNotificationQueueStore) Save(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError) {
+	item.PreSave()
+	if err := item.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(item); err != nil {
+		return nil, model.NewAppError("SqlPushNotificationQueueStore.Save", "store.sql_push_notification_queue.save.app_error", nil, "id="+item.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return item, nil
+}
+
+func (s SqlPushNotificationQueueStore) GetBatch(limit int) ([]*model.PushNotificationQueueItem, *model.AppError) {
+	var items []*model.PushNotificationQueueItem
+
+	if _, err := s.GetMaster().Select(&items, "SELECT * FROM PushNotificationQueue ORDER BY CreateAt ASC LIMIT :Limit", map[string]interface{}{"Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlPushNotificationQueueStore.GetBatch", "store.sql_push_notification_queue.get_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return items, nil
+}
+
+func (s SqlPushNotificationQueueStore) Update(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError) {
+	if err := item.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().Update(item); err != nil {
+		return nil, model.NewAppError("SqlPushNotificationQueueStore.Update", "store.sql_push_notification_queue.update.app_error", nil, "id="+item.Id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return item, nil
+}
+
+func (s SqlPushNotificationQueueStore) Delete(id string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM PushNotificationQueue WHERE Id = :Id", map[string]interface{}{"Id": id}); err != nil {
+		return model.NewAppError("SqlPushNotificationQueueStore.Delete", "store.sql_push_notification_queue.delete.app_error", nil, "id="+id+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlPushNotificationQueueStore) DeleteExpired(expiryTime int64) (int64, *model.AppError) {
+	sqlResult, err := s.GetMaster().Exec("DELETE FROM PushNotificationQueue WHERE CreateAt < :ExpiryTime", map[string]interface{}{"ExpiryTime": expiryTime})
+	if err != nil {
+		return 0, model.NewAppError("SqlPushNotificationQueueStore.DeleteExpired", "store.sql_push_notification_queue.delete_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlPushNotificationQueueStore.DeleteExpired", "store.sql_push_notification_queue.delete_expired.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected, nil
+}
+
+func (s SqlPushNotificationQueueStore) Count() (int64, *model.AppError) {
+	count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM PushNotificationQueue")
+	if err != nil {
+		return 0, model.NewAppError("SqlPushNotificationQueueStore.Count", "store.sql_push_notification_queue.count.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return count, nil
+}
@@ -121,6 +121,40 @@ func (s *SqlRoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
 	return dbRole.ToModel(), nil
 }
 
+// UpdateMultiple updates the given existing roles in a single transaction, so that either all of
+// them or none of them are changed.
+func (s *SqlRoleStore) UpdateMultiple(roles []*model.Role) ([]*model.Role, *model.AppError) {
+	for _, role := range roles {
+		if !role.IsValid() {
+			return nil, model.NewAppError("SqlRoleStore.UpdateMultiple", "store.sql_role.save.invalid_role.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlRoleStore.UpdateMultiple", "store.sql_role.save.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	updatedRoles := make([]*model.Role, 0, len(roles))
+	for _, role := range roles {
+		dbRole := NewRoleFromModel(role)
+		dbRole.UpdateAt = model.GetMillis()
+		if rowsChanged, err := transaction.Update(dbRole); err != nil {
+			return nil, model.NewAppError("SqlRoleStore.UpdateMultiple", "store.sql_role.save.update.app_error", nil, err.Error(), http.StatusInternalServerError)
+		} else if rowsChanged != 1 {
+			return nil, model.NewAppError("SqlRoleStore.UpdateMultiple", "store.sql_role.save.update.app_error", nil, "no record to update, role_id="+role.Id, http.StatusInternalServerError)
+		}
+		updatedRoles = append(updatedRoles, dbRole.ToModel())
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlRoleStore.UpdateMultiple", "store.sql_role.save_role.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return updatedRoles, nil
+}
+
 func (s *SqlRoleStore) createRole(role *model.Role, transaction *gorp.Transaction) (*model.Role, *model.AppError) {
 	// Check the role is valid before proceeding.
 	if !role.IsValidWithoutId() {
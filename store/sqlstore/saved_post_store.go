@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlSavedPostStore struct {
+	SqlStore
+}
+
+func NewSqlSavedPostStore(sqlStore SqlStore) store.SavedPostStore {
+	s := &SqlSavedPostStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.SavedPost{}, "SavedPosts").SetKeys(false, "UserId", "PostId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("PostId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlSavedPostStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_savedposts_post_id", "SavedPosts", "PostId")
+}
+
+func (s SqlSavedPostStore) Save(savedPost *model.SavedPost) (*model.SavedPost, *model.AppError) {
+	savedPost.PreSave()
+	if err := savedPost.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.GetMaster().Insert(savedPost); err != nil {
+		if IsUniqueConstraintError(err, []string{"PRIMARY", "savedposts_pkey"}) {
+			return nil, model.NewAppError("SqlSavedPostStore.Save", "store.sql_saved_post.save.exists.app_error", nil, "user_id="+savedPost.UserId+", post_id="+savedPost.PostId, http.StatusBadRequest)
+		}
+		return nil, model.NewAppError("SqlSavedPostStore.Save", "store.sql_saved_post.save.app_error", nil, "user_id="+savedPost.UserId+", post_id="+savedPost.PostId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return savedPost, nil
+}
+
+func (s SqlSavedPostStore) Get(userId, postId string) (*model.SavedPost, *model.AppError) {
+	var savedPost *model.SavedPost
+	if err := s.GetReplica().SelectOne(&savedPost, "SELECT * FROM SavedPosts WHERE UserId = :UserId AND PostId = :PostId", map[string]interface{}{"UserId": userId, "PostId": postId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewAppError("SqlSavedPostStore.Get", "store.sql_saved_post.get.app_error", nil, "user_id="+userId+", post_id="+postId, http.StatusNotFound)
+		}
+		return nil, model.NewAppError("SqlSavedPostStore.Get", "store.sql_saved_post.get.app_error", nil, "user_id="+userId+", post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return savedPost, nil
+}
+
+// GetForUser returns a page of the posts saved by the user, most recently saved first.
+func (s SqlSavedPostStore) GetForUser(userId string, offset, limit int) ([]*model.SavedPost, *model.AppError) {
+	var savedPosts []*model.SavedPost
+
+	query := `
+		SELECT *
+		FROM SavedPosts
+		WHERE UserId = :UserId
+		ORDER BY SavedAt DESC, PostId
+		LIMIT :Limit OFFSET :Offset`
+
+	if _, err := s.GetReplica().Select(&savedPosts, query, map[string]interface{}{"UserId": userId, "Offset": offset, "Limit": limit}); err != nil {
+		return nil, model.NewAppError("SqlSavedPostStore.GetForUser", "store.sql_saved_post.get_for_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return savedPosts, nil
+}
+
+func (s SqlSavedPostStore) Delete(userId, postId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE UserId = :UserId AND PostId = :PostId", map[string]interface{}{"UserId": userId, "PostId": postId}); err != nil {
+		return model.NewAppError("SqlSavedPostStore.Delete", "store.sql_saved_post.delete.app_error", nil, "user_id="+userId+", post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlSavedPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlSavedPostStore.PermanentDeleteByUser", "store.sql_saved_post.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlSavedPostStore) PermanentDeleteByPost(postId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM SavedPosts WHERE PostId = :PostId", map[string]interface{}{"PostId": postId}); err != nil {
+		return model.NewAppError("SqlSavedPostStore.PermanentDeleteByPost", "store.sql_saved_post.permanent_delete_by_post.app_error", nil, "post_id="+postId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// MigrateFlaggedPostPreferences copies the flagged_post preferences of all users into SavedPosts,
+// skipping posts that no longer exist or that are already saved, and returns the number of posts
+// saved. The preferences themselves are left untouched. Since preferences don't record when they
+// were set, the posts are saved as of now.
+func (s SqlSavedPostStore) MigrateFlaggedPostPreferences() (int64, *model.AppError) {
+	query := `
+		INSERT INTO SavedPosts (UserId, PostId, SavedAt)
+		SELECT
+			Preferences.UserId, Preferences.Name, :SavedAt
+		FROM
+			Preferences
+		INNER JOIN Posts
+			ON Posts.Id = Preferences.Name
+		LEFT JOIN SavedPosts
+			ON SavedPosts.UserId = Preferences.UserId
+			AND SavedPosts.PostId = Preferences.Name
+		WHERE
+			Preferences.Category = :Category
+			AND Preferences.Value = 'true'
+			AND SavedPosts.UserId IS NULL`
+
+	result, err := s.GetMaster().Exec(query, map[string]interface{}{"Category": model.PREFERENCE_CATEGORY_FLAGGED_POST, "SavedAt": model.GetMillis()})
+	if err != nil {
+		return 0, model.NewAppError("SqlSavedPostStore.MigrateFlaggedPostPreferences", "store.sql_saved_post.migrate_flagged_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	saved, err := result.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlSavedPostStore.MigrateFlaggedPostPreferences", "store.sql_saved_post.migrate_flagged_posts.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return saved, nil
+}
+
+// CleanupBatch deletes up to limit saved posts whose post no longer exists, and returns the number
+// deleted.
+func (s SqlSavedPostStore) CleanupBatch(limit int64) (int64, *model.AppError) {
+	query :=
+		`DELETE FROM
+			SavedPosts
+		WHERE
+			PostId IN (
+				SELECT
+					*
+				FROM (
+					SELECT
+						SavedPosts.PostId
+					FROM
+						SavedPosts
+					LEFT JOIN
+						Posts
+					ON
+						SavedPosts.PostId = Posts.Id
+					WHERE
+						Posts.Id IS null
+					LIMIT
+						:Limit
+				)
+				AS t
+			)`
+
+	sqlResult, err := s.GetMaster().Exec(query, map[string]interface{}{"Limit": limit})
+	if err != nil {
+		return 0, model.NewAppError("SqlSavedPostStore.CleanupBatch", "store.sql_saved_post.cleanup_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, model.NewAppError("SqlSavedPostStore.CleanupBatch", "store.sql_saved_post.cleanup_batch.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return rowsAffected, nil
+}
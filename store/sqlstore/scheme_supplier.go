@@ -51,7 +51,7 @@ func (s *SqlSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppEr
 		}
 		defer finalizeTransaction(transaction)
 
-		newScheme, appErr := s.createScheme(scheme, transaction)
+		newScheme, appErr := s.createScheme(scheme, nil, transaction)
 		if appErr != nil {
 			return nil, appErr
 		}
@@ -78,7 +78,28 @@ func (s *SqlSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppEr
 	return scheme, nil
 }
 
-func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Transaction) (*model.Scheme, *model.AppError) {
+func (s *SqlSchemeStore) SaveWithRolePermissions(scheme *model.Scheme, rolePermissions map[string][]string) (*model.Scheme, *model.AppError) {
+	if len(scheme.Id) != 0 {
+		return nil, model.NewAppError("SqlSchemeStore.SaveWithRolePermissions", "store.sql_scheme.save.invalid_scheme.app_error", nil, "schemeId="+scheme.Id, http.StatusBadRequest)
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlSchemeStore.SaveWithRolePermissions", "store.sql_scheme.save.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	newScheme, appErr := s.createScheme(scheme, rolePermissions, transaction)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlSchemeStore.SaveWithRolePermissions", "store.sql_scheme.save_scheme.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return newScheme, nil
+}
+
+func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, rolePermissions map[string][]string, transaction *gorp.Transaction) (*model.Scheme, *model.AppError) {
 	// Fetch the default system scheme roles to populate default permissions.
 	defaultRoleNames := []string{model.TEAM_ADMIN_ROLE_ID, model.TEAM_USER_ROLE_ID, model.TEAM_GUEST_ROLE_ID, model.CHANNEL_ADMIN_ROLE_ID, model.CHANNEL_USER_ROLE_ID, model.CHANNEL_GUEST_ROLE_ID}
 	defaultRoles := make(map[string]*model.Role)
@@ -108,6 +129,15 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 		return nil, model.NewAppError("SqlSchemeStore.SaveScheme", "store.sql_scheme.save.retrieve_default_scheme_roles.app_error", nil, "", http.StatusInternalServerError)
 	}
 
+	// Roles given their own permissions don't take them from the default role.
+	for roleName, permissions := range rolePermissions {
+		if defaultRole, ok := defaultRoles[roleName]; ok {
+			roleCopy := *defaultRole
+			roleCopy.Permissions = permissions
+			defaultRoles[roleName] = &roleCopy
+		}
+	}
+
 	// Create the appropriate default roles for the scheme.
 	if scheme.Scope == model.SCHEME_SCOPE_TEAM {
 		// Team Admin Role
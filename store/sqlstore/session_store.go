@@ -29,6 +29,8 @@ func NewSqlSessionStore(sqlStore SqlStore) store.SessionStore {
 		table.ColMap("Token").SetMaxSize(26)
 		table.ColMap("UserId").SetMaxSize(26)
 		table.ColMap("DeviceId").SetMaxSize(512)
+		table.ColMap("Platform").SetMaxSize(64)
+		table.ColMap("IpAddress").SetMaxSize(64)
 		table.ColMap("Roles").SetMaxSize(64)
 		table.ColMap("Props").SetMaxSize(1000)
 	}
@@ -236,6 +238,32 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
 	return count, nil
 }
 
+// GetUserIdsWithPasswordUpdatedBefore returns the ids of active users who are signed in with a
+// password that was last changed before the given time. Bots and users who sign in through another
+// authentication service are excluded.
+func (me SqlSessionStore) GetUserIdsWithPasswordUpdatedBefore(time int64) ([]string, *model.AppError) {
+	var userIds []string
+
+	query := `
+		SELECT DISTINCT
+			Users.Id
+		FROM
+			Sessions
+			INNER JOIN Users ON Users.Id = Sessions.UserId
+			LEFT JOIN Bots ON Bots.UserId = Users.Id
+		WHERE
+			Users.AuthService = ''
+			AND Users.DeleteAt = 0
+			AND Users.LastPasswordUpdate < :Time
+			AND Bots.UserId IS NULL`
+
+	if _, err := me.GetReplica().Select(&userIds, query, map[string]interface{}{"Time": time}); err != nil {
+		return nil, model.NewAppError("SqlSessionStore.GetUserIdsWithPasswordUpdatedBefore", "store.sql_session.get_user_ids_with_password_updated_before.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return userIds, nil
+}
+
 func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 	mlog.Debug("Cleaning up session store.")
 
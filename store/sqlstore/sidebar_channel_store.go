@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlSidebarChannelStore struct {
+	SqlStore
+}
+
+func NewSqlSidebarChannelStore(sqlStore SqlStore) store.SidebarChannelStore {
+	s := &SqlSidebarChannelStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.SidebarChannel{}, "SidebarChannels").SetKeys(false, "UserId", "TeamId", "ChannelId")
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("ChannelId").SetMaxSize(26)
+	}
+
+	return s
+}
+
+func (s SqlSidebarChannelStore) CreateIndexesIfNotExists() {
+	s.CreateIndexIfNotExists("idx_sidebar_channels_channel_id", "SidebarChannels", "ChannelId")
+}
+
+// GetForTeam returns the channels that the given user has ordered in their sidebar on the given team
+// and is still a member of, in order.
+func (s SqlSidebarChannelStore) GetForTeam(userId string, teamId string) ([]*model.SidebarChannel, *model.AppError) {
+	var sidebarChannels []*model.SidebarChannel
+
+	query := `
+		SELECT SidebarChannels.*
+		FROM SidebarChannels
+		INNER JOIN ChannelMembers ON ChannelMembers.ChannelId = SidebarChannels.ChannelId
+			AND ChannelMembers.UserId = SidebarChannels.UserId
+		WHERE SidebarChannels.UserId = :UserId
+		AND SidebarChannels.TeamId = :TeamId
+		ORDER BY SidebarChannels.SortOrder`
+
+	if _, err := s.GetReplica().Select(&sidebarChannels, query, map[string]interface{}{"UserId": userId, "TeamId": teamId}); err != nil {
+		return nil, model.NewAppError("SqlSidebarChannelStore.GetForTeam", "store.sql_sidebar_channel.get_for_team.app_error", nil, "user_id="+userId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return sidebarChannels, nil
+}
+
+// SaveOrder replaces the order of the channels in the given user's sidebar on the given team.
+func (s SqlSidebarChannelStore) SaveOrder(userId string, teamId string, channelIds []string) *model.AppError {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return model.NewAppError("SqlSidebarChannelStore.SaveOrder", "store.sql_sidebar_channel.save_order.open_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	if _, err := transaction.Exec("DELETE FROM SidebarChannels WHERE UserId = :UserId AND TeamId = :TeamId", map[string]interface{}{"UserId": userId, "TeamId": teamId}); err != nil {
+		return model.NewAppError("SqlSidebarChannelStore.SaveOrder", "store.sql_sidebar_channel.save_order.app_error", nil, "user_id="+userId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	for i, channelId := range channelIds {
+		sidebarChannel := &model.SidebarChannel{
+			UserId:    userId,
+			TeamId:    teamId,
+			ChannelId: channelId,
+			SortOrder: int64(i),
+		}
+
+		if err := transaction.Insert(sidebarChannel); err != nil {
+			return model.NewAppError("SqlSidebarChannelStore.SaveOrder", "store.sql_sidebar_channel.save_order.app_error", nil, "user_id="+userId+", team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return model.NewAppError("SqlSidebarChannelStore.SaveOrder", "store.sql_sidebar_channel.save_order.commit_transaction.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (s SqlSidebarChannelStore) PermanentDeleteByUser(userId string) *model.AppError {
+	if _, err := s.GetMaster().Exec("DELETE FROM SidebarChannels WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return model.NewAppError("SqlSidebarChannelStore.PermanentDeleteByUser", "store.sql_sidebar_channel.permanent_delete_by_user.app_error", nil, "user_id="+userId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -64,6 +64,7 @@ type SqlStore interface {
 	CreateIndexIfNotExists(indexName string, tableName string, columnName string) bool
 	CreateCompositeIndexIfNotExists(indexName string, tableName string, columnNames []string) bool
 	CreateFullTextIndexIfNotExists(indexName string, tableName string, columnName string) bool
+	CreateTrigramIndexIfNotExists(indexName string, tableName string, columnName string) bool
 	RemoveIndexIfExists(indexName string, tableName string) bool
 	GetAllConns() []*gorp.DbMap
 	Close()
@@ -98,5 +99,13 @@ type SqlStore interface {
 	TermsOfService() store.TermsOfServiceStore
 	UserTermsOfService() store.UserTermsOfServiceStore
 	LinkMetadata() store.LinkMetadataStore
+	PushNotificationQueue() store.PushNotificationQueueStore
+	ChannelTopicHistory() store.ChannelTopicHistoryStore
+	TeamMemberHistory() store.TeamMemberHistoryStore
+	SidebarChannel() store.SidebarChannelStore
+	Mention() store.MentionStore
+	PasswordHistory() store.PasswordHistoryStore
+	SavedPost() store.SavedPostStore
+	ConfigChangeRequest() store.ConfigChangeRequestStore
 	getQueryBuilder() sq.StatementBuilderType
 }
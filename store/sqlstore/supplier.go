@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	sqltrace "log"
+	"net/url"
 	"os"
 	"strings"
 	"sync/atomic"
@@ -29,6 +30,7 @@ import (
 
 const (
 	INDEX_TYPE_FULL_TEXT = "full_text"
+	INDEX_TYPE_TRIGRAM   = "trigram"
 	INDEX_TYPE_DEFAULT   = "default"
 	DB_PING_ATTEMPTS     = 18
 	DB_PING_TIMEOUT_SECS = 10
@@ -65,40 +67,49 @@ const (
 	EXIT_REMOVE_INDEX_SQLITE         = 136
 	EXIT_TABLE_EXISTS_SQLITE         = 137
 	EXIT_DOES_COLUMN_EXISTS_SQLITE   = 138
+	EXIT_CREATE_SCHEMA               = 139
 )
 
 type SqlSupplierOldStores struct {
-	team                 store.TeamStore
-	channel              store.ChannelStore
-	post                 store.PostStore
-	user                 store.UserStore
-	bot                  store.BotStore
-	audit                store.AuditStore
-	cluster              store.ClusterDiscoveryStore
-	compliance           store.ComplianceStore
-	session              store.SessionStore
-	oauth                store.OAuthStore
-	system               store.SystemStore
-	webhook              store.WebhookStore
-	command              store.CommandStore
-	commandWebhook       store.CommandWebhookStore
-	preference           store.PreferenceStore
-	license              store.LicenseStore
-	token                store.TokenStore
-	emoji                store.EmojiStore
-	status               store.StatusStore
-	fileInfo             store.FileInfoStore
-	reaction             store.ReactionStore
-	job                  store.JobStore
-	userAccessToken      store.UserAccessTokenStore
-	plugin               store.PluginStore
-	channelMemberHistory store.ChannelMemberHistoryStore
-	role                 store.RoleStore
-	scheme               store.SchemeStore
-	TermsOfService       store.TermsOfServiceStore
-	group                store.GroupStore
-	UserTermsOfService   store.UserTermsOfServiceStore
-	linkMetadata         store.LinkMetadataStore
+	team                  store.TeamStore
+	channel               store.ChannelStore
+	post                  store.PostStore
+	user                  store.UserStore
+	bot                   store.BotStore
+	audit                 store.AuditStore
+	cluster               store.ClusterDiscoveryStore
+	compliance            store.ComplianceStore
+	session               store.SessionStore
+	oauth                 store.OAuthStore
+	system                store.SystemStore
+	webhook               store.WebhookStore
+	command               store.CommandStore
+	commandWebhook        store.CommandWebhookStore
+	preference            store.PreferenceStore
+	license               store.LicenseStore
+	token                 store.TokenStore
+	emoji                 store.EmojiStore
+	status                store.StatusStore
+	fileInfo              store.FileInfoStore
+	reaction              store.ReactionStore
+	job                   store.JobStore
+	userAccessToken       store.UserAccessTokenStore
+	plugin                store.PluginStore
+	channelMemberHistory  store.ChannelMemberHistoryStore
+	role                  store.RoleStore
+	scheme                store.SchemeStore
+	TermsOfService        store.TermsOfServiceStore
+	group                 store.GroupStore
+	UserTermsOfService    store.UserTermsOfServiceStore
+	linkMetadata          store.LinkMetadataStore
+	pushNotificationQueue store.PushNotificationQueueStore
+	channelTopicHistory   store.ChannelTopicHistoryStore
+	teamMemberHistory     store.TeamMemberHistoryStore
+	sidebarChannel        store.SidebarChannelStore
+	mention               store.MentionStore
+	passwordHistory       store.PasswordHistoryStore
+	savedPost             store.SavedPostStore
+	configChangeRequest   store.ConfigChangeRequestStore
 }
 
 type SqlSupplier struct {
@@ -151,6 +162,14 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.oldStores.TermsOfService = NewSqlTermsOfServiceStore(supplier, metrics)
 	supplier.oldStores.UserTermsOfService = NewSqlUserTermsOfServiceStore(supplier)
 	supplier.oldStores.linkMetadata = NewSqlLinkMetadataStore(supplier)
+	supplier.oldStores.pushNotificationQueue = NewSqlPushNotificationQueueStore(supplier)
+	supplier.oldStores.channelTopicHistory = NewSqlChannelTopicHistoryStore(supplier)
+	supplier.oldStores.teamMemberHistory = NewSqlTeamMemberHistoryStore(supplier)
+	supplier.oldStores.sidebarChannel = NewSqlSidebarChannelStore(supplier)
+	supplier.oldStores.mention = NewSqlMentionStore(supplier)
+	supplier.oldStores.passwordHistory = NewSqlPasswordHistoryStore(supplier)
+	supplier.oldStores.savedPost = NewSqlSavedPostStore(supplier)
+	supplier.oldStores.configChangeRequest = NewSqlConfigChangeRequestStore(supplier)
 	supplier.oldStores.reaction = NewSqlReactionStore(supplier)
 	supplier.oldStores.role = NewSqlRoleStore(supplier)
 	supplier.oldStores.scheme = NewSqlSchemeStore(supplier)
@@ -195,6 +214,14 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.oldStores.TermsOfService.(SqlTermsOfServiceStore).CreateIndexesIfNotExists()
 	supplier.oldStores.UserTermsOfService.(SqlUserTermsOfServiceStore).CreateIndexesIfNotExists()
 	supplier.oldStores.linkMetadata.(*SqlLinkMetadataStore).CreateIndexesIfNotExists()
+	supplier.oldStores.pushNotificationQueue.(*SqlPushNotificationQueueStore).CreateIndexesIfNotExists()
+	supplier.oldStores.channelTopicHistory.(*SqlChannelTopicHistoryStore).CreateIndexesIfNotExists()
+	supplier.oldStores.teamMemberHistory.(*SqlTeamMemberHistoryStore).CreateIndexesIfNotExists()
+	supplier.oldStores.sidebarChannel.(*SqlSidebarChannelStore).CreateIndexesIfNotExists()
+	supplier.oldStores.mention.(*SqlMentionStore).CreateIndexesIfNotExists()
+	supplier.oldStores.passwordHistory.(*SqlPasswordHistoryStore).CreateIndexesIfNotExists()
+	supplier.oldStores.savedPost.(*SqlSavedPostStore).CreateIndexesIfNotExists()
+	supplier.oldStores.configChangeRequest.(*SqlConfigChangeRequestStore).CreateIndexesIfNotExists()
 	supplier.oldStores.group.(*SqlGroupStore).CreateIndexesIfNotExists()
 
 	supplier.oldStores.preference.(*SqlPreferenceStore).DeleteUnusedFeatures()
@@ -210,7 +237,67 @@ func (s *SqlSupplier) Next() store.LayeredStoreSupplier {
 	return s.next
 }
 
-func setupConnection(con_type string, dataSource string, settings *model.SqlSettings) *gorp.DbMap {
+// postgresDataSourceWithSchema sets the search_path of a Postgres data source to the configured
+// schema, so that unqualified table names in queries refer to the tables in that schema.
+func postgresDataSourceWithSchema(dataSource string, settings *model.SqlSettings) string {
+	if *settings.DriverName != model.DATABASE_DRIVER_POSTGRES || settings.SchemaName == nil || *settings.SchemaName == "" {
+		return dataSource
+	}
+
+	if u, err := url.Parse(dataSource); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		query := u.Query()
+		query.Set("search_path", *settings.SchemaName)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	// The driver also accepts key/value connection strings, where later keys take precedence.
+	return dataSource + " search_path=" + *settings.SchemaName
+}
+
+// dataSourceWithTransactionIsolation sets the default isolation level of the transactions run over
+// the connections to a data source, which is the same as running SET SESSION TRANSACTION ISOLATION
+// LEVEL on MySQL or SET SESSION CHARACTERISTICS AS TRANSACTION on Postgres for each connection. The
+// data source is only changed when a level is set, so that the server's default applies otherwise.
+// The transaction_isolation variable set on MySQL requires MySQL 5.7.20 or later.
+func dataSourceWithTransactionIsolation(dataSource string, isolation *string, settings *model.SqlSettings) string {
+	if isolation == nil || *isolation == "" {
+		return dataSource
+	}
+
+	switch *settings.DriverName {
+	case model.DATABASE_DRIVER_MYSQL:
+		config, err := mysql.ParseDSN(dataSource)
+		if err != nil {
+			// Leave the error to be reported when connecting.
+			return dataSource
+		}
+		if config.Params == nil {
+			config.Params = map[string]string{}
+		}
+		// The driver sets each unknown parameter as a system variable of the connection.
+		config.Params["transaction_isolation"] = "'" + strings.ToUpper(*isolation) + "'"
+		return config.FormatDSN()
+
+	case model.DATABASE_DRIVER_POSTGRES:
+		level := strings.Replace(*isolation, "-", " ", -1)
+
+		if u, err := url.Parse(dataSource); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+			query := u.Query()
+			query.Set("default_transaction_isolation", level)
+			u.RawQuery = query.Encode()
+			return u.String()
+		}
+
+		return dataSource + " default_transaction_isolation='" + level + "'"
+	}
+
+	return dataSource
+}
+
+func setupConnection(con_type string, dataSource string, isolation *string, settings *model.SqlSettings) *gorp.DbMap {
+	dataSource = dataSourceWithTransactionIsolation(postgresDataSourceWithSchema(dataSource, settings), isolation, settings)
+
 	db, err := dbsql.Open(*settings.DriverName, dataSource)
 	if err != nil {
 		mlog.Critical("Failed to open SQL connection to err.", mlog.Err(err))
@@ -265,19 +352,28 @@ func setupConnection(con_type string, dataSource string, settings *model.SqlSett
 }
 
 func (s *SqlSupplier) initConnection() {
-	s.master = setupConnection("master", *s.settings.DataSource, s.settings)
+	s.master = setupConnection("master", *s.settings.DataSource, s.settings.WriteTransactionIsolation, s.settings)
+
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES && s.hasSchemaName() {
+		// The schema name is validated to be a plain identifier by the config.
+		if _, err := s.master.ExecNoTimeout("CREATE SCHEMA IF NOT EXISTS " + *s.settings.SchemaName); err != nil {
+			mlog.Critical("Failed to create database schema", mlog.String("schema_name", *s.settings.SchemaName), mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_CREATE_SCHEMA)
+		}
+	}
 
 	if len(s.settings.DataSourceReplicas) > 0 {
 		s.replicas = make([]*gorp.DbMap, len(s.settings.DataSourceReplicas))
 		for i, replica := range s.settings.DataSourceReplicas {
-			s.replicas[i] = setupConnection(fmt.Sprintf("replica-%v", i), replica, s.settings)
+			s.replicas[i] = setupConnection(fmt.Sprintf("replica-%v", i), replica, s.settings.ReadTransactionIsolation, s.settings)
 		}
 	}
 
 	if len(s.settings.DataSourceSearchReplicas) > 0 {
 		s.searchReplicas = make([]*gorp.DbMap, len(s.settings.DataSourceSearchReplicas))
 		for i, replica := range s.settings.DataSourceSearchReplicas {
-			s.searchReplicas[i] = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, s.settings)
+			s.searchReplicas[i] = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, s.settings.ReadTransactionIsolation, s.settings)
 		}
 	}
 }
@@ -356,12 +452,20 @@ func (ss *SqlSupplier) MarkSystemRanUnitTests() {
 	}
 }
 
+// hasSchemaName returns whether the server keeps its tables in a Postgres schema of its own, in which
+// case the tables and triggers of other schemas are ignored when checking for existing ones.
+func (ss *SqlSupplier) hasSchemaName() bool {
+	return ss.settings.SchemaName != nil && *ss.settings.SchemaName != ""
+}
+
 func (ss *SqlSupplier) DoesTableExist(tableName string) bool {
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		count, err := ss.GetMaster().SelectInt(
-			`SELECT count(relname) FROM pg_class WHERE relname=$1`,
-			strings.ToLower(tableName),
-		)
+		query := `SELECT count(relname) FROM pg_class WHERE relname=$1`
+		if ss.hasSchemaName() {
+			query += ` AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = current_schema())`
+		}
+
+		count, err := ss.GetMaster().SelectInt(query, strings.ToLower(tableName))
 
 		if err != nil {
 			mlog.Critical("Failed to check if table exists", mlog.Err(err))
@@ -487,14 +591,21 @@ func (ss *SqlSupplier) DoesColumnExist(tableName string, columnName string) bool
 
 func (ss *SqlSupplier) DoesTriggerExist(triggerName string) bool {
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		count, err := ss.GetMaster().SelectInt(`
+		query := `
 			SELECT
 				COUNT(0)
 			FROM
 				pg_trigger
+			JOIN
+				pg_class ON pg_class.oid = pg_trigger.tgrelid
 			WHERE
 				tgname = $1
-		`, triggerName)
+		`
+		if ss.hasSchemaName() {
+			query += `AND pg_class.relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = current_schema())`
+		}
+
+		count, err := ss.GetMaster().SelectInt(query, triggerName)
 
 		if err != nil {
 			mlog.Critical("Failed to check if trigger exists", mlog.Err(err))
@@ -661,7 +772,11 @@ func (ss *SqlSupplier) GetMaxLengthOfColumnIfExists(tableName string, columnName
 	if ss.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		result, err = ss.GetMaster().SelectStr("SELECT CHARACTER_MAXIMUM_LENGTH FROM information_schema.columns WHERE table_name = '" + tableName + "' AND COLUMN_NAME = '" + columnName + "'")
 	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		result, err = ss.GetMaster().SelectStr("SELECT character_maximum_length FROM information_schema.columns WHERE table_name = '" + strings.ToLower(tableName) + "' AND column_name = '" + strings.ToLower(columnName) + "'")
+		query := "SELECT character_maximum_length FROM information_schema.columns WHERE table_name = '" + strings.ToLower(tableName) + "' AND column_name = '" + strings.ToLower(columnName) + "'"
+		if ss.hasSchemaName() {
+			query += " AND table_schema = current_schema()"
+		}
+		result, err = ss.GetMaster().SelectStr(query)
 	}
 
 	if err != nil {
@@ -760,6 +875,22 @@ func (ss *SqlSupplier) CreateFullTextIndexIfNotExists(indexName string, tableNam
 	return ss.createIndexIfNotExists(indexName, tableName, []string{columnName}, INDEX_TYPE_FULL_TEXT, false)
 }
 
+// CreateTrigramIndexIfNotExists creates an index to speed up similarity and pattern matching searches on the given
+// column. It is only supported on Postgres, where it requires the pg_trgm extension: if the extension isn't
+// available and can't be installed, no index is created.
+func (ss *SqlSupplier) CreateTrigramIndexIfNotExists(indexName string, tableName string, columnName string) bool {
+	if ss.DriverName() != model.DATABASE_DRIVER_POSTGRES {
+		return false
+	}
+
+	if _, err := ss.GetMaster().ExecNoTimeout("CREATE EXTENSION IF NOT EXISTS pg_trgm"); err != nil {
+		mlog.Warn("Unable to install the pg_trgm extension, skipping trigram index", mlog.String("index_name", indexName), mlog.Err(err))
+		return false
+	}
+
+	return ss.createIndexIfNotExists(indexName, tableName, []string{columnName}, INDEX_TYPE_TRIGRAM, false)
+}
+
 func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string, columnNames []string, indexType string, unique bool) bool {
 
 	uniqueStr := ""
@@ -783,6 +914,8 @@ func (ss *SqlSupplier) createIndexIfNotExists(indexName string, tableName string
 			columnName := columnNames[0]
 			postgresColumnNames := convertMySQLFullTextColumnsToPostgres(columnName)
 			query = "CREATE INDEX " + indexName + " ON " + tableName + " USING gin(to_tsvector('english', " + postgresColumnNames + "))"
+		} else if indexType == INDEX_TYPE_TRIGRAM {
+			query = "CREATE INDEX " + indexName + " ON " + tableName + " USING gin(" + strings.Join(columnNames, ", ") + " gin_trgm_ops)"
 		} else {
 			query = "CREATE " + uniqueStr + "INDEX " + indexName + " ON " + tableName + " (" + strings.Join(columnNames, ", ") + ")"
 		}
@@ -1053,6 +1186,38 @@ func (ss *SqlSupplier) LinkMetadata() store.LinkMetadataStore {
 	return ss.oldStores.linkMetadata
 }
 
+func (ss *SqlSupplier) PushNotificationQueue() store.PushNotificationQueueStore {
+	return ss.oldStores.pushNotificationQueue
+}
+
+func (ss *SqlSupplier) ChannelTopicHistory() store.ChannelTopicHistoryStore {
+	return ss.oldStores.channelTopicHistory
+}
+
+func (ss *SqlSupplier) TeamMemberHistory() store.TeamMemberHistoryStore {
+	return ss.oldStores.teamMemberHistory
+}
+
+func (ss *SqlSupplier) SidebarChannel() store.SidebarChannelStore {
+	return ss.oldStores.sidebarChannel
+}
+
+func (ss *SqlSupplier) Mention() store.MentionStore {
+	return ss.oldStores.mention
+}
+
+func (ss *SqlSupplier) PasswordHistory() store.PasswordHistoryStore {
+	return ss.oldStores.passwordHistory
+}
+
+func (ss *SqlSupplier) SavedPost() store.SavedPostStore {
+	return ss.oldStores.savedPost
+}
+
+func (ss *SqlSupplier) ConfigChangeRequest() store.ConfigChangeRequestStore {
+	return ss.oldStores.configChangeRequest
+}
+
 func (ss *SqlSupplier) DropAllTables() {
 	ss.master.TruncateTables()
 }
@@ -1147,6 +1312,16 @@ func (me mattermConverter) FromDb(target interface{}) (gorp.CustomScanner, bool)
 			return json.Unmarshal(b, target)
 		}
 		return gorp.CustomScanner{Holder: new(string), Target: target, Binder: binder}, true
+	case **model.Config:
+		binder := func(holder, target interface{}) error {
+			s, ok := holder.(*string)
+			if !ok {
+				return errors.New(utils.T("store.sql.convert_config"))
+			}
+			b := []byte(*s)
+			return json.Unmarshal(b, target)
+		}
+		return gorp.CustomScanner{Holder: new(string), Target: target, Binder: binder}, true
 	}
 
 	return gorp.CustomScanner{}, false
@@ -58,6 +58,71 @@ func (s *SqlReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *mod
 	return reaction, nil
 }
 
+// SaveMultiple saves the given reactions in a single transaction. As with Save, reactions that
+// already exist are not considered an error, but are returned as duplicates rather than as saved.
+func (s *SqlReactionStore) SaveMultiple(reactions []*model.Reaction) (*model.BulkReactionsResult, *model.AppError) {
+	for _, reaction := range reactions {
+		reaction.PreSave()
+		if err := reaction.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, model.NewAppError("SqlReactionStore.SaveMultiple", "store.sql_reaction.save.begin.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer finalizeTransaction(transaction)
+
+	result := &model.BulkReactionsResult{
+		Saved:      []*model.Reaction{},
+		Duplicates: []*model.Reaction{},
+	}
+
+	postIds := make(map[string]bool)
+	for _, reaction := range reactions {
+		// A failed insert would abort the whole transaction on Postgres, so existing reactions are
+		// skipped up front instead.
+		count, err := transaction.SelectInt(
+			`SELECT
+				COUNT(*)
+			FROM
+				Reactions
+			WHERE
+				PostId = :PostId AND
+				UserId = :UserId AND
+				EmojiName = :EmojiName`,
+			map[string]interface{}{"PostId": reaction.PostId, "UserId": reaction.UserId, "EmojiName": reaction.EmojiName})
+		if err != nil {
+			return nil, model.NewAppError("SqlReactionStore.SaveMultiple", "store.sql_reaction.save.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		if count > 0 {
+			result.Duplicates = append(result.Duplicates, reaction)
+			continue
+		}
+
+		if err := transaction.Insert(reaction); err != nil {
+			return nil, model.NewAppError("SqlReactionStore.SaveMultiple", "store.sql_reaction.save.save.app_error", nil, err.Error(), http.StatusBadRequest)
+		}
+
+		result.Saved = append(result.Saved, reaction)
+		postIds[reaction.PostId] = true
+	}
+
+	for postId := range postIds {
+		if err := updatePostForReactionsOnInsert(transaction, postId); err != nil {
+			return nil, model.NewAppError("SqlReactionStore.SaveMultiple", "store.sql_reaction.save.save.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, model.NewAppError("SqlReactionStore.SaveMultiple", "store.sql_reaction.save.commit.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
+
 func (s *SqlReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
 	transaction, err := s.GetMaster().Begin()
 	if err != nil {
@@ -4,10 +4,21 @@
 package sqlstore
 
 import (
+	"context"
+	"database/sql"
 	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
 
+	"github.com/blang/semver"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	DATABASE_TRANSACTION_QUERY_SNIPPET_LENGTH = 256
 )
 
 type SqlSystemStore struct {
@@ -56,6 +67,26 @@ func (s SqlSystemStore) Update(system *model.System) *model.AppError {
 	return nil
 }
 
+func (s SqlSystemStore) UpdateOptimistically(system *model.System, currentValue string) (bool, *model.AppError) {
+	query := "UPDATE Systems SET Value = :Value WHERE Name = :Name AND Value = :OldValue"
+	params := map[string]interface{}{
+		"Name":     system.Name,
+		"Value":    system.Value,
+		"OldValue": currentValue,
+	}
+	sqlResult, err := s.GetMaster().Exec(query, params)
+	if err != nil {
+		return false, model.NewAppError("SqlSystemStore.UpdateOptimistically", "store.sql_system.update.app_error", nil, "name="+system.Name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	rows, err := sqlResult.RowsAffected()
+	if err != nil {
+		return false, model.NewAppError("SqlSystemStore.UpdateOptimistically", "store.sql_system.update.app_error", nil, "name="+system.Name+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return rows == 1, nil
+}
+
 func (s SqlSystemStore) Get() (model.StringMap, *model.AppError) {
 	var systems []model.System
 	props := make(model.StringMap)
@@ -86,3 +117,204 @@ func (s SqlSystemStore) PermanentDeleteByName(name string) (*model.System, *mode
 
 	return &system, nil
 }
+
+// GetSchemaMigrations lists the schema upgrades up to CURRENT_SCHEMA_VERSION, marking those
+// through the database's schema version as applied.
+func (s SqlSystemStore) GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError) {
+	props, appErr := s.Get()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var schemaVersion *semver.Version
+	if props["Version"] != "" {
+		version, err := semver.Parse(props["Version"])
+		if err != nil {
+			return nil, model.NewAppError("SqlSystemStore.GetSchemaMigrations", "store.sql_system.get_schema_migrations.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+		schemaVersion = &version
+	}
+
+	currentSchemaVersion := semver.MustParse(CURRENT_SCHEMA_VERSION)
+
+	var migrations []*model.SchemaMigration
+	for _, upgrade := range schemaUpgrades {
+		version := semver.MustParse(upgrade.Version)
+		if version.GT(currentSchemaVersion) {
+			break
+		}
+
+		migration := &model.SchemaMigration{
+			Id:     upgrade.Version,
+			Name:   upgrade.Name,
+			Status: model.SCHEMA_MIGRATION_STATUS_PENDING,
+		}
+
+		if schemaVersion != nil && version.LTE(*schemaVersion) {
+			migration.Status = model.SCHEMA_MIGRATION_STATUS_APPLIED
+			migration.AppliedAt, _ = strconv.ParseInt(props[SCHEMA_VERSION_APPLIED_AT_PREFIX+upgrade.Version], 10, 64)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+// GetOpenTransactions returns the transactions currently open on the master database, oldest first.
+// Listing the transactions of other database users requires the PROCESS privilege on MySQL.
+func (s SqlSystemStore) GetOpenTransactions() ([]*model.DatabaseTransaction, *model.AppError) {
+	var query string
+	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+		query = `
+			SELECT
+				pid AS Pid,
+				CAST(EXTRACT(EPOCH FROM (now() - xact_start)) * 1000 AS bigint) AS DurationMs,
+				COALESCE(state, '') AS State,
+				LEFT(COALESCE(query, ''), :SnippetLength) AS QuerySnippet
+			FROM
+				pg_stat_activity
+			WHERE
+				datname = current_database()
+				AND xact_start IS NOT NULL
+				AND pid <> pg_backend_pid()
+			ORDER BY xact_start`
+	} else {
+		query = `
+			SELECT
+				trx_mysql_thread_id AS Pid,
+				TIMESTAMPDIFF(MICROSECOND, trx_started, NOW()) DIV 1000 AS DurationMs,
+				trx_state AS State,
+				LEFT(COALESCE(trx_query, ''), :SnippetLength) AS QuerySnippet
+			FROM
+				information_schema.INNODB_TRX
+			WHERE
+				trx_mysql_thread_id <> CONNECTION_ID()
+			ORDER BY trx_started`
+	}
+
+	transactions := []*model.DatabaseTransaction{}
+	if _, err := s.GetMaster().Select(&transactions, query, map[string]interface{}{"SnippetLength": DATABASE_TRANSACTION_QUERY_SNIPPET_LENGTH}); err != nil {
+		return nil, model.NewAppError("SqlSystemStore.GetOpenTransactions", "store.sql_system.get_open_transactions.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return transactions, nil
+}
+
+// RunReportingQuery runs the given SELECT statement against a replica inside a read-only
+// transaction, binding params to the query's named (:Name) parameters. Each row is returned as a
+// map of column name to value.
+func (s SqlSystemStore) RunReportingQuery(query string, params map[string]interface{}) ([]map[string]interface{}, *model.AppError) {
+	dbmap := s.GetReplica()
+
+	boundQuery, args, err := bindReportingQueryParams(dbmap.Dialect.BindVar, query, params)
+	if err != nil {
+		return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.params.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbmap.QueryTimeout)
+	defer cancel()
+
+	tx, err := dbmap.Db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, boundQuery, args...)
+	if err != nil {
+		return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	results := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+
+		// Postgres folds unquoted column names to lower case, so they are lower cased on every
+		// database for the keys to be the same.
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			// MySQL returns most column types as raw bytes
+			if b, ok := values[i].([]byte); ok {
+				row[strings.ToLower(column)] = string(b)
+			} else {
+				row[strings.ToLower(column)] = values[i]
+			}
+		}
+		results = append(results, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, model.NewAppError("SqlSystemStore.RunReportingQuery", "store.sql_system.run_reporting_query.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return results, nil
+}
+
+// bindReportingQueryParams replaces each named (:Name) parameter in the given query with the
+// dialect's bind variable and returns the matching positional arguments. Quoted strings and
+// Postgres type casts (::type) are left untouched.
+func bindReportingQueryParams(bindVar func(int) string, query string, params map[string]interface{}) (string, []interface{}, error) {
+	var bound []rune
+	var args []interface{}
+	var quote rune
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if quote != 0 {
+			if r == quote {
+				quote = 0
+			}
+			bound = append(bound, r)
+			continue
+		}
+
+		if r == '\'' || r == '"' || r == '`' {
+			quote = r
+			bound = append(bound, r)
+			continue
+		}
+
+		if r != ':' || (i > 0 && runes[i-1] == ':') || (i+1 < len(runes) && runes[i+1] == ':') {
+			bound = append(bound, r)
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+			end++
+		}
+		if end == i+1 {
+			bound = append(bound, r)
+			continue
+		}
+
+		name := string(runes[i+1 : end])
+		value, ok := params[name]
+		if !ok {
+			return "", nil, errors.Errorf("missing value for parameter %v", name)
+		}
+
+		bound = append(bound, []rune(bindVar(len(args)))...)
+		args = append(args, value)
+		i = end - 1
+	}
+
+	return string(bound), args, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package sqlstore
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/mlog"
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+type SqlTeamMemberHistoryStore struct {
+	SqlStore
+}
+
+func NewSqlTeamMemberHistoryStore(sqlStore SqlStore) store.TeamMemberHistoryStore {
+	s := &SqlTeamMemberHistoryStore{
+		SqlStore: sqlStore,
+	}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.TeamMemberHistory{}, "TeamMemberHistory").SetKeys(false, "TeamId", "UserId", "JoinTime")
+		table.ColMap("TeamId").SetMaxSize(26)
+		table.ColMap("UserId").SetMaxSize(26)
+		table.ColMap("JoinTime").SetNotNull(true)
+	}
+
+	return s
+}
+
+func (s SqlTeamMemberHistoryStore) CreateIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_team_member_history_team_id_join_time", "TeamMemberHistory", []string{"TeamId", "JoinTime"})
+	s.CreateCompositeIndexIfNotExists("idx_team_member_history_team_id_leave_time", "TeamMemberHistory", []string{"TeamId", "LeaveTime"})
+}
+
+func (s SqlTeamMemberHistoryStore) LogJoinEvent(userId string, teamId string, joinTime int64) *model.AppError {
+	teamMemberHistory := &model.TeamMemberHistory{
+		UserId:   userId,
+		TeamId:   teamId,
+		JoinTime: joinTime,
+	}
+
+	if err := s.GetMaster().Insert(teamMemberHistory); err != nil {
+		return model.NewAppError("SqlTeamMemberHistoryStore.LogJoinEvent", "store.sql_team_member_history.log_join_event.app_error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (s SqlTeamMemberHistoryStore) LogLeaveEvent(userId string, teamId string, leaveTime int64) *model.AppError {
+	query := `
+		UPDATE TeamMemberHistory
+		SET LeaveTime = :LeaveTime
+		WHERE UserId = :UserId
+		AND TeamId = :TeamId
+		AND LeaveTime IS NULL`
+
+	params := map[string]interface{}{"UserId": userId, "TeamId": teamId, "LeaveTime": leaveTime}
+	sqlResult, err := s.GetMaster().Exec(query, params)
+	if err != nil {
+		return model.NewAppError("SqlTeamMemberHistoryStore.LogLeaveEvent", "store.sql_team_member_history.log_leave_event.update_error", params, err.Error(), http.StatusInternalServerError)
+	}
+
+	if rows, err := sqlResult.RowsAffected(); err == nil && rows != 1 {
+		// users who joined before the TeamMemberHistory table was introduced have no join event to update
+		mlog.Warn("Team join event for user and team not found", mlog.String("user", userId), mlog.String("team", teamId))
+	}
+	return nil
+}
+
+// GetForTeamDuring returns the history of the members who joined or left the given team between
+// startTime, inclusive, and endTime, exclusive.
+func (s SqlTeamMemberHistoryStore) GetForTeamDuring(teamId string, startTime int64, endTime int64) ([]*model.TeamMemberHistory, *model.AppError) {
+	var histories []*model.TeamMemberHistory
+
+	query := `
+		SELECT *
+		FROM TeamMemberHistory
+		WHERE TeamId = :TeamId
+		AND ((JoinTime >= :StartTime AND JoinTime < :EndTime)
+			OR (LeaveTime >= :StartTime AND LeaveTime < :EndTime))`
+
+	params := map[string]interface{}{"TeamId": teamId, "StartTime": startTime, "EndTime": endTime}
+	if _, err := s.GetReplica().Select(&histories, query, params); err != nil {
+		return nil, model.NewAppError("SqlTeamMemberHistoryStore.GetForTeamDuring", "store.sql_team_member_history.get_for_team_during.app_error", nil, "team_id="+teamId+", "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return histories, nil
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,12 +67,68 @@ const (
 	OLDEST_SUPPORTED_VERSION = VERSION_3_0_0
 )
 
+// SCHEMA_VERSION_APPLIED_AT_PREFIX prefixes the names of the Systems entries recording when the
+// schema was upgraded to each version.
+const SCHEMA_VERSION_APPLIED_AT_PREFIX = "SchemaVersionAppliedAt_"
+
 const (
 	EXIT_VERSION_SAVE                   = 1003
 	EXIT_THEME_MIGRATION                = 1004
 	EXIT_TEAM_INVITEID_MIGRATION_FAILED = 1006
 )
 
+// schemaUpgrade migrates the database schema to the given version.
+type schemaUpgrade struct {
+	Version string
+	Name    string
+	Upgrade func(sqlStore SqlStore)
+}
+
+// schemaUpgrades lists the schema upgrades in the order in which UpgradeDatabase applies them.
+var schemaUpgrades = []schemaUpgrade{
+	{VERSION_3_1_0, "UpgradeDatabaseToVersion31", UpgradeDatabaseToVersion31},
+	{VERSION_3_2_0, "UpgradeDatabaseToVersion32", UpgradeDatabaseToVersion32},
+	{VERSION_3_3_0, "UpgradeDatabaseToVersion33", UpgradeDatabaseToVersion33},
+	{VERSION_3_4_0, "UpgradeDatabaseToVersion34", UpgradeDatabaseToVersion34},
+	{VERSION_3_5_0, "UpgradeDatabaseToVersion35", UpgradeDatabaseToVersion35},
+	{VERSION_3_6_0, "UpgradeDatabaseToVersion36", UpgradeDatabaseToVersion36},
+	{VERSION_3_7_0, "UpgradeDatabaseToVersion37", UpgradeDatabaseToVersion37},
+	{VERSION_3_8_0, "UpgradeDatabaseToVersion38", UpgradeDatabaseToVersion38},
+	{VERSION_3_9_0, "UpgradeDatabaseToVersion39", UpgradeDatabaseToVersion39},
+	{VERSION_3_10_0, "UpgradeDatabaseToVersion310", UpgradeDatabaseToVersion310},
+	{VERSION_4_0_0, "UpgradeDatabaseToVersion40", UpgradeDatabaseToVersion40},
+	{VERSION_4_1_0, "UpgradeDatabaseToVersion41", UpgradeDatabaseToVersion41},
+	{VERSION_4_2_0, "UpgradeDatabaseToVersion42", UpgradeDatabaseToVersion42},
+	{VERSION_4_3_0, "UpgradeDatabaseToVersion43", UpgradeDatabaseToVersion43},
+	{VERSION_4_4_0, "UpgradeDatabaseToVersion44", UpgradeDatabaseToVersion44},
+	{VERSION_4_5_0, "UpgradeDatabaseToVersion45", UpgradeDatabaseToVersion45},
+	{VERSION_4_6_0, "UpgradeDatabaseToVersion46", UpgradeDatabaseToVersion46},
+	{VERSION_4_7_0, "UpgradeDatabaseToVersion47", UpgradeDatabaseToVersion47},
+	{VERSION_4_7_1, "UpgradeDatabaseToVersion471", UpgradeDatabaseToVersion471},
+	{VERSION_4_7_2, "UpgradeDatabaseToVersion472", UpgradeDatabaseToVersion472},
+	{VERSION_4_8_0, "UpgradeDatabaseToVersion48", UpgradeDatabaseToVersion48},
+	{VERSION_4_8_1, "UpgradeDatabaseToVersion481", UpgradeDatabaseToVersion481},
+	{VERSION_4_9_0, "UpgradeDatabaseToVersion49", UpgradeDatabaseToVersion49},
+	{VERSION_4_10_0, "UpgradeDatabaseToVersion410", UpgradeDatabaseToVersion410},
+	{VERSION_5_0_0, "UpgradeDatabaseToVersion50", UpgradeDatabaseToVersion50},
+	{VERSION_5_1_0, "UpgradeDatabaseToVersion51", UpgradeDatabaseToVersion51},
+	{VERSION_5_2_0, "UpgradeDatabaseToVersion52", UpgradeDatabaseToVersion52},
+	{VERSION_5_3_0, "UpgradeDatabaseToVersion53", UpgradeDatabaseToVersion53},
+	{VERSION_5_4_0, "UpgradeDatabaseToVersion54", UpgradeDatabaseToVersion54},
+	{VERSION_5_5_0, "UpgradeDatabaseToVersion55", UpgradeDatabaseToVersion55},
+	{VERSION_5_6_0, "UpgradeDatabaseToVersion56", UpgradeDatabaseToVersion56},
+	{VERSION_5_7_0, "UpgradeDatabaseToVersion57", UpgradeDatabaseToVersion57},
+	{VERSION_5_8_0, "UpgradeDatabaseToVersion58", UpgradeDatabaseToVersion58},
+	{VERSION_5_9_0, "UpgradeDatabaseToVersion59", UpgradeDatabaseToVersion59},
+	{VERSION_5_10_0, "UpgradeDatabaseToVersion510", UpgradeDatabaseToVersion510},
+	{VERSION_5_11_0, "UpgradeDatabaseToVersion511", UpgradeDatabaseToVersion511},
+	{VERSION_5_12_0, "UpgradeDatabaseToVersion512", UpgradeDatabaseToVersion512},
+	{VERSION_5_13_0, "UpgradeDatabaseToVersion513", UpgradeDatabaseToVersion513},
+	{VERSION_5_14_0, "UpgradeDatabaseToVersion514", UpgradeDatabaseToVersion514},
+	{VERSION_5_15_0, "UpgradeDatabaseToVersion515", UpgradeDatabaseToVersion515},
+	{VERSION_5_16_0, "UpgradeDatabaseToVersion516", UpgradeDatabaseToVersion516},
+}
+
 // UpgradeDatabase attempts to migrate the schema to the latest supported version.
 // The value of model.CurrentVersion is accepted as a parameter for unit testing, but it is not
 // used to stop migrations at that version.
@@ -124,47 +181,9 @@ func UpgradeDatabase(sqlStore SqlStore, currentModelVersionString string) error
 
 	// Otherwise, apply any necessary migrations. Note that these methods currently invoke
 	// os.Exit instead of returning an error.
-	UpgradeDatabaseToVersion31(sqlStore)
-	UpgradeDatabaseToVersion32(sqlStore)
-	UpgradeDatabaseToVersion33(sqlStore)
-	UpgradeDatabaseToVersion34(sqlStore)
-	UpgradeDatabaseToVersion35(sqlStore)
-	UpgradeDatabaseToVersion36(sqlStore)
-	UpgradeDatabaseToVersion37(sqlStore)
-	UpgradeDatabaseToVersion38(sqlStore)
-	UpgradeDatabaseToVersion39(sqlStore)
-	UpgradeDatabaseToVersion310(sqlStore)
-	UpgradeDatabaseToVersion40(sqlStore)
-	UpgradeDatabaseToVersion41(sqlStore)
-	UpgradeDatabaseToVersion42(sqlStore)
-	UpgradeDatabaseToVersion43(sqlStore)
-	UpgradeDatabaseToVersion44(sqlStore)
-	UpgradeDatabaseToVersion45(sqlStore)
-	UpgradeDatabaseToVersion46(sqlStore)
-	UpgradeDatabaseToVersion47(sqlStore)
-	UpgradeDatabaseToVersion471(sqlStore)
-	UpgradeDatabaseToVersion472(sqlStore)
-	UpgradeDatabaseToVersion48(sqlStore)
-	UpgradeDatabaseToVersion481(sqlStore)
-	UpgradeDatabaseToVersion49(sqlStore)
-	UpgradeDatabaseToVersion410(sqlStore)
-	UpgradeDatabaseToVersion50(sqlStore)
-	UpgradeDatabaseToVersion51(sqlStore)
-	UpgradeDatabaseToVersion52(sqlStore)
-	UpgradeDatabaseToVersion53(sqlStore)
-	UpgradeDatabaseToVersion54(sqlStore)
-	UpgradeDatabaseToVersion55(sqlStore)
-	UpgradeDatabaseToVersion56(sqlStore)
-	UpgradeDatabaseToVersion57(sqlStore)
-	UpgradeDatabaseToVersion58(sqlStore)
-	UpgradeDatabaseToVersion59(sqlStore)
-	UpgradeDatabaseToVersion510(sqlStore)
-	UpgradeDatabaseToVersion511(sqlStore)
-	UpgradeDatabaseToVersion512(sqlStore)
-	UpgradeDatabaseToVersion513(sqlStore)
-	UpgradeDatabaseToVersion514(sqlStore)
-	UpgradeDatabaseToVersion515(sqlStore)
-	UpgradeDatabaseToVersion516(sqlStore)
+	for _, upgrade := range schemaUpgrades {
+		upgrade.Upgrade(sqlStore)
+	}
 
 	return nil
 }
@@ -176,6 +195,11 @@ func saveSchemaVersion(sqlStore SqlStore, version string) {
 		os.Exit(EXIT_VERSION_SAVE)
 	}
 
+	appliedAt := &model.System{Name: SCHEMA_VERSION_APPLIED_AT_PREFIX + version, Value: strconv.FormatInt(model.GetMillis(), 10)}
+	if err := sqlStore.System().SaveOrUpdate(appliedAt); err != nil {
+		mlog.Error("Failed to record when the database schema was upgraded", mlog.String("version", version), mlog.Err(err))
+	}
+
 	mlog.Warn(fmt.Sprintf("The database schema has been upgraded to version %v", version))
 }
 
@@ -745,6 +769,17 @@ func UpgradeDatabaseToVersion516(sqlStore SqlStore) {
 		sqlStore.GetMaster().Exec("ALTER TABLE Tokens MODIFY Extra text")
 	}
 
+	sqlStore.CreateColumnIfNotExists("Sessions", "Platform", "varchar(64)", "varchar(64)", "")
+	sqlStore.CreateColumnIfNotExists("Sessions", "IpAddress", "varchar(64)", "varchar(64)", "")
+
+	// MySQL doesn't allow a default value for text columns.
+	if sqlStore.CreateColumnIfNotExistsNoDefault("OutgoingWebhooks", "PayloadTemplate", "text", "varchar(4000)") {
+		sqlStore.GetMaster().Exec("UPDATE OutgoingWebhooks SET PayloadTemplate = '' WHERE PayloadTemplate IS NULL")
+	}
+
+	// Proposed configurations are as large as the active one, which may exceed TEXT on MySQL.
+	sqlStore.AlterColumnTypeIfExists("ConfigChangeRequests", "ProposedConfig", "mediumtext", "text")
+
 	// 	saveSchemaVersion(sqlStore, VERSION_5_16_0)
 	// }
 }
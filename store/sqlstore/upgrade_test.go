@@ -6,6 +6,7 @@ package sqlstore
 import (
 	"testing"
 
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 	"github.com/stretchr/testify/require"
 )
@@ -100,5 +101,22 @@ func TestSaveSchemaVersion(t *testing.T) {
 			require.Equal(t, CURRENT_SCHEMA_VERSION, props["Version"])
 			require.Equal(t, CURRENT_SCHEMA_VERSION, sqlStore.GetCurrentSchemaVersion())
 		})
+
+		t.Run("records when the version was applied", func(t *testing.T) {
+			saveSchemaVersion(sqlStore, CURRENT_SCHEMA_VERSION)
+			props, err := ss.System().Get()
+			require.Nil(t, err)
+
+			require.NotEmpty(t, props[SCHEMA_VERSION_APPLIED_AT_PREFIX+CURRENT_SCHEMA_VERSION])
+
+			migrations, err := ss.System().GetSchemaMigrations()
+			require.Nil(t, err)
+			require.NotEmpty(t, migrations)
+
+			last := migrations[len(migrations)-1]
+			require.Equal(t, CURRENT_SCHEMA_VERSION, last.Id)
+			require.Equal(t, model.SCHEMA_MIGRATION_STATUS_APPLIED, last.Status)
+			require.NotZero(t, last.AppliedAt)
+		})
 	})
 }
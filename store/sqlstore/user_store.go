@@ -15,6 +15,7 @@ import (
 	"github.com/mattermost/gorp"
 
 	"github.com/mattermost/mattermost-server/einterfaces"
+	"github.com/mattermost/mattermost-server/mlog"
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/mattermost/mattermost-server/store"
 	"github.com/mattermost/mattermost-server/utils"
@@ -35,6 +36,10 @@ var (
 	USER_SEARCH_TYPE_ALL                = []string{"Username", "FirstName", "LastName", "Nickname", "Email"}
 )
 
+// USER_FUZZY_SEARCH_MIN_SIMILARITY is the minimum trigram similarity between a username and the search term for the
+// user to be returned by a fuzzy search on Postgres.
+const USER_FUZZY_SEARCH_MIN_SIMILARITY = 0.2
+
 type SqlUserStore struct {
 	SqlStore
 	metrics einterfaces.MetricsInterface
@@ -116,6 +121,9 @@ func (us SqlUserStore) CreateIndexesIfNotExists() {
 	us.CreateFullTextIndexIfNotExists("idx_users_all_no_full_name_txt", "Users", strings.Join(USER_SEARCH_TYPE_ALL_NO_FULL_NAME, ", "))
 	us.CreateFullTextIndexIfNotExists("idx_users_names_txt", "Users", strings.Join(USER_SEARCH_TYPE_NAMES, ", "))
 	us.CreateFullTextIndexIfNotExists("idx_users_names_no_full_name_txt", "Users", strings.Join(USER_SEARCH_TYPE_NAMES_NO_FULL_NAME, ", "))
+
+	// Supports fuzzy username searches on Postgres.
+	us.CreateTrigramIndexIfNotExists("idx_users_username_trgm", "Users", "lower(Username)")
 }
 
 func (us SqlUserStore) Save(user *model.User) (*model.User, *model.AppError) {
@@ -1163,9 +1171,7 @@ func (us SqlUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId
 }
 
 func (us SqlUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
-	query := us.usersQuery.
-		OrderBy("Username ASC").
-		Limit(uint64(options.Limit))
+	query := us.usersQuery
 
 	if teamId != "" {
 		query = query.Join("TeamMembers tm ON ( tm.UserId = u.Id AND tm.DeleteAt = 0 AND tm.TeamId = ? )", teamId)
@@ -1183,9 +1189,7 @@ func (us SqlUserStore) SearchWithoutTeam(term string, options *model.UserSearchO
 				WHERE
 					TeamMembers.UserId = u.Id
 					AND TeamMembers.DeleteAt = 0
-			) = 0`).
-		OrderBy("u.Username ASC").
-		Limit(uint64(options.Limit))
+			) = 0`)
 
 	return us.performSearch(query, term, options)
 }
@@ -1193,9 +1197,7 @@ func (us SqlUserStore) SearchWithoutTeam(term string, options *model.UserSearchO
 func (us SqlUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		LeftJoin("TeamMembers tm ON ( tm.UserId = u.Id AND tm.DeleteAt = 0 AND tm.TeamId = ? )", notInTeamId).
-		Where("tm.UserId IS NULL").
-		OrderBy("u.Username ASC").
-		Limit(uint64(options.Limit))
+		Where("tm.UserId IS NULL")
 
 	if options.GroupConstrained {
 		query = applyTeamGroupConstrainedFilter(query, notInTeamId)
@@ -1207,9 +1209,7 @@ func (us SqlUserStore) SearchNotInTeam(notInTeamId string, term string, options
 func (us SqlUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
 		LeftJoin("ChannelMembers cm ON ( cm.UserId = u.Id AND cm.ChannelId = ? )", channelId).
-		Where("cm.UserId IS NULL").
-		OrderBy("Username ASC").
-		Limit(uint64(options.Limit))
+		Where("cm.UserId IS NULL")
 
 	if teamId != "" {
 		query = query.Join("TeamMembers tm ON ( tm.UserId = u.Id AND tm.DeleteAt = 0 AND tm.TeamId = ? )", teamId)
@@ -1224,9 +1224,7 @@ func (us SqlUserStore) SearchNotInChannel(teamId string, channelId string, term
 
 func (us SqlUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
 	query := us.usersQuery.
-		Join("ChannelMembers cm ON ( cm.UserId = u.Id AND cm.ChannelId = ? )", channelId).
-		OrderBy("Username ASC").
-		Limit(uint64(options.Limit))
+		Join("ChannelMembers cm ON ( cm.UserId = u.Id AND cm.ChannelId = ? )", channelId)
 
 	return us.performSearch(query, term, options)
 }
@@ -1246,25 +1244,150 @@ var spaceFulltextSearchChar = []string{
 	"@",
 }
 
-func generateSearchQuery(query sq.SelectBuilder, terms []string, fields []string, isPostgreSQL bool) sq.SelectBuilder {
+func generateSearchQuery(query sq.SelectBuilder, terms []string, fields []string, includeCustomAttrs bool, isPostgreSQL bool) sq.SelectBuilder {
 	for _, term := range terms {
-		searchFields := []string{}
-		termArgs := []interface{}{}
-		for _, field := range fields {
+		query = query.Where(generateSearchTermClause(term, fields, includeCustomAttrs, isPostgreSQL))
+	}
+
+	return query
+}
+
+// generateSearchTermClause returns a condition matching users for which any of the given fields starts with the term.
+func generateSearchTermClause(term string, fields []string, includeCustomAttrs bool, isPostgreSQL bool) sq.Sqlizer {
+	searchFields := []string{}
+	termArgs := []interface{}{}
+	for _, field := range fields {
+		if isPostgreSQL {
+			searchFields = append(searchFields, fmt.Sprintf("lower(%s) LIKE lower(?) escape '*' ", field))
+		} else {
+			searchFields = append(searchFields, fmt.Sprintf("%s LIKE ? escape '*' ", field))
+		}
+		termArgs = append(termArgs, fmt.Sprintf("%s%%", strings.TrimLeft(term, "@")))
+	}
+	if includeCustomAttrs {
+		// Custom profile attributes are stored in the Props column alongside other props, so
+		// only the values of the searchable attributes are matched.
+		for _, attribute := range model.USER_SEARCH_CUSTOM_ATTRIBUTES {
 			if isPostgreSQL {
-				searchFields = append(searchFields, fmt.Sprintf("lower(%s) LIKE lower(?) escape '*' ", field))
+				searchFields = append(searchFields, fmt.Sprintf("lower(u.Props::jsonb->>'%s') LIKE lower(?) escape '*' ", attribute))
 			} else {
-				searchFields = append(searchFields, fmt.Sprintf("%s LIKE ? escape '*' ", field))
+				searchFields = append(searchFields, fmt.Sprintf("lower(JSON_UNQUOTE(JSON_EXTRACT(u.Props, '$.%s'))) LIKE lower(?) escape '*' ", attribute))
 			}
 			termArgs = append(termArgs, fmt.Sprintf("%s%%", strings.TrimLeft(term, "@")))
 		}
-		query = query.Where(fmt.Sprintf("(%s)", strings.Join(searchFields, " OR ")), termArgs...)
 	}
 
-	return query
+	return sq.Expr(fmt.Sprintf("(%s)", strings.Join(searchFields, " OR ")), termArgs...)
+}
+
+// fuzzyUserSearchResult is a user matched by a fuzzy search, along with the values used to rank it.
+type fuzzyUserSearchResult struct {
+	model.User
+	PrefixMatch     int
+	SimilarityScore float64
+}
+
+// performFuzzySearch finds the users whose name starts with the term, as well as those whose username is similar to
+// it. Prefix matches are ranked first, followed by the other matches in order of similarity and then edit distance.
+// Similarity is measured using trigrams on Postgres and Soundex on MySQL.
+func (us SqlUserStore) performFuzzySearch(query sq.SelectBuilder, term string, fields []string, options *model.UserSearchOptions, isPostgreSQL bool) ([]*model.User, error) {
+	prefixClause, prefixArgs, err := generateSearchTermClause(sanitizeSearchTerm(term, "*"), fields, options.IncludeCustomAttrs, isPostgreSQL).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	username := strings.ToLower(strings.TrimLeft(term, "@"))
+
+	var similarityClause, similarityFilter string
+	var similarityFilterArgs []interface{}
+	if isPostgreSQL {
+		similarityClause = "similarity(lower(u.Username), ?)"
+		similarityFilter = similarityClause + " >= ?"
+		similarityFilterArgs = []interface{}{username, USER_FUZZY_SEARCH_MIN_SIMILARITY}
+	} else {
+		similarityClause = "(SOUNDEX(u.Username) = SOUNDEX(?))"
+		similarityFilter = "SOUNDEX(u.Username) = SOUNDEX(?)"
+		similarityFilterArgs = []interface{}{username}
+	}
+
+	query = query.
+		Column(sq.Expr("CASE WHEN "+prefixClause+" THEN 1 ELSE 0 END AS PrefixMatch", prefixArgs...)).
+		Column(sq.Expr(similarityClause+" AS SimilarityScore", username)).
+		Where(sq.Or{
+			sq.Expr(prefixClause, prefixArgs...),
+			sq.Expr(similarityFilter, similarityFilterArgs...),
+		}).
+		OrderBy("PrefixMatch DESC", "SimilarityScore DESC", "u.Username ASC").
+		Limit(uint64(options.Limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*fuzzyUserSearchResult
+	if _, err := us.GetReplica().Select(&results, queryString, args...); err != nil {
+		return nil, err
+	}
+
+	// Neither database provides edit distance out of the box, so break ties between equally similar matches here.
+	distances := make(map[string]int, len(results))
+	for _, result := range results {
+		distances[result.Id] = levenshteinDistance(strings.ToLower(result.Username), username)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].PrefixMatch != results[j].PrefixMatch {
+			return results[i].PrefixMatch > results[j].PrefixMatch
+		}
+		if results[i].SimilarityScore != results[j].SimilarityScore {
+			return results[i].SimilarityScore > results[j].SimilarityScore
+		}
+		return distances[results[i].Id] < distances[results[j].Id]
+	})
+
+	users := make([]*model.User, 0, len(results))
+	for _, result := range results {
+		user := result.User
+		user.Sanitize(map[string]bool{})
+		users = append(users, &user)
+	}
+
+	return users, nil
+}
+
+// levenshteinDistance returns the number of single character edits needed to change a into b.
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(br)]
 }
 
 func (us SqlUserStore) performSearch(query sq.SelectBuilder, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	rawTerms := strings.Fields(term)
 	term = sanitizeSearchTerm(term, "*")
 
 	searchType := USER_SEARCH_TYPE_NAMES_NO_FULL_NAME
@@ -1290,11 +1413,23 @@ func (us SqlUserStore) performSearch(query sq.SelectBuilder, term string, option
 		query = query.Where("u.DeleteAt = 0")
 	}
 
+	query = applyViewRestrictionsFilter(query, options.ViewRestrictions, true)
+
+	// Only single words are matched fuzzily, since that's what is typed when mentioning someone.
+	if options.Fuzzy && len(rawTerms) == 1 {
+		users, err := us.performFuzzySearch(query, rawTerms[0], searchType, options, isPostgreSQL)
+		if err == nil {
+			return users, nil
+		}
+
+		mlog.Warn("Fuzzy user search failed, falling back to prefix search.", mlog.Err(err))
+	}
+
 	if strings.TrimSpace(term) != "" {
-		query = generateSearchQuery(query, strings.Fields(term), searchType, isPostgreSQL)
+		query = generateSearchQuery(query, strings.Fields(term), searchType, options.IncludeCustomAttrs, isPostgreSQL)
 	}
 
-	query = applyViewRestrictionsFilter(query, options.ViewRestrictions, true)
+	query = query.OrderBy("u.Username ASC").Limit(uint64(options.Limit))
 
 	queryString, args, err := query.ToSql()
 	if err != nil {
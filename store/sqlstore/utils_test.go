@@ -1,6 +1,7 @@
 package sqlstore
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +59,34 @@ func TestSanitizeSearchTerm(t *testing.T) {
 	result = sanitizeSearchTerm(term, "*")
 	require.Equal(t, result, expected)
 }
+
+func TestBindReportingQueryParams(t *testing.T) {
+	mysqlBindVar := func(i int) string { return "?" }
+	postgresBindVar := func(i int) string { return fmt.Sprintf("$%d", i+1) }
+
+	t.Run("named params", func(t *testing.T) {
+		query, args, err := bindReportingQueryParams(postgresBindVar, "SELECT * FROM Posts WHERE ChannelId = :ChannelId AND CreateAt > :Since", map[string]interface{}{"ChannelId": "abc", "Since": 100})
+		require.Nil(t, err)
+		require.Equal(t, "SELECT * FROM Posts WHERE ChannelId = $1 AND CreateAt > $2", query)
+		require.Equal(t, []interface{}{"abc", 100}, args)
+	})
+
+	t.Run("repeated param", func(t *testing.T) {
+		query, args, err := bindReportingQueryParams(mysqlBindVar, "SELECT * FROM Users WHERE Username = :Term OR Email = :Term", map[string]interface{}{"Term": "a"})
+		require.Nil(t, err)
+		require.Equal(t, "SELECT * FROM Users WHERE Username = ? OR Email = ?", query)
+		require.Equal(t, []interface{}{"a", "a"}, args)
+	})
+
+	t.Run("quoted strings and casts are ignored", func(t *testing.T) {
+		query, args, err := bindReportingQueryParams(postgresBindVar, "SELECT CreateAt::text FROM Posts WHERE Message = ':Message' AND Id = :Id", map[string]interface{}{"Id": "abc"})
+		require.Nil(t, err)
+		require.Equal(t, "SELECT CreateAt::text FROM Posts WHERE Message = ':Message' AND Id = $1", query)
+		require.Equal(t, []interface{}{"abc"}, args)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		_, _, err := bindReportingQueryParams(mysqlBindVar, "SELECT * FROM Posts WHERE Id = :Id", nil)
+		require.NotNil(t, err)
+	})
+}
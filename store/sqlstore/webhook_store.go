@@ -63,6 +63,7 @@ func NewSqlWebhookStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		tableo.ColMap("TriggerWhen").SetMaxSize(1)
 		tableo.ColMap("Username").SetMaxSize(64)
 		tableo.ColMap("IconURL").SetMaxSize(1024)
+		tableo.ColMap("PayloadTemplate").SetMaxSize(model.OUTGOING_HOOK_PAYLOAD_TEMPLATE_MAX_SIZE)
 	}
 
 	return s
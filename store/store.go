@@ -46,6 +46,14 @@ type Store interface {
 	Group() GroupStore
 	UserTermsOfService() UserTermsOfServiceStore
 	LinkMetadata() LinkMetadataStore
+	PushNotificationQueue() PushNotificationQueueStore
+	ChannelTopicHistory() ChannelTopicHistoryStore
+	TeamMemberHistory() TeamMemberHistoryStore
+	SidebarChannel() SidebarChannelStore
+	Mention() MentionStore
+	PasswordHistory() PasswordHistoryStore
+	SavedPost() SavedPostStore
+	ConfigChangeRequest() ConfigChangeRequestStore
 	MarkSystemRanUnitTests()
 	Close()
 	LockToMaster()
@@ -134,10 +142,12 @@ type ChannelStore interface {
 	GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, *model.AppError)
 	GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, *model.AppError)
 	GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError)
+	GetTeamChannelCount(teamId string) (int64, *model.AppError)
 	GetAll(teamId string) ([]*model.Channel, *model.AppError)
 	GetChannelsByIds(channelIds []string) ([]*model.Channel, *model.AppError)
 	GetForPost(postId string) (*model.Channel, *model.AppError)
 	SaveMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError)
+	SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, *model.AppError)
 	UpdateMember(member *model.ChannelMember) (*model.ChannelMember, *model.AppError)
 	GetMembers(channelId string, offset, limit int) (*model.ChannelMembers, *model.AppError)
 	GetMember(channelId string, userId string) (*model.ChannelMember, *model.AppError)
@@ -162,6 +172,8 @@ type ChannelStore interface {
 	PermanentDeleteMembersByUser(userId string) *model.AppError
 	PermanentDeleteMembersByChannel(channelId string) *model.AppError
 	UpdateLastViewedAt(channelIds []string, userId string) (map[string]int64, *model.AppError)
+	SaveRecentChannel(recent *model.RecentChannel) *model.AppError
+	GetRecentChannels(userId string, teamId string, limit int) ([]*model.RecentChannel, *model.AppError)
 	IncrementMentionCount(channelId string, userId string) *model.AppError
 	AnalyticsTypeCount(teamId string, channelType string) (int64, *model.AppError)
 	GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, *model.AppError)
@@ -217,9 +229,12 @@ type PostStore interface {
 	GetPostIdBeforeTime(channelId string, time int64) (string, *model.AppError)
 	GetEtag(channelId string, allowFromCache bool) string
 	Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, *model.AppError)
+	SearchCount(teamId string, userId string, params *model.SearchParams, excludedPostIds []string) (int64, *model.AppError)
+	SearchPostIds(teamId string, userId string, params *model.SearchParams, limit int) ([]string, *model.AppError)
 	AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, *model.AppError)
 	AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, *model.AppError)
+	GetChannelPostCount(channelId string, since int64, until int64) (int64, *model.AppError)
 	ClearCaches()
 	InvalidateLastPostTimeCache(channelId string)
 	GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, *model.AppError)
@@ -318,6 +333,7 @@ type SessionStore interface {
 	UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, *model.AppError)
 	UpdateProps(session *model.Session) *model.AppError
 	AnalyticsSessionCount() (int64, *model.AppError)
+	GetUserIdsWithPasswordUpdatedBefore(time int64) ([]string, *model.AppError)
 	Cleanup(expiryTime int64, batchSize int64)
 }
 
@@ -344,6 +360,10 @@ type ComplianceStore interface {
 	GetAll(offset, limit int) (model.Compliances, *model.AppError)
 	ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError)
 	MessageExport(after int64, limit int) ([]*model.MessageExport, *model.AppError)
+
+	// ChannelMessageExport returns up to limit posts made in the given channels, or in any channel if none are
+	// given, no later than until. Posts are ordered by creation time and id, starting after the given post.
+	ChannelMessageExport(channelIds []string, afterCreateAt int64, afterPostId string, until int64, limit int) ([]*model.MessageExport, *model.AppError)
 }
 
 type OAuthStore interface {
@@ -372,9 +392,16 @@ type SystemStore interface {
 	Save(system *model.System) *model.AppError
 	SaveOrUpdate(system *model.System) *model.AppError
 	Update(system *model.System) *model.AppError
+	// UpdateOptimistically updates the system value only if it is still currentValue, returning
+	// whether it was updated.
+	UpdateOptimistically(system *model.System, currentValue string) (bool, *model.AppError)
 	Get() (model.StringMap, *model.AppError)
 	GetByName(name string) (*model.System, *model.AppError)
 	PermanentDeleteByName(name string) (*model.System, *model.AppError)
+	// RunReportingQuery returns a row per result, keyed by lower cased column name.
+	RunReportingQuery(query string, params map[string]interface{}) ([]map[string]interface{}, *model.AppError)
+	GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError)
+	GetOpenTransactions() ([]*model.DatabaseTransaction, *model.AppError)
 }
 
 type WebhookStore interface {
@@ -458,7 +485,7 @@ type EmojiStore interface {
 	Get(id string, allowFromCache bool) (*model.Emoji, *model.AppError)
 	GetByName(name string, allowFromCache bool) (*model.Emoji, *model.AppError)
 	GetMultipleByName(names []string) ([]*model.Emoji, *model.AppError)
-	GetList(offset, limit int, sort string) ([]*model.Emoji, *model.AppError)
+	GetList(offset, limit int, sort string, opts model.EmojiListOpts) ([]*model.Emoji, *model.AppError)
 	Delete(emoji *model.Emoji, time int64) *model.AppError
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError)
 }
@@ -489,6 +516,7 @@ type FileInfoStore interface {
 
 type ReactionStore interface {
 	Save(reaction *model.Reaction) (*model.Reaction, *model.AppError)
+	SaveMultiple(reactions []*model.Reaction) (*model.BulkReactionsResult, *model.AppError)
 	Delete(reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, *model.AppError)
 	DeleteAllWithEmojiName(emojiName string) *model.AppError
@@ -537,6 +565,7 @@ type PluginStore interface {
 
 type RoleStore interface {
 	Save(role *model.Role) (*model.Role, *model.AppError)
+	UpdateMultiple(roles []*model.Role) ([]*model.Role, *model.AppError)
 	Get(roleId string) (*model.Role, *model.AppError)
 	GetAll() ([]*model.Role, *model.AppError)
 	GetByName(name string) (*model.Role, *model.AppError)
@@ -547,6 +576,11 @@ type RoleStore interface {
 
 type SchemeStore interface {
 	Save(scheme *model.Scheme) (*model.Scheme, *model.AppError)
+
+	// SaveWithRolePermissions creates a new scheme in a single transaction with its roles. Each role is
+	// given the permissions in rolePermissions keyed by the name of the default role it is based on, if
+	// any, rather than those of the default role.
+	SaveWithRolePermissions(scheme *model.Scheme, rolePermissions map[string][]string) (*model.Scheme, *model.AppError)
 	Get(schemeId string) (*model.Scheme, *model.AppError)
 	GetByName(schemeName string) (*model.Scheme, *model.AppError)
 	GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError)
@@ -612,6 +646,65 @@ type LinkMetadataStore interface {
 	Get(url string, timestamp int64) (*model.LinkMetadata, *model.AppError)
 }
 
+type PushNotificationQueueStore interface {
+	Save(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError)
+	GetBatch(limit int) ([]*model.PushNotificationQueueItem, *model.AppError)
+	Update(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError)
+	Delete(id string) *model.AppError
+	DeleteExpired(expiryTime int64) (int64, *model.AppError)
+	Count() (int64, *model.AppError)
+}
+
+type ChannelTopicHistoryStore interface {
+	Save(history *model.ChannelTopicHistory) (*model.ChannelTopicHistory, *model.AppError)
+	GetForChannel(channelId string, limit int) ([]*model.ChannelTopicHistory, *model.AppError)
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError)
+}
+
+type TeamMemberHistoryStore interface {
+	LogJoinEvent(userId string, teamId string, joinTime int64) *model.AppError
+	LogLeaveEvent(userId string, teamId string, leaveTime int64) *model.AppError
+	GetForTeamDuring(teamId string, startTime int64, endTime int64) ([]*model.TeamMemberHistory, *model.AppError)
+}
+
+type SidebarChannelStore interface {
+	GetForTeam(userId string, teamId string) ([]*model.SidebarChannel, *model.AppError)
+	SaveOrder(userId string, teamId string, channelIds []string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type MentionStore interface {
+	SaveMultiple(mentions []*model.Mention) *model.AppError
+	GetPostsForUser(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError)
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type PasswordHistoryStore interface {
+	Save(history *model.PasswordHistory) *model.AppError
+	GetForUser(userId string, limit int) ([]*model.PasswordHistory, *model.AppError)
+	PruneForUser(userId string, keep int) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+}
+
+type SavedPostStore interface {
+	Save(savedPost *model.SavedPost) (*model.SavedPost, *model.AppError)
+	Get(userId, postId string) (*model.SavedPost, *model.AppError)
+	GetForUser(userId string, offset, limit int) ([]*model.SavedPost, *model.AppError)
+	Delete(userId, postId string) *model.AppError
+	PermanentDeleteByUser(userId string) *model.AppError
+	PermanentDeleteByPost(postId string) *model.AppError
+	MigrateFlaggedPostPreferences() (int64, *model.AppError)
+	CleanupBatch(limit int64) (int64, *model.AppError)
+}
+
+type ConfigChangeRequestStore interface {
+	Save(request *model.ConfigChangeRequest) (*model.ConfigChangeRequest, *model.AppError)
+	Get(id string) (*model.ConfigChangeRequest, *model.AppError)
+	GetPending() ([]*model.ConfigChangeRequest, *model.AppError)
+	Review(id, status, reviewedBy string) *model.AppError
+	Reopen(id string) *model.AppError
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
@@ -51,6 +51,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetDeleted", func(t *testing.T) { testChannelStoreGetDeleted(t, ss) })
 	t.Run("ChannelMemberStore", func(t *testing.T) { testChannelMemberStore(t, ss) })
 	t.Run("ChannelDeleteMemberStore", func(t *testing.T) { testChannelDeleteMemberStore(t, ss) })
+	t.Run("SaveMultipleMembers", func(t *testing.T) { testChannelStoreSaveMultipleMembers(t, ss) })
 	t.Run("GetChannels", func(t *testing.T) { testChannelStoreGetChannels(t, ss) })
 	t.Run("GetAllChannels", func(t *testing.T) { testChannelStoreGetAllChannels(t, ss, s) })
 	t.Run("GetMoreChannels", func(t *testing.T) { testChannelStoreGetMoreChannels(t, ss) })
@@ -60,6 +61,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetMembersForUser", func(t *testing.T) { testChannelStoreGetMembersForUser(t, ss) })
 	t.Run("GetMembersForUserWithPagination", func(t *testing.T) { testChannelStoreGetMembersForUserWithPagination(t, ss) })
 	t.Run("UpdateLastViewedAt", func(t *testing.T) { testChannelStoreUpdateLastViewedAt(t, ss) })
+	t.Run("RecentChannels", func(t *testing.T) { testChannelStoreRecentChannels(t, ss) })
 	t.Run("IncrementMentionCount", func(t *testing.T) { testChannelStoreIncrementMentionCount(t, ss) })
 	t.Run("UpdateChannelMember", func(t *testing.T) { testUpdateChannelMember(t, ss) })
 	t.Run("GetMember", func(t *testing.T) { testGetMember(t, ss) })
@@ -77,6 +79,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetPinnedPosts", func(t *testing.T) { testChannelStoreGetPinnedPosts(t, ss) })
 	t.Run("GetPinnedPostCount", func(t *testing.T) { testChannelStoreGetPinnedPostCount(t, ss) })
 	t.Run("MaxChannelsPerTeam", func(t *testing.T) { testChannelStoreMaxChannelsPerTeam(t, ss) })
+	t.Run("GetTeamChannelCount", func(t *testing.T) { testChannelStoreGetTeamChannelCount(t, ss) })
 	t.Run("GetChannelsByScheme", func(t *testing.T) { testChannelStoreGetChannelsByScheme(t, ss) })
 	t.Run("MigrateChannelMembers", func(t *testing.T) { testChannelStoreMigrateChannelMembers(t, ss) })
 	t.Run("ResetAllChannelSchemes", func(t *testing.T) { testResetAllChannelSchemes(t, ss) })
@@ -965,6 +968,64 @@ func testChannelMemberStore(t *testing.T, ss store.Store) {
 	assert.EqualValues(t, 0, c1t4.ExtraUpdateAt, "ExtraUpdateAt should be 0")
 }
 
+func testChannelStoreSaveMultipleMembers(t *testing.T, ss store.Store) {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Bulk",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	saved, err := ss.Channel().SaveMultipleMembers(nil)
+	require.Nil(t, err)
+	assert.Empty(t, saved)
+
+	var members []*model.ChannelMember
+	for i := 0; i < 3; i++ {
+		members = append(members, &model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      model.NewId(),
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+			SchemeUser:  true,
+			SchemeAdmin: i == 0,
+		})
+	}
+
+	saved, err = ss.Channel().SaveMultipleMembers(members)
+	require.Nil(t, err)
+	assert.Equal(t, members, saved)
+
+	count, err := ss.Channel().GetMemberCount(channel.Id, false)
+	require.Nil(t, err)
+	assert.EqualValues(t, 3, count)
+
+	member, err := ss.Channel().GetMember(channel.Id, members[0].UserId)
+	require.Nil(t, err)
+	assert.True(t, member.SchemeAdmin)
+	assert.Equal(t, model.GetDefaultChannelNotifyProps(), member.NotifyProps)
+
+	t.Run("existing member", func(t *testing.T) {
+		newMember := &model.ChannelMember{ChannelId: channel.Id, UserId: model.NewId(), NotifyProps: model.GetDefaultChannelNotifyProps(), SchemeUser: true}
+		saved, err := ss.Channel().SaveMultipleMembers([]*model.ChannelMember{
+			newMember,
+			{ChannelId: channel.Id, UserId: members[1].UserId, NotifyProps: model.GetDefaultChannelNotifyProps(), SchemeUser: true},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, []*model.ChannelMember{newMember}, saved)
+
+		count, err := ss.Channel().GetMemberCount(channel.Id, false)
+		require.Nil(t, err)
+		assert.EqualValues(t, 4, count)
+
+		saved, err = ss.Channel().SaveMultipleMembers([]*model.ChannelMember{
+			{ChannelId: channel.Id, UserId: members[2].UserId, NotifyProps: model.GetDefaultChannelNotifyProps(), SchemeUser: true},
+		})
+		require.Nil(t, err)
+		assert.Empty(t, saved)
+	})
+}
+
 func testChannelDeleteMemberStore(t *testing.T, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -1814,6 +1875,86 @@ func testChannelStoreUpdateLastViewedAt(t *testing.T, ss store.Store) {
 	}
 }
 
+func testChannelStoreRecentChannels(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId1 := model.NewId()
+	teamId2 := model.NewId()
+
+	var channels []*model.Channel
+	for _, teamId := range []string{teamId1, teamId2, teamId1} {
+		channel := &model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Channel",
+			Name:        "zz" + model.NewId() + "b",
+			Type:        model.CHANNEL_OPEN,
+		}
+		_, err := ss.Channel().Save(channel, -1)
+		require.Nil(t, err)
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.Nil(t, err)
+
+		channels = append(channels, channel)
+	}
+	c1, c2, c3 := channels[0], channels[1], channels[2]
+
+	err := ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: "junk", LastViewedAt: 100})
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: c1.Id, LastViewedAt: 100}))
+	require.Nil(t, ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: c2.Id, LastViewedAt: 200}))
+	require.Nil(t, ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: c3.Id, LastViewedAt: 300}))
+
+	// Only the latest visit to each channel is kept
+	require.Nil(t, ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: c1.Id, LastViewedAt: 400}))
+	require.Nil(t, ss.Channel().SaveRecentChannel(&model.RecentChannel{UserId: userId, ChannelId: c3.Id, LastViewedAt: 50}))
+
+	t.Run("all teams", func(t *testing.T) {
+		recent, err := ss.Channel().GetRecentChannels(userId, "", 10)
+		require.Nil(t, err)
+		require.Equal(t, []*model.RecentChannel{
+			{UserId: userId, ChannelId: c1.Id, LastViewedAt: 400},
+			{UserId: userId, ChannelId: c3.Id, LastViewedAt: 300},
+			{UserId: userId, ChannelId: c2.Id, LastViewedAt: 200},
+		}, recent)
+	})
+
+	t.Run("single team", func(t *testing.T) {
+		recent, err := ss.Channel().GetRecentChannels(userId, teamId1, 10)
+		require.Nil(t, err)
+		require.Len(t, recent, 2)
+		assert.Equal(t, c1.Id, recent[0].ChannelId)
+		assert.Equal(t, c3.Id, recent[1].ChannelId)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		recent, err := ss.Channel().GetRecentChannels(userId, "", 1)
+		require.Nil(t, err)
+		require.Len(t, recent, 1)
+		assert.Equal(t, c1.Id, recent[0].ChannelId)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		recent, err := ss.Channel().GetRecentChannels(model.NewId(), "", 10)
+		require.Nil(t, err)
+		assert.Empty(t, recent)
+	})
+
+	t.Run("left and deleted channels are omitted", func(t *testing.T) {
+		require.Nil(t, ss.Channel().RemoveMember(c3.Id, userId))
+		require.Nil(t, ss.Channel().Delete(c2.Id, model.GetMillis()))
+
+		recent, err := ss.Channel().GetRecentChannels(userId, "", 10)
+		require.Nil(t, err)
+		require.Len(t, recent, 1)
+		assert.Equal(t, c1.Id, recent[0].ChannelId)
+	})
+}
+
 func testChannelStoreIncrementMentionCount(t *testing.T, ss store.Store) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
@@ -3468,6 +3609,47 @@ func testChannelStoreMaxChannelsPerTeam(t *testing.T, ss store.Store) {
 	assert.Nil(t, err)
 }
 
+func testChannelStoreGetTeamChannelCount(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	count, err := ss.Channel().GetTeamChannelCount(teamId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	for _, channelType := range []string{model.CHANNEL_OPEN, model.CHANNEL_PRIVATE, model.CHANNEL_OPEN} {
+		_, err = ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Channel",
+			Name:        model.NewId(),
+			Type:        channelType,
+		}, -1)
+		require.Nil(t, err)
+	}
+
+	// Channels on other teams are not counted.
+	_, err = ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Channel",
+		Name:        model.NewId(),
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	// Archived channels are not counted.
+	archived, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel",
+		Name:        model.NewId(),
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	count, err = ss.Channel().GetTeamChannelCount(teamId)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
 func testChannelStoreGetChannelsByScheme(t *testing.T, ss store.Store) {
 	// Create some schemes.
 	s1 := &model.Scheme{
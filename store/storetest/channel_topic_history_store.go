@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestChannelTopicHistoryStore(t *testing.T, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testChannelTopicHistoryStoreSave(t, ss) })
+	t.Run("GetForChannel", func(t *testing.T) { testChannelTopicHistoryStoreGetForChannel(t, ss) })
+	t.Run("PermanentDeleteBatch", func(t *testing.T) { testChannelTopicHistoryStorePermanentDeleteBatch(t, ss) })
+}
+
+func makeChannelTopicHistory(channelId string, createAt int64) *model.ChannelTopicHistory {
+	return &model.ChannelTopicHistory{
+		ChannelId:   channelId,
+		Type:        model.CHANNEL_TOPIC_TYPE_HEADER,
+		Topic:       "topic " + model.NewId(),
+		SetByUserId: model.NewId(),
+		CreateAt:    createAt,
+	}
+}
+
+func testChannelTopicHistoryStoreSave(t *testing.T, ss store.Store) {
+	t.Run("should save history", func(t *testing.T) {
+		history, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(model.NewId(), 0))
+		require.Nil(t, err)
+
+		assert.Len(t, history.Id, 26)
+		assert.NotZero(t, history.CreateAt)
+	})
+
+	t.Run("should fail to save invalid history", func(t *testing.T) {
+		history := makeChannelTopicHistory(model.NewId(), 0)
+		history.Type = ""
+
+		_, err := ss.ChannelTopicHistory().Save(history)
+		assert.NotNil(t, err)
+	})
+}
+
+func testChannelTopicHistoryStoreGetForChannel(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	first, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now-2000))
+	require.Nil(t, err)
+	second, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now-1000))
+	require.Nil(t, err)
+	third, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now))
+	require.Nil(t, err)
+	_, err = ss.ChannelTopicHistory().Save(makeChannelTopicHistory(model.NewId(), now))
+	require.Nil(t, err)
+
+	t.Run("should return newest first", func(t *testing.T) {
+		list, err := ss.ChannelTopicHistory().GetForChannel(channelId, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.ChannelTopicHistory{third, second, first}, list)
+	})
+
+	t.Run("should respect the limit", func(t *testing.T) {
+		list, err := ss.ChannelTopicHistory().GetForChannel(channelId, 2)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.ChannelTopicHistory{third, second}, list)
+	})
+
+	t.Run("should return an empty list for a channel without history", func(t *testing.T) {
+		list, err := ss.ChannelTopicHistory().GetForChannel(model.NewId(), 10)
+		require.Nil(t, err)
+		assert.Empty(t, list)
+	})
+}
+
+func testChannelTopicHistoryStorePermanentDeleteBatch(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	now := model.GetMillis()
+
+	_, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now-3000))
+	require.Nil(t, err)
+	_, err = ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now-2000))
+	require.Nil(t, err)
+	recent, err := ss.ChannelTopicHistory().Save(makeChannelTopicHistory(channelId, now))
+	require.Nil(t, err)
+
+	deleted, err := ss.ChannelTopicHistory().PermanentDeleteBatch(now-1000, 1)
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = ss.ChannelTopicHistory().PermanentDeleteBatch(now-1000, 1000)
+	require.Nil(t, err)
+	assert.True(t, deleted >= 1)
+
+	list, err := ss.ChannelTopicHistory().GetForChannel(channelId, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.ChannelTopicHistory{recent}, list)
+}
@@ -21,6 +21,7 @@ func TestComplianceStore(t *testing.T, ss store.Store) {
 	t.Run("MessageExportPrivateChannel", func(t *testing.T) { testMessageExportPrivateChannel(t, ss) })
 	t.Run("MessageExportDirectMessageChannel", func(t *testing.T) { testMessageExportDirectMessageChannel(t, ss) })
 	t.Run("MessageExportGroupMessageChannel", func(t *testing.T) { testMessageExportGroupMessageChannel(t, ss) })
+	t.Run("ChannelMessageExport", func(t *testing.T) { testChannelMessageExport(t, ss) })
 }
 
 func testComplianceStore(t *testing.T, ss store.Store) {
@@ -637,3 +638,85 @@ func testMessageExportGroupMessageChannel(t *testing.T, ss store.Store) {
 	assert.Equal(t, user1.Email, *messageExportMap[post.Id].UserEmail)
 	assert.Equal(t, user1.Username, *messageExportMap[post.Id].Username)
 }
+
+func testChannelMessageExport(t *testing.T, ss store.Store) {
+	startTime := model.GetMillis()
+
+	user := &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewId(),
+	}
+	user, err := ss.User().Save(user)
+	require.Nil(t, err)
+
+	channel1, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		Name:        model.NewId(),
+		DisplayName: "Channel 1",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	channel2, err := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		Name:        model.NewId(),
+		DisplayName: "Channel 2",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	// two posts share a creation time, so paging must also order by id
+	var posts []*model.Post
+	for _, createAt := range []int64{startTime, startTime + 10, startTime + 10, startTime + 20} {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channel1.Id,
+			UserId:    user.Id,
+			CreateAt:  createAt,
+			Message:   "zz" + model.NewId() + "a",
+		})
+		require.Nil(t, err)
+		posts = append(posts, post)
+	}
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: channel2.Id,
+		UserId:    user.Id,
+		CreateAt:  startTime + 10,
+		Message:   "zz" + model.NewId() + "b",
+	})
+	require.Nil(t, err)
+
+	// read everything up to startTime+10 from channel1, two posts at a time
+	var exported []string
+	afterCreateAt, afterPostId := startTime, ""
+	for {
+		messages, err := ss.Compliance().ChannelMessageExport([]string{channel1.Id}, afterCreateAt, afterPostId, startTime+10, 2)
+		require.Nil(t, err)
+
+		for _, message := range messages {
+			assert.Equal(t, channel1.Id, *message.ChannelId)
+			exported = append(exported, *message.PostId)
+		}
+
+		if len(messages) < 2 {
+			break
+		}
+
+		afterCreateAt, afterPostId = *messages[len(messages)-1].PostCreateAt, *messages[len(messages)-1].PostId
+	}
+
+	assert.Len(t, exported, 3)
+	assert.Contains(t, exported, posts[0].Id)
+	assert.Contains(t, exported, posts[1].Id)
+	assert.Contains(t, exported, posts[2].Id)
+
+	// without channels, posts from every channel are returned
+	messages, err := ss.Compliance().ChannelMessageExport(nil, startTime+10, "", startTime+10, 100)
+	require.Nil(t, err)
+	channelIds := map[string]int{}
+	for _, message := range messages {
+		channelIds[*message.ChannelId]++
+	}
+	assert.Equal(t, 2, channelIds[channel1.Id])
+	assert.Equal(t, 1, channelIds[channel2.Id])
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestConfigChangeRequestStore(t *testing.T, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testConfigChangeRequestStoreSaveAndGet(t, ss) })
+	t.Run("GetPending", func(t *testing.T) { testConfigChangeRequestStoreGetPending(t, ss) })
+	t.Run("Review", func(t *testing.T) { testConfigChangeRequestStoreReview(t, ss) })
+	t.Run("Reopen", func(t *testing.T) { testConfigChangeRequestStoreReopen(t, ss) })
+}
+
+func makeConfigChangeRequest(t *testing.T, ss store.Store, siteURL string) *model.ConfigChangeRequest {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.ServiceSettings.SiteURL = siteURL
+
+	request, err := ss.ConfigChangeRequest().Save(&model.ConfigChangeRequest{ProposedConfig: cfg, ProposedBy: model.NewId(), BaseConfigHash: model.NewId()})
+	require.Nil(t, err)
+
+	return request
+}
+
+func testConfigChangeRequestStoreSaveAndGet(t *testing.T, ss store.Store) {
+	request := makeConfigChangeRequest(t, ss, "http://proposed.example.com")
+	assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_PENDING, request.Status)
+	assert.NotZero(t, request.CreateAt)
+
+	received, err := ss.ConfigChangeRequest().Get(request.Id)
+	require.Nil(t, err)
+	assert.Equal(t, request.ProposedBy, received.ProposedBy)
+	assert.Equal(t, request.BaseConfigHash, received.BaseConfigHash)
+	assert.Equal(t, "http://proposed.example.com", *received.ProposedConfig.ServiceSettings.SiteURL)
+	assert.Equal(t, request.ProposedConfig, received.ProposedConfig)
+
+	_, err = ss.ConfigChangeRequest().Get(model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	_, err = ss.ConfigChangeRequest().Save(&model.ConfigChangeRequest{ProposedBy: model.NewId()})
+	assert.NotNil(t, err)
+}
+
+func testConfigChangeRequestStoreGetPending(t *testing.T, ss store.Store) {
+	first := makeConfigChangeRequest(t, ss, "http://first.example.com")
+	second := makeConfigChangeRequest(t, ss, "http://second.example.com")
+	reviewed := makeConfigChangeRequest(t, ss, "http://reviewed.example.com")
+	require.Nil(t, ss.ConfigChangeRequest().Review(reviewed.Id, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, model.NewId()))
+
+	pending, err := ss.ConfigChangeRequest().GetPending()
+	require.Nil(t, err)
+
+	var ids []string
+	for _, request := range pending {
+		assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_PENDING, request.Status)
+		ids = append(ids, request.Id)
+	}
+	assert.Contains(t, ids, first.Id)
+	assert.Contains(t, ids, second.Id)
+	assert.NotContains(t, ids, reviewed.Id)
+}
+
+func testConfigChangeRequestStoreReview(t *testing.T, ss store.Store) {
+	request := makeConfigChangeRequest(t, ss, "http://review.example.com")
+	reviewerId := model.NewId()
+
+	require.Nil(t, ss.ConfigChangeRequest().Review(request.Id, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, reviewerId))
+
+	received, err := ss.ConfigChangeRequest().Get(request.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, received.Status)
+	assert.Equal(t, reviewerId, received.ReviewedBy)
+
+	err = ss.ConfigChangeRequest().Review(request.Id, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_config_change_request.review.not_pending.app_error", err.Id)
+
+	received, err = ss.ConfigChangeRequest().Get(request.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, received.Status)
+}
+
+func testConfigChangeRequestStoreReopen(t *testing.T, ss store.Store) {
+	request := makeConfigChangeRequest(t, ss, "http://reopen.example.com")
+	require.Nil(t, ss.ConfigChangeRequest().Review(request.Id, model.CONFIG_CHANGE_REQUEST_STATUS_APPROVED, model.NewId()))
+
+	require.Nil(t, ss.ConfigChangeRequest().Reopen(request.Id))
+
+	received, err := ss.ConfigChangeRequest().Get(request.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_PENDING, received.Status)
+	assert.Empty(t, received.ReviewedBy)
+
+	rejected := makeConfigChangeRequest(t, ss, "http://rejected.example.com")
+	require.Nil(t, ss.ConfigChangeRequest().Review(rejected.Id, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, model.NewId()))
+
+	require.Nil(t, ss.ConfigChangeRequest().Reopen(rejected.Id))
+
+	received, err = ss.ConfigChangeRequest().Get(rejected.Id)
+	require.Nil(t, err)
+	assert.Equal(t, model.CONFIG_CHANGE_REQUEST_STATUS_REJECTED, received.Status)
+}
@@ -279,7 +279,7 @@ func testEmojiGetList(t *testing.T, ss store.Store) {
 		}
 	}()
 
-	if result, err := ss.Emoji().GetList(0, 100, ""); err != nil {
+	if result, err := ss.Emoji().GetList(0, 100, "", model.EmojiListOpts{}); err != nil {
 		t.Fatal(err)
 	} else {
 		for _, emoji := range emojis {
@@ -298,19 +298,38 @@ func testEmojiGetList(t *testing.T, ss store.Store) {
 		}
 	}
 
-	remojis, err := ss.Emoji().GetList(0, 3, model.EMOJI_SORT_BY_NAME)
+	remojis, err := ss.Emoji().GetList(0, 3, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{})
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(remojis))
 	assert.Equal(t, emojis[0].Name, remojis[0].Name)
 	assert.Equal(t, emojis[1].Name, remojis[1].Name)
 	assert.Equal(t, emojis[2].Name, remojis[2].Name)
 
-	remojis, err = ss.Emoji().GetList(1, 2, model.EMOJI_SORT_BY_NAME)
+	remojis, err = ss.Emoji().GetList(1, 2, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{})
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(remojis))
 	assert.Equal(t, emojis[1].Name, remojis[0].Name)
 	assert.Equal(t, emojis[2].Name, remojis[1].Name)
 
+	t.Run("filter by creator", func(t *testing.T) {
+		remojis, err := ss.Emoji().GetList(0, 100, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{CreatorId: emojis[1].CreatorId})
+		require.Nil(t, err)
+		require.Len(t, remojis, 1)
+		assert.Equal(t, emojis[1].Id, remojis[0].Id)
+	})
+
+	t.Run("filter by prefix", func(t *testing.T) {
+		remojis, err := ss.Emoji().GetList(0, 100, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{Prefix: emojis[2].Name[:30]})
+		require.Nil(t, err)
+		require.Len(t, remojis, 1)
+		assert.Equal(t, emojis[2].Id, remojis[0].Id)
+	})
+
+	t.Run("prefix should escape wildcards", func(t *testing.T) {
+		remojis, err := ss.Emoji().GetList(0, 100, model.EMOJI_SORT_BY_NAME, model.EmojiListOpts{Prefix: "%"})
+		require.Nil(t, err)
+		assert.Len(t, remojis, 0)
+	})
 }
 
 func testEmojiSearch(t *testing.T, ss store.Store) {
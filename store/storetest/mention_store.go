@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestMentionStore(t *testing.T, ss store.Store) {
+	t.Run("SaveMultiple", func(t *testing.T) { testMentionStoreSaveMultiple(t, ss) })
+	t.Run("GetPostsForUser", func(t *testing.T) { testMentionStoreGetPostsForUser(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testMentionStorePermanentDeleteByUser(t, ss) })
+}
+
+func makeMentionedPost(t *testing.T, ss store.Store, channel *model.Channel, userIds []string, createAt int64) *model.Post {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: channel.Id,
+		UserId:    model.NewId(),
+		Message:   "mention " + model.NewId(),
+		CreateAt:  createAt,
+	})
+	require.Nil(t, err)
+
+	var mentions []*model.Mention
+	for _, userId := range userIds {
+		mentions = append(mentions, &model.Mention{
+			UserId:    userId,
+			PostId:    post.Id,
+			ChannelId: channel.Id,
+			TeamId:    channel.TeamId,
+			CreateAt:  post.CreateAt,
+		})
+	}
+	require.Nil(t, ss.Mention().SaveMultiple(mentions))
+
+	return post
+}
+
+func testMentionStoreSaveMultiple(t *testing.T, ss store.Store) {
+	firstUserId := model.NewId()
+	channel := makeChannelWithMember(t, ss, model.NewId(), firstUserId)
+
+	// More mentions than are inserted in a single statement.
+	userIds := []string{firstUserId}
+	for len(userIds) < 2501 {
+		userIds = append(userIds, model.NewId())
+	}
+
+	lastUserId := userIds[len(userIds)-1]
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      lastUserId,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.Nil(t, err)
+
+	post := makeMentionedPost(t, ss, channel, userIds, 1000)
+
+	for _, userId := range []string{firstUserId, lastUserId} {
+		postList, err := ss.Mention().GetPostsForUser(userId, "", 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{post.Id}, postList.Order)
+	}
+}
+
+func testMentionStoreGetPostsForUser(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	channel := makeChannelWithMember(t, ss, teamId, userId)
+	otherTeamChannel := makeChannelWithMember(t, ss, model.NewId(), userId)
+	leftChannel := makeChannelWithMember(t, ss, teamId, userId)
+
+	dm, err := ss.Channel().CreateDirectChannel(&model.User{Id: userId}, &model.User{Id: otherUserId})
+	require.Nil(t, err)
+
+	p1 := makeMentionedPost(t, ss, channel, []string{userId, otherUserId}, 1000)
+	p2 := makeMentionedPost(t, ss, otherTeamChannel, []string{userId}, 2000)
+	p3 := makeMentionedPost(t, ss, dm, []string{userId}, 3000)
+	p4 := makeMentionedPost(t, ss, channel, []string{userId}, 4000)
+	makeMentionedPost(t, ss, channel, []string{otherUserId}, 5000)
+	makeMentionedPost(t, ss, leftChannel, []string{userId}, 6000)
+
+	require.Nil(t, ss.Channel().RemoveMember(leftChannel.Id, userId))
+	err = ss.Post().Delete(p4.Id, model.GetMillis(), userId)
+	require.Nil(t, err)
+
+	t.Run("all teams", func(t *testing.T) {
+		postList, err := ss.Mention().GetPostsForUser(userId, "", 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{p3.Id, p2.Id, p1.Id}, postList.Order)
+	})
+
+	t.Run("single team", func(t *testing.T) {
+		postList, err := ss.Mention().GetPostsForUser(userId, teamId, 0, 10)
+		require.Nil(t, err)
+		assert.Equal(t, []string{p3.Id, p1.Id}, postList.Order)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		postList, err := ss.Mention().GetPostsForUser(userId, "", 1, 1)
+		require.Nil(t, err)
+		assert.Equal(t, []string{p2.Id}, postList.Order)
+		assert.Equal(t, p2.Message, postList.Posts[p2.Id].Message)
+	})
+}
+
+func testMentionStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	channel := makeChannelWithMember(t, ss, model.NewId(), userId)
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      otherUserId,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.Nil(t, err)
+
+	makeMentionedPost(t, ss, channel, []string{userId, otherUserId}, 1000)
+
+	require.Nil(t, ss.Mention().PermanentDeleteByUser(userId))
+
+	postList, err := ss.Mention().GetPostsForUser(userId, "", 0, 10)
+	require.Nil(t, err)
+	assert.Empty(t, postList.Order)
+
+	postList, err = ss.Mention().GetPostsForUser(otherUserId, "", 0, 10)
+	require.Nil(t, err)
+	assert.Len(t, postList.Order, 1)
+}
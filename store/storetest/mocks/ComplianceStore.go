@@ -14,6 +14,31 @@ type ComplianceStore struct {
 	mock.Mock
 }
 
+// ChannelMessageExport provides a mock function with given fields: channelIds, afterCreateAt, afterPostId, until, limit
+func (_m *ComplianceStore) ChannelMessageExport(channelIds []string, afterCreateAt int64, afterPostId string, until int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	ret := _m.Called(channelIds, afterCreateAt, afterPostId, until, limit)
+
+	var r0 []*model.MessageExport
+	if rf, ok := ret.Get(0).(func([]string, int64, string, int64, int) []*model.MessageExport); ok {
+		r0 = rf(channelIds, afterCreateAt, afterPostId, until, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MessageExport)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]string, int64, string, int64, int) *model.AppError); ok {
+		r1 = rf(channelIds, afterCreateAt, afterPostId, until, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ComplianceExport provides a mock function with given fields: compliance
 func (_m *ComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
 	ret := _m.Called(compliance)
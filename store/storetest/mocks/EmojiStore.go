@@ -80,13 +80,13 @@ func (_m *EmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji,
 	return r0, r1
 }
 
-// GetList provides a mock function with given fields: offset, limit, sort
-func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
-	ret := _m.Called(offset, limit, sort)
+// GetList provides a mock function with given fields: offset, limit, sort, opts
+func (_m *EmojiStore) GetList(offset int, limit int, sort string, opts model.EmojiListOpts) ([]*model.Emoji, *model.AppError) {
+	ret := _m.Called(offset, limit, sort, opts)
 
 	var r0 []*model.Emoji
-	if rf, ok := ret.Get(0).(func(int, int, string) []*model.Emoji); ok {
-		r0 = rf(offset, limit, sort)
+	if rf, ok := ret.Get(0).(func(int, int, string, model.EmojiListOpts) []*model.Emoji); ok {
+		r0 = rf(offset, limit, sort, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Emoji)
@@ -94,8 +94,8 @@ func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoj
 	}
 
 	var r1 *model.AppError
-	if rf, ok := ret.Get(1).(func(int, int, string) *model.AppError); ok {
-		r1 = rf(offset, limit, sort)
+	if rf, ok := ret.Get(1).(func(int, int, string, model.EmojiListOpts) *model.AppError); ok {
+		r1 = rf(offset, limit, sort, opts)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.AppError)
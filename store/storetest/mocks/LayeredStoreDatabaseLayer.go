@@ -78,6 +78,22 @@ func (_m *LayeredStoreDatabaseLayer) ChannelMemberHistory() store.ChannelMemberH
 	return r0
 }
 
+// ChannelTopicHistory provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ChannelTopicHistory() store.ChannelTopicHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTopicHistoryStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTopicHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTopicHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // CheckIntegrity provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	ret := _m.Called()
@@ -163,6 +179,22 @@ func (_m *LayeredStoreDatabaseLayer) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConfigChangeRequest provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) ConfigChangeRequest() store.ConfigChangeRequestStore {
+	ret := _m.Called()
+
+	var r0 store.ConfigChangeRequestStore
+	if rf, ok := ret.Get(0).(func() store.ConfigChangeRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConfigChangeRequestStore)
+		}
+	}
+
+	return r0
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) DropAllTables() {
 	_m.Called()
@@ -288,6 +320,22 @@ func (_m *LayeredStoreDatabaseLayer) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// Mention provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) Mention() store.MentionStore {
+	ret := _m.Called()
+
+	var r0 store.MentionStore
+	if rf, ok := ret.Get(0).(func() store.MentionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MentionStore)
+		}
+	}
+
+	return r0
+}
+
 // Next provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Next() store.LayeredStoreSupplier {
 	ret := _m.Called()
@@ -320,6 +368,22 @@ func (_m *LayeredStoreDatabaseLayer) OAuth() store.OAuthStore {
 	return r0
 }
 
+// PasswordHistory provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PasswordHistory() store.PasswordHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.PasswordHistoryStore
+	if rf, ok := ret.Get(0).(func() store.PasswordHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PasswordHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -368,6 +432,22 @@ func (_m *LayeredStoreDatabaseLayer) Preference() store.PreferenceStore {
 	return r0
 }
 
+// PushNotificationQueue provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) PushNotificationQueue() store.PushNotificationQueueStore {
+	ret := _m.Called()
+
+	var r0 store.PushNotificationQueueStore
+	if rf, ok := ret.Get(0).(func() store.PushNotificationQueueStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PushNotificationQueueStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -400,6 +480,22 @@ func (_m *LayeredStoreDatabaseLayer) Role() store.RoleStore {
 	return r0
 }
 
+// SavedPost provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) SavedPost() store.SavedPostStore {
+	ret := _m.Called()
+
+	var r0 store.SavedPostStore
+	if rf, ok := ret.Get(0).(func() store.SavedPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedPostStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -437,6 +533,22 @@ func (_m *LayeredStoreDatabaseLayer) SetChainNext(_a0 store.LayeredStoreSupplier
 	_m.Called(_a0)
 }
 
+// SidebarChannel provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) SidebarChannel() store.SidebarChannelStore {
+	ret := _m.Called()
+
+	var r0 store.SidebarChannelStore
+	if rf, ok := ret.Get(0).(func() store.SidebarChannelStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SidebarChannelStore)
+		}
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) Status() store.StatusStore {
 	ret := _m.Called()
@@ -485,6 +597,22 @@ func (_m *LayeredStoreDatabaseLayer) Team() store.TeamStore {
 	return r0
 }
 
+// TeamMemberHistory provides a mock function with given fields:
+func (_m *LayeredStoreDatabaseLayer) TeamMemberHistory() store.TeamMemberHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.TeamMemberHistoryStore
+	if rf, ok := ret.Get(0).(func() store.TeamMemberHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TeamMemberHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // TermsOfService provides a mock function with given fields:
 func (_m *LayeredStoreDatabaseLayer) TermsOfService() store.TermsOfServiceStore {
 	ret := _m.Called()
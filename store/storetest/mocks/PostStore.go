@@ -133,6 +133,29 @@ func (_m *PostStore) Get(id string, skipFetchThreads bool) (*model.PostList, *mo
 	return r0, r1
 }
 
+// GetChannelPostCount provides a mock function with given fields: channelId, since, until
+func (_m *PostStore) GetChannelPostCount(channelId string, since int64, until int64) (int64, *model.AppError) {
+	ret := _m.Called(channelId, since, until)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, int64, int64) int64); ok {
+		r0 = rf(channelId, since, until)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, int64, int64) *model.AppError); ok {
+		r1 = rf(channelId, since, until)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterId
 func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
 	ret := _m.Called(limit, afterId)
@@ -742,6 +765,54 @@ func (_m *PostStore) Search(teamId string, userId string, params *model.SearchPa
 	return r0, r1
 }
 
+// SearchCount provides a mock function with given fields: teamId, userId, params, excludedPostIds
+func (_m *PostStore) SearchCount(teamId string, userId string, params *model.SearchParams, excludedPostIds []string) (int64, *model.AppError) {
+	ret := _m.Called(teamId, userId, params, excludedPostIds)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, *model.SearchParams, []string) int64); ok {
+		r0 = rf(teamId, userId, params, excludedPostIds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, *model.SearchParams, []string) *model.AppError); ok {
+		r1 = rf(teamId, userId, params, excludedPostIds)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SearchPostIds provides a mock function with given fields: teamId, userId, params, limit
+func (_m *PostStore) SearchPostIds(teamId string, userId string, params *model.SearchParams, limit int) ([]string, *model.AppError) {
+	ret := _m.Called(teamId, userId, params, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string, *model.SearchParams, int) []string); ok {
+		r0 = rf(teamId, userId, params, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, string, *model.SearchParams, int) *model.AppError); ok {
+		r1 = rf(teamId, userId, params, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: newPost, oldPost
 func (_m *PostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
 	ret := _m.Called(newPost, oldPost)
@@ -152,3 +152,28 @@ func (_m *ReactionStore) Save(reaction *model.Reaction) (*model.Reaction, *model
 
 	return r0, r1
 }
+
+// SaveMultiple provides a mock function with given fields: reactions
+func (_m *ReactionStore) SaveMultiple(reactions []*model.Reaction) (*model.BulkReactionsResult, *model.AppError) {
+	ret := _m.Called(reactions)
+
+	var r0 *model.BulkReactionsResult
+	if rf, ok := ret.Get(0).(func([]*model.Reaction) *model.BulkReactionsResult); ok {
+		r0 = rf(reactions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BulkReactionsResult)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]*model.Reaction) *model.AppError); ok {
+		r1 = rf(reactions)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
@@ -179,3 +179,28 @@ func (_m *RoleStore) Save(role *model.Role) (*model.Role, *model.AppError) {
 
 	return r0, r1
 }
+
+// UpdateMultiple provides a mock function with given fields: roles
+func (_m *RoleStore) UpdateMultiple(roles []*model.Role) ([]*model.Role, *model.AppError) {
+	ret := _m.Called(roles)
+
+	var r0 []*model.Role
+	if rf, ok := ret.Get(0).(func([]*model.Role) []*model.Role); ok {
+		r0 = rf(roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Role)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func([]*model.Role) *model.AppError); ok {
+		r1 = rf(roles)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
@@ -154,3 +154,28 @@ func (_m *SchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *model.AppErro
 
 	return r0, r1
 }
+
+// SaveWithRolePermissions provides a mock function with given fields: scheme, rolePermissions
+func (_m *SchemeStore) SaveWithRolePermissions(scheme *model.Scheme, rolePermissions map[string][]string) (*model.Scheme, *model.AppError) {
+	ret := _m.Called(scheme, rolePermissions)
+
+	var r0 *model.Scheme
+	if rf, ok := ret.Get(0).(func(*model.Scheme, map[string][]string) *model.Scheme); ok {
+		r0 = rf(scheme, rolePermissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Scheme)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.Scheme, map[string][]string) *model.AppError); ok {
+		r1 = rf(scheme, rolePermissions)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
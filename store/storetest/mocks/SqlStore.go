@@ -94,6 +94,22 @@ func (_m *SqlStore) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelTopicHistory provides a mock function with given fields:
+func (_m *SqlStore) ChannelTopicHistory() store.ChannelTopicHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTopicHistoryStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTopicHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTopicHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *SqlStore) Close() {
 	_m.Called()
@@ -163,6 +179,22 @@ func (_m *SqlStore) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConfigChangeRequest provides a mock function with given fields:
+func (_m *SqlStore) ConfigChangeRequest() store.ConfigChangeRequestStore {
+	ret := _m.Called()
+
+	var r0 store.ConfigChangeRequestStore
+	if rf, ok := ret.Get(0).(func() store.ConfigChangeRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConfigChangeRequestStore)
+		}
+	}
+
+	return r0
+}
+
 // CreateColumnIfNotExists provides a mock function with given fields: tableName, columnName, mySqlColType, postgresColType, defaultValue
 func (_m *SqlStore) CreateColumnIfNotExists(tableName string, columnName string, mySqlColType string, postgresColType string, defaultValue string) bool {
 	ret := _m.Called(tableName, columnName, mySqlColType, postgresColType, defaultValue)
@@ -233,6 +265,20 @@ func (_m *SqlStore) CreateIndexIfNotExists(indexName string, tableName string, c
 	return r0
 }
 
+// CreateTrigramIndexIfNotExists provides a mock function with given fields: indexName, tableName, columnName
+func (_m *SqlStore) CreateTrigramIndexIfNotExists(indexName string, tableName string, columnName string) bool {
+	ret := _m.Called(indexName, tableName, columnName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(indexName, tableName, columnName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CreateUniqueIndexIfNotExists provides a mock function with given fields: indexName, tableName, columnName
 func (_m *SqlStore) CreateUniqueIndexIfNotExists(indexName string, tableName string, columnName string) bool {
 	ret := _m.Called(indexName, tableName, columnName)
@@ -485,6 +531,22 @@ func (_m *SqlStore) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// Mention provides a mock function with given fields:
+func (_m *SqlStore) Mention() store.MentionStore {
+	ret := _m.Called()
+
+	var r0 store.MentionStore
+	if rf, ok := ret.Get(0).(func() store.MentionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MentionStore)
+		}
+	}
+
+	return r0
+}
+
 // OAuth provides a mock function with given fields:
 func (_m *SqlStore) OAuth() store.OAuthStore {
 	ret := _m.Called()
@@ -501,6 +563,22 @@ func (_m *SqlStore) OAuth() store.OAuthStore {
 	return r0
 }
 
+// PasswordHistory provides a mock function with given fields:
+func (_m *SqlStore) PasswordHistory() store.PasswordHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.PasswordHistoryStore
+	if rf, ok := ret.Get(0).(func() store.PasswordHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PasswordHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *SqlStore) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -549,6 +627,22 @@ func (_m *SqlStore) Preference() store.PreferenceStore {
 	return r0
 }
 
+// PushNotificationQueue provides a mock function with given fields:
+func (_m *SqlStore) PushNotificationQueue() store.PushNotificationQueueStore {
+	ret := _m.Called()
+
+	var r0 store.PushNotificationQueueStore
+	if rf, ok := ret.Get(0).(func() store.PushNotificationQueueStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PushNotificationQueueStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *SqlStore) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -637,6 +731,22 @@ func (_m *SqlStore) Role() store.RoleStore {
 	return r0
 }
 
+// SavedPost provides a mock function with given fields:
+func (_m *SqlStore) SavedPost() store.SavedPostStore {
+	ret := _m.Called()
+
+	var r0 store.SavedPostStore
+	if rf, ok := ret.Get(0).(func() store.SavedPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedPostStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *SqlStore) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -669,6 +779,22 @@ func (_m *SqlStore) Session() store.SessionStore {
 	return r0
 }
 
+// SidebarChannel provides a mock function with given fields:
+func (_m *SqlStore) SidebarChannel() store.SidebarChannelStore {
+	ret := _m.Called()
+
+	var r0 store.SidebarChannelStore
+	if rf, ok := ret.Get(0).(func() store.SidebarChannelStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SidebarChannelStore)
+		}
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields:
 func (_m *SqlStore) Status() store.StatusStore {
 	ret := _m.Called()
@@ -717,6 +843,22 @@ func (_m *SqlStore) Team() store.TeamStore {
 	return r0
 }
 
+// TeamMemberHistory provides a mock function with given fields:
+func (_m *SqlStore) TeamMemberHistory() store.TeamMemberHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.TeamMemberHistoryStore
+	if rf, ok := ret.Get(0).(func() store.TeamMemberHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TeamMemberHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // TermsOfService provides a mock function with given fields:
 func (_m *SqlStore) TermsOfService() store.TermsOfServiceStore {
 	ret := _m.Called()
@@ -78,6 +78,22 @@ func (_m *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return r0
 }
 
+// ChannelTopicHistory provides a mock function with given fields:
+func (_m *Store) ChannelTopicHistory() store.ChannelTopicHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.ChannelTopicHistoryStore
+	if rf, ok := ret.Get(0).(func() store.ChannelTopicHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelTopicHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // CheckIntegrity provides a mock function with given fields:
 func (_m *Store) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	ret := _m.Called()
@@ -163,6 +179,22 @@ func (_m *Store) Compliance() store.ComplianceStore {
 	return r0
 }
 
+// ConfigChangeRequest provides a mock function with given fields:
+func (_m *Store) ConfigChangeRequest() store.ConfigChangeRequestStore {
+	ret := _m.Called()
+
+	var r0 store.ConfigChangeRequestStore
+	if rf, ok := ret.Get(0).(func() store.ConfigChangeRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ConfigChangeRequestStore)
+		}
+	}
+
+	return r0
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *Store) DropAllTables() {
 	_m.Called()
@@ -288,6 +320,22 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// Mention provides a mock function with given fields:
+func (_m *Store) Mention() store.MentionStore {
+	ret := _m.Called()
+
+	var r0 store.MentionStore
+	if rf, ok := ret.Get(0).(func() store.MentionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MentionStore)
+		}
+	}
+
+	return r0
+}
+
 // OAuth provides a mock function with given fields:
 func (_m *Store) OAuth() store.OAuthStore {
 	ret := _m.Called()
@@ -304,6 +352,22 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// PasswordHistory provides a mock function with given fields:
+func (_m *Store) PasswordHistory() store.PasswordHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.PasswordHistoryStore
+	if rf, ok := ret.Get(0).(func() store.PasswordHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PasswordHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -352,6 +416,22 @@ func (_m *Store) Preference() store.PreferenceStore {
 	return r0
 }
 
+// PushNotificationQueue provides a mock function with given fields:
+func (_m *Store) PushNotificationQueue() store.PushNotificationQueueStore {
+	ret := _m.Called()
+
+	var r0 store.PushNotificationQueueStore
+	if rf, ok := ret.Get(0).(func() store.PushNotificationQueueStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PushNotificationQueueStore)
+		}
+	}
+
+	return r0
+}
+
 // Reaction provides a mock function with given fields:
 func (_m *Store) Reaction() store.ReactionStore {
 	ret := _m.Called()
@@ -384,6 +464,22 @@ func (_m *Store) Role() store.RoleStore {
 	return r0
 }
 
+// SavedPost provides a mock function with given fields:
+func (_m *Store) SavedPost() store.SavedPostStore {
+	ret := _m.Called()
+
+	var r0 store.SavedPostStore
+	if rf, ok := ret.Get(0).(func() store.SavedPostStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SavedPostStore)
+		}
+	}
+
+	return r0
+}
+
 // Scheme provides a mock function with given fields:
 func (_m *Store) Scheme() store.SchemeStore {
 	ret := _m.Called()
@@ -416,6 +512,22 @@ func (_m *Store) Session() store.SessionStore {
 	return r0
 }
 
+// SidebarChannel provides a mock function with given fields:
+func (_m *Store) SidebarChannel() store.SidebarChannelStore {
+	ret := _m.Called()
+
+	var r0 store.SidebarChannelStore
+	if rf, ok := ret.Get(0).(func() store.SidebarChannelStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SidebarChannelStore)
+		}
+	}
+
+	return r0
+}
+
 // Status provides a mock function with given fields:
 func (_m *Store) Status() store.StatusStore {
 	ret := _m.Called()
@@ -464,6 +576,22 @@ func (_m *Store) Team() store.TeamStore {
 	return r0
 }
 
+// TeamMemberHistory provides a mock function with given fields:
+func (_m *Store) TeamMemberHistory() store.TeamMemberHistoryStore {
+	ret := _m.Called()
+
+	var r0 store.TeamMemberHistoryStore
+	if rf, ok := ret.Get(0).(func() store.TeamMemberHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.TeamMemberHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // TermsOfService provides a mock function with given fields:
 func (_m *Store) TermsOfService() store.TermsOfServiceStore {
 	ret := _m.Called()
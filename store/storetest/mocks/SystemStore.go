@@ -64,6 +64,56 @@ func (_m *SystemStore) GetByName(name string) (*model.System, *model.AppError) {
 	return r0, r1
 }
 
+// GetOpenTransactions provides a mock function with given fields:
+func (_m *SystemStore) GetOpenTransactions() ([]*model.DatabaseTransaction, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 []*model.DatabaseTransaction
+	if rf, ok := ret.Get(0).(func() []*model.DatabaseTransaction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DatabaseTransaction)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetSchemaMigrations provides a mock function with given fields:
+func (_m *SystemStore) GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError) {
+	ret := _m.Called()
+
+	var r0 []*model.SchemaMigration
+	if rf, ok := ret.Get(0).(func() []*model.SchemaMigration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SchemaMigration)
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func() *model.AppError); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByName provides a mock function with given fields: name
 func (_m *SystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
 	ret := _m.Called(name)
@@ -89,6 +139,31 @@ func (_m *SystemStore) PermanentDeleteByName(name string) (*model.System, *model
 	return r0, r1
 }
 
+// RunReportingQuery provides a mock function with given fields: query, params
+func (_m *SystemStore) RunReportingQuery(query string, params map[string]interface{}) ([]map[string]interface{}, *model.AppError) {
+	ret := _m.Called(query, params)
+
+	var r0 []map[string]interface{}
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) []map[string]interface{}); ok {
+		r0 = rf(query, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]interface{})
+		}
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(string, map[string]interface{}) *model.AppError); ok {
+		r1 = rf(query, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: system
 func (_m *SystemStore) Save(system *model.System) *model.AppError {
 	ret := _m.Called(system)
@@ -136,3 +211,26 @@ func (_m *SystemStore) Update(system *model.System) *model.AppError {
 
 	return r0
 }
+
+// UpdateOptimistically provides a mock function with given fields: system, currentValue
+func (_m *SystemStore) UpdateOptimistically(system *model.System, currentValue string) (bool, *model.AppError) {
+	ret := _m.Called(system, currentValue)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.System, string) bool); ok {
+		r0 = rf(system, currentValue)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 *model.AppError
+	if rf, ok := ret.Get(1).(func(*model.System, string) *model.AppError); ok {
+		r1 = rf(system, currentValue)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestPasswordHistoryStore(t *testing.T, ss store.Store) {
+	t.Run("GetForUser", func(t *testing.T) { testPasswordHistoryStoreGetForUser(t, ss) })
+	t.Run("PruneForUser", func(t *testing.T) { testPasswordHistoryStorePruneForUser(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPasswordHistoryStorePermanentDeleteByUser(t, ss) })
+}
+
+func testPasswordHistoryStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	h1 := &model.PasswordHistory{UserId: userId, Password: "hash1", CreateAt: 1000}
+	h2 := &model.PasswordHistory{UserId: userId, Password: "hash2", CreateAt: 2000}
+	h3 := &model.PasswordHistory{UserId: userId, Password: "hash3", CreateAt: 3000}
+	other := &model.PasswordHistory{UserId: model.NewId(), Password: "hash4", CreateAt: 2500}
+
+	for _, history := range []*model.PasswordHistory{h2, h1, h3, other} {
+		require.Nil(t, ss.PasswordHistory().Save(history))
+	}
+
+	histories, err := ss.PasswordHistory().GetForUser(userId, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PasswordHistory{h3, h2, h1}, histories)
+
+	histories, err = ss.PasswordHistory().GetForUser(userId, 2)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PasswordHistory{h3, h2}, histories)
+
+	histories, err = ss.PasswordHistory().GetForUser(model.NewId(), 10)
+	require.Nil(t, err)
+	assert.Empty(t, histories)
+}
+
+func testPasswordHistoryStorePruneForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	h1 := &model.PasswordHistory{UserId: userId, Password: "hash1", CreateAt: 1000}
+	h2 := &model.PasswordHistory{UserId: userId, Password: "hash2", CreateAt: 2000}
+	h3 := &model.PasswordHistory{UserId: userId, Password: "hash3", CreateAt: 3000}
+	other := &model.PasswordHistory{UserId: otherUserId, Password: "hash4", CreateAt: 1000}
+
+	for _, history := range []*model.PasswordHistory{h1, h2, h3, other} {
+		require.Nil(t, ss.PasswordHistory().Save(history))
+	}
+
+	require.Nil(t, ss.PasswordHistory().PruneForUser(userId, 5))
+
+	histories, err := ss.PasswordHistory().GetForUser(userId, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PasswordHistory{h3, h2, h1}, histories)
+
+	require.Nil(t, ss.PasswordHistory().PruneForUser(userId, 2))
+
+	histories, err = ss.PasswordHistory().GetForUser(userId, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.PasswordHistory{h3, h2}, histories)
+
+	require.Nil(t, ss.PasswordHistory().PruneForUser(userId, 0))
+
+	histories, err = ss.PasswordHistory().GetForUser(userId, 10)
+	require.Nil(t, err)
+	assert.Empty(t, histories)
+
+	histories, err = ss.PasswordHistory().GetForUser(otherUserId, 10)
+	require.Nil(t, err)
+	assert.Len(t, histories, 1)
+}
+
+func testPasswordHistoryStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	require.Nil(t, ss.PasswordHistory().Save(&model.PasswordHistory{UserId: userId, Password: "hash1", CreateAt: 1000}))
+	require.Nil(t, ss.PasswordHistory().Save(&model.PasswordHistory{UserId: otherUserId, Password: "hash2", CreateAt: 1000}))
+
+	require.Nil(t, ss.PasswordHistory().PermanentDeleteByUser(userId))
+
+	histories, err := ss.PasswordHistory().GetForUser(userId, 10)
+	require.Nil(t, err)
+	assert.Empty(t, histories)
+
+	histories, err = ss.PasswordHistory().GetForUser(otherUserId, 10)
+	require.Nil(t, err)
+	assert.Len(t, histories, 1)
+}
@@ -4,6 +4,9 @@
 package storetest
 
 import (
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,6 +22,8 @@ func TestPluginStore(t *testing.T, ss store.Store) {
 	t.Run("PluginDelete", func(t *testing.T) { testPluginDelete(t, ss) })
 	t.Run("PluginDeleteAll", func(t *testing.T) { testPluginDeleteAll(t, ss) })
 	t.Run("PluginDeleteExpired", func(t *testing.T) { testPluginDeleteExpired(t, ss) })
+	t.Run("PluginCompareAndSet", func(t *testing.T) { testPluginCompareAndSet(t, ss) })
+	t.Run("PluginCompareAndSetConcurrent", func(t *testing.T) { testPluginCompareAndSetConcurrent(t, ss) })
 }
 
 func testPluginSaveGet(t *testing.T, ss store.Store) {
@@ -185,3 +190,135 @@ func testPluginDeleteExpired(t *testing.T, ss store.Store) {
 		assert.Equal(t, kv2.ExpireAt, received.ExpireAt)
 	}
 }
+
+func testPluginCompareAndSet(t *testing.T, ss store.Store) {
+	kv := &model.PluginKeyValue{
+		PluginId: model.NewId(),
+		Key:      model.NewId(),
+	}
+	defer func() {
+		_ = ss.Plugin().Delete(kv.PluginId, kv.Key)
+	}()
+
+	assertValue := func(t *testing.T, expected []byte) {
+		t.Helper()
+
+		received, err := ss.Plugin().Get(kv.PluginId, kv.Key)
+		require.Nil(t, err)
+		assert.Equal(t, expected, received.Value)
+	}
+
+	t.Run("should fail to update a key that doesn't exist", func(t *testing.T) {
+		kv.Value = []byte("value1")
+		updated, err := ss.Plugin().CompareAndSet(kv, []byte("value0"))
+		require.Nil(t, err)
+		assert.False(t, updated)
+	})
+
+	t.Run("should insert when the old value is nil", func(t *testing.T) {
+		kv.Value = []byte("value1")
+		updated, err := ss.Plugin().CompareAndSet(kv, nil)
+		require.Nil(t, err)
+		assert.True(t, updated)
+		assertValue(t, []byte("value1"))
+	})
+
+	t.Run("should fail to insert a key that already exists", func(t *testing.T) {
+		kv.Value = []byte("value2")
+		updated, err := ss.Plugin().CompareAndSet(kv, nil)
+		require.Nil(t, err)
+		assert.False(t, updated)
+		assertValue(t, []byte("value1"))
+	})
+
+	t.Run("should fail to update when the old value doesn't match", func(t *testing.T) {
+		kv.Value = []byte("value2")
+		updated, err := ss.Plugin().CompareAndSet(kv, []byte("incorrect"))
+		require.Nil(t, err)
+		assert.False(t, updated)
+		assertValue(t, []byte("value1"))
+	})
+
+	t.Run("should update when the old value matches", func(t *testing.T) {
+		kv.Value = []byte("value2")
+		updated, err := ss.Plugin().CompareAndSet(kv, []byte("value1"))
+		require.Nil(t, err)
+		assert.True(t, updated)
+		assertValue(t, []byte("value2"))
+	})
+
+	t.Run("should succeed when setting the value it already has", func(t *testing.T) {
+		kv.Value = []byte("value2")
+		updated, err := ss.Plugin().CompareAndSet(kv, []byte("value2"))
+		require.Nil(t, err)
+		assert.True(t, updated)
+		assertValue(t, []byte("value2"))
+	})
+
+	t.Run("should delete when the new value is nil", func(t *testing.T) {
+		kv.Value = nil
+		updated, err := ss.Plugin().CompareAndSet(kv, []byte("value2"))
+		require.Nil(t, err)
+		assert.True(t, updated)
+
+		_, err = ss.Plugin().Get(kv.PluginId, kv.Key)
+		assert.NotNil(t, err)
+	})
+}
+
+func testPluginCompareAndSetConcurrent(t *testing.T, ss store.Store) {
+	const writers = 10
+
+	pluginId := model.NewId()
+	key := model.NewId()
+	defer func() {
+		_ = ss.Plugin().Delete(pluginId, key)
+	}()
+
+	updated, err := ss.Plugin().CompareAndSet(&model.PluginKeyValue{PluginId: pluginId, Key: key, Value: []byte("0")}, nil)
+	require.Nil(t, err)
+	require.True(t, updated)
+
+	// Each writer increments the counter, retrying whenever another writer changed it first. If
+	// the compare wasn't atomic, some increments would be lost.
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for attempt := 0; attempt < 100; attempt++ {
+				current, err := ss.Plugin().Get(pluginId, key)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				count, _ := strconv.Atoi(string(current.Value))
+				next := &model.PluginKeyValue{PluginId: pluginId, Key: key, Value: []byte(strconv.Itoa(count + 1))}
+
+				updated, err := ss.Plugin().CompareAndSet(next, current.Value)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if updated {
+					return
+				}
+			}
+
+			errs <- errors.New("gave up after too many conflicting writes")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.Nil(t, err)
+	}
+
+	received, appErr := ss.Plugin().Get(pluginId, key)
+	require.Nil(t, appErr)
+	assert.Equal(t, strconv.Itoa(writers), string(received.Value))
+}
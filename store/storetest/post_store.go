@@ -35,12 +35,15 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetPostsSince", func(t *testing.T) { testPostStoreGetPostsSince(t, ss) })
 	t.Run("GetPostBeforeAfter", func(t *testing.T) { testPostStoreGetPostBeforeAfter(t, ss) })
 	t.Run("Search", func(t *testing.T) { testPostStoreSearch(t, ss) })
+	t.Run("SearchCount", func(t *testing.T) { testPostStoreSearchCount(t, ss) })
+	t.Run("SearchPostIds", func(t *testing.T) { testPostStoreSearchPostIds(t, ss) })
 	t.Run("UserCountsWithPostsByDay", func(t *testing.T) { testUserCountsWithPostsByDay(t, ss) })
 	t.Run("PostCountsByDay", func(t *testing.T) { testPostCountsByDay(t, ss) })
 	t.Run("GetFlaggedPostsForTeam", func(t *testing.T) { testPostStoreGetFlaggedPostsForTeam(t, ss, s) })
 	t.Run("GetFlaggedPosts", func(t *testing.T) { testPostStoreGetFlaggedPosts(t, ss) })
 	t.Run("GetFlaggedPostsForChannel", func(t *testing.T) { testPostStoreGetFlaggedPostsForChannel(t, ss) })
 	t.Run("GetPostsCreatedAt", func(t *testing.T) { testPostStoreGetPostsCreatedAt(t, ss) })
+	t.Run("GetChannelPostCount", func(t *testing.T) { testPostStoreGetChannelPostCount(t, ss) })
 	t.Run("Overwrite", func(t *testing.T) { testPostStoreOverwrite(t, ss) })
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, ss) })
@@ -1274,6 +1277,110 @@ func testPostStoreGetPostBeforeAfter(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPostStoreSearchCount(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	channel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel1", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	otherChannel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel2", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+
+	var quokkaIds []string
+	for _, post := range []*model.Post{
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "counting quokkas"},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "more quokkas"},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "quokkas again"},
+	} {
+		saved, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		quokkaIds = append(quokkaIds, saved.Id)
+	}
+
+	for _, post := range []*model.Post{
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "something else"},
+		{ChannelId: otherChannel.Id, UserId: model.NewId(), Message: "quokkas elsewhere"},
+	} {
+		_, err = ss.Post().Save(post)
+		require.Nil(t, err)
+	}
+
+	count, err := ss.Post().SearchCount(teamId, userId, &model.SearchParams{Terms: "quokkas"}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = ss.Post().SearchCount(teamId, userId, &model.SearchParams{Terms: "quokkas", Limit: 1}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count, "limit should be ignored")
+
+	count, err = ss.Post().SearchCount(teamId, userId, &model.SearchParams{Terms: "quokkas"}, quokkaIds[:2])
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count, "excluded posts shouldn't be counted")
+
+	count, err = ss.Post().SearchCount(teamId, userId, &model.SearchParams{}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func testPostStoreSearchPostIds(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	channel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel1", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.Nil(t, err)
+
+	otherChannel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: "Channel2", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, err)
+
+	now := model.GetMillis()
+	var quokkaIds []string
+	for _, post := range []*model.Post{
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "counting quokkas", CreateAt: now - 3000},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "more quokkas", CreateAt: now - 2000},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "quokkas again", CreateAt: now - 1000},
+	} {
+		saved, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		quokkaIds = append(quokkaIds, saved.Id)
+	}
+
+	for _, post := range []*model.Post{
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "something else"},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "tagged", Hashtags: "#quokka"},
+		{ChannelId: channel.Id, UserId: model.NewId(), Message: "tagged", Hashtags: "#quokkas"},
+		{ChannelId: otherChannel.Id, UserId: model.NewId(), Message: "quokkas elsewhere"},
+	} {
+		_, err = ss.Post().Save(post)
+		require.Nil(t, err)
+	}
+
+	postIds, err := ss.Post().SearchPostIds(teamId, userId, &model.SearchParams{Terms: "quokkas"}, 10)
+	require.Nil(t, err)
+	assert.ElementsMatch(t, quokkaIds, postIds)
+
+	postIds, err = ss.Post().SearchPostIds(teamId, userId, &model.SearchParams{Terms: "quokkas", Limit: 1}, 10)
+	require.Nil(t, err)
+	assert.Len(t, postIds, 3, "the limit of the search parameters should be ignored")
+
+	postIds, err = ss.Post().SearchPostIds(teamId, userId, &model.SearchParams{Terms: "quokkas"}, 2)
+	require.Nil(t, err)
+	assert.Equal(t, []string{quokkaIds[2], quokkaIds[1]}, postIds, "the most recent posts should be returned up to the limit")
+
+	// Hashtags are matched exactly, as with Search.
+	postIds, err = ss.Post().SearchPostIds(teamId, userId, &model.SearchParams{Terms: "#quokka", IsHashtag: true}, 10)
+	require.Nil(t, err)
+	assert.Len(t, postIds, 1)
+
+	postIds, err = ss.Post().SearchPostIds(teamId, userId, &model.SearchParams{}, 10)
+	require.Nil(t, err)
+	assert.Empty(t, postIds)
+}
+
 func testPostStoreSearch(t *testing.T, ss store.Store) {
 	teamId := model.NewId()
 	userId := model.NewId()
@@ -1875,16 +1982,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 		t.Fatal("should be empty")
 	}
 
-	preferences := model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o1.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o1.Id})
 	require.Nil(t, err)
 
 	r2, err := ss.Post().GetFlaggedPostsForTeam(o1.UserId, c1.TeamId, 0, 2)
@@ -1894,16 +1992,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 		t.Fatal("should have 1 post")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o2.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o2.Id})
 	require.Nil(t, err)
 
 	r3, err := ss.Post().GetFlaggedPostsForTeam(o1.UserId, c1.TeamId, 0, 1)
@@ -1934,16 +2023,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 		t.Fatal("should have 2 posts")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o3.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o3.Id})
 	require.Nil(t, err)
 
 	r4, err = ss.Post().GetFlaggedPostsForTeam(o1.UserId, c1.TeamId, 0, 2)
@@ -1953,15 +2033,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 		t.Fatal("should have 2 posts")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o4.Id,
-			Value:    "true",
-		},
-	}
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o4.Id})
 	require.Nil(t, err)
 
 	r4, err = ss.Post().GetFlaggedPostsForTeam(o1.UserId, c1.TeamId, 0, 2)
@@ -1978,15 +2050,7 @@ func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupp
 		t.Fatal("should have 0 posts")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o5.Id,
-			Value:    "true",
-		},
-	}
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o5.Id})
 	require.Nil(t, err)
 
 	r4, err = ss.Post().GetFlaggedPostsForTeam(o1.UserId, c1.TeamId, 0, 10)
@@ -2033,16 +2097,7 @@ func testPostStoreGetFlaggedPosts(t *testing.T, ss store.Store) {
 		t.Fatal("should be empty")
 	}
 
-	preferences := model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o1.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o1.Id})
 	require.Nil(t, err)
 
 	r2, err := ss.Post().GetFlaggedPosts(o1.UserId, 0, 2)
@@ -2052,16 +2107,7 @@ func testPostStoreGetFlaggedPosts(t *testing.T, ss store.Store) {
 		t.Fatal("should have 1 post")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o2.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o2.Id})
 	require.Nil(t, err)
 
 	r3, err := ss.Post().GetFlaggedPosts(o1.UserId, 0, 1)
@@ -2092,16 +2138,7 @@ func testPostStoreGetFlaggedPosts(t *testing.T, ss store.Store) {
 		t.Fatal("should have 2 posts")
 	}
 
-	preferences = model.Preferences{
-		{
-			UserId:   o1.UserId,
-			Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-			Name:     o3.Id,
-			Value:    "true",
-		},
-	}
-
-	err = ss.Preference().Save(&preferences)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o3.Id})
 	require.Nil(t, err)
 
 	r4, err = ss.Post().GetFlaggedPosts(o1.UserId, 0, 2)
@@ -2152,14 +2189,7 @@ func testPostStoreGetFlaggedPostsForChannel(t *testing.T, ss store.Store) {
 		t.Fatal("should be empty")
 	}
 
-	preference := model.Preference{
-		UserId:   o1.UserId,
-		Category: model.PREFERENCE_CATEGORY_FLAGGED_POST,
-		Name:     o1.Id,
-		Value:    "true",
-	}
-
-	err = ss.Preference().Save(&model.Preferences{preference})
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o1.Id})
 	require.Nil(t, err)
 
 	r, err = ss.Post().GetFlaggedPostsForChannel(o1.UserId, o1.ChannelId, 0, 10)
@@ -2169,12 +2199,10 @@ func testPostStoreGetFlaggedPostsForChannel(t *testing.T, ss store.Store) {
 		t.Fatal("should have 1 post")
 	}
 
-	preference.Name = o2.Id
-	err = ss.Preference().Save(&model.Preferences{preference})
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o2.Id})
 	require.Nil(t, err)
 
-	preference.Name = o3.Id
-	err = ss.Preference().Save(&model.Preferences{preference})
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o3.Id})
 	require.Nil(t, err)
 
 	r, err = ss.Post().GetFlaggedPostsForChannel(o1.UserId, o1.ChannelId, 0, 1)
@@ -2205,8 +2233,7 @@ func testPostStoreGetFlaggedPostsForChannel(t *testing.T, ss store.Store) {
 		t.Fatal("should have 2 posts")
 	}
 
-	preference.Name = o4.Id
-	err = ss.Preference().Save(&model.Preferences{preference})
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: o1.UserId, PostId: o4.Id})
 	require.Nil(t, err)
 
 	r, err = ss.Post().GetFlaggedPostsForChannel(o1.UserId, o4.ChannelId, 0, 10)
@@ -2258,6 +2285,50 @@ func testPostStoreGetPostsCreatedAt(t *testing.T, ss store.Store) {
 	assert.Equal(t, 2, len(r1))
 }
 
+func testPostStoreGetChannelPostCount(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	createTime := model.GetMillis()
+
+	for i := 0; i < 3; i++ {
+		_, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    model.NewId(),
+			Message:   "zz" + model.NewId() + "b",
+			CreateAt:  createTime + int64(i),
+		})
+		require.Nil(t, err)
+	}
+
+	deleted, err := ss.Post().Save(&model.Post{
+		ChannelId: channelId,
+		UserId:    model.NewId(),
+		Message:   "zz" + model.NewId() + "b",
+		CreateAt:  createTime,
+	})
+	require.Nil(t, err)
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), ""))
+
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "zz" + model.NewId() + "b",
+		CreateAt:  createTime,
+	})
+	require.Nil(t, err)
+
+	count, err := ss.Post().GetChannelPostCount(channelId, 0, 0)
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = ss.Post().GetChannelPostCount(channelId, createTime+1, 0)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = ss.Post().GetChannelPostCount(channelId, createTime, createTime+2)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
 func testPostStoreOverwrite(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -0,0 +1,139 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestPushNotificationQueueStore(t *testing.T, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testPushNotificationQueueStoreSave(t, ss) })
+	t.Run("GetBatch", func(t *testing.T) { testPushNotificationQueueStoreGetBatch(t, ss) })
+	t.Run("Update", func(t *testing.T) { testPushNotificationQueueStoreUpdate(t, ss) })
+	t.Run("DeleteExpired", func(t *testing.T) { testPushNotificationQueueStoreDeleteExpired(t, ss) })
+}
+
+func makeQueuedPushNotification() *model.PushNotificationQueueItem {
+	return &model.PushNotificationQueueItem{
+		SessionId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   (&model.PushNotification{Type: model.PUSH_TYPE_MESSAGE}).ToJson(),
+	}
+}
+
+func testPushNotificationQueueStoreSave(t *testing.T, ss store.Store) {
+	t.Run("should save item", func(t *testing.T) {
+		item, err := ss.PushNotificationQueue().Save(makeQueuedPushNotification())
+		require.Nil(t, err)
+		defer ss.PushNotificationQueue().Delete(item.Id)
+
+		assert.Len(t, item.Id, 26)
+		assert.NotZero(t, item.CreateAt)
+	})
+
+	t.Run("should fail to save invalid item", func(t *testing.T) {
+		item := makeQueuedPushNotification()
+		item.Message = ""
+
+		_, err := ss.PushNotificationQueue().Save(item)
+		assert.NotNil(t, err)
+	})
+}
+
+func testPushNotificationQueueStoreGetBatch(t *testing.T, ss store.Store) {
+	first := makeQueuedPushNotification()
+	first.CreateAt = model.GetMillis() - 2000
+	first, err := ss.PushNotificationQueue().Save(first)
+	require.Nil(t, err)
+	defer ss.PushNotificationQueue().Delete(first.Id)
+
+	second := makeQueuedPushNotification()
+	second.CreateAt = model.GetMillis() - 1000
+	second, err = ss.PushNotificationQueue().Save(second)
+	require.Nil(t, err)
+	defer ss.PushNotificationQueue().Delete(second.Id)
+
+	count, err := ss.PushNotificationQueue().Count()
+	require.Nil(t, err)
+	assert.True(t, count >= 2)
+
+	items, err := ss.PushNotificationQueue().GetBatch(1000)
+	require.Nil(t, err)
+
+	firstIndex, secondIndex := -1, -1
+	for i, item := range items {
+		if item.Id == first.Id {
+			firstIndex = i
+		} else if item.Id == second.Id {
+			secondIndex = i
+		}
+	}
+	require.NotEqual(t, -1, firstIndex)
+	require.NotEqual(t, -1, secondIndex)
+	assert.True(t, firstIndex < secondIndex, "items should be returned oldest first")
+
+	require.Nil(t, ss.PushNotificationQueue().Delete(first.Id))
+
+	items, err = ss.PushNotificationQueue().GetBatch(1000)
+	require.Nil(t, err)
+	for _, item := range items {
+		assert.NotEqual(t, first.Id, item.Id)
+	}
+}
+
+func testPushNotificationQueueStoreUpdate(t *testing.T, ss store.Store) {
+	item, err := ss.PushNotificationQueue().Save(makeQueuedPushNotification())
+	require.Nil(t, err)
+	defer ss.PushNotificationQueue().Delete(item.Id)
+
+	item.Retries = 2
+	item.LastAttemptAt = model.GetMillis()
+	_, err = ss.PushNotificationQueue().Update(item)
+	require.Nil(t, err)
+
+	items, err := ss.PushNotificationQueue().GetBatch(1000)
+	require.Nil(t, err)
+
+	var updated *model.PushNotificationQueueItem
+	for _, i := range items {
+		if i.Id == item.Id {
+			updated = i
+		}
+	}
+	require.NotNil(t, updated)
+	assert.Equal(t, 2, updated.Retries)
+	assert.Equal(t, item.LastAttemptAt, updated.LastAttemptAt)
+}
+
+func testPushNotificationQueueStoreDeleteExpired(t *testing.T, ss store.Store) {
+	expired := makeQueuedPushNotification()
+	expired.CreateAt = model.GetMillis() - model.PUSH_NOTIFICATION_QUEUE_LIFETIME - 1000
+	expired, err := ss.PushNotificationQueue().Save(expired)
+	require.Nil(t, err)
+	defer ss.PushNotificationQueue().Delete(expired.Id)
+
+	current, err := ss.PushNotificationQueue().Save(makeQueuedPushNotification())
+	require.Nil(t, err)
+	defer ss.PushNotificationQueue().Delete(current.Id)
+
+	deleted, err := ss.PushNotificationQueue().DeleteExpired(model.GetMillis() - model.PUSH_NOTIFICATION_QUEUE_LIFETIME)
+	require.Nil(t, err)
+	assert.True(t, deleted >= 1)
+
+	items, err := ss.PushNotificationQueue().GetBatch(1000)
+	require.Nil(t, err)
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	assert.NotContains(t, ids, expired.Id)
+	assert.Contains(t, ids, current.Id)
+}
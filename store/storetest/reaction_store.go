@@ -13,6 +13,7 @@ import (
 
 func TestReactionStore(t *testing.T, ss store.Store) {
 	t.Run("ReactionSave", func(t *testing.T) { testReactionSave(t, ss) })
+	t.Run("ReactionSaveMultiple", func(t *testing.T) { testReactionSaveMultiple(t, ss) })
 	t.Run("ReactionDelete", func(t *testing.T) { testReactionDelete(t, ss) })
 	t.Run("ReactionGetForPost", func(t *testing.T) { testReactionGetForPost(t, ss) })
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, ss) })
@@ -108,6 +109,53 @@ func testReactionSave(t *testing.T, ss store.Store) {
 	}
 }
 
+func testReactionSaveMultiple(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+	})
+	require.Nil(t, err)
+
+	userId := model.NewId()
+
+	existing, err := ss.Reaction().Save(&model.Reaction{UserId: userId, PostId: post.Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	reactions := []*model.Reaction{
+		{UserId: userId, PostId: post.Id, EmojiName: "smile"},
+		{UserId: userId, PostId: post.Id, EmojiName: "+1", CreateAt: 1000},
+		{UserId: model.NewId(), PostId: post.Id, EmojiName: "+1"},
+		{UserId: userId, PostId: post.Id, EmojiName: "+1", CreateAt: 1000},
+	}
+
+	result, err := ss.Reaction().SaveMultiple(reactions)
+	require.Nil(t, err)
+	require.Equal(t, []*model.Reaction{reactions[1], reactions[2]}, result.Saved)
+	require.Equal(t, []*model.Reaction{reactions[0], reactions[3]}, result.Duplicates)
+
+	stored, err := ss.Reaction().GetForPost(post.Id, false)
+	require.Nil(t, err)
+	require.Len(t, stored, 3)
+	require.Equal(t, int64(1000), stored[0].CreateAt)
+	require.Equal(t, existing.CreateAt, stored[1].CreateAt)
+
+	postList, err := ss.Post().Get(post.Id, false)
+	require.Nil(t, err)
+	require.True(t, postList.Posts[post.Id].HasReactions)
+
+	t.Run("invalid reaction", func(t *testing.T) {
+		_, err := ss.Reaction().SaveMultiple([]*model.Reaction{
+			{UserId: model.NewId(), PostId: post.Id, EmojiName: "heart"},
+			{UserId: model.NewId(), PostId: post.Id, EmojiName: "not valid"},
+		})
+		require.NotNil(t, err)
+
+		stored, err := ss.Reaction().GetForPost(post.Id, false)
+		require.Nil(t, err)
+		require.Len(t, stored, 3)
+	})
+}
+
 func testReactionDelete(t *testing.T, ss store.Store) {
 	post, err := ss.Post().Save(&model.Post{
 		ChannelId: model.NewId(),
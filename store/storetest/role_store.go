@@ -15,6 +15,7 @@ import (
 
 func TestRoleStore(t *testing.T, ss store.Store) {
 	t.Run("Save", func(t *testing.T) { testRoleStoreSave(t, ss) })
+	t.Run("UpdateMultiple", func(t *testing.T) { testRoleStoreUpdateMultiple(t, ss) })
 	t.Run("Get", func(t *testing.T) { testRoleStoreGet(t, ss) })
 	t.Run("GetAll", func(t *testing.T) { testRoleStoreGetAll(t, ss) })
 	t.Run("GetByName", func(t *testing.T) { testRoleStoreGetByName(t, ss) })
@@ -96,6 +97,56 @@ func testRoleStoreSave(t *testing.T, ss store.Store) {
 	assert.NotNil(t, err)
 }
 
+func testRoleStoreUpdateMultiple(t *testing.T, ss store.Store) {
+	r1, err := ss.Role().Save(&model.Role{
+		Name:        model.NewId(),
+		DisplayName: model.NewId(),
+		Permissions: []string{"create_post"},
+	})
+	require.Nil(t, err)
+
+	r2, err := ss.Role().Save(&model.Role{
+		Name:        model.NewId(),
+		DisplayName: model.NewId(),
+		Permissions: []string{"create_post", "add_reaction"},
+	})
+	require.Nil(t, err)
+
+	t.Run("updates all roles", func(t *testing.T) {
+		r1.Permissions = []string{"add_reaction"}
+		r2.Permissions = []string{}
+
+		roles, err := ss.Role().UpdateMultiple([]*model.Role{r1, r2})
+		require.Nil(t, err)
+		require.Len(t, roles, 2)
+
+		d1, err := ss.Role().Get(r1.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"add_reaction"}, d1.Permissions)
+
+		d2, err := ss.Role().Get(r2.Id)
+		require.Nil(t, err)
+		assert.Empty(t, d2.Permissions)
+	})
+
+	t.Run("updates no roles if one fails", func(t *testing.T) {
+		r1.Permissions = []string{"create_post"}
+		missing := &model.Role{
+			Id:          model.NewId(),
+			Name:        model.NewId(),
+			DisplayName: model.NewId(),
+			Permissions: []string{},
+		}
+
+		_, err := ss.Role().UpdateMultiple([]*model.Role{r1, missing})
+		require.NotNil(t, err)
+
+		d1, err := ss.Role().Get(r1.Id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"add_reaction"}, d1.Permissions)
+	})
+}
+
 func testRoleStoreGetAll(t *testing.T, ss store.Store) {
 	prev, err := ss.Role().GetAll()
 	require.Nil(t, err)
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestSavedPostStore(t *testing.T, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testSavedPostStoreSave(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testSavedPostStoreGetForUser(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testSavedPostStoreDelete(t, ss) })
+	t.Run("MigrateFlaggedPostPreferences", func(t *testing.T) { testSavedPostStoreMigrateFlaggedPostPreferences(t, ss) })
+	t.Run("CleanupBatch", func(t *testing.T) { testSavedPostStoreCleanupBatch(t, ss) })
+}
+
+func testSavedPostStoreSave(t *testing.T, ss store.Store) {
+	savedPost, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: model.NewId()})
+	require.Nil(t, err)
+	assert.NotZero(t, savedPost.SavedAt)
+
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: savedPost.UserId, PostId: savedPost.PostId})
+	require.NotNil(t, err)
+	assert.Equal(t, "store.sql_saved_post.save.exists.app_error", err.Id)
+
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: "junk", PostId: model.NewId()})
+	assert.NotNil(t, err)
+
+	received, err := ss.SavedPost().Get(savedPost.UserId, savedPost.PostId)
+	require.Nil(t, err)
+	assert.Equal(t, savedPost, received)
+
+	_, err = ss.SavedPost().Get(savedPost.UserId, model.NewId())
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func testSavedPostStoreGetForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+
+	s1 := &model.SavedPost{UserId: userId, PostId: model.NewId(), SavedAt: 1000}
+	s2 := &model.SavedPost{UserId: userId, PostId: model.NewId(), SavedAt: 2000}
+	s3 := &model.SavedPost{UserId: userId, PostId: model.NewId(), SavedAt: 3000}
+	other := &model.SavedPost{UserId: model.NewId(), PostId: s1.PostId, SavedAt: 2500}
+
+	for _, savedPost := range []*model.SavedPost{s2, s1, s3, other} {
+		_, err := ss.SavedPost().Save(savedPost)
+		require.Nil(t, err)
+	}
+
+	savedPosts, err := ss.SavedPost().GetForUser(userId, 0, 10)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.SavedPost{s3, s2, s1}, savedPosts)
+
+	savedPosts, err = ss.SavedPost().GetForUser(userId, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []*model.SavedPost{s2}, savedPosts)
+}
+
+func testSavedPostStoreDelete(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	postId := model.NewId()
+
+	s1, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: postId})
+	require.Nil(t, err)
+	s2, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: model.NewId()})
+	require.Nil(t, err)
+	s3, err := ss.SavedPost().Save(&model.SavedPost{UserId: model.NewId(), PostId: postId})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SavedPost().Delete(userId, s1.PostId))
+	_, err = ss.SavedPost().Get(userId, s1.PostId)
+	assert.NotNil(t, err)
+	_, err = ss.SavedPost().Get(userId, s2.PostId)
+	assert.Nil(t, err)
+
+	require.Nil(t, ss.SavedPost().PermanentDeleteByPost(postId))
+	_, err = ss.SavedPost().Get(s3.UserId, postId)
+	assert.NotNil(t, err)
+
+	require.Nil(t, ss.SavedPost().PermanentDeleteByUser(userId))
+	_, err = ss.SavedPost().Get(userId, s2.PostId)
+	assert.NotNil(t, err)
+}
+
+func testSavedPostStoreMigrateFlaggedPostPreferences(t *testing.T, ss store.Store) {
+	p1, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+	p2, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+
+	userId := model.NewId()
+	alreadySaved, err := ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: p2.Id, SavedAt: 1000})
+	require.Nil(t, err)
+
+	deletedPostId := model.NewId()
+	preferences := model.Preferences{
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: p1.Id, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: p2.Id, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: deletedPostId, Value: "true"},
+		{UserId: userId, Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, Name: model.PREFERENCE_NAME_USE_MILITARY_TIME, Value: "true"},
+	}
+	require.Nil(t, ss.Preference().Save(&preferences))
+
+	saved, err := ss.SavedPost().MigrateFlaggedPostPreferences()
+	require.Nil(t, err)
+	assert.True(t, saved >= 1)
+
+	_, err = ss.SavedPost().Get(userId, p1.Id)
+	assert.Nil(t, err)
+
+	received, err := ss.SavedPost().Get(userId, p2.Id)
+	require.Nil(t, err)
+	assert.Equal(t, alreadySaved.SavedAt, received.SavedAt)
+
+	_, err = ss.SavedPost().Get(userId, deletedPostId)
+	assert.NotNil(t, err)
+
+	// The preferences are copied rather than moved.
+	flagged, err := ss.Preference().GetCategory(userId, model.PREFERENCE_CATEGORY_FLAGGED_POST)
+	require.Nil(t, err)
+	assert.Len(t, flagged, 3)
+
+	_, err = ss.Preference().Get(userId, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, model.PREFERENCE_NAME_USE_MILITARY_TIME)
+	assert.Nil(t, err)
+}
+
+func testSavedPostStoreCleanupBatch(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId() + "b"})
+	require.Nil(t, err)
+
+	userId := model.NewId()
+	deletedPostId := model.NewId()
+
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: post.Id})
+	require.Nil(t, err)
+	_, err = ss.SavedPost().Save(&model.SavedPost{UserId: userId, PostId: deletedPostId})
+	require.Nil(t, err)
+
+	_, err = ss.SavedPost().CleanupBatch(10000)
+	require.Nil(t, err)
+
+	_, err = ss.SavedPost().Get(userId, post.Id)
+	assert.Nil(t, err)
+
+	_, err = ss.SavedPost().Get(userId, deletedPostId)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
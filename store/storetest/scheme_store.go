@@ -17,6 +17,7 @@ func TestSchemeStore(t *testing.T, ss store.Store) {
 	createDefaultRoles(t, ss)
 
 	t.Run("Save", func(t *testing.T) { testSchemeStoreSave(t, ss) })
+	t.Run("SaveWithRolePermissions", func(t *testing.T) { testSchemeStoreSaveWithRolePermissions(t, ss) })
 	t.Run("Get", func(t *testing.T) { testSchemeStoreGet(t, ss) })
 	t.Run("GetAllPage", func(t *testing.T) { testSchemeStoreGetAllPage(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testSchemeStoreDelete(t, ss) })
@@ -169,6 +170,47 @@ func testSchemeStoreSave(t *testing.T, ss store.Store) {
 	assert.NotNil(t, err)
 }
 
+func testSchemeStoreSaveWithRolePermissions(t *testing.T, ss store.Store) {
+	s1 := &model.Scheme{
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Description: model.NewId(),
+		Scope:       model.SCHEME_SCOPE_CHANNEL,
+	}
+
+	d1, err := ss.Scheme().SaveWithRolePermissions(s1, map[string][]string{
+		model.CHANNEL_USER_ROLE_ID:  {"read_channel"},
+		model.CHANNEL_GUEST_ROLE_ID: {},
+	})
+	require.Nil(t, err)
+	assert.Len(t, d1.Id, 26)
+	assert.Empty(t, d1.DefaultTeamAdminRole)
+
+	// Roles without permissions of their own take those of the default role.
+	role1, err := ss.Role().GetByName(d1.DefaultChannelAdminRole)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"manage_public_channel_members", "manage_private_channel_members"}, role1.Permissions)
+	assert.True(t, role1.SchemeManaged)
+
+	role2, err := ss.Role().GetByName(d1.DefaultChannelUserRole)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"read_channel"}, role2.Permissions)
+	assert.True(t, role2.SchemeManaged)
+
+	role3, err := ss.Role().GetByName(d1.DefaultChannelGuestRole)
+	require.Nil(t, err)
+	assert.Empty(t, role3.Permissions)
+
+	// The default roles are left alone.
+	defaultRole, err := ss.Role().GetByName(model.CHANNEL_USER_ROLE_ID)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"read_channel", "create_post"}, defaultRole.Permissions)
+
+	// An existing scheme can't be saved this way.
+	_, err = ss.Scheme().SaveWithRolePermissions(d1, nil)
+	assert.NotNil(t, err)
+}
+
 func testSchemeStoreGet(t *testing.T, ss store.Store) {
 	// Save a scheme to test with.
 	s1 := &model.Scheme{
@@ -28,6 +28,7 @@ func TestSessionStore(t *testing.T, ss store.Store) {
 	t.Run("SessionUpdateDeviceId2", func(t *testing.T) { testSessionUpdateDeviceId2(t, ss) })
 	t.Run("UpdateLastActivityAt", func(t *testing.T) { testSessionStoreUpdateLastActivityAt(t, ss) })
 	t.Run("SessionCount", func(t *testing.T) { testSessionCount(t, ss) })
+	t.Run("GetUserIdsWithPasswordUpdatedBefore", func(t *testing.T) { testSessionGetUserIdsWithPasswordUpdatedBefore(t, ss) })
 }
 
 func testSessionStoreSave(t *testing.T, ss store.Store) {
@@ -340,3 +341,41 @@ func testSessionCleanup(t *testing.T, ss store.Store) {
 	removeErr = ss.Session().Remove(s2.Id)
 	require.Nil(t, removeErr)
 }
+
+func testSessionGetUserIdsWithPasswordUpdatedBefore(t *testing.T, ss store.Store) {
+	makeUserWithSession := func(user *model.User) *model.User {
+		user.Email = MakeEmail()
+		user.Username = model.NewId()
+
+		user, err := ss.User().Save(user)
+		require.Nil(t, err)
+
+		_, err = ss.Session().Save(&model.Session{UserId: user.Id})
+		require.Nil(t, err)
+
+		return user
+	}
+
+	u1 := makeUserWithSession(&model.User{Password: "password"})
+	u2 := makeUserWithSession(&model.User{AuthService: model.USER_AUTH_SERVICE_GITLAB, AuthData: model.NewString(model.NewId())})
+	u3 := makeUserWithSession(&model.User{Password: "password", DeleteAt: model.GetMillis()})
+
+	_, botUser := makeBotWithUser(t, ss, &model.Bot{Username: model.NewId(), OwnerId: model.NewId()})
+	_, err := ss.Session().Save(&model.Session{UserId: botUser.Id})
+	require.Nil(t, err)
+
+	noSessionUser, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId(), Password: "password"})
+	require.Nil(t, err)
+
+	userIds, err := ss.Session().GetUserIdsWithPasswordUpdatedBefore(u1.LastPasswordUpdate)
+	require.Nil(t, err)
+	assert.NotContains(t, userIds, u1.Id)
+
+	userIds, err = ss.Session().GetUserIdsWithPasswordUpdatedBefore(u1.LastPasswordUpdate + 1)
+	require.Nil(t, err)
+	assert.Contains(t, userIds, u1.Id)
+	assert.NotContains(t, userIds, u2.Id)
+	assert.NotContains(t, userIds, u3.Id)
+	assert.NotContains(t, userIds, botUser.Id)
+	assert.NotContains(t, userIds, noSessionUser.Id)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestSidebarChannelStore(t *testing.T, ss store.Store) {
+	t.Run("SaveOrder", func(t *testing.T) { testSidebarChannelStoreSaveOrder(t, ss) })
+	t.Run("GetForTeamExcludesFormerMemberships", func(t *testing.T) { testSidebarChannelStoreGetForTeamExcludesFormerMemberships(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testSidebarChannelStorePermanentDeleteByUser(t, ss) })
+}
+
+func makeChannelWithMember(t *testing.T, ss store.Store, teamId string, userId string) *model.Channel {
+	channel, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      userId,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.Nil(t, err)
+
+	return channel
+}
+
+func sidebarChannelIds(sidebarChannels []*model.SidebarChannel) []string {
+	var channelIds []string
+	for _, sidebarChannel := range sidebarChannels {
+		channelIds = append(channelIds, sidebarChannel.ChannelId)
+	}
+	return channelIds
+}
+
+func testSidebarChannelStoreSaveOrder(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	c1 := makeChannelWithMember(t, ss, teamId, userId)
+	c2 := makeChannelWithMember(t, ss, teamId, userId)
+	c3 := makeChannelWithMember(t, ss, teamId, userId)
+
+	t.Run("should return nothing before the order is saved", func(t *testing.T) {
+		sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+		require.Nil(t, err)
+		assert.Empty(t, sidebarChannels)
+	})
+
+	t.Run("should save the order", func(t *testing.T) {
+		err := ss.SidebarChannel().SaveOrder(userId, teamId, []string{c2.Id, c3.Id, c1.Id})
+		require.Nil(t, err)
+
+		sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+		require.Nil(t, err)
+		assert.Equal(t, []string{c2.Id, c3.Id, c1.Id}, sidebarChannelIds(sidebarChannels))
+	})
+
+	t.Run("should replace the saved order", func(t *testing.T) {
+		err := ss.SidebarChannel().SaveOrder(userId, teamId, []string{c1.Id, c2.Id})
+		require.Nil(t, err)
+
+		sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+		require.Nil(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id}, sidebarChannelIds(sidebarChannels))
+	})
+
+	t.Run("should not affect other teams", func(t *testing.T) {
+		otherTeamId := model.NewId()
+		c4 := makeChannelWithMember(t, ss, otherTeamId, userId)
+
+		err := ss.SidebarChannel().SaveOrder(userId, otherTeamId, []string{c4.Id})
+		require.Nil(t, err)
+
+		sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+		require.Nil(t, err)
+		assert.Equal(t, []string{c1.Id, c2.Id}, sidebarChannelIds(sidebarChannels))
+	})
+}
+
+func testSidebarChannelStoreGetForTeamExcludesFormerMemberships(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	c1 := makeChannelWithMember(t, ss, teamId, userId)
+	c2 := makeChannelWithMember(t, ss, teamId, userId)
+
+	require.Nil(t, ss.SidebarChannel().SaveOrder(userId, teamId, []string{c2.Id, c1.Id}))
+	require.Nil(t, ss.Channel().RemoveMember(c2.Id, userId))
+
+	sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+	require.Nil(t, err)
+	assert.Equal(t, []string{c1.Id}, sidebarChannelIds(sidebarChannels))
+}
+
+func testSidebarChannelStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	c1 := makeChannelWithMember(t, ss, teamId, userId)
+	_, err := ss.Channel().SaveMember(&model.ChannelMember{
+		ChannelId:   c1.Id,
+		UserId:      otherUserId,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, ss.SidebarChannel().SaveOrder(userId, teamId, []string{c1.Id}))
+	require.Nil(t, ss.SidebarChannel().SaveOrder(otherUserId, teamId, []string{c1.Id}))
+
+	require.Nil(t, ss.SidebarChannel().PermanentDeleteByUser(userId))
+
+	sidebarChannels, err := ss.SidebarChannel().GetForTeam(userId, teamId)
+	require.Nil(t, err)
+	assert.Empty(t, sidebarChannels)
+
+	sidebarChannels, err = ss.SidebarChannel().GetForTeam(otherUserId, teamId)
+	require.Nil(t, err)
+	assert.Len(t, sidebarChannels, 1)
+}
@@ -12,37 +12,45 @@ import (
 
 // Store can be used to provide mock stores for testing.
 type Store struct {
-	TeamStore                 mocks.TeamStore
-	ChannelStore              mocks.ChannelStore
-	PostStore                 mocks.PostStore
-	UserStore                 mocks.UserStore
-	BotStore                  mocks.BotStore
-	AuditStore                mocks.AuditStore
-	ClusterDiscoveryStore     mocks.ClusterDiscoveryStore
-	ComplianceStore           mocks.ComplianceStore
-	SessionStore              mocks.SessionStore
-	OAuthStore                mocks.OAuthStore
-	SystemStore               mocks.SystemStore
-	WebhookStore              mocks.WebhookStore
-	CommandStore              mocks.CommandStore
-	CommandWebhookStore       mocks.CommandWebhookStore
-	PreferenceStore           mocks.PreferenceStore
-	LicenseStore              mocks.LicenseStore
-	TokenStore                mocks.TokenStore
-	EmojiStore                mocks.EmojiStore
-	StatusStore               mocks.StatusStore
-	FileInfoStore             mocks.FileInfoStore
-	ReactionStore             mocks.ReactionStore
-	JobStore                  mocks.JobStore
-	UserAccessTokenStore      mocks.UserAccessTokenStore
-	PluginStore               mocks.PluginStore
-	ChannelMemberHistoryStore mocks.ChannelMemberHistoryStore
-	RoleStore                 mocks.RoleStore
-	SchemeStore               mocks.SchemeStore
-	TermsOfServiceStore       mocks.TermsOfServiceStore
-	GroupStore                mocks.GroupStore
-	UserTermsOfServiceStore   mocks.UserTermsOfServiceStore
-	LinkMetadataStore         mocks.LinkMetadataStore
+	TeamStore                  mocks.TeamStore
+	ChannelStore               mocks.ChannelStore
+	PostStore                  mocks.PostStore
+	UserStore                  mocks.UserStore
+	BotStore                   mocks.BotStore
+	AuditStore                 mocks.AuditStore
+	ClusterDiscoveryStore      mocks.ClusterDiscoveryStore
+	ComplianceStore            mocks.ComplianceStore
+	SessionStore               mocks.SessionStore
+	OAuthStore                 mocks.OAuthStore
+	SystemStore                mocks.SystemStore
+	WebhookStore               mocks.WebhookStore
+	CommandStore               mocks.CommandStore
+	CommandWebhookStore        mocks.CommandWebhookStore
+	PreferenceStore            mocks.PreferenceStore
+	LicenseStore               mocks.LicenseStore
+	TokenStore                 mocks.TokenStore
+	EmojiStore                 mocks.EmojiStore
+	StatusStore                mocks.StatusStore
+	FileInfoStore              mocks.FileInfoStore
+	ReactionStore              mocks.ReactionStore
+	JobStore                   mocks.JobStore
+	UserAccessTokenStore       mocks.UserAccessTokenStore
+	PluginStore                mocks.PluginStore
+	ChannelMemberHistoryStore  mocks.ChannelMemberHistoryStore
+	RoleStore                  mocks.RoleStore
+	SchemeStore                mocks.SchemeStore
+	TermsOfServiceStore        mocks.TermsOfServiceStore
+	GroupStore                 mocks.GroupStore
+	UserTermsOfServiceStore    mocks.UserTermsOfServiceStore
+	LinkMetadataStore          mocks.LinkMetadataStore
+	PushNotificationQueueStore mocks.PushNotificationQueueStore
+	ChannelTopicHistoryStore   mocks.ChannelTopicHistoryStore
+	TeamMemberHistoryStore     mocks.TeamMemberHistoryStore
+	SidebarChannelStore        mocks.SidebarChannelStore
+	MentionStore               mocks.MentionStore
+	PasswordHistoryStore       mocks.PasswordHistoryStore
+	SavedPostStore             mocks.SavedPostStore
+	ConfigChangeRequestStore   mocks.ConfigChangeRequestStore
 }
 
 func (s *Store) Team() store.TeamStore                             { return &s.TeamStore }
@@ -78,15 +86,39 @@ func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 }
 func (s *Store) Group() store.GroupStore               { return &s.GroupStore }
 func (s *Store) LinkMetadata() store.LinkMetadataStore { return &s.LinkMetadataStore }
-func (s *Store) MarkSystemRanUnitTests()               { /* do nothing */ }
-func (s *Store) Close()                                { /* do nothing */ }
-func (s *Store) LockToMaster()                         { /* do nothing */ }
-func (s *Store) UnlockFromMaster()                     { /* do nothing */ }
-func (s *Store) DropAllTables()                        { /* do nothing */ }
-func (s *Store) TotalMasterDbConnections() int         { return 1 }
-func (s *Store) TotalReadDbConnections() int           { return 1 }
-func (s *Store) TotalSearchDbConnections() int         { return 1 }
-func (s *Store) GetCurrentSchemaVersion() string       { return "" }
+func (s *Store) PushNotificationQueue() store.PushNotificationQueueStore {
+	return &s.PushNotificationQueueStore
+}
+func (s *Store) ChannelTopicHistory() store.ChannelTopicHistoryStore {
+	return &s.ChannelTopicHistoryStore
+}
+func (s *Store) TeamMemberHistory() store.TeamMemberHistoryStore {
+	return &s.TeamMemberHistoryStore
+}
+func (s *Store) SidebarChannel() store.SidebarChannelStore {
+	return &s.SidebarChannelStore
+}
+func (s *Store) Mention() store.MentionStore {
+	return &s.MentionStore
+}
+func (s *Store) PasswordHistory() store.PasswordHistoryStore {
+	return &s.PasswordHistoryStore
+}
+func (s *Store) SavedPost() store.SavedPostStore {
+	return &s.SavedPostStore
+}
+func (s *Store) ConfigChangeRequest() store.ConfigChangeRequestStore {
+	return &s.ConfigChangeRequestStore
+}
+func (s *Store) MarkSystemRanUnitTests()         { /* do nothing */ }
+func (s *Store) Close()                          { /* do nothing */ }
+func (s *Store) LockToMaster()                   { /* do nothing */ }
+func (s *Store) UnlockFromMaster()               { /* do nothing */ }
+func (s *Store) DropAllTables()                  { /* do nothing */ }
+func (s *Store) TotalMasterDbConnections() int   { return 1 }
+func (s *Store) TotalReadDbConnections() int     { return 1 }
+func (s *Store) TotalSearchDbConnections() int   { return 1 }
+func (s *Store) GetCurrentSchemaVersion() string { return "" }
 func (s *Store) CheckIntegrity() <-chan store.IntegrityCheckResult {
 	return make(chan store.IntegrityCheckResult)
 }
@@ -120,5 +152,13 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.PluginStore,
 		&s.RoleStore,
 		&s.SchemeStore,
+		&s.PushNotificationQueueStore,
+		&s.ChannelTopicHistoryStore,
+		&s.TeamMemberHistoryStore,
+		&s.SidebarChannelStore,
+		&s.MentionStore,
+		&s.PasswordHistoryStore,
+		&s.SavedPostStore,
+		&s.ConfigChangeRequestStore,
 	)
 }
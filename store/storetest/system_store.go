@@ -16,7 +16,11 @@ import (
 func TestSystemStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testSystemStore(t, ss) })
 	t.Run("SaveOrUpdate", func(t *testing.T) { testSystemStoreSaveOrUpdate(t, ss) })
+	t.Run("UpdateOptimistically", func(t *testing.T) { testSystemStoreUpdateOptimistically(t, ss) })
 	t.Run("PermanentDeleteByName", func(t *testing.T) { testSystemStorePermanentDeleteByName(t, ss) })
+	t.Run("RunReportingQuery", func(t *testing.T) { testSystemStoreRunReportingQuery(t, ss) })
+	t.Run("GetSchemaMigrations", func(t *testing.T) { testSystemStoreGetSchemaMigrations(t, ss) })
+	t.Run("GetOpenTransactions", func(t *testing.T) { testSystemStoreGetOpenTransactions(t, ss) })
 }
 
 func testSystemStore(t *testing.T, ss store.Store) {
@@ -53,6 +57,28 @@ func testSystemStoreSaveOrUpdate(t *testing.T, ss store.Store) {
 	}
 }
 
+func testSystemStoreUpdateOptimistically(t *testing.T, ss store.Store) {
+	system := &model.System{Name: model.NewId(), Value: "value"}
+	require.Nil(t, ss.System().Save(system))
+
+	updated, err := ss.System().UpdateOptimistically(&model.System{Name: system.Name, Value: "value2"}, "other")
+	require.Nil(t, err)
+	assert.False(t, updated)
+
+	updated, err = ss.System().UpdateOptimistically(&model.System{Name: system.Name, Value: "value2"}, "value")
+	require.Nil(t, err)
+	assert.True(t, updated)
+
+	rsystem, err := ss.System().GetByName(system.Name)
+	require.Nil(t, err)
+	assert.Equal(t, "value2", rsystem.Value)
+
+	// A second node taking over from the same previous value loses.
+	updated, err = ss.System().UpdateOptimistically(&model.System{Name: system.Name, Value: "value3"}, "value")
+	require.Nil(t, err)
+	assert.False(t, updated)
+}
+
 func testSystemStorePermanentDeleteByName(t *testing.T, ss store.Store) {
 	s1 := &model.System{Name: model.NewId(), Value: "value"}
 	s2 := &model.System{Name: model.NewId(), Value: "value"}
@@ -86,3 +112,86 @@ func testSystemStorePermanentDeleteByName(t *testing.T, ss store.Store) {
 	_, err = ss.System().GetByName(s2.Name)
 	assert.NotNil(t, err)
 }
+
+func testSystemStoreRunReportingQuery(t *testing.T, ss store.Store) {
+	system := &model.System{Name: model.NewId(), Value: "value"}
+	err := ss.System().Save(system)
+	require.Nil(t, err)
+	defer ss.System().PermanentDeleteByName(system.Name)
+
+	t.Run("with params", func(t *testing.T) {
+		results, err := ss.System().RunReportingQuery("SELECT Name, Value FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": system.Name})
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, system.Name, results[0]["name"])
+		assert.Equal(t, system.Value, results[0]["value"])
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		results, err := ss.System().RunReportingQuery("SELECT Name FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": model.NewId()})
+		require.Nil(t, err)
+		assert.Len(t, results, 0)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		_, err := ss.System().RunReportingQuery("SELECT Name FROM Systems WHERE Name = :Name", nil)
+		require.NotNil(t, err)
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		_, err := ss.System().RunReportingQuery("DELETE FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": system.Name})
+		require.NotNil(t, err)
+
+		rsystem, err := ss.System().GetByName(system.Name)
+		require.Nil(t, err)
+		assert.Equal(t, system.Value, rsystem.Value)
+	})
+}
+
+func testSystemStoreGetSchemaMigrations(t *testing.T, ss store.Store) {
+	version, err := ss.System().GetByName("Version")
+	require.Nil(t, err)
+	defer func() {
+		require.Nil(t, ss.System().SaveOrUpdate(version))
+	}()
+
+	t.Run("all applied", func(t *testing.T) {
+		migrations, err := ss.System().GetSchemaMigrations()
+		require.Nil(t, err)
+		require.NotEmpty(t, migrations)
+
+		for _, migration := range migrations {
+			assert.Equal(t, model.SCHEMA_MIGRATION_STATUS_APPLIED, migration.Status, migration.Id)
+		}
+	})
+
+	t.Run("some pending", func(t *testing.T) {
+		require.Nil(t, ss.System().SaveOrUpdate(&model.System{Name: "Version", Value: "5.0.0"}))
+
+		migrations, err := ss.System().GetSchemaMigrations()
+		require.Nil(t, err)
+
+		pending := 0
+		for _, migration := range migrations {
+			if migration.Status == model.SCHEMA_MIGRATION_STATUS_PENDING {
+				pending++
+				assert.Zero(t, migration.AppliedAt)
+			} else {
+				assert.NotEqual(t, "5.1.0", migration.Id)
+			}
+		}
+		assert.NotZero(t, pending)
+		assert.Equal(t, "3.1.0", migrations[0].Id)
+		assert.Equal(t, "UpgradeDatabaseToVersion31", migrations[0].Name)
+	})
+}
+
+func testSystemStoreGetOpenTransactions(t *testing.T, ss store.Store) {
+	transactions, err := ss.System().GetOpenTransactions()
+	require.Nil(t, err)
+	require.NotNil(t, transactions)
+
+	for _, transaction := range transactions {
+		assert.True(t, transaction.DurationMs >= 0)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See License.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/model"
+	"github.com/mattermost/mattermost-server/store"
+)
+
+func TestTeamMemberHistoryStore(t *testing.T, ss store.Store) {
+	t.Run("LogJoinEvent", func(t *testing.T) { testTeamMemberHistoryStoreLogJoinEvent(t, ss) })
+	t.Run("LogLeaveEvent", func(t *testing.T) { testTeamMemberHistoryStoreLogLeaveEvent(t, ss) })
+	t.Run("GetForTeamDuring", func(t *testing.T) { testTeamMemberHistoryStoreGetForTeamDuring(t, ss) })
+}
+
+func testTeamMemberHistoryStoreLogJoinEvent(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+
+	err := ss.TeamMemberHistory().LogJoinEvent(userId, teamId, 1000)
+	require.Nil(t, err)
+
+	histories, err := ss.TeamMemberHistory().GetForTeamDuring(teamId, 0, 2000)
+	require.Nil(t, err)
+	require.Len(t, histories, 1)
+
+	assert.Equal(t, userId, histories[0].UserId)
+	assert.Equal(t, int64(1000), histories[0].JoinTime)
+	assert.Nil(t, histories[0].LeaveTime)
+}
+
+func testTeamMemberHistoryStoreLogLeaveEvent(t *testing.T, ss store.Store) {
+	t.Run("should set the leave time of the open join event", func(t *testing.T) {
+		teamId := model.NewId()
+		userId := model.NewId()
+
+		require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(userId, teamId, 1000))
+		require.Nil(t, ss.TeamMemberHistory().LogLeaveEvent(userId, teamId, 1500))
+		require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(userId, teamId, 2000))
+
+		histories, err := ss.TeamMemberHistory().GetForTeamDuring(teamId, 0, 3000)
+		require.Nil(t, err)
+		require.Len(t, histories, 2)
+
+		for _, history := range histories {
+			if history.JoinTime == 1000 {
+				require.NotNil(t, history.LeaveTime)
+				assert.Equal(t, int64(1500), *history.LeaveTime)
+			} else {
+				assert.Nil(t, history.LeaveTime)
+			}
+		}
+	})
+
+	t.Run("should not fail without a join event", func(t *testing.T) {
+		err := ss.TeamMemberHistory().LogLeaveEvent(model.NewId(), model.NewId(), 1000)
+		assert.Nil(t, err)
+	})
+}
+
+func testTeamMemberHistoryStoreGetForTeamDuring(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+
+	joinedBefore := model.NewId()
+	require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(joinedBefore, teamId, 500))
+
+	leftDuring := model.NewId()
+	require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(leftDuring, teamId, 500))
+	require.Nil(t, ss.TeamMemberHistory().LogLeaveEvent(leftDuring, teamId, 1500))
+
+	joinedDuring := model.NewId()
+	require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(joinedDuring, teamId, 1000))
+
+	joinedAfter := model.NewId()
+	require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(joinedAfter, teamId, 2000))
+
+	require.Nil(t, ss.TeamMemberHistory().LogJoinEvent(model.NewId(), model.NewId(), 1000))
+
+	histories, err := ss.TeamMemberHistory().GetForTeamDuring(teamId, 1000, 2000)
+	require.Nil(t, err)
+
+	var userIds []string
+	for _, history := range histories {
+		assert.Equal(t, teamId, history.TeamId)
+		userIds = append(userIds, history.UserId)
+	}
+	assert.ElementsMatch(t, []string{leftDuring, joinedDuring}, userIds)
+}
@@ -68,6 +68,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetRecentlyActiveUsersForTeam", func(t *testing.T) { testUserStoreGetRecentlyActiveUsersForTeam(t, ss, s) })
 	t.Run("GetNewUsersForTeam", func(t *testing.T) { testUserStoreGetNewUsersForTeam(t, ss) })
 	t.Run("Search", func(t *testing.T) { testUserStoreSearch(t, ss) })
+	t.Run("SearchFuzzy", func(t *testing.T) { testUserStoreSearchFuzzy(t, ss) })
 	t.Run("SearchNotInChannel", func(t *testing.T) { testUserStoreSearchNotInChannel(t, ss) })
 	t.Run("SearchInChannel", func(t *testing.T) { testUserStoreSearchInChannel(t, ss) })
 	t.Run("SearchNotInTeam", func(t *testing.T) { testUserStoreSearchNotInTeam(t, ss) })
@@ -2346,6 +2347,7 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 		LastName:  "Yu",
 		Nickname:  "enyu",
 		Email:     MakeEmail(),
+		Props:     model.StringMap{"department": "Accounting", "note": "Oceanography"},
 	}
 	_, err = ss.User().Save(u5)
 	require.Nil(t, err)
@@ -2612,6 +2614,49 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 			},
 			[]*model.User{u1, u2},
 		},
+		{
+			"search custom attributes when not included",
+			tid,
+			"accoun",
+			&model.UserSearchOptions{
+				AllowFullNames: true,
+				Limit:          model.USER_SEARCH_DEFAULT_LIMIT,
+			},
+			[]*model.User{},
+		},
+		{
+			"search custom attributes",
+			tid,
+			"accoun",
+			&model.UserSearchOptions{
+				AllowFullNames:     true,
+				IncludeCustomAttrs: true,
+				Limit:              model.USER_SEARCH_DEFAULT_LIMIT,
+			},
+			[]*model.User{u5},
+		},
+		{
+			"search custom attributes does not match other props",
+			tid,
+			"ocean",
+			&model.UserSearchOptions{
+				AllowFullNames:     true,
+				IncludeCustomAttrs: true,
+				Limit:              model.USER_SEARCH_DEFAULT_LIMIT,
+			},
+			[]*model.User{},
+		},
+		{
+			"search custom attributes does not match keys",
+			tid,
+			"depart",
+			&model.UserSearchOptions{
+				AllowFullNames:     true,
+				IncludeCustomAttrs: true,
+				Limit:              model.USER_SEARCH_DEFAULT_LIMIT,
+			},
+			[]*model.User{},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -2651,6 +2696,84 @@ func testUserStoreSearch(t *testing.T, ss store.Store) {
 	})
 }
 
+func testUserStoreSearchFuzzy(t *testing.T, ss store.Store) {
+	u1 := &model.User{
+		Username: "fuzzyjohn",
+		Email:    MakeEmail(),
+	}
+	_, err := ss.User().Save(u1)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	u2 := &model.User{
+		Username: "fuzzyjhnny",
+		Email:    MakeEmail(),
+	}
+	_, err = ss.User().Save(u2)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u2.Id)) }()
+
+	u3 := &model.User{
+		Username: "unrelated" + model.NewId(),
+		Email:    MakeEmail(),
+	}
+	_, err = ss.User().Save(u3)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u3.Id)) }()
+
+	tid := model.NewId()
+	for _, u := range []*model.User{u1, u2, u3} {
+		_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: tid, UserId: u.Id}, -1)
+		require.Nil(t, err)
+	}
+
+	t.Run("prefix matches only without fuzzy", func(t *testing.T) {
+		users, err := ss.User().Search(tid, "fuzzyjhn", &model.UserSearchOptions{Limit: model.USER_SEARCH_DEFAULT_LIMIT})
+		require.Nil(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, u2.Id, users[0].Id)
+	})
+
+	t.Run("prefix matches ranked before similar usernames", func(t *testing.T) {
+		users, err := ss.User().Search(tid, "@fuzzyjhn", &model.UserSearchOptions{Fuzzy: true, Limit: model.USER_SEARCH_DEFAULT_LIMIT})
+		require.Nil(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, u2.Id, users[0].Id)
+		assert.Equal(t, u1.Id, users[1].Id)
+		assert.Empty(t, users[0].Password)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		users, err := ss.User().Search(tid, "fuzzyjhn", &model.UserSearchOptions{Fuzzy: true, Limit: 1})
+		require.Nil(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, u2.Id, users[0].Id)
+	})
+
+	t.Run("in channel", func(t *testing.T) {
+		c1 := model.Channel{
+			TeamId:      tid,
+			DisplayName: "NameName",
+			Name:        "zz" + model.NewId() + "b",
+			Type:        model.CHANNEL_OPEN,
+		}
+		_, err := ss.Channel().Save(&c1, -1)
+		require.Nil(t, err)
+
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   c1.Id,
+			UserId:      u1.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.Nil(t, err)
+
+		users, err := ss.User().SearchInChannel(c1.Id, "fuzzyjhn", &model.UserSearchOptions{Fuzzy: true, Limit: model.USER_SEARCH_DEFAULT_LIMIT})
+		require.Nil(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, u1.Id, users[0].Id)
+	})
+}
+
 func testUserStoreSearchNotInChannel(t *testing.T, ss store.Store) {
 	u1 := &model.User{
 		Username:  "jimbo1" + model.NewId(),
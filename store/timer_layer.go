@@ -15,38 +15,46 @@ import (
 
 type TimerLayer struct {
 	Store
-	Metrics                   einterfaces.MetricsInterface
-	AuditStore                AuditStore
-	BotStore                  BotStore
-	ChannelStore              ChannelStore
-	ChannelMemberHistoryStore ChannelMemberHistoryStore
-	ClusterDiscoveryStore     ClusterDiscoveryStore
-	CommandStore              CommandStore
-	CommandWebhookStore       CommandWebhookStore
-	ComplianceStore           ComplianceStore
-	EmojiStore                EmojiStore
-	FileInfoStore             FileInfoStore
-	GroupStore                GroupStore
-	JobStore                  JobStore
-	LicenseStore              LicenseStore
-	LinkMetadataStore         LinkMetadataStore
-	OAuthStore                OAuthStore
-	PluginStore               PluginStore
-	PostStore                 PostStore
-	PreferenceStore           PreferenceStore
-	ReactionStore             ReactionStore
-	RoleStore                 RoleStore
-	SchemeStore               SchemeStore
-	SessionStore              SessionStore
-	StatusStore               StatusStore
-	SystemStore               SystemStore
-	TeamStore                 TeamStore
-	TermsOfServiceStore       TermsOfServiceStore
-	TokenStore                TokenStore
-	UserStore                 UserStore
-	UserAccessTokenStore      UserAccessTokenStore
-	UserTermsOfServiceStore   UserTermsOfServiceStore
-	WebhookStore              WebhookStore
+	Metrics                    einterfaces.MetricsInterface
+	AuditStore                 AuditStore
+	BotStore                   BotStore
+	ChannelStore               ChannelStore
+	ChannelMemberHistoryStore  ChannelMemberHistoryStore
+	ChannelTopicHistoryStore   ChannelTopicHistoryStore
+	ClusterDiscoveryStore      ClusterDiscoveryStore
+	CommandStore               CommandStore
+	CommandWebhookStore        CommandWebhookStore
+	ComplianceStore            ComplianceStore
+	ConfigChangeRequestStore   ConfigChangeRequestStore
+	EmojiStore                 EmojiStore
+	FileInfoStore              FileInfoStore
+	GroupStore                 GroupStore
+	JobStore                   JobStore
+	LicenseStore               LicenseStore
+	LinkMetadataStore          LinkMetadataStore
+	MentionStore               MentionStore
+	OAuthStore                 OAuthStore
+	PasswordHistoryStore       PasswordHistoryStore
+	PluginStore                PluginStore
+	PostStore                  PostStore
+	PreferenceStore            PreferenceStore
+	PushNotificationQueueStore PushNotificationQueueStore
+	ReactionStore              ReactionStore
+	RoleStore                  RoleStore
+	SavedPostStore             SavedPostStore
+	SchemeStore                SchemeStore
+	SessionStore               SessionStore
+	SidebarChannelStore        SidebarChannelStore
+	StatusStore                StatusStore
+	SystemStore                SystemStore
+	TeamStore                  TeamStore
+	TeamMemberHistoryStore     TeamMemberHistoryStore
+	TermsOfServiceStore        TermsOfServiceStore
+	TokenStore                 TokenStore
+	UserStore                  UserStore
+	UserAccessTokenStore       UserAccessTokenStore
+	UserTermsOfServiceStore    UserTermsOfServiceStore
+	WebhookStore               WebhookStore
 }
 
 func (s *TimerLayer) Audit() AuditStore {
@@ -65,6 +73,10 @@ func (s *TimerLayer) ChannelMemberHistory() ChannelMemberHistoryStore {
 	return s.ChannelMemberHistoryStore
 }
 
+func (s *TimerLayer) ChannelTopicHistory() ChannelTopicHistoryStore {
+	return s.ChannelTopicHistoryStore
+}
+
 func (s *TimerLayer) ClusterDiscovery() ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
@@ -81,6 +93,10 @@ func (s *TimerLayer) Compliance() ComplianceStore {
 	return s.ComplianceStore
 }
 
+func (s *TimerLayer) ConfigChangeRequest() ConfigChangeRequestStore {
+	return s.ConfigChangeRequestStore
+}
+
 func (s *TimerLayer) Emoji() EmojiStore {
 	return s.EmojiStore
 }
@@ -105,10 +121,18 @@ func (s *TimerLayer) LinkMetadata() LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) Mention() MentionStore {
+	return s.MentionStore
+}
+
 func (s *TimerLayer) OAuth() OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) PasswordHistory() PasswordHistoryStore {
+	return s.PasswordHistoryStore
+}
+
 func (s *TimerLayer) Plugin() PluginStore {
 	return s.PluginStore
 }
@@ -121,6 +145,10 @@ func (s *TimerLayer) Preference() PreferenceStore {
 	return s.PreferenceStore
 }
 
+func (s *TimerLayer) PushNotificationQueue() PushNotificationQueueStore {
+	return s.PushNotificationQueueStore
+}
+
 func (s *TimerLayer) Reaction() ReactionStore {
 	return s.ReactionStore
 }
@@ -129,6 +157,10 @@ func (s *TimerLayer) Role() RoleStore {
 	return s.RoleStore
 }
 
+func (s *TimerLayer) SavedPost() SavedPostStore {
+	return s.SavedPostStore
+}
+
 func (s *TimerLayer) Scheme() SchemeStore {
 	return s.SchemeStore
 }
@@ -137,6 +169,10 @@ func (s *TimerLayer) Session() SessionStore {
 	return s.SessionStore
 }
 
+func (s *TimerLayer) SidebarChannel() SidebarChannelStore {
+	return s.SidebarChannelStore
+}
+
 func (s *TimerLayer) Status() StatusStore {
 	return s.StatusStore
 }
@@ -149,6 +185,10 @@ func (s *TimerLayer) Team() TeamStore {
 	return s.TeamStore
 }
 
+func (s *TimerLayer) TeamMemberHistory() TeamMemberHistoryStore {
+	return s.TeamMemberHistoryStore
+}
+
 func (s *TimerLayer) TermsOfService() TermsOfServiceStore {
 	return s.TermsOfServiceStore
 }
@@ -193,6 +233,11 @@ type TimerLayerChannelMemberHistoryStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChannelTopicHistoryStore struct {
+	ChannelTopicHistoryStore
+	Root *TimerLayer
+}
+
 type TimerLayerClusterDiscoveryStore struct {
 	ClusterDiscoveryStore
 	Root *TimerLayer
@@ -213,6 +258,11 @@ type TimerLayerComplianceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerConfigChangeRequestStore struct {
+	ConfigChangeRequestStore
+	Root *TimerLayer
+}
+
 type TimerLayerEmojiStore struct {
 	EmojiStore
 	Root *TimerLayer
@@ -243,11 +293,21 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerMentionStore struct {
+	MentionStore
+	Root *TimerLayer
+}
+
 type TimerLayerOAuthStore struct {
 	OAuthStore
 	Root *TimerLayer
 }
 
+type TimerLayerPasswordHistoryStore struct {
+	PasswordHistoryStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	PluginStore
 	Root *TimerLayer
@@ -263,6 +323,11 @@ type TimerLayerPreferenceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPushNotificationQueueStore struct {
+	PushNotificationQueueStore
+	Root *TimerLayer
+}
+
 type TimerLayerReactionStore struct {
 	ReactionStore
 	Root *TimerLayer
@@ -273,6 +338,11 @@ type TimerLayerRoleStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSavedPostStore struct {
+	SavedPostStore
+	Root *TimerLayer
+}
+
 type TimerLayerSchemeStore struct {
 	SchemeStore
 	Root *TimerLayer
@@ -283,6 +353,11 @@ type TimerLayerSessionStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSidebarChannelStore struct {
+	SidebarChannelStore
+	Root *TimerLayer
+}
+
 type TimerLayerStatusStore struct {
 	StatusStore
 	Root *TimerLayer
@@ -298,6 +373,11 @@ type TimerLayerTeamStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerTeamMemberHistoryStore struct {
+	TeamMemberHistoryStore
+	Root *TimerLayer
+}
+
 type TimerLayerTermsOfServiceStore struct {
 	TermsOfServiceStore
 	Root *TimerLayer
@@ -1224,6 +1304,38 @@ func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) GetRecentChannels(userId string, teamId string, limit int) ([]*model.RecentChannel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetRecentChannels(userId, teamId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetRecentChannels", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) GetTeamChannelCount(teamId string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.GetTeamChannelCount(teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannelCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1592,6 +1704,38 @@ func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelStore.SaveMultipleMembers(members)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMultipleMembers", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelStore) SaveRecentChannel(recent *model.RecentChannel) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ChannelStore.SaveRecentChannel(recent)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveRecentChannel", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, *model.AppError) {
 	start := timemodule.Now()
 
@@ -1816,6 +1960,54 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerChannelTopicHistoryStore) GetForChannel(channelId string, limit int) ([]*model.ChannelTopicHistory, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTopicHistoryStore.GetForChannel(channelId, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTopicHistoryStore.GetForChannel", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTopicHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTopicHistoryStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTopicHistoryStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerChannelTopicHistoryStore) Save(history *model.ChannelTopicHistory) (*model.ChannelTopicHistory, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ChannelTopicHistoryStore.Save(history)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelTopicHistoryStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerClusterDiscoveryStore) Cleanup() *model.AppError {
 	start := timemodule.Now()
 
@@ -2120,6 +2312,22 @@ func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) *model.AppE
 	return resultVar0
 }
 
+func (s *TimerLayerComplianceStore) ChannelMessageExport(channelIds []string, afterCreateAt int64, afterPostId string, until int64, limit int) ([]*model.MessageExport, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ComplianceStore.ChannelMessageExport(channelIds, afterCreateAt, afterPostId, until, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ChannelMessageExport", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, *model.AppError) {
 	start := timemodule.Now()
 
@@ -2216,6 +2424,86 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerConfigChangeRequestStore) Get(id string) (*model.ConfigChangeRequest, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigChangeRequestStore.Get(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigChangeRequestStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerConfigChangeRequestStore) GetPending() ([]*model.ConfigChangeRequest, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigChangeRequestStore.GetPending()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigChangeRequestStore.GetPending", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerConfigChangeRequestStore) Reopen(id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ConfigChangeRequestStore.Reopen(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigChangeRequestStore.Reopen", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerConfigChangeRequestStore) Review(id string, status string, reviewedBy string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.ConfigChangeRequestStore.Review(id, status, reviewedBy)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigChangeRequestStore.Review", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerConfigChangeRequestStore) Save(request *model.ConfigChangeRequest) (*model.ConfigChangeRequest, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ConfigChangeRequestStore.Save(request)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ConfigChangeRequestStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) *model.AppError {
 	start := timemodule.Now()
 
@@ -2264,10 +2552,10 @@ func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*mod
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, *model.AppError) {
+func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string, opts model.EmojiListOpts) ([]*model.Emoji, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort)
+	resultVar0, resultVar1 := s.EmojiStore.GetList(offset, limit, sort, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3256,6 +3544,54 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerMentionStore) GetPostsForUser(userId string, teamId string, offset int, limit int) (*model.PostList, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.MentionStore.GetPostsForUser(userId, teamId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionStore.GetPostsForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerMentionStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MentionStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerMentionStore) SaveMultiple(mentions []*model.Mention) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.MentionStore.SaveMultiple(mentions)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MentionStore.SaveMultiple", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerOAuthStore) DeleteApp(id string) *model.AppError {
 	start := timemodule.Now()
 
@@ -3560,10 +3896,10 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+func (s *TimerLayerPasswordHistoryStore) GetForUser(userId string, limit int) ([]*model.PasswordHistory, *model.AppError) {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	resultVar0, resultVar1 := s.PasswordHistoryStore.GetForUser(userId, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -3571,23 +3907,87 @@ func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 		if resultVar1 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.GetForUser", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
 
-func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+func (s *TimerLayerPasswordHistoryStore) PermanentDeleteByUser(userId string) *model.AppError {
 	start := timemodule.Now()
 
-	resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	resultVar0 := s.PasswordHistoryStore.PermanentDeleteByUser(userId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
 		success := "false"
-		if resultVar1 == nil {
+		if resultVar0 == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerPasswordHistoryStore) PruneForUser(userId string, keep int) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.PasswordHistoryStore.PruneForUser(userId, keep)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.PruneForUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerPasswordHistoryStore) Save(history *model.PasswordHistory) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.PasswordHistoryStore.Save(history)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.Save", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PluginStore.CompareAndSet(keyVal, oldValue)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
 	return resultVar0, resultVar1
 }
@@ -3784,6 +4184,22 @@ func (s *TimerLayerPostStore) Get(id string, skipFetchThreads bool) (*model.Post
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPostStore) GetChannelPostCount(channelId string, since int64, until int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostStore.GetChannelPostCount(channelId, since, until)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetChannelPostCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4216,6 +4632,38 @@ func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerPostStore) SearchCount(teamId string, userId string, params *model.SearchParams, excludedPostIds []string) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostStore.SearchCount(teamId, userId, params, excludedPostIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchCount", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPostStore) SearchPostIds(teamId string, userId string, params *model.SearchParams, limit int) ([]string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PostStore.SearchPostIds(teamId, userId, params, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostIds", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4376,6 +4824,102 @@ func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) *model.
 	return resultVar0
 }
 
+func (s *TimerLayerPushNotificationQueueStore) Count() (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PushNotificationQueueStore.Count()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.Count", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPushNotificationQueueStore) Delete(id string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.PushNotificationQueueStore.Delete(id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerPushNotificationQueueStore) DeleteExpired(expiryTime int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PushNotificationQueueStore.DeleteExpired(expiryTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.DeleteExpired", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPushNotificationQueueStore) GetBatch(limit int) ([]*model.PushNotificationQueueItem, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PushNotificationQueueStore.GetBatch(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.GetBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPushNotificationQueueStore) Save(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PushNotificationQueueStore.Save(item)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerPushNotificationQueueStore) Update(item *model.PushNotificationQueueItem) (*model.PushNotificationQueueItem, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.PushNotificationQueueStore.Update(item)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PushNotificationQueueStore.Update", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4472,6 +5016,22 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerReactionStore) SaveMultiple(reactions []*model.Reaction) (*model.BulkReactionsResult, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.ReactionStore.SaveMultiple(reactions)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.SaveMultiple", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerRoleStore) Delete(roldId string) (*model.Role, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4584,6 +5144,150 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, *model.AppErr
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerRoleStore) UpdateMultiple(roles []*model.Role) ([]*model.Role, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.RoleStore.UpdateMultiple(roles)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.UpdateMultiple", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) CleanupBatch(limit int64) (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.CleanupBatch(limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.CleanupBatch", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) Delete(userId string, postId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.Delete(userId, postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Delete", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) Get(userId string, postId string) (*model.SavedPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.Get(userId, postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Get", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) GetForUser(userId string, offset int, limit int) ([]*model.SavedPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.GetForUser(userId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.GetForUser", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) MigrateFlaggedPostPreferences() (int64, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.MigrateFlaggedPostPreferences()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.MigrateFlaggedPostPreferences", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSavedPostStore) PermanentDeleteByPost(postId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.PermanentDeleteByPost(postId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.PermanentDeleteByPost", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.SavedPostStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSavedPostStore) Save(savedPost *model.SavedPost) (*model.SavedPost, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SavedPostStore.Save(savedPost)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SavedPostStore.Save", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4680,6 +5384,22 @@ func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, *mode
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSchemeStore) SaveWithRolePermissions(scheme *model.Scheme, rolePermissions map[string][]string) (*model.Scheme, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SchemeStore.SaveWithRolePermissions(scheme, rolePermissions)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.SaveWithRolePermissions", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, *model.AppError) {
 	start := timemodule.Now()
 
@@ -4760,6 +5480,22 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) (
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSessionStore) GetUserIdsWithPasswordUpdatedBefore(time int64) ([]string, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SessionStore.GetUserIdsWithPasswordUpdatedBefore(time)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetUserIdsWithPasswordUpdatedBefore", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) *model.AppError {
 	start := timemodule.Now()
 
@@ -4888,6 +5624,54 @@ func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (strin
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSidebarChannelStore) GetForTeam(userId string, teamId string) ([]*model.SidebarChannel, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SidebarChannelStore.GetForTeam(userId, teamId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SidebarChannelStore.GetForTeam", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSidebarChannelStore) PermanentDeleteByUser(userId string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.SidebarChannelStore.PermanentDeleteByUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SidebarChannelStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerSidebarChannelStore) SaveOrder(userId string, teamId string, channelIds []string) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.SidebarChannelStore.SaveOrder(userId, teamId, channelIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SidebarChannelStore.SaveOrder", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, *model.AppError) {
 	start := timemodule.Now()
 
@@ -5016,6 +5800,38 @@ func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, *model.Ap
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSystemStore) GetOpenTransactions() ([]*model.DatabaseTransaction, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SystemStore.GetOpenTransactions()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetOpenTransactions", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerSystemStore) GetSchemaMigrations() ([]*model.SchemaMigration, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SystemStore.GetSchemaMigrations()
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetSchemaMigrations", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, *model.AppError) {
 	start := timemodule.Now()
 
@@ -5032,6 +5848,22 @@ func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.Syste
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerSystemStore) RunReportingQuery(query string, params map[string]interface{}) ([]map[string]interface{}, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SystemStore.RunReportingQuery(query, params)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.RunReportingQuery", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerSystemStore) Save(system *model.System) *model.AppError {
 	start := timemodule.Now()
 
@@ -5080,6 +5912,22 @@ func (s *TimerLayerSystemStore) Update(system *model.System) *model.AppError {
 	return resultVar0
 }
 
+func (s *TimerLayerSystemStore) UpdateOptimistically(system *model.System, currentValue string) (bool, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.SystemStore.UpdateOptimistically(system, currentValue)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.UpdateOptimistically", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
 func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, *model.AppError) {
 	start := timemodule.Now()
 
@@ -5816,6 +6664,54 @@ func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string
 	return resultVar0, resultVar1
 }
 
+func (s *TimerLayerTeamMemberHistoryStore) GetForTeamDuring(teamId string, startTime int64, endTime int64) ([]*model.TeamMemberHistory, *model.AppError) {
+	start := timemodule.Now()
+
+	resultVar0, resultVar1 := s.TeamMemberHistoryStore.GetForTeamDuring(teamId, startTime, endTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar1 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamMemberHistoryStore.GetForTeamDuring", success, elapsed)
+	}
+	return resultVar0, resultVar1
+}
+
+func (s *TimerLayerTeamMemberHistoryStore) LogJoinEvent(userId string, teamId string, joinTime int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.TeamMemberHistoryStore.LogJoinEvent(userId, teamId, joinTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamMemberHistoryStore.LogJoinEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
+func (s *TimerLayerTeamMemberHistoryStore) LogLeaveEvent(userId string, teamId string, leaveTime int64) *model.AppError {
+	start := timemodule.Now()
+
+	resultVar0 := s.TeamMemberHistoryStore.LogLeaveEvent(userId, teamId, leaveTime)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if resultVar0 == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamMemberHistoryStore.LogLeaveEvent", success, elapsed)
+	}
+	return resultVar0
+}
+
 func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, *model.AppError) {
 	start := timemodule.Now()
 
@@ -7590,27 +8486,35 @@ func NewTimerLayer(childStore Store, metrics einterfaces.MetricsInterface) *Time
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ChannelTopicHistoryStore = &TimerLayerChannelTopicHistoryStore{ChannelTopicHistoryStore: childStore.ChannelTopicHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &TimerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ConfigChangeRequestStore = &TimerLayerConfigChangeRequestStore{ConfigChangeRequestStore: childStore.ConfigChangeRequest(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MentionStore = &TimerLayerMentionStore{MentionStore: childStore.Mention(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PasswordHistoryStore = &TimerLayerPasswordHistoryStore{PasswordHistoryStore: childStore.PasswordHistory(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.PushNotificationQueueStore = &TimerLayerPushNotificationQueueStore{PushNotificationQueueStore: childStore.PushNotificationQueue(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SavedPostStore = &TimerLayerSavedPostStore{SavedPostStore: childStore.SavedPost(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SidebarChannelStore = &TimerLayerSidebarChannelStore{SidebarChannelStore: childStore.SidebarChannel(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &TimerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
 	newStore.TeamStore = &TimerLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TeamMemberHistoryStore = &TimerLayerTeamMemberHistoryStore{TeamMemberHistoryStore: childStore.TeamMemberHistory(), Root: &newStore}
 	newStore.TermsOfServiceStore = &TimerLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
 	newStore.TokenStore = &TimerLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
@@ -31,7 +31,19 @@ a0v85XL6i9ote2P+fLZ3wX9EoioHzgdgB7arOxY50QRJO7OyCqpKFKv6lRWTXuSt
 hwIDAQAB
 -----END PUBLIC KEY-----`)
 
+// LicenseValidatorIface verifies the signature of a license, returning the license itself if it is valid.
+type LicenseValidatorIface interface {
+	ValidateLicense(signed []byte) (bool, string)
+}
+
+// LicenseValidatorImpl validates licenses signed with the production key.
+type LicenseValidatorImpl struct{}
+
 func ValidateLicense(signed []byte) (bool, string) {
+	return (&LicenseValidatorImpl{}).ValidateLicense(signed)
+}
+
+func (*LicenseValidatorImpl) ValidateLicense(signed []byte) (bool, string) {
 	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(signed)))
 
 	_, err := base64.StdEncoding.Decode(decoded, signed)
@@ -587,3 +587,14 @@ func (c *Context) RequireBotUserId() *Context {
 	}
 	return c
 }
+
+func (c *Context) RequireConfigChangeRequestId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if len(c.Params.ConfigChangeRequestId) != 26 {
+		c.SetInvalidUrlParam("config_change_request_id")
+	}
+	return c
+}
@@ -61,6 +61,7 @@ type Params struct {
 	SyncableId             string
 	SyncableType           model.GroupSyncableType
 	BotUserId              string
+	ConfigChangeRequestId  string
 	Q                      string
 	IsLinked               *bool
 	IsConfigured           *bool
@@ -263,6 +264,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.BotUserId = val
 	}
 
+	if val, ok := props["config_change_request_id"]; ok {
+		params.ConfigChangeRequestId = val
+	}
+
 	params.Q = query.Get("q")
 
 	if val, err := strconv.ParseBool(query.Get("is_linked")); err == nil {